@@ -31,16 +31,33 @@ func main() {
 	log := config.NewLogger(cfg.Env)
 	slog.SetDefault(log)
 
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		if err := runMigrate(ctx, log, cfg, os.Args[2:]); err != nil {
+			log.Error("migration failed", "err", err)
+			os.Exit(1)
+		}
+		return
+	}
+
 	log.Info("starting pr-reviewer application")
 
 	application := app.New(ctx, log, cfg)
 
+	for _, w := range application.Workers {
+		go w.Run(ctx)
+	}
 	go application.Srv.MustRun(ctx)
 
 	<-ctx.Done()
 
 	log.Info("received stop signal")
 
+	application.Health.SetShuttingDown()
+
+	log.Info("readiness probe switched to failing, draining traffic", "timeout", cfg.HealthConfig.DrainTimeout)
+
+	time.Sleep(cfg.HealthConfig.DrainTimeout)
+
 	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
 	defer cancel()
 
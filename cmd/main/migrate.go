@@ -0,0 +1,111 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strconv"
+
+	"github.com/whitxowl/pr-reviewer-assignment-service.git/internal/config"
+	"github.com/whitxowl/pr-reviewer-assignment-service.git/migrations"
+	"github.com/whitxowl/pr-reviewer-assignment-service.git/pkg/migrator"
+	"github.com/whitxowl/pr-reviewer-assignment-service.git/pkg/postgres"
+	"github.com/whitxowl/pr-reviewer-assignment-service.git/pkg/sqlite"
+)
+
+const migrateUsage = "usage: migrate up | down | status | to <version>"
+
+var errMigrateUsage = errors.New(migrateUsage)
+
+// runMigrate implements the "migrate" subcommand using the embedded migrations.
+func runMigrate(ctx context.Context, log *slog.Logger, cfg *config.Config, args []string) error {
+	if len(args) == 0 {
+		return errMigrateUsage
+	}
+
+	m, closeDB, err := newMigrator(ctx, log, cfg)
+	if err != nil {
+		return err
+	}
+	defer closeDB()
+
+	var results []*migrator.Result
+
+	switch args[0] {
+	case "up":
+		results, err = m.Up(ctx)
+	case "down":
+		results, err = m.Down(ctx)
+	case "to":
+		if len(args) != 2 {
+			return errMigrateUsage
+		}
+
+		version, parseErr := strconv.ParseInt(args[1], 10, 64)
+		if parseErr != nil {
+			return fmt.Errorf("invalid version %q: %w", args[1], parseErr)
+		}
+
+		results, err = m.To(ctx, version)
+	case "status":
+		statuses, statusErr := m.Status(ctx)
+		if statusErr != nil {
+			return statusErr
+		}
+
+		for _, status := range statuses {
+			fmt.Printf("%-8s %d %s\n", status.State, status.Source.Version, status.Source.Path)
+		}
+
+		return nil
+	default:
+		return errMigrateUsage
+	}
+
+	for _, result := range results {
+		fmt.Println(result)
+	}
+
+	return err
+}
+
+// newMigrator opens the database of the configured storage driver.
+// The returned func closes the migrator together with that database.
+func newMigrator(ctx context.Context, log *slog.Logger, cfg *config.Config) (*migrator.Migrator, func(), error) {
+	switch cfg.StorageDriver {
+	case config.StorageDriverSQLite:
+		db, err := sqlite.Open(ctx, cfg.SQLiteConfig.Path)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		m, err := migrator.NewSQLite(db, migrations.SQLiteFS, log.WithGroup("migrator"))
+		if err != nil {
+			db.Close()
+
+			return nil, nil, err
+		}
+
+		return m, func() { m.Close() }, nil
+	case config.StorageDriverPostgres:
+		pgPool, err := postgres.NewPool(ctx, cfg.StorageConfig.DSN())
+		if err != nil {
+			return nil, nil, err
+		}
+
+		m, err := migrator.NewPostgres(pgPool, migrations.FS, log.WithGroup("migrator"))
+		if err != nil {
+			pgPool.Close()
+
+			return nil, nil, err
+		}
+
+		return m, func() {
+			m.Close()
+			pgPool.Close()
+		}, nil
+	default:
+		return nil, nil, fmt.Errorf("storage driver %q has no migrations", cfg.StorageDriver)
+	}
+}
@@ -0,0 +1,143 @@
+package apiErr
+
+import (
+	"errors"
+	"net/http"
+
+	serviceErr "github.com/whitxowl/pr-reviewer-assignment-service.git/internal/service/errors"
+)
+
+const (
+	CodeTeamExists     = "TEAM_EXISTS"
+	CodeTeamNotEmpty   = "TEAM_NOT_EMPTY"
+	CodePRExists       = "PR_EXISTS"
+	CodeRepoExists     = "REPOSITORY_EXISTS"
+	CodePRMerged       = "PR_MERGED"
+	CodeNotAssigned    = "NOT_ASSIGNED"
+	CodeAssigned       = "ALREADY_ASSIGNED"
+	CodeInactive       = "REVIEWER_INACTIVE"
+	CodeNotFound       = "NOT_FOUND"
+	CodeInvalidRequest = "INVALID_REQUEST"
+	CodeInternalError  = "INTERNAL_ERROR"
+)
+
+// Statuses is the HTTP status of every error code. A code always maps to
+// exactly one status; openapi.yml is checked against it in tests.
+var Statuses = map[string]int{
+	CodeTeamExists:     http.StatusConflict,
+	CodeTeamNotEmpty:   http.StatusConflict,
+	CodePRExists:       http.StatusConflict,
+	CodeRepoExists:     http.StatusConflict,
+	CodePRMerged:       http.StatusConflict,
+	CodeNotAssigned:    http.StatusConflict,
+	CodeAssigned:       http.StatusConflict,
+	CodeInactive:       http.StatusConflict,
+	CodeNotFound:       http.StatusNotFound,
+	CodeInvalidRequest: http.StatusBadRequest,
+	CodeInternalError:  http.StatusInternalServerError,
+}
+
+// Error is an API error rendered by Middleware as ErrorResponse.
+type Error struct {
+	Code    string
+	Status  int
+	Message string
+	Details map[string]any
+
+	err error
+}
+
+func New(code string, message string) *Error {
+	return &Error{
+		Code:    code,
+		Status:  Statuses[code],
+		Message: message,
+	}
+}
+
+func (e *Error) Error() string {
+	if e.err != nil {
+		return e.Code + ": " + e.Message + ": " + e.err.Error()
+	}
+	return e.Code + ": " + e.Message
+}
+
+func (e *Error) Unwrap() error {
+	return e.err
+}
+
+// WithDetails returns a copy of e carrying additional machine-readable details.
+func (e *Error) WithDetails(details map[string]any) *Error {
+	c := *e
+	c.Details = details
+	return &c
+}
+
+// wrap returns a copy of e that keeps err as its cause.
+func (e *Error) wrap(err error) *Error {
+	c := *e
+	c.err = err
+	return &c
+}
+
+// catalogue maps service errors to the API errors returned for them.
+var catalogue = []struct {
+	err    error
+	apiErr *Error
+}{
+	{serviceErr.ErrTeamExists, New(CodeTeamExists, "team already exists")},
+	{serviceErr.ErrTeamNotFound, New(CodeNotFound, "team not found")},
+	{serviceErr.ErrTeamNotEmpty, New(CodeTeamNotEmpty, "team has members")},
+	{serviceErr.ErrUserNotFound, New(CodeNotFound, "user not found")},
+	{serviceErr.ErrDuplicateUser, New(CodeInvalidRequest, "user is listed more than once")},
+	{serviceErr.ErrInvalidUserID, New(CodeInvalidRequest, "user_id does not match the configured format")},
+	{serviceErr.ErrInvalidTimezone, New(CodeInvalidRequest, "unknown time zone")},
+	{serviceErr.ErrInvalidWorkingHours, New(CodeInvalidRequest, "invalid working hours")},
+	{serviceErr.ErrInvalidTag, New(CodeInvalidRequest, "invalid skill tag")},
+	{serviceErr.ErrInvalidLevel, New(CodeInvalidRequest, "unknown seniority level")},
+	{serviceErr.ErrInvalidSeniorityRule, New(CodeInvalidRequest, "invalid seniority rule")},
+	{serviceErr.ErrAbsenceNotFound, New(CodeNotFound, "absence not found")},
+	{serviceErr.ErrInvalidAbsence, New(CodeInvalidRequest, "absence must end after it starts")},
+	{serviceErr.ErrInvalidCalendar, New(CodeInvalidRequest, "invalid iCalendar file")},
+	{serviceErr.ErrInvalidPRID, New(CodeInvalidRequest, "pull_request_id does not match the configured format")},
+	{serviceErr.ErrPRExists, New(CodePRExists, "PR id already exists")},
+	{serviceErr.ErrPRNotFound, New(CodeNotFound, "PR not found")},
+	{serviceErr.ErrPRMerged, New(CodePRMerged, "PR is merged")},
+	{serviceErr.ErrAuthorNotCorrect, New(CodeNotFound, "author not found or has no team")},
+	{serviceErr.ErrAuthorNotInTeam, New(CodeNotFound, "author is not a member of the team")},
+	{serviceErr.ErrReviewerNotFound, New(CodeNotAssigned, "reviewer is not assigned to this PR")},
+	{serviceErr.ErrReviewerIsAuthor, New(CodeInvalidRequest, "author cannot review own PR")},
+	{serviceErr.ErrReviewerInactive, New(CodeInactive, "reviewer is not active")},
+	{serviceErr.ErrReviewerAssigned, New(CodeAssigned, "reviewer is already assigned to this PR")},
+	{serviceErr.ErrRepositoryExists, New(CodeRepoExists, "repository already exists")},
+	{serviceErr.ErrRepositoryNotFound, New(CodeNotFound, "repository not found")},
+	{serviceErr.ErrInvalidPattern, New(CodeInvalidRequest, "invalid code owner pattern")},
+}
+
+var errInternal = New(CodeInternalError, "internal server error")
+
+// From converts any error returned by a handler into an API error.
+// Unknown errors become INTERNAL_ERROR so that their text never leaks to clients.
+func From(err error) *Error {
+	var e *Error
+	if errors.As(err, &e) {
+		return e
+	}
+
+	for _, entry := range catalogue {
+		if errors.Is(err, entry.err) {
+			return entry.apiErr.wrap(err)
+		}
+	}
+
+	return errInternal.wrap(err)
+}
+
+func InvalidRequest(message string) *Error {
+	return New(CodeInvalidRequest, message)
+}
+
+// InvalidBody reports a request body that failed to bind.
+func InvalidBody(err error) *Error {
+	return New(CodeInvalidRequest, "invalid request body: "+err.Error()).wrap(err)
+}
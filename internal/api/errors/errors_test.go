@@ -0,0 +1,180 @@
+package apiErr
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"slices"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	serviceErr "github.com/whitxowl/pr-reviewer-assignment-service.git/internal/service/errors"
+	"gopkg.in/yaml.v3"
+)
+
+const specPath = "../../../openapi.yml"
+
+type spec struct {
+	Components struct {
+		Responses map[string]response `yaml:"responses"`
+		Schemas   struct {
+			ErrorResponse struct {
+				Properties struct {
+					Error struct {
+						Properties struct {
+							Code struct {
+								Enum []string `yaml:"enum"`
+							} `yaml:"code"`
+						} `yaml:"properties"`
+					} `yaml:"error"`
+				} `yaml:"properties"`
+			} `yaml:"ErrorResponse"`
+		} `yaml:"schemas"`
+	} `yaml:"components"`
+	Paths map[string]map[string]struct {
+		Responses map[string]response `yaml:"responses"`
+	} `yaml:"paths"`
+}
+
+type response struct {
+	Content map[string]struct {
+		Example  *errorExample `yaml:"example"`
+		Examples map[string]struct {
+			Value *errorExample `yaml:"value"`
+		} `yaml:"examples"`
+	} `yaml:"content"`
+}
+
+type errorExample struct {
+	Error struct {
+		Code string `yaml:"code"`
+	} `yaml:"error"`
+}
+
+// codes returns the error codes used by the examples of r.
+func (r response) codes() []string {
+	var codes []string
+	for _, content := range r.Content {
+		if content.Example != nil && content.Example.Error.Code != "" {
+			codes = append(codes, content.Example.Error.Code)
+		}
+		for _, example := range content.Examples {
+			if example.Value != nil && example.Value.Error.Code != "" {
+				codes = append(codes, example.Value.Error.Code)
+			}
+		}
+	}
+	return codes
+}
+
+func loadSpec(t *testing.T) *spec {
+	t.Helper()
+
+	data, err := os.ReadFile(specPath)
+	require.NoError(t, err)
+
+	var s spec
+	require.NoError(t, yaml.Unmarshal(data, &s))
+
+	return &s
+}
+
+func TestStatuses_MatchSpecEnum(t *testing.T) {
+	s := loadSpec(t)
+
+	enum := s.Components.Schemas.ErrorResponse.Properties.Error.Properties.Code.Enum
+	require.NotEmpty(t, enum)
+
+	assert.ElementsMatch(t, enum, slices.Collect(maps.Keys(Statuses)))
+}
+
+func TestCatalogue_CodesInSpec(t *testing.T) {
+	for _, entry := range catalogue {
+		status, ok := Statuses[entry.apiErr.Code]
+		assert.True(t, ok, "code %s of %v has no status", entry.apiErr.Code, entry.err)
+		assert.Equal(t, status, entry.apiErr.Status)
+	}
+}
+
+func TestSpecExamples_MatchStatuses(t *testing.T) {
+	s := loadSpec(t)
+
+	check := func(where string, status string, r response) {
+		for _, code := range r.codes() {
+			expected, ok := Statuses[code]
+			if !assert.True(t, ok, "%s: unknown code %s", where, code) {
+				continue
+			}
+			if status != "" {
+				assert.Equal(t, strconv.Itoa(expected), status, "%s: code %s", where, code)
+			}
+		}
+	}
+
+	for name, r := range s.Components.Responses {
+		// Shared responses carry no status of their own; check only that
+		// their codes exist.
+		check("components/responses/"+name, "", r)
+	}
+
+	for path, operations := range s.Paths {
+		for method, operation := range operations {
+			for status, r := range operation.Responses {
+				check(fmt.Sprintf("%s %s %s", method, path, status), status, r)
+			}
+		}
+	}
+}
+
+func TestFrom(t *testing.T) {
+	tests := []struct {
+		name           string
+		err            error
+		expectedCode   string
+		expectedStatus int
+	}{
+		{name: "team exists", err: serviceErr.ErrTeamExists, expectedCode: CodeTeamExists, expectedStatus: 409},
+		{name: "team not found", err: serviceErr.ErrTeamNotFound, expectedCode: CodeNotFound, expectedStatus: 404},
+		{name: "team not empty", err: serviceErr.ErrTeamNotEmpty, expectedCode: CodeTeamNotEmpty, expectedStatus: 409},
+		{name: "user not found", err: serviceErr.ErrUserNotFound, expectedCode: CodeNotFound, expectedStatus: 404},
+		{name: "duplicate user", err: serviceErr.ErrDuplicateUser, expectedCode: CodeInvalidRequest, expectedStatus: 400},
+		{name: "invalid user id", err: serviceErr.ErrInvalidUserID, expectedCode: CodeInvalidRequest, expectedStatus: 400},
+		{name: "invalid timezone", err: serviceErr.ErrInvalidTimezone, expectedCode: CodeInvalidRequest, expectedStatus: 400},
+		{name: "invalid working hours", err: serviceErr.ErrInvalidWorkingHours, expectedCode: CodeInvalidRequest, expectedStatus: 400},
+		{name: "invalid tag", err: serviceErr.ErrInvalidTag, expectedCode: CodeInvalidRequest, expectedStatus: 400},
+		{name: "invalid level", err: serviceErr.ErrInvalidLevel, expectedCode: CodeInvalidRequest, expectedStatus: 400},
+		{name: "invalid seniority rule", err: serviceErr.ErrInvalidSeniorityRule, expectedCode: CodeInvalidRequest, expectedStatus: 400},
+		{name: "absence not found", err: serviceErr.ErrAbsenceNotFound, expectedCode: CodeNotFound, expectedStatus: 404},
+		{name: "invalid absence", err: serviceErr.ErrInvalidAbsence, expectedCode: CodeInvalidRequest, expectedStatus: 400},
+		{name: "invalid calendar", err: serviceErr.ErrInvalidCalendar, expectedCode: CodeInvalidRequest, expectedStatus: 400},
+		{name: "invalid pr id", err: serviceErr.ErrInvalidPRID, expectedCode: CodeInvalidRequest, expectedStatus: 400},
+		{name: "pr exists", err: serviceErr.ErrPRExists, expectedCode: CodePRExists, expectedStatus: 409},
+		{name: "pr not found", err: serviceErr.ErrPRNotFound, expectedCode: CodeNotFound, expectedStatus: 404},
+		{name: "pr merged", err: serviceErr.ErrPRMerged, expectedCode: CodePRMerged, expectedStatus: 409},
+		{name: "author not correct", err: serviceErr.ErrAuthorNotCorrect, expectedCode: CodeNotFound, expectedStatus: 404},
+		{name: "author not in team", err: serviceErr.ErrAuthorNotInTeam, expectedCode: CodeNotFound, expectedStatus: 404},
+		{name: "reviewer not assigned", err: serviceErr.ErrReviewerNotFound, expectedCode: CodeNotAssigned, expectedStatus: 409},
+		{name: "reviewer is author", err: serviceErr.ErrReviewerIsAuthor, expectedCode: CodeInvalidRequest, expectedStatus: 400},
+		{name: "reviewer inactive", err: serviceErr.ErrReviewerInactive, expectedCode: CodeInactive, expectedStatus: 409},
+		{name: "reviewer assigned", err: serviceErr.ErrReviewerAssigned, expectedCode: CodeAssigned, expectedStatus: 409},
+		{name: "repository exists", err: serviceErr.ErrRepositoryExists, expectedCode: CodeRepoExists, expectedStatus: 409},
+		{name: "repository not found", err: serviceErr.ErrRepositoryNotFound, expectedCode: CodeNotFound, expectedStatus: 404},
+		{name: "invalid pattern", err: serviceErr.ErrInvalidPattern, expectedCode: CodeInvalidRequest, expectedStatus: 400},
+		{name: "wrapped service error", err: fmt.Errorf("op: %w", serviceErr.ErrPRMerged), expectedCode: CodePRMerged, expectedStatus: 409},
+		{name: "api error", err: InvalidRequest("user_id is required"), expectedCode: CodeInvalidRequest, expectedStatus: 400},
+		{name: "unknown error", err: errors.New("connection refused"), expectedCode: CodeInternalError, expectedStatus: 500},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := From(tt.err)
+
+			assert.Equal(t, tt.expectedCode, result.Code)
+			assert.Equal(t, tt.expectedStatus, result.Status)
+			assert.ErrorIs(t, result, tt.err)
+		})
+	}
+}
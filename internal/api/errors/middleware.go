@@ -0,0 +1,47 @@
+package apiErr
+
+import (
+	"log/slog"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ErrorResponse struct {
+	Error ErrorDetail `json:"error"`
+}
+
+type ErrorDetail struct {
+	Code    string         `json:"code"`
+	Message string         `json:"message"`
+	Details map[string]any `json:"details,omitempty"`
+}
+
+// Middleware renders the last error attached with c.Error as ErrorResponse.
+// Handlers only call c.Error(err) and return.
+func Middleware(log *slog.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Next()
+
+		if len(c.Errors) == 0 || c.Writer.Written() {
+			return
+		}
+
+		err := c.Errors.Last().Err
+		apiErr := From(err)
+
+		if apiErr.Status >= 500 {
+			log.ErrorContext(c.Request.Context(), "request failed",
+				slog.String("path", c.Request.URL.Path),
+				slog.String("error", err.Error()),
+			)
+		}
+
+		c.JSON(apiErr.Status, ErrorResponse{
+			Error: ErrorDetail{
+				Code:    apiErr.Code,
+				Message: apiErr.Message,
+				Details: apiErr.Details,
+			},
+		})
+	}
+}
@@ -0,0 +1,69 @@
+package health
+
+import (
+	"time"
+
+	"github.com/whitxowl/pr-reviewer-assignment-service.git/internal/domain"
+)
+
+const (
+	statusOK   = "ok"
+	statusFail = "fail"
+)
+
+type LiveResponse struct {
+	Status string `json:"status"`
+}
+
+type ReadyResponse struct {
+	Status       string                   `json:"status"`
+	ShuttingDown bool                     `json:"shutting_down"`
+	Checks       map[string]CheckResponse `json:"checks"`
+	Workers      []WorkerResponse         `json:"workers"`
+}
+
+type CheckResponse struct {
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+type WorkerResponse struct {
+	Name      string     `json:"name"`
+	Status    string     `json:"status"`
+	LastRunAt *time.Time `json:"last_run_at"`
+	LastError string     `json:"last_error,omitempty"`
+}
+
+func toStatus(ok bool) string {
+	if ok {
+		return statusOK
+	}
+	return statusFail
+}
+
+func ToReadyResponse(readiness *domain.Readiness) ReadyResponse {
+	response := ReadyResponse{
+		Status:       toStatus(readiness.Ready),
+		ShuttingDown: readiness.ShuttingDown,
+		Checks:       make(map[string]CheckResponse, len(readiness.Checks)),
+		Workers:      make([]WorkerResponse, len(readiness.Workers)),
+	}
+
+	for _, check := range readiness.Checks {
+		response.Checks[check.Name] = CheckResponse{
+			Status: toStatus(check.OK),
+			Error:  check.Error,
+		}
+	}
+
+	for i, worker := range readiness.Workers {
+		response.Workers[i] = WorkerResponse{
+			Name:      worker.Name,
+			Status:    toStatus(worker.Running),
+			LastRunAt: worker.LastRunAt,
+			LastError: worker.LastError,
+		}
+	}
+
+	return response
+}
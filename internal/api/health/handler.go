@@ -0,0 +1,24 @@
+package health
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (h *Handler) livez(c *gin.Context) {
+	c.JSON(http.StatusOK, LiveResponse{Status: statusOK})
+}
+
+func (h *Handler) readyz(c *gin.Context) {
+	readiness := h.healthService.Ready(c.Request.Context())
+
+	response := ToReadyResponse(readiness)
+
+	if !readiness.Ready {
+		c.JSON(http.StatusServiceUnavailable, response)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
@@ -0,0 +1,28 @@
+package health
+
+import (
+	"context"
+
+	"github.com/gin-gonic/gin"
+	"github.com/whitxowl/pr-reviewer-assignment-service.git/internal/domain"
+)
+
+type HealthService interface {
+	Ready(ctx context.Context) *domain.Readiness
+}
+
+type Handler struct {
+	healthService HealthService
+}
+
+func New(healthService HealthService) *Handler {
+	return &Handler{
+		healthService: healthService,
+	}
+}
+
+func (h *Handler) RegisterRoutes(router *gin.RouterGroup) {
+	router.GET("/health", h.livez)
+	router.GET("/livez", h.livez)
+	router.GET("/readyz", h.readyz)
+}
@@ -0,0 +1,90 @@
+package absence
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	apiErr "github.com/whitxowl/pr-reviewer-assignment-service.git/internal/api/errors"
+)
+
+// maxCalendarSize bounds the request body of a calendar upload.
+const maxCalendarSize = 1 << 20
+
+func (h *Handler) add(c *gin.Context) {
+	var req AddAbsenceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(apiErr.InvalidBody(err))
+		return
+	}
+
+	absence, err := h.absenceService.AddAbsence(c.Request.Context(), req.ToDomain())
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	response := ToAbsenceEnvelopeResponse(absence)
+
+	c.JSON(http.StatusCreated, response)
+}
+
+func (h *Handler) get(c *gin.Context) {
+	userID := c.Query("user_id")
+	if userID == "" {
+		c.Error(apiErr.InvalidRequest("user_id is required"))
+		return
+	}
+
+	absences, err := h.absenceService.GetAbsences(c.Request.Context(), userID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	response := ToAbsencesResponse(userID, absences)
+
+	c.JSON(http.StatusOK, response)
+}
+
+func (h *Handler) delete(c *gin.Context) {
+	var req DeleteAbsenceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(apiErr.InvalidBody(err))
+		return
+	}
+
+	err := h.absenceService.DeleteAbsence(c.Request.Context(), req.UserID, req.AbsenceID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (h *Handler) importCalendar(c *gin.Context) {
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxCalendarSize)
+
+	var req ImportCalendarRequest
+	if err := c.ShouldBind(&req); err != nil {
+		c.Error(apiErr.InvalidBody(err))
+		return
+	}
+
+	file, err := req.File.Open()
+	if err != nil {
+		c.Error(apiErr.InvalidBody(err))
+		return
+	}
+	defer file.Close()
+
+	absences, skipped, err := h.absenceService.ImportCalendar(c.Request.Context(), req.UserID, file, req.Reassign)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	response := ToImportCalendarResponse(req.UserID, absences, skipped)
+
+	c.JSON(http.StatusOK, response)
+}
@@ -0,0 +1,124 @@
+package absence
+
+import (
+	"mime/multipart"
+	"time"
+
+	"github.com/whitxowl/pr-reviewer-assignment-service.git/internal/domain"
+)
+
+type AddAbsenceRequest struct {
+	UserID   string    `json:"user_id" binding:"required"`
+	StartsAt time.Time `json:"starts_at" binding:"required"`
+	EndsAt   time.Time `json:"ends_at" binding:"required"`
+	Reason   string    `json:"reason"`
+	// Reassign hands the user's open reviews over to other reviewers when the
+	// absence starts.
+	Reassign bool `json:"reassign"`
+}
+
+func (r *AddAbsenceRequest) ToDomain() domain.Absence {
+	return domain.Absence{
+		UserID:   r.UserID,
+		StartsAt: r.StartsAt,
+		EndsAt:   r.EndsAt,
+		Reason:   r.Reason,
+		Reassign: r.Reassign,
+	}
+}
+
+type DeleteAbsenceRequest struct {
+	UserID    string `json:"user_id" binding:"required"`
+	AbsenceID int64  `json:"absence_id" binding:"required"`
+}
+
+// ImportCalendarRequest is a multipart/form-data upload of an .ics file.
+type ImportCalendarRequest struct {
+	UserID   string                `form:"user_id" binding:"required"`
+	Reassign bool                  `form:"reassign"`
+	File     *multipart.FileHeader `form:"file" binding:"required"`
+}
+
+type AbsenceEnvelopeResponse struct {
+	Absence AbsenceResponse `json:"absence"`
+}
+
+type AbsencesResponse struct {
+	UserID   string            `json:"user_id"`
+	Absences []AbsenceResponse `json:"absences"`
+}
+
+// ImportCalendarResponse lists the absences imported and the calendar events
+// that were skipped.
+type ImportCalendarResponse struct {
+	UserID        string                 `json:"user_id"`
+	Absences      []AbsenceResponse      `json:"absences"`
+	SkippedEvents []SkippedEventResponse `json:"skipped_events"`
+}
+
+type SkippedEventResponse struct {
+	UID     string `json:"uid"`
+	Summary string `json:"summary"`
+	Reason  string `json:"reason"`
+}
+
+type AbsenceResponse struct {
+	AbsenceID    int64      `json:"absence_id"`
+	UserID       string     `json:"user_id"`
+	StartsAt     time.Time  `json:"starts_at"`
+	EndsAt       time.Time  `json:"ends_at"`
+	Reason       string     `json:"reason"`
+	Reassign     bool       `json:"reassign"`
+	ReassignedAt *time.Time `json:"reassigned_at,omitempty"`
+	UID          string     `json:"uid,omitempty"`
+}
+
+func ToAbsenceEnvelopeResponse(absence *domain.Absence) AbsenceEnvelopeResponse {
+	return AbsenceEnvelopeResponse{
+		Absence: toAbsenceResponse(*absence),
+	}
+}
+
+func ToAbsencesResponse(userID string, absences []domain.Absence) AbsencesResponse {
+	response := AbsencesResponse{
+		UserID:   userID,
+		Absences: make([]AbsenceResponse, len(absences)),
+	}
+	for i, absence := range absences {
+		response.Absences[i] = toAbsenceResponse(absence)
+	}
+	return response
+}
+
+func ToImportCalendarResponse(
+	userID string,
+	absences []domain.Absence,
+	skipped []domain.SkippedEvent,
+) ImportCalendarResponse {
+	response := ImportCalendarResponse{
+		UserID:        userID,
+		Absences:      ToAbsencesResponse(userID, absences).Absences,
+		SkippedEvents: make([]SkippedEventResponse, len(skipped)),
+	}
+	for i, event := range skipped {
+		response.SkippedEvents[i] = SkippedEventResponse{
+			UID:     event.UID,
+			Summary: event.Summary,
+			Reason:  event.Reason,
+		}
+	}
+	return response
+}
+
+func toAbsenceResponse(absence domain.Absence) AbsenceResponse {
+	return AbsenceResponse{
+		AbsenceID:    absence.ID,
+		UserID:       absence.UserID,
+		StartsAt:     absence.StartsAt,
+		EndsAt:       absence.EndsAt,
+		Reason:       absence.Reason,
+		Reassign:     absence.Reassign,
+		ReassignedAt: absence.ReassignedAt,
+		UID:          absence.UID,
+	}
+}
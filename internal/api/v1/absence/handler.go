@@ -0,0 +1,36 @@
+package absence
+
+import (
+	"context"
+	"io"
+
+	"github.com/gin-gonic/gin"
+	"github.com/whitxowl/pr-reviewer-assignment-service.git/internal/domain"
+)
+
+type AbsenceService interface {
+	AddAbsence(ctx context.Context, absence domain.Absence) (*domain.Absence, error)
+	GetAbsences(ctx context.Context, userID string) ([]domain.Absence, error)
+	DeleteAbsence(ctx context.Context, userID string, absenceID int64) error
+	ImportCalendar(ctx context.Context, userID string, r io.Reader, reassign bool) ([]domain.Absence, []domain.SkippedEvent, error)
+}
+
+type Handler struct {
+	absenceService AbsenceService
+}
+
+func New(absenceService AbsenceService) *Handler {
+	return &Handler{
+		absenceService: absenceService,
+	}
+}
+
+func (h *Handler) RegisterRoutes(router *gin.RouterGroup) {
+	absenceGroup := router.Group("/users/absences")
+	{
+		absenceGroup.POST("/add", h.add)
+		absenceGroup.GET("/get", h.get)
+		absenceGroup.POST("/delete", h.delete)
+		absenceGroup.POST("/import", h.importCalendar)
+	}
+}
@@ -3,13 +3,76 @@ package pr
 import (
 	"time"
 
+	apiErr "github.com/whitxowl/pr-reviewer-assignment-service.git/internal/api/errors"
 	"github.com/whitxowl/pr-reviewer-assignment-service.git/internal/domain"
 )
 
 type CreatePRRequest struct {
-	PRID     string `json:"pull_request_id" binding:"required"`
-	PRName   string `json:"pull_request_name" binding:"required"`
-	AuthorID string `json:"author_id" binding:"required"`
+	// Repository scopes PRID; PRs without it share one global namespace.
+	Repository string `json:"repository"`
+	PRID       string `json:"pull_request_id" binding:"required"`
+	PRName     string `json:"pull_request_name" binding:"required"`
+	AuthorID   string `json:"author_id" binding:"required"`
+	// TeamName picks the team reviewers come from when the author is in several teams.
+	TeamName string `json:"team_name"`
+	// ChangedFiles are matched against the repository's code owner rules.
+	ChangedFiles []string `json:"changed_files"`
+	// RequiredTags each need a reviewer with the skill tag, where the team has one.
+	RequiredTags []string `json:"required_tags"`
+}
+
+func (r *CreatePRRequest) ToDomain() domain.PRDraft {
+	return domain.PRDraft{
+		Repository:      r.Repository,
+		PullRequestID:   r.PRID,
+		PullRequestName: r.PRName,
+		AuthorID:        r.AuthorID,
+		TeamName:        r.TeamName,
+		ChangedFiles:    r.ChangedFiles,
+		RequiredTags:    r.RequiredTags,
+	}
+}
+
+// ImportQuery picks one of the domain.Import* modes.
+type ImportQuery struct {
+	Mode string `form:"mode,default=atomic" binding:"oneof=atomic best_effort"`
+}
+
+// ImportPRRequest is a PR of an import. AssignedReviewers, when present, are
+// kept instead of assigning reviewers; an empty list keeps the PR without any.
+type ImportPRRequest struct {
+	CreatePRRequest
+	AssignedReviewers []string `json:"assigned_reviewers"`
+}
+
+func (r *ImportPRRequest) ToDomain() domain.ImportDraft {
+	return domain.ImportDraft{
+		Draft:     r.CreatePRRequest.ToDomain(),
+		Reviewers: r.AssignedReviewers,
+	}
+}
+
+// Import result statuses.
+const (
+	importImported = "imported"
+	importFailed   = "failed"
+	importSkipped  = "skipped" // not imported since another PR of an atomic import failed
+)
+
+type ImportResponse struct {
+	Mode     string                 `json:"mode"`
+	Imported int                    `json:"imported"`
+	Failed   int                    `json:"failed"`
+	Results  []ImportResultResponse `json:"results"`
+}
+
+type ImportResultResponse struct {
+	Index      int                 `json:"index"`
+	Repository string              `json:"repository,omitempty"`
+	PRID       string              `json:"pull_request_id"`
+	Status     string              `json:"status"`
+	Error      *apiErr.ErrorDetail `json:"error,omitempty"`
+	PR         *PRResponse         `json:"pr,omitempty"`
 }
 
 type CreatePRResponse struct {
@@ -17,15 +80,138 @@ type CreatePRResponse struct {
 }
 
 type PRResponse struct {
-	PRID      string   `json:"pull_request_id"`
-	PRName    string   `json:"pull_request_name"`
-	AuthorID  string   `json:"author_id"`
-	Status    string   `json:"status"`
-	Reviewers []string `json:"assigned_reviewers"`
+	Repository string   `json:"repository,omitempty"`
+	PRID       string   `json:"pull_request_id"`
+	PRName     string   `json:"pull_request_name"`
+	AuthorID   string   `json:"author_id"`
+	TeamName   string   `json:"team_name"`
+	Status     string   `json:"status"`
+	Reviewers  []string `json:"assigned_reviewers"`
+
+	Rationale []RationaleResponse `json:"assignment_rationale"`
+	// SeniorityViolations are the team's seniority rules the assignment could not satisfy.
+	SeniorityViolations []SeniorityViolationResponse `json:"seniority_violations,omitempty"`
+}
+
+// SeniorityViolationResponse is a seniority rule with Assigned reviewers of its
+// level out of the MinReviewers it requires.
+type SeniorityViolationResponse struct {
+	AuthorLevel  string `json:"author_level"`
+	MinLevel     string `json:"min_level"`
+	MinReviewers int    `json:"min_reviewers"`
+	Assigned     int    `json:"assigned"`
+}
+
+// RationaleResponse explains why a reviewer was assigned.
+type RationaleResponse struct {
+	ReviewerID string             `json:"reviewer_id"`
+	Strategy   string             `json:"strategy"`
+	Rule       string             `json:"rule,omitempty"`
+	PoolSize   int                `json:"pool_size"`
+	Excluded   []ExcludedResponse `json:"excluded"`
+}
+
+type ExcludedResponse struct {
+	UserID string `json:"user_id"`
+	Reason string `json:"reason"`
+}
+
+type GetPRResponse struct {
+	PR PRDetailResponse `json:"pr"`
+}
+
+type PRDetailResponse struct {
+	Repository string     `json:"repository,omitempty"`
+	PRID       string     `json:"pull_request_id"`
+	PRName     string     `json:"pull_request_name"`
+	AuthorID   string     `json:"author_id"`
+	TeamName   string     `json:"team_name"`
+	Status     string     `json:"status"`
+	Reviewers  []string   `json:"assigned_reviewers"`
+	CreatedAt  *time.Time `json:"created_at"`
+	MergedAt   *time.Time `json:"merged_at"`
+
+	Rationale []RationaleResponse `json:"assignment_rationale"`
+}
+
+type PendingResponse struct {
+	PullRequests []PendingPRResponse `json:"pull_requests"`
+}
+
+type PendingPRResponse struct {
+	Repository       string     `json:"repository,omitempty"`
+	PRID             string     `json:"pull_request_id"`
+	PRName           string     `json:"pull_request_name"`
+	AuthorID         string     `json:"author_id"`
+	TeamName         string     `json:"team_name"`
+	MissingReviewers int        `json:"missing_reviewers"`
+	QueuedAt         *time.Time `json:"queued_at"`
+}
+
+func ToPendingResponse(pending []domain.PendingSlots) PendingResponse {
+	response := PendingResponse{
+		PullRequests: make([]PendingPRResponse, len(pending)),
+	}
+
+	for i, slots := range pending {
+		response.PullRequests[i] = PendingPRResponse{
+			Repository:       slots.PR.Repository,
+			PRID:             slots.PR.PullRequestID,
+			PRName:           slots.PR.PullRequestName,
+			AuthorID:         slots.PR.AuthorID,
+			TeamName:         slots.PR.TeamName,
+			MissingReviewers: slots.Slots,
+			QueuedAt:         slots.QueuedAt,
+		}
+	}
+
+	return response
+}
+
+// PreviewRequest describes a hypothetical PR; nothing is created.
+type PreviewRequest struct {
+	Repository string `json:"repository"`
+	// PRID picks the reviewers of that PR in the hash order; optional.
+	PRID         string   `json:"pull_request_id"`
+	AuthorID     string   `json:"author_id" binding:"required"`
+	TeamName     string   `json:"team_name"`
+	ChangedFiles []string `json:"changed_files"`
+	RequiredTags []string `json:"required_tags"`
+}
+
+func (r *PreviewRequest) ToDomain() domain.PRDraft {
+	return domain.PRDraft{
+		Repository:    r.Repository,
+		PullRequestID: r.PRID,
+		AuthorID:      r.AuthorID,
+		TeamName:      r.TeamName,
+		ChangedFiles:  r.ChangedFiles,
+		RequiredTags:  r.RequiredTags,
+	}
+}
+
+type PreviewResponse struct {
+	TeamName   string              `json:"team_name"`
+	Candidates []CandidateResponse `json:"candidates"`
+	Picks      []RationaleResponse `json:"picks"`
+	// Queued is the number of slots that would wait for a reviewer under capacity.
+	Queued int `json:"queued,omitempty"`
+	// UncoveredTags are the required tags no eligible team member has.
+	UncoveredTags []string `json:"uncovered_tags,omitempty"`
+	// SeniorityViolations are the seniority rules the picks would not satisfy.
+	SeniorityViolations []SeniorityViolationResponse `json:"seniority_violations,omitempty"`
+}
+
+type CandidateResponse struct {
+	Rank     int    `json:"rank"`
+	UserID   string `json:"user_id"`
+	Strategy string `json:"strategy"`
+	Rule     string `json:"rule,omitempty"`
 }
 
 type MergeRequest struct {
-	PRID string `json:"pull_request_id" binding:"required"`
+	Repository string `json:"repository"`
+	PRID       string `json:"pull_request_id" binding:"required"`
 }
 
 type MergeResponse struct {
@@ -33,17 +219,30 @@ type MergeResponse struct {
 }
 
 type PRMergedResponse struct {
-	PRID      string     `json:"pull_request_id"`
-	PRName    string     `json:"pull_request_name"`
-	AuthorID  string     `json:"author_id"`
-	Status    string     `json:"status"`
-	Reviewers []string   `json:"assigned_reviewers"`
-	MergedAt  *time.Time `json:"merged_at"`
+	Repository string     `json:"repository,omitempty"`
+	PRID       string     `json:"pull_request_id"`
+	PRName     string     `json:"pull_request_name"`
+	AuthorID   string     `json:"author_id"`
+	Status     string     `json:"status"`
+	Reviewers  []string   `json:"assigned_reviewers"`
+	MergedAt   *time.Time `json:"merged_at"`
 }
 
 type ReassignRequest struct {
+	Repository    string `json:"repository"`
 	PRID          string `json:"pull_request_id" binding:"required"`
 	OldReviewerID string `json:"old_reviewer_id" binding:"required"`
+	// NewReviewerID names the replacement instead of selecting one; optional.
+	NewReviewerID string `json:"new_reviewer_id"`
+	// DryRun shows who would be chosen without reassigning.
+	DryRun bool `json:"dry_run"`
+}
+
+// ReviewerRequest names a reviewer to add to or remove from a PR.
+type ReviewerRequest struct {
+	Repository string `json:"repository"`
+	PRID       string `json:"pull_request_id" binding:"required"`
+	ReviewerID string `json:"reviewer_id" binding:"required"`
 }
 
 type ReassignResponse struct {
@@ -51,57 +250,170 @@ type ReassignResponse struct {
 }
 
 type PRReassignResponse struct {
+	Repository string   `json:"repository,omitempty"`
 	PRID       string   `json:"pull_request_id"`
 	PRName     string   `json:"pull_request_name"`
 	AuthorID   string   `json:"author_id"`
 	Status     string   `json:"status"`
 	Reviewers  []string `json:"assigned_reviewers"`
 	ReplacedBy string   `json:"replaced_by"`
-}
+	DryRun     bool     `json:"dry_run"`
 
-type ErrorResponse struct {
-	Error ErrorDetail `json:"error"`
-}
-
-type ErrorDetail struct {
-	Code    string `json:"code"`
-	Message string `json:"message"`
+	Rationale           []RationaleResponse          `json:"assignment_rationale"`
+	SeniorityViolations []SeniorityViolationResponse `json:"seniority_violations,omitempty"`
 }
 
 func ToCreatePRResponse(pr *domain.PullRequest) CreatePRResponse {
 	return CreatePRResponse{
 		PR: PRResponse{
-			PRID:      pr.PullRequestID,
-			PRName:    pr.PullRequestName,
-			AuthorID:  pr.AuthorID,
-			Status:    pr.Status,
-			Reviewers: pr.AssignedReviewers,
+			Repository: pr.Repository,
+			PRID:       pr.PullRequestID,
+			PRName:     pr.PullRequestName,
+			AuthorID:   pr.AuthorID,
+			TeamName:   pr.TeamName,
+			Status:     pr.Status,
+			Reviewers:  pr.AssignedReviewers,
+
+			Rationale:           toRationaleResponse(pr.Rationales),
+			SeniorityViolations: toSeniorityViolationsResponse(pr.SeniorityViolations),
+		},
+	}
+}
+
+func ToImportResponse(mode string, reqs []ImportPRRequest, results []domain.ImportResult) ImportResponse {
+	response := ImportResponse{
+		Mode:    mode,
+		Results: make([]ImportResultResponse, len(results)),
+	}
+
+	for i, result := range results {
+		item := ImportResultResponse{
+			Index:      i,
+			Repository: reqs[i].Repository,
+			PRID:       reqs[i].PRID,
+			Status:     importSkipped,
+		}
+
+		switch {
+		case result.Err != nil:
+			e := apiErr.From(result.Err)
+			item.Status = importFailed
+			item.Error = &apiErr.ErrorDetail{Code: e.Code, Message: e.Message, Details: e.Details}
+			response.Failed++
+		case result.PR != nil:
+			pr := ToCreatePRResponse(result.PR).PR
+			item.Status = importImported
+			item.PR = &pr
+			response.Imported++
+		}
+
+		response.Results[i] = item
+	}
+
+	return response
+}
+
+func ToGetPRResponse(pr *domain.PullRequest) GetPRResponse {
+	return GetPRResponse{
+		PR: PRDetailResponse{
+			Repository: pr.Repository,
+			PRID:       pr.PullRequestID,
+			PRName:     pr.PullRequestName,
+			AuthorID:   pr.AuthorID,
+			TeamName:   pr.TeamName,
+			Status:     pr.Status,
+			Reviewers:  pr.AssignedReviewers,
+			CreatedAt:  pr.CreatedAt,
+			MergedAt:   pr.MergedAt,
+
+			Rationale: toRationaleResponse(pr.Rationales),
 		},
 	}
 }
 
+func toRationaleResponse(rationales []domain.AssignmentRationale) []RationaleResponse {
+	response := make([]RationaleResponse, len(rationales))
+	for i, rationale := range rationales {
+		excluded := make([]ExcludedResponse, len(rationale.Excluded))
+		for j, candidate := range rationale.Excluded {
+			excluded[j] = ExcludedResponse{UserID: candidate.UserID, Reason: candidate.Reason}
+		}
+
+		response[i] = RationaleResponse{
+			ReviewerID: rationale.ReviewerID,
+			Strategy:   rationale.Strategy,
+			Rule:       rationale.Rule,
+			PoolSize:   rationale.PoolSize,
+			Excluded:   excluded,
+		}
+	}
+	return response
+}
+
+func ToPreviewResponse(preview *domain.AssignmentPreview) PreviewResponse {
+	candidates := make([]CandidateResponse, len(preview.Candidates))
+	for i, candidate := range preview.Candidates {
+		candidates[i] = CandidateResponse{
+			Rank:     i + 1,
+			UserID:   candidate.UserID,
+			Strategy: candidate.Strategy,
+			Rule:     candidate.Rule,
+		}
+	}
+
+	return PreviewResponse{
+		TeamName:   preview.TeamName,
+		Candidates: candidates,
+		Picks:      toRationaleResponse(preview.Picks),
+		Queued:     preview.Queued,
+
+		UncoveredTags:       preview.UncoveredTags,
+		SeniorityViolations: toSeniorityViolationsResponse(preview.SeniorityViolations),
+	}
+}
+
 func ToMergeResponse(pr *domain.PullRequest) MergeResponse {
 	return MergeResponse{
 		PR: PRMergedResponse{
-			PRID:      pr.PullRequestID,
-			PRName:    pr.PullRequestName,
-			AuthorID:  pr.AuthorID,
-			Status:    pr.Status,
-			Reviewers: pr.AssignedReviewers,
-			MergedAt:  pr.MergedAt,
+			Repository: pr.Repository,
+			PRID:       pr.PullRequestID,
+			PRName:     pr.PullRequestName,
+			AuthorID:   pr.AuthorID,
+			Status:     pr.Status,
+			Reviewers:  pr.AssignedReviewers,
+			MergedAt:   pr.MergedAt,
 		},
 	}
 }
 
-func ToReassignResponse(pr *domain.PullRequest, newReviewerID string) ReassignResponse {
+func ToReassignResponse(pr *domain.PullRequest, newReviewerID string, dryRun bool) ReassignResponse {
 	return ReassignResponse{
 		PR: PRReassignResponse{
+			Repository: pr.Repository,
 			PRID:       pr.PullRequestID,
 			PRName:     pr.PullRequestName,
 			AuthorID:   pr.AuthorID,
 			Status:     pr.Status,
 			Reviewers:  pr.AssignedReviewers,
 			ReplacedBy: newReviewerID,
+			DryRun:     dryRun,
+
+			Rationale:           toRationaleResponse(pr.Rationales),
+			SeniorityViolations: toSeniorityViolationsResponse(pr.SeniorityViolations),
 		},
 	}
 }
+
+func toSeniorityViolationsResponse(violations []domain.SeniorityViolation) []SeniorityViolationResponse {
+	var response []SeniorityViolationResponse
+	for _, violation := range violations {
+		response = append(response, SeniorityViolationResponse{
+			AuthorLevel:  violation.Rule.AuthorLevel,
+			MinLevel:     violation.Rule.MinLevel,
+			MinReviewers: violation.Rule.MinReviewers,
+			Assigned:     violation.Assigned,
+		})
+	}
+
+	return response
+}
@@ -8,9 +8,29 @@ import (
 )
 
 type PRService interface {
-	CreatePR(ctx context.Context, prID string, prName string, authorID string) (*domain.PullRequest, error)
-	SetStatusMerged(ctx context.Context, prID string) (*domain.PullRequest, error)
-	ReassignReviewer(ctx context.Context, prID string, oldReviewerID string) (*domain.PullRequest, string, error)
+	CreatePR(ctx context.Context, draft domain.PRDraft) (*domain.PullRequest, error)
+	PreviewAssignment(ctx context.Context, draft domain.PRDraft) (*domain.AssignmentPreview, error)
+	GetPR(ctx context.Context, repository string, prID string) (*domain.PullRequest, error)
+	GetPendingSlots(ctx context.Context) ([]domain.PendingSlots, error)
+	SetStatusMerged(ctx context.Context, repository string, prID string) (*domain.PullRequest, error)
+	ReassignReviewer(
+		ctx context.Context,
+		repository string,
+		prID string,
+		oldReviewerID string,
+		dryRun bool,
+	) (*domain.PullRequest, string, error)
+	ReassignReviewerTo(
+		ctx context.Context,
+		repository string,
+		prID string,
+		oldReviewerID string,
+		newReviewerID string,
+		dryRun bool,
+	) (*domain.PullRequest, error)
+	AddReviewer(ctx context.Context, repository string, prID string, reviewerID string) (*domain.PullRequest, error)
+	RemoveReviewer(ctx context.Context, repository string, prID string, reviewerID string) (*domain.PullRequest, error)
+	ImportPRs(ctx context.Context, drafts []domain.ImportDraft, atomic bool) ([]domain.ImportResult, error)
 }
 
 type Handler struct {
@@ -27,7 +47,13 @@ func (h *Handler) RegisterRoutes(router *gin.RouterGroup) {
 	prGroup := router.Group("/pullRequest")
 	{
 		prGroup.POST("create", h.create)
+		prGroup.POST("import", h.importPRs)
+		prGroup.GET("get", h.get)
+		prGroup.GET("pending", h.pending)
 		prGroup.POST("merge", h.merge)
 		prGroup.POST("reassign", h.reassign)
+		prGroup.POST("addReviewer", h.addReviewer)
+		prGroup.POST("removeReviewer", h.removeReviewer)
+		prGroup.POST("previewAssignment", h.previewAssignment)
 	}
 }
@@ -1,88 +1,173 @@
 package pr
 
 import (
+	"bufio"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"io"
 	"net/http"
+	"unicode"
 
 	"github.com/gin-gonic/gin"
-	serviceErr "github.com/whitxowl/pr-reviewer-assignment-service.git/internal/service/errors"
+	"github.com/gin-gonic/gin/binding"
+	apiErr "github.com/whitxowl/pr-reviewer-assignment-service.git/internal/api/errors"
+	"github.com/whitxowl/pr-reviewer-assignment-service.git/internal/domain"
+)
+
+const (
+	// maxImportSize bounds the request body of a PR import.
+	maxImportSize = 8 << 20
+	// maxImportPRs bounds the PRs of one import.
+	maxImportPRs = 1000
 )
 
 func (h *Handler) create(c *gin.Context) {
 	var req CreatePRRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, ErrorResponse{
-			Error: ErrorDetail{
-				Code:    "INVALID_REQUEST",
-				Message: "invalid request body: " + err.Error(),
-			},
-		})
+		c.Error(apiErr.InvalidBody(err))
 		return
 	}
 
-	pr, err := h.prService.CreatePR(c.Request.Context(), req.PRID, req.PRName, req.AuthorID)
-	if errors.Is(err, serviceErr.ErrAuthorNotCorrect) {
-		c.JSON(http.StatusNotFound, ErrorResponse{
-			Error: ErrorDetail{
-				Code:    "NOT_FOUND",
-				Message: "resource not found",
-			},
-		})
+	pr, err := h.prService.CreatePR(c.Request.Context(), req.ToDomain())
+	if err != nil {
+		c.Error(err)
 		return
 	}
-	if errors.Is(err, serviceErr.ErrPRExists) {
-		c.JSON(http.StatusConflict, ErrorResponse{
-			Error: ErrorDetail{
-				Code:    "PR_EXISTS",
-				Message: "PR id already exists",
-			},
-		})
+
+	response := ToCreatePRResponse(pr)
+
+	c.JSON(http.StatusCreated, response)
+}
+
+func (h *Handler) importPRs(c *gin.Context) {
+	var query ImportQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.Error(apiErr.InvalidRequest("invalid query: " + err.Error()))
 		return
 	}
+
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxImportSize)
+
+	reqs, err := decodeImport(c.Request.Body)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, ErrorResponse{
-			Error: ErrorDetail{
-				Code:    "INTERNAL_ERROR",
-				Message: "internal server error",
-			},
-		})
+		c.Error(apiErr.InvalidBody(err))
 		return
 	}
 
-	response := ToCreatePRResponse(pr)
+	drafts := make([]domain.ImportDraft, len(reqs))
+	for i := range reqs {
+		drafts[i] = reqs[i].ToDomain()
+	}
 
-	c.JSON(http.StatusCreated, response)
+	results, err := h.prService.ImportPRs(c.Request.Context(), drafts, query.Mode == domain.ImportAtomic)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	response := ToImportResponse(query.Mode, reqs, results)
+
+	c.JSON(http.StatusOK, response)
+}
+
+// decodeImport reads the PRs of an import from a JSON array or from a stream of
+// JSON objects such as NDJSON, and validates each of them.
+func decodeImport(body io.Reader) ([]ImportPRRequest, error) {
+	r := bufio.NewReader(body)
+
+	var first byte
+	for {
+		b, err := r.ReadByte()
+		if err != nil {
+			return nil, err
+		}
+		if !unicode.IsSpace(rune(b)) {
+			first = b
+			break
+		}
+	}
+	if err := r.UnreadByte(); err != nil {
+		return nil, err
+	}
+
+	array := first == '['
+	dec := json.NewDecoder(r)
+	if array {
+		if _, err := dec.Token(); err != nil {
+			return nil, err
+		}
+	}
+
+	var reqs []ImportPRRequest
+	for !array || dec.More() {
+		var req ImportPRRequest
+		err := dec.Decode(&req)
+		if !array && errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("pull request %d: %w", len(reqs), err)
+		}
+		if err = binding.Validator.ValidateStruct(&req); err != nil {
+			return nil, fmt.Errorf("pull request %d: %w", len(reqs), err)
+		}
+		if len(reqs) == maxImportPRs {
+			return nil, fmt.Errorf("more than %d pull requests", maxImportPRs)
+		}
+
+		reqs = append(reqs, req)
+	}
+
+	if array {
+		if _, err := dec.Token(); err != nil {
+			return nil, err
+		}
+	}
+
+	return reqs, nil
+}
+
+func (h *Handler) get(c *gin.Context) {
+	prID := c.Query("pull_request_id")
+	if prID == "" {
+		c.Error(apiErr.InvalidRequest("pull_request_id is required"))
+		return
+	}
+
+	pr, err := h.prService.GetPR(c.Request.Context(), c.Query("repository"), prID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	response := ToGetPRResponse(pr)
+
+	c.JSON(http.StatusOK, response)
+}
+
+func (h *Handler) pending(c *gin.Context) {
+	pending, err := h.prService.GetPendingSlots(c.Request.Context())
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	response := ToPendingResponse(pending)
+
+	c.JSON(http.StatusOK, response)
 }
 
 func (h *Handler) merge(c *gin.Context) {
 	var req MergeRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, ErrorResponse{
-			Error: ErrorDetail{
-				Code:    "INVALID_REQUEST",
-				Message: "invalid request body: " + err.Error(),
-			},
-		})
+		c.Error(apiErr.InvalidBody(err))
 		return
 	}
 
-	pr, err := h.prService.SetStatusMerged(c.Request.Context(), req.PRID)
-	if errors.Is(err, serviceErr.ErrPRNotFound) {
-		c.JSON(http.StatusNotFound, ErrorResponse{
-			Error: ErrorDetail{
-				Code:    "NOT_FOUND",
-				Message: "resource not found",
-			},
-		})
-		return
-	}
+	pr, err := h.prService.SetStatusMerged(c.Request.Context(), req.Repository, req.PRID)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, ErrorResponse{
-			Error: ErrorDetail{
-				Code:    "INTERNAL_ERROR",
-				Message: "internal server error",
-			},
-		})
+		c.Error(err)
 		return
 	}
 
@@ -94,45 +179,83 @@ func (h *Handler) merge(c *gin.Context) {
 func (h *Handler) reassign(c *gin.Context) {
 	var req ReassignRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, ErrorResponse{
-			Error: ErrorDetail{
-				Code:    "INVALID_REQUEST",
-				Message: "invalid request body: " + err.Error(),
-			},
-		})
+		c.Error(apiErr.InvalidBody(err))
 		return
 	}
 
-	pr, newReviewerID, err := h.prService.ReassignReviewer(c.Request.Context(), req.PRID, req.OldReviewerID)
-	if errors.Is(err, serviceErr.ErrPRNotFound) || errors.Is(err, serviceErr.ErrReviewerNotFound) {
-		c.JSON(http.StatusNotFound, ErrorResponse{
-			Error: ErrorDetail{
-				Code:    "NOT_FOUND",
-				Message: "resource not found",
-			},
-		})
+	if req.NewReviewerID != "" {
+		pr, err := h.prService.ReassignReviewerTo(
+			c.Request.Context(), req.Repository, req.PRID, req.OldReviewerID, req.NewReviewerID, req.DryRun)
+		if err != nil {
+			c.Error(err)
+			return
+		}
+
+		c.JSON(http.StatusOK, ToReassignResponse(pr, req.NewReviewerID, req.DryRun))
 		return
 	}
-	if errors.Is(err, serviceErr.ErrPRMerged) {
-		c.JSON(http.StatusConflict, ErrorResponse{
-			Error: ErrorDetail{
-				Code:    "PR_MERGED",
-				Message: "cannot reassign on merged PR",
-			},
-		})
+
+	pr, newReviewerID, err := h.prService.ReassignReviewer(c.Request.Context(), req.Repository, req.PRID, req.OldReviewerID, req.DryRun)
+	if err != nil {
+		c.Error(err)
 		return
 	}
+
+	response := ToReassignResponse(pr, newReviewerID, req.DryRun)
+
+	c.JSON(http.StatusOK, response)
+}
+
+func (h *Handler) addReviewer(c *gin.Context) {
+	var req ReviewerRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(apiErr.InvalidBody(err))
+		return
+	}
+
+	pr, err := h.prService.AddReviewer(c.Request.Context(), req.Repository, req.PRID, req.ReviewerID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	response := ToGetPRResponse(pr)
+
+	c.JSON(http.StatusOK, response)
+}
+
+func (h *Handler) removeReviewer(c *gin.Context) {
+	var req ReviewerRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(apiErr.InvalidBody(err))
+		return
+	}
+
+	pr, err := h.prService.RemoveReviewer(c.Request.Context(), req.Repository, req.PRID, req.ReviewerID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	response := ToGetPRResponse(pr)
+
+	c.JSON(http.StatusOK, response)
+}
+
+func (h *Handler) previewAssignment(c *gin.Context) {
+	var req PreviewRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(apiErr.InvalidBody(err))
+		return
+	}
+
+	preview, err := h.prService.PreviewAssignment(c.Request.Context(), req.ToDomain())
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, ErrorResponse{
-			Error: ErrorDetail{
-				Code:    "INTERNAL_ERROR",
-				Message: "internal server error",
-			},
-		})
+		c.Error(err)
 		return
 	}
 
-	response := ToReassignResponse(pr, newReviewerID)
+	response := ToPreviewResponse(preview)
 
 	c.JSON(http.StatusOK, response)
 }
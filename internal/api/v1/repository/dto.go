@@ -0,0 +1,105 @@
+package repository
+
+import (
+	"github.com/whitxowl/pr-reviewer-assignment-service.git/internal/domain"
+)
+
+type CreateRepositoryRequest struct {
+	Name string `json:"name" binding:"required"`
+	// TeamName owns the repository; reviewers come from the author's team when empty.
+	TeamName       string `json:"team_name"`
+	ReviewersCount int    `json:"reviewers_count" binding:"omitempty,min=1,max=10"`
+}
+
+func (r *CreateRepositoryRequest) ToDomain() domain.Repository {
+	return domain.Repository{
+		Name:           r.Name,
+		TeamName:       r.TeamName,
+		ReviewersCount: r.ReviewersCount,
+	}
+}
+
+// UpdateRepositoryRequest changes only the fields present in the body.
+type UpdateRepositoryRequest struct {
+	Name           string  `json:"name" binding:"required"`
+	TeamName       *string `json:"team_name"`
+	ReviewersCount *int    `json:"reviewers_count" binding:"omitempty,min=1,max=10"`
+}
+
+func (r *UpdateRepositoryRequest) ToDomain() domain.RepositoryUpdate {
+	return domain.RepositoryUpdate{
+		Name:           r.Name,
+		TeamName:       r.TeamName,
+		ReviewersCount: r.ReviewersCount,
+	}
+}
+
+type RepositoryEnvelopeResponse struct {
+	Repository RepositoryResponse `json:"repository"`
+}
+
+type RepositoryResponse struct {
+	Name           string `json:"name"`
+	TeamName       string `json:"team_name"`
+	ReviewersCount int    `json:"reviewers_count"`
+}
+
+func ToRepositoryEnvelopeResponse(repo *domain.Repository) RepositoryEnvelopeResponse {
+	return RepositoryEnvelopeResponse{
+		Repository: RepositoryResponse{
+			Name:           repo.Name,
+			TeamName:       repo.TeamName,
+			ReviewersCount: repo.ReviewersCount,
+		},
+	}
+}
+
+type SetCodeOwnersRequest struct {
+	Name string `json:"name" binding:"required"`
+	// Rules are in CODEOWNERS order: for each file, the last matching rule wins.
+	Rules []CodeOwnerRuleRequest `json:"rules" binding:"dive"`
+}
+
+type CodeOwnerRuleRequest struct {
+	Pattern   string   `json:"pattern" binding:"required"`
+	UserIDs   []string `json:"user_ids"`
+	TeamNames []string `json:"team_names"`
+}
+
+func (r *SetCodeOwnersRequest) ToDomain() []domain.CodeOwnerRule {
+	rules := make([]domain.CodeOwnerRule, len(r.Rules))
+	for i, rule := range r.Rules {
+		rules[i] = domain.CodeOwnerRule{
+			Pattern:   rule.Pattern,
+			UserIDs:   rule.UserIDs,
+			TeamNames: rule.TeamNames,
+		}
+	}
+	return rules
+}
+
+type CodeOwnersResponse struct {
+	Name  string                  `json:"name"`
+	Rules []CodeOwnerRuleResponse `json:"rules"`
+}
+
+type CodeOwnerRuleResponse struct {
+	Pattern   string   `json:"pattern"`
+	UserIDs   []string `json:"user_ids"`
+	TeamNames []string `json:"team_names"`
+}
+
+func ToCodeOwnersResponse(name string, rules []domain.CodeOwnerRule) CodeOwnersResponse {
+	response := CodeOwnersResponse{
+		Name:  name,
+		Rules: make([]CodeOwnerRuleResponse, len(rules)),
+	}
+	for i, rule := range rules {
+		response.Rules[i] = CodeOwnerRuleResponse{
+			Pattern:   rule.Pattern,
+			UserIDs:   rule.UserIDs,
+			TeamNames: rule.TeamNames,
+		}
+	}
+	return response
+}
@@ -0,0 +1,37 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/gin-gonic/gin"
+	"github.com/whitxowl/pr-reviewer-assignment-service.git/internal/domain"
+)
+
+type RepositoryService interface {
+	CreateRepository(ctx context.Context, repo domain.Repository) (*domain.Repository, error)
+	GetRepository(ctx context.Context, name string) (*domain.Repository, error)
+	UpdateRepository(ctx context.Context, update domain.RepositoryUpdate) (*domain.Repository, error)
+	SetCodeOwners(ctx context.Context, repository string, rules []domain.CodeOwnerRule) ([]domain.CodeOwnerRule, error)
+	GetCodeOwners(ctx context.Context, repository string) ([]domain.CodeOwnerRule, error)
+}
+
+type Handler struct {
+	repositoryService RepositoryService
+}
+
+func New(repositoryService RepositoryService) *Handler {
+	return &Handler{
+		repositoryService: repositoryService,
+	}
+}
+
+func (h *Handler) RegisterRoutes(router *gin.RouterGroup) {
+	repositoryGroup := router.Group("/repository")
+	{
+		repositoryGroup.POST("/add", h.add)
+		repositoryGroup.GET("/get", h.get)
+		repositoryGroup.POST("/update", h.update)
+		repositoryGroup.POST("/setCodeOwners", h.setCodeOwners)
+		repositoryGroup.GET("/getCodeOwners", h.getCodeOwners)
+	}
+}
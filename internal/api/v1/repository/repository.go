@@ -0,0 +1,98 @@
+package repository
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	apiErr "github.com/whitxowl/pr-reviewer-assignment-service.git/internal/api/errors"
+)
+
+func (h *Handler) add(c *gin.Context) {
+	var req CreateRepositoryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(apiErr.InvalidBody(err))
+		return
+	}
+
+	repo, err := h.repositoryService.CreateRepository(c.Request.Context(), req.ToDomain())
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	response := ToRepositoryEnvelopeResponse(repo)
+
+	c.JSON(http.StatusCreated, response)
+}
+
+func (h *Handler) get(c *gin.Context) {
+	name := c.Query("name")
+	if name == "" {
+		c.Error(apiErr.InvalidRequest("name is required"))
+		return
+	}
+
+	repo, err := h.repositoryService.GetRepository(c.Request.Context(), name)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	response := ToRepositoryEnvelopeResponse(repo)
+
+	c.JSON(http.StatusOK, response)
+}
+
+func (h *Handler) update(c *gin.Context) {
+	var req UpdateRepositoryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(apiErr.InvalidBody(err))
+		return
+	}
+
+	repo, err := h.repositoryService.UpdateRepository(c.Request.Context(), req.ToDomain())
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	response := ToRepositoryEnvelopeResponse(repo)
+
+	c.JSON(http.StatusOK, response)
+}
+
+func (h *Handler) setCodeOwners(c *gin.Context) {
+	var req SetCodeOwnersRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(apiErr.InvalidBody(err))
+		return
+	}
+
+	rules, err := h.repositoryService.SetCodeOwners(c.Request.Context(), req.Name, req.ToDomain())
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	response := ToCodeOwnersResponse(req.Name, rules)
+
+	c.JSON(http.StatusOK, response)
+}
+
+func (h *Handler) getCodeOwners(c *gin.Context) {
+	name := c.Query("name")
+	if name == "" {
+		c.Error(apiErr.InvalidRequest("name is required"))
+		return
+	}
+
+	rules, err := h.repositoryService.GetCodeOwners(c.Request.Context(), name)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	response := ToCodeOwnersResponse(name, rules)
+
+	c.JSON(http.StatusOK, response)
+}
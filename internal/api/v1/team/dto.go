@@ -1,6 +1,10 @@
 package team
 
-import "github.com/whitxowl/pr-reviewer-assignment-service.git/internal/domain"
+import (
+	"time"
+
+	"github.com/whitxowl/pr-reviewer-assignment-service.git/internal/domain"
+)
 
 type CreateTeamRequest struct {
 	TeamName string              `json:"team_name" binding:"required"`
@@ -11,6 +15,8 @@ type TeamMemberRequest struct {
 	UserID   string `json:"user_id" binding:"required"`
 	Username string `json:"username" binding:"required"`
 	IsActive bool   `json:"is_active"`
+	// Tags replace the user's skill tags; omitted, they stay as they are.
+	Tags []string `json:"tags"`
 }
 
 type CreateTeamResponse struct {
@@ -18,23 +24,21 @@ type CreateTeamResponse struct {
 }
 
 type TeamResponse struct {
-	TeamName string               `json:"team_name"`
-	Members  []TeamMemberResponse `json:"members"`
+	TeamName   string               `json:"team_name"`
+	Members    []TeamMemberResponse `json:"members"`
+	IsArchived bool                 `json:"is_archived"`
+	ArchivedAt *time.Time           `json:"archived_at,omitempty"`
+	// MaxOpenReviews is the default review limit of the team's members.
+	MaxOpenReviews *int                   `json:"max_open_reviews,omitempty"`
+	SeniorityRules []SeniorityRuleMessage `json:"seniority_rules,omitempty"`
 }
 
 type TeamMemberResponse struct {
-	UserID   string `json:"user_id"`
-	Username string `json:"username"`
-	IsActive bool   `json:"is_active"`
-}
-
-type ErrorResponse struct {
-	Error ErrorDetail `json:"error"`
-}
-
-type ErrorDetail struct {
-	Code    string `json:"code"`
-	Message string `json:"message"`
+	UserID   string   `json:"user_id"`
+	Username string   `json:"username"`
+	IsActive bool     `json:"is_active"`
+	Tags     []string `json:"tags,omitempty"`
+	Level    string   `json:"level,omitempty"`
 }
 
 func (r *CreateTeamRequest) ToDomain() domain.Team {
@@ -49,6 +53,7 @@ func (r *CreateTeamRequest) ToDomain() domain.Team {
 			Username: member.Username,
 			TeamName: r.TeamName,
 			IsActive: member.IsActive,
+			Tags:     member.Tags,
 		}
 	}
 
@@ -58,8 +63,12 @@ func (r *CreateTeamRequest) ToDomain() domain.Team {
 func ToTeamResponse(team *domain.Team) CreateTeamResponse {
 	response := CreateTeamResponse{
 		Team: TeamResponse{
-			TeamName: team.TeamName,
-			Members:  make([]TeamMemberResponse, len(team.Members)),
+			TeamName:       team.TeamName,
+			Members:        make([]TeamMemberResponse, len(team.Members)),
+			IsArchived:     team.ArchivedAt != nil,
+			ArchivedAt:     team.ArchivedAt,
+			MaxOpenReviews: team.MaxOpenReviews,
+			SeniorityRules: toSeniorityRuleMessages(team.SeniorityRules),
 		},
 	}
 
@@ -68,6 +77,8 @@ func ToTeamResponse(team *domain.Team) CreateTeamResponse {
 			UserID:   member.UserID,
 			Username: member.Username,
 			IsActive: member.IsActive,
+			Tags:     member.Tags,
+			Level:    member.Level,
 		}
 	}
 
@@ -75,14 +86,23 @@ func ToTeamResponse(team *domain.Team) CreateTeamResponse {
 }
 
 type GetTeamResponse struct {
-	TeamName string               `json:"team_name"`
-	Members  []TeamMemberResponse `json:"members"`
+	TeamName   string               `json:"team_name"`
+	Members    []TeamMemberResponse `json:"members"`
+	IsArchived bool                 `json:"is_archived"`
+	ArchivedAt *time.Time           `json:"archived_at,omitempty"`
+	// MaxOpenReviews is the default review limit of the team's members.
+	MaxOpenReviews *int                   `json:"max_open_reviews,omitempty"`
+	SeniorityRules []SeniorityRuleMessage `json:"seniority_rules,omitempty"`
 }
 
 func ToGetTeamResponse(team *domain.Team) GetTeamResponse {
 	response := GetTeamResponse{
-		TeamName: team.TeamName,
-		Members:  make([]TeamMemberResponse, len(team.Members)),
+		TeamName:       team.TeamName,
+		Members:        make([]TeamMemberResponse, len(team.Members)),
+		IsArchived:     team.ArchivedAt != nil,
+		ArchivedAt:     team.ArchivedAt,
+		MaxOpenReviews: team.MaxOpenReviews,
+		SeniorityRules: toSeniorityRuleMessages(team.SeniorityRules),
 	}
 
 	for i, member := range team.Members {
@@ -90,6 +110,311 @@ func ToGetTeamResponse(team *domain.Team) GetTeamResponse {
 			UserID:   member.UserID,
 			Username: member.Username,
 			IsActive: member.IsActive,
+			Tags:     member.Tags,
+			Level:    member.Level,
+		}
+	}
+
+	return response
+}
+
+type PairingsQuery struct {
+	TeamName string `form:"team_name" binding:"required"`
+	// Window counts only assignments within it, e.g. 720h; all when omitted.
+	Window time.Duration `form:"window" binding:"min=0"`
+}
+
+type PairingMatrixResponse struct {
+	TeamName string   `json:"team_name"`
+	Window   string   `json:"window,omitempty"`
+	Members  []string `json:"members"`
+	// Matrix maps each member as an author to how many of their PRs each other
+	// member reviews.
+	Matrix map[string]map[string]int `json:"matrix"`
+}
+
+func ToPairingMatrixResponse(matrix *domain.PairingMatrix) PairingMatrixResponse {
+	response := PairingMatrixResponse{
+		TeamName: matrix.TeamName,
+		Members:  matrix.Members,
+		Matrix:   make(map[string]map[string]int, len(matrix.Members)),
+	}
+	if matrix.Window > 0 {
+		response.Window = matrix.Window.String()
+	}
+
+	for _, authorID := range matrix.Members {
+		row := make(map[string]int, len(matrix.Members))
+		for _, reviewerID := range matrix.Members {
+			if reviewerID != authorID {
+				row[reviewerID] = 0
+			}
+		}
+		response.Matrix[authorID] = row
+	}
+	for _, pairing := range matrix.Pairings {
+		response.Matrix[pairing.AuthorID][pairing.ReviewerID] = pairing.Count
+	}
+
+	return response
+}
+
+type TeamNameRequest struct {
+	TeamName string `json:"team_name" binding:"required"`
+}
+
+type SetMaxOpenReviewsRequest struct {
+	TeamName string `json:"team_name" binding:"required"`
+	// MaxOpenReviews is null to remove the team default.
+	MaxOpenReviews *int `json:"max_open_reviews" binding:"omitempty,min=0"`
+}
+
+type SetSeniorityRulesRequest struct {
+	TeamName string `json:"team_name" binding:"required"`
+	// Rules replace the team's rules; an empty list removes them.
+	Rules []SeniorityRuleMessage `json:"rules"`
+}
+
+// SeniorityRuleMessage requires a PR by an author of AuthorLevel to have at
+// least MinReviewers reviewers of MinLevel or above.
+type SeniorityRuleMessage struct {
+	AuthorLevel  string `json:"author_level"`
+	MinLevel     string `json:"min_level"`
+	MinReviewers int    `json:"min_reviewers"`
+}
+
+func (r *SetSeniorityRulesRequest) ToDomain() []domain.SeniorityRule {
+	rules := make([]domain.SeniorityRule, len(r.Rules))
+	for i, rule := range r.Rules {
+		rules[i] = domain.SeniorityRule{
+			AuthorLevel:  rule.AuthorLevel,
+			MinLevel:     rule.MinLevel,
+			MinReviewers: rule.MinReviewers,
+		}
+	}
+
+	return rules
+}
+
+func toSeniorityRuleMessages(rules []domain.SeniorityRule) []SeniorityRuleMessage {
+	var messages []SeniorityRuleMessage
+	for _, rule := range rules {
+		messages = append(messages, SeniorityRuleMessage{
+			AuthorLevel:  rule.AuthorLevel,
+			MinLevel:     rule.MinLevel,
+			MinReviewers: rule.MinReviewers,
+		})
+	}
+
+	return messages
+}
+
+type RenameTeamRequest struct {
+	TeamName    string `json:"team_name" binding:"required"`
+	NewTeamName string `json:"new_team_name" binding:"required"`
+}
+
+type ArchiveTeamResponse struct {
+	Team TeamResponse `json:"team"`
+	// OpenPullRequests are open PRs that keep reviewers from the archived team.
+	OpenPullRequests []PRRefResponse `json:"open_pull_requests"`
+}
+
+type PRRefResponse struct {
+	Repository    string `json:"repository,omitempty"`
+	PullRequestID string `json:"pull_request_id"`
+}
+
+func ToArchiveTeamResponse(team *domain.Team, openPRs []domain.PRRef) ArchiveTeamResponse {
+	response := ArchiveTeamResponse{
+		Team:             ToTeamResponse(team).Team,
+		OpenPullRequests: make([]PRRefResponse, len(openPRs)),
+	}
+
+	for i, ref := range openPRs {
+		response.OpenPullRequests[i] = PRRefResponse{
+			Repository:    ref.Repository,
+			PullRequestID: ref.PullRequestID,
+		}
+	}
+
+	return response
+}
+
+type AddMembersRequest struct {
+	TeamName string              `json:"team_name" binding:"required"`
+	Members  []TeamMemberRequest `json:"members" binding:"required,min=1,dive"`
+}
+
+func (r *AddMembersRequest) ToDomain() []*domain.User {
+	users := make([]*domain.User, len(r.Members))
+	for i, member := range r.Members {
+		users[i] = &domain.User{
+			UserID:   member.UserID,
+			Username: member.Username,
+			TeamName: r.TeamName,
+			IsActive: member.IsActive,
+			Tags:     member.Tags,
+		}
+	}
+
+	return users
+}
+
+type RemoveMembersRequest struct {
+	TeamName string   `json:"team_name" binding:"required"`
+	UserIDs  []string `json:"user_ids" binding:"required,min=1,dive,required"`
+	Reassign bool     `json:"reassign"`
+}
+
+type MoveMemberRequest struct {
+	UserID string `json:"user_id" binding:"required"`
+	// FromTeamName is the team the user leaves; the primary team when empty.
+	FromTeamName string `json:"from_team_name"`
+	TeamName     string `json:"team_name" binding:"required"`
+	Reassign     bool   `json:"reassign"`
+}
+
+type DepartedReviewerResponse struct {
+	UserID         string                `json:"user_id"`
+	FormerTeamName string                `json:"former_team_name"`
+	PullRequests   []DepartedPullRequest `json:"pull_requests"`
+}
+
+type DepartedPullRequest struct {
+	Repository    string `json:"repository,omitempty"`
+	PullRequestID string `json:"pull_request_id"`
+	// ReplacedBy is set for reassigned PRs; empty when no candidate was found.
+	ReplacedBy *string `json:"replaced_by,omitempty"`
+}
+
+type MembershipResponse struct {
+	Team              TeamResponse               `json:"team"`
+	DepartedReviewers []DepartedReviewerResponse `json:"departed_reviewers"`
+}
+
+type MoveMemberResponse struct {
+	User              UserResponse               `json:"user"`
+	DepartedReviewers []DepartedReviewerResponse `json:"departed_reviewers"`
+}
+
+type UserResponse struct {
+	UserID    string   `json:"user_id"`
+	Username  string   `json:"username"`
+	TeamName  string   `json:"team_name"`
+	TeamNames []string `json:"team_names"`
+	IsActive  bool     `json:"is_active"`
+}
+
+func ToMembershipResponse(team *domain.Team, departed []domain.DepartedReviewer) MembershipResponse {
+	return MembershipResponse{
+		Team:              ToTeamResponse(team).Team,
+		DepartedReviewers: toDepartedReviewersResponse(departed),
+	}
+}
+
+func ToMoveMemberResponse(user *domain.User, departed []domain.DepartedReviewer) MoveMemberResponse {
+	return MoveMemberResponse{
+		User: UserResponse{
+			UserID:    user.UserID,
+			Username:  user.Username,
+			TeamName:  user.TeamName,
+			TeamNames: user.TeamNames,
+			IsActive:  user.IsActive,
+		},
+		DepartedReviewers: toDepartedReviewersResponse(departed),
+	}
+}
+
+func toDepartedReviewersResponse(departed []domain.DepartedReviewer) []DepartedReviewerResponse {
+	response := make([]DepartedReviewerResponse, len(departed))
+	for i, reviewer := range departed {
+		response[i] = DepartedReviewerResponse{
+			UserID:         reviewer.UserID,
+			FormerTeamName: reviewer.FormerTeamName,
+			PullRequests:   make([]DepartedPullRequest, len(reviewer.PullRequests)),
+		}
+		for j, ref := range reviewer.PullRequests {
+			pr := DepartedPullRequest{
+				Repository:    ref.Repository,
+				PullRequestID: ref.PullRequestID,
+			}
+			if newReviewerID, ok := reviewer.ReplacedBy[ref]; ok {
+				pr.ReplacedBy = &newReviewerID
+			}
+			response[i].PullRequests[j] = pr
+		}
+	}
+
+	return response
+}
+
+type SyncTeamQuery struct {
+	DryRun   bool   `form:"dry_run"`
+	Missing  string `form:"missing,default=deactivate" binding:"oneof=deactivate detach"`
+	Reassign bool   `form:"reassign"`
+}
+
+type SyncTeamRequest struct {
+	Members []TeamMemberRequest `json:"members" binding:"required,dive"`
+}
+
+func (r *SyncTeamRequest) ToDomain(teamName string) []*domain.User {
+	users := make([]*domain.User, len(r.Members))
+	for i, member := range r.Members {
+		users[i] = &domain.User{
+			UserID:   member.UserID,
+			Username: member.Username,
+			TeamName: teamName,
+			IsActive: member.IsActive,
+			Tags:     member.Tags,
+		}
+	}
+
+	return users
+}
+
+type SyncTeamResponse struct {
+	TeamName    string               `json:"team_name"`
+	DryRun      bool                 `json:"dry_run"`
+	TeamCreated bool                 `json:"team_created"`
+	Added       []TeamMemberResponse `json:"added"`
+	Updated     []TeamMemberResponse `json:"updated"`
+	Deactivated []TeamMemberResponse `json:"deactivated"`
+	Detached    []TeamMemberResponse `json:"detached"`
+	Unchanged   []string             `json:"unchanged"`
+	// DepartedReviewers are detached members still reviewing open PRs of the team.
+	DepartedReviewers []DepartedReviewerResponse `json:"departed_reviewers"`
+}
+
+func ToSyncTeamResponse(diff *domain.TeamDiff, departed []domain.DepartedReviewer, dryRun bool) SyncTeamResponse {
+	unchanged := diff.Unchanged
+	if unchanged == nil {
+		unchanged = []string{}
+	}
+
+	return SyncTeamResponse{
+		TeamName:          diff.TeamName,
+		DryRun:            dryRun,
+		TeamCreated:       diff.TeamCreated,
+		Added:             toTeamMembersResponse(diff.Added),
+		Updated:           toTeamMembersResponse(diff.Updated),
+		Deactivated:       toTeamMembersResponse(diff.Deactivated),
+		Detached:          toTeamMembersResponse(diff.Detached),
+		Unchanged:         unchanged,
+		DepartedReviewers: toDepartedReviewersResponse(departed),
+	}
+}
+
+func toTeamMembersResponse(users []*domain.User) []TeamMemberResponse {
+	response := make([]TeamMemberResponse, len(users))
+	for i, user := range users {
+		response[i] = TeamMemberResponse{
+			UserID:   user.UserID,
+			Username: user.Username,
+			IsActive: user.IsActive,
+			Tags:     user.Tags,
+			Level:    user.Level,
 		}
 	}
 
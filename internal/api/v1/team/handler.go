@@ -2,6 +2,7 @@ package team
 
 import (
 	"context"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/whitxowl/pr-reviewer-assignment-service.git/internal/domain"
@@ -10,6 +11,24 @@ import (
 type TeamService interface {
 	CreateTeam(ctx context.Context, team domain.Team) error
 	GetTeam(ctx context.Context, teamName string) (*domain.Team, error)
+	GetPairingMatrix(ctx context.Context, teamName string, window time.Duration) (*domain.PairingMatrix, error)
+	RenameTeam(ctx context.Context, teamName string, newTeamName string) (*domain.Team, error)
+	ArchiveTeam(ctx context.Context, teamName string) (*domain.Team, []domain.PRRef, error)
+	UnarchiveTeam(ctx context.Context, teamName string) (*domain.Team, error)
+	SetMaxOpenReviews(ctx context.Context, teamName string, limit *int) (*domain.Team, error)
+	SetSeniorityRules(ctx context.Context, teamName string, rules []domain.SeniorityRule) (*domain.Team, error)
+	DeleteTeam(ctx context.Context, teamName string) error
+	AddMembers(ctx context.Context, teamName string, members []*domain.User) (*domain.Team, error)
+	RemoveMembers(ctx context.Context, teamName string, userIDs []string, reassign bool) (*domain.Team, []domain.DepartedReviewer, error)
+	MoveUser(ctx context.Context, userID string, fromTeamName string, teamName string, reassign bool) (*domain.User, []domain.DepartedReviewer, error)
+	SyncTeam(
+		ctx context.Context,
+		teamName string,
+		members []*domain.User,
+		missing string,
+		reassign bool,
+		dryRun bool,
+	) (*domain.TeamDiff, []domain.DepartedReviewer, error)
 }
 
 type Handler struct {
@@ -27,5 +46,16 @@ func (h *Handler) RegisterRoutes(router *gin.RouterGroup) {
 	{
 		teamGroup.POST("/add", h.add)
 		teamGroup.GET("/get", h.get)
+		teamGroup.GET("/pairings", h.pairings)
+		teamGroup.POST("/rename", h.rename)
+		teamGroup.POST("/archive", h.archive)
+		teamGroup.POST("/unarchive", h.unarchive)
+		teamGroup.POST("/delete", h.delete)
+		teamGroup.POST("/setMaxOpenReviews", h.setMaxOpenReviews)
+		teamGroup.POST("/setSeniorityRules", h.setSeniorityRules)
+		teamGroup.POST("/members/add", h.addMembers)
+		teamGroup.POST("/members/remove", h.removeMembers)
+		teamGroup.POST("/members/move", h.moveMember)
+		teamGroup.PUT("/:team_name", h.sync)
 	}
 }
@@ -1,44 +1,24 @@
 package team
 
 import (
-	"errors"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
-	serviceErr "github.com/whitxowl/pr-reviewer-assignment-service.git/internal/service/errors"
+	apiErr "github.com/whitxowl/pr-reviewer-assignment-service.git/internal/api/errors"
 )
 
 func (h *Handler) add(c *gin.Context) {
 	var req CreateTeamRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, ErrorResponse{
-			Error: ErrorDetail{
-				Code:    "INVALID_REQUEST",
-				Message: "invalid request body: " + err.Error(),
-			},
-		})
+		c.Error(apiErr.InvalidBody(err))
 		return
 	}
 
 	team := req.ToDomain()
 
 	err := h.teamService.CreateTeam(c.Request.Context(), team)
-	if errors.Is(err, serviceErr.ErrTeamExists) {
-		c.JSON(http.StatusBadRequest, ErrorResponse{
-			Error: ErrorDetail{
-				Code:    "TEAM_EXISTS",
-				Message: team.TeamName + " already exists",
-			},
-		})
-		return
-	}
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, ErrorResponse{
-			Error: ErrorDetail{
-				Code:    "INTERNAL_ERROR",
-				Message: "internal server error",
-			},
-		})
+		c.Error(err)
 		return
 	}
 
@@ -50,36 +30,227 @@ func (h *Handler) add(c *gin.Context) {
 func (h *Handler) get(c *gin.Context) {
 	teamName := c.Query("team_name")
 	if teamName == "" {
-		c.JSON(http.StatusBadRequest, ErrorResponse{
-			Error: ErrorDetail{
-				Code:    "INVALID_REQUEST",
-				Message: "invalid request body: team_name is required",
-			},
-		})
+		c.Error(apiErr.InvalidRequest("team_name is required"))
 		return
 	}
 
 	team, err := h.teamService.GetTeam(c.Request.Context(), teamName)
-	if errors.Is(err, serviceErr.ErrTeamNotFound) {
-		c.JSON(http.StatusNotFound, ErrorResponse{
-			Error: ErrorDetail{
-				Code:    "NOT_FOUND",
-				Message: "resource not found",
-			},
-		})
+	if err != nil {
+		c.Error(err)
 		return
 	}
+
+	response := ToGetTeamResponse(team)
+
+	c.JSON(http.StatusOK, response)
+}
+
+func (h *Handler) pairings(c *gin.Context) {
+	var query PairingsQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.Error(apiErr.InvalidRequest("invalid query: " + err.Error()))
+		return
+	}
+
+	matrix, err := h.teamService.GetPairingMatrix(c.Request.Context(), query.TeamName, query.Window)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, ErrorResponse{
-			Error: ErrorDetail{
-				Code:    "INTERNAL_ERROR",
-				Message: "internal server error",
-			},
-		})
+		c.Error(err)
 		return
 	}
 
-	response := ToGetTeamResponse(team)
+	response := ToPairingMatrixResponse(matrix)
+
+	c.JSON(http.StatusOK, response)
+}
+
+func (h *Handler) rename(c *gin.Context) {
+	var req RenameTeamRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(apiErr.InvalidBody(err))
+		return
+	}
+
+	team, err := h.teamService.RenameTeam(c.Request.Context(), req.TeamName, req.NewTeamName)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	response := ToTeamResponse(team)
+
+	c.JSON(http.StatusOK, response)
+}
+
+func (h *Handler) archive(c *gin.Context) {
+	var req TeamNameRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(apiErr.InvalidBody(err))
+		return
+	}
+
+	team, openPRs, err := h.teamService.ArchiveTeam(c.Request.Context(), req.TeamName)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	response := ToArchiveTeamResponse(team, openPRs)
+
+	c.JSON(http.StatusOK, response)
+}
+
+func (h *Handler) unarchive(c *gin.Context) {
+	var req TeamNameRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(apiErr.InvalidBody(err))
+		return
+	}
+
+	team, err := h.teamService.UnarchiveTeam(c.Request.Context(), req.TeamName)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	response := ToTeamResponse(team)
+
+	c.JSON(http.StatusOK, response)
+}
+
+func (h *Handler) setMaxOpenReviews(c *gin.Context) {
+	var req SetMaxOpenReviewsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(apiErr.InvalidBody(err))
+		return
+	}
+
+	team, err := h.teamService.SetMaxOpenReviews(c.Request.Context(), req.TeamName, req.MaxOpenReviews)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	response := ToTeamResponse(team)
+
+	c.JSON(http.StatusOK, response)
+}
+
+func (h *Handler) setSeniorityRules(c *gin.Context) {
+	var req SetSeniorityRulesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(apiErr.InvalidBody(err))
+		return
+	}
+
+	team, err := h.teamService.SetSeniorityRules(c.Request.Context(), req.TeamName, req.ToDomain())
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	response := ToTeamResponse(team)
+
+	c.JSON(http.StatusOK, response)
+}
+
+func (h *Handler) delete(c *gin.Context) {
+	var req TeamNameRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(apiErr.InvalidBody(err))
+		return
+	}
+
+	if err := h.teamService.DeleteTeam(c.Request.Context(), req.TeamName); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (h *Handler) addMembers(c *gin.Context) {
+	var req AddMembersRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(apiErr.InvalidBody(err))
+		return
+	}
+
+	team, err := h.teamService.AddMembers(c.Request.Context(), req.TeamName, req.ToDomain())
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	response := ToTeamResponse(team)
+
+	c.JSON(http.StatusOK, response)
+}
+
+func (h *Handler) removeMembers(c *gin.Context) {
+	var req RemoveMembersRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(apiErr.InvalidBody(err))
+		return
+	}
+
+	team, departed, err := h.teamService.RemoveMembers(c.Request.Context(), req.TeamName, req.UserIDs, req.Reassign)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	response := ToMembershipResponse(team, departed)
+
+	c.JSON(http.StatusOK, response)
+}
+
+func (h *Handler) moveMember(c *gin.Context) {
+	var req MoveMemberRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(apiErr.InvalidBody(err))
+		return
+	}
+
+	user, departed, err := h.teamService.MoveUser(c.Request.Context(), req.UserID, req.FromTeamName, req.TeamName, req.Reassign)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	response := ToMoveMemberResponse(user, departed)
+
+	c.JSON(http.StatusOK, response)
+}
+
+func (h *Handler) sync(c *gin.Context) {
+	var query SyncTeamQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.Error(apiErr.InvalidRequest("invalid query: " + err.Error()))
+		return
+	}
+
+	var req SyncTeamRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(apiErr.InvalidBody(err))
+		return
+	}
+
+	teamName := c.Param("team_name")
+
+	diff, departed, err := h.teamService.SyncTeam(
+		c.Request.Context(),
+		teamName,
+		req.ToDomain(teamName),
+		query.Missing,
+		query.Reassign,
+		query.DryRun,
+	)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	response := ToSyncTeamResponse(diff, departed, query.DryRun)
 
 	c.JSON(http.StatusOK, response)
 }
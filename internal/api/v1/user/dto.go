@@ -1,6 +1,12 @@
 package user
 
-import "github.com/whitxowl/pr-reviewer-assignment-service.git/internal/domain"
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/whitxowl/pr-reviewer-assignment-service.git/internal/domain"
+)
 
 type SetIsActiveRequest struct {
 	UserID   string `json:"user_id" binding:"required"`
@@ -11,11 +17,117 @@ type SetIsActiveResponse struct {
 	User UserResponse `json:"user"`
 }
 
+type SetUsernameRequest struct {
+	UserID   string `json:"user_id" binding:"required"`
+	Username string `json:"username" binding:"required"`
+}
+
+type SetMaxOpenReviewsRequest struct {
+	UserID string `json:"user_id" binding:"required"`
+	// MaxOpenReviews is null to fall back to the primary team's default.
+	MaxOpenReviews *int `json:"max_open_reviews" binding:"omitempty,min=0"`
+}
+
+type SetLevelRequest struct {
+	UserID string `json:"user_id" binding:"required"`
+	// Level is junior, middle or senior; empty to make it unknown.
+	Level string `json:"level"`
+}
+
+type SetWorkingHoursRequest struct {
+	UserID string `json:"user_id" binding:"required"`
+	// Timezone is an IANA time zone name; empty means UTC.
+	Timezone string `json:"timezone"`
+	// WorkingHours is null to make the user available at any time.
+	WorkingHours *WorkingHours `json:"working_hours"`
+}
+
+// WorkingHours is a weekly schedule with times as "HH:MM". An end before the
+// start is a shift spanning midnight.
+type WorkingHours struct {
+	Start string   `json:"start" binding:"required"`
+	End   string   `json:"end" binding:"required"`
+	Days  []string `json:"days" binding:"omitempty,dive,oneof=sun mon tue wed thu fri sat"`
+}
+
+var weekdays = []string{"sun", "mon", "tue", "wed", "thu", "fri", "sat"}
+
+func (r *SetWorkingHoursRequest) ToDomain() (*domain.WorkingHours, error) {
+	if r.WorkingHours == nil {
+		return nil, nil
+	}
+
+	start, err := parseClock(r.WorkingHours.Start)
+	if err != nil {
+		return nil, err
+	}
+	end, err := parseClock(r.WorkingHours.End)
+	if err != nil {
+		return nil, err
+	}
+
+	hours := &domain.WorkingHours{Start: start, End: end}
+	for _, day := range r.WorkingHours.Days {
+		hours.Days = append(hours.Days, time.Weekday(slices.Index(weekdays, day)))
+	}
+
+	return hours, nil
+}
+
+// parseClock returns the minutes after midnight of an "HH:MM" time.
+func parseClock(value string) (int, error) {
+	t, err := time.Parse("15:04", value)
+	if err != nil {
+		return 0, fmt.Errorf("time %q is not HH:MM", value)
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
+
+func formatClock(minutes int) string {
+	return fmt.Sprintf("%02d:%02d", minutes/60, minutes%60)
+}
+
+type UserEnvelopeResponse struct {
+	User UserResponse `json:"user"`
+}
+
+type SearchUsersQuery struct {
+	Query    string `form:"query"`
+	TeamName string `form:"team_name"`
+	IsActive *bool  `form:"is_active"`
+	Limit    int    `form:"limit,default=20" binding:"min=1,max=100"`
+	Offset   int    `form:"offset" binding:"min=0"`
+}
+
+func (q *SearchUsersQuery) ToDomain() domain.UserSearch {
+	return domain.UserSearch{
+		Prefix:   q.Query,
+		TeamName: q.TeamName,
+		IsActive: q.IsActive,
+		Limit:    q.Limit,
+		Offset:   q.Offset,
+	}
+}
+
+type SearchUsersResponse struct {
+	Users  []UserResponse `json:"users"`
+	Total  int            `json:"total"`
+	Limit  int            `json:"limit"`
+	Offset int            `json:"offset"`
+}
+
 type UserResponse struct {
-	UserID   string `json:"user_id"`
-	Username string `json:"username"`
-	TeamName string `json:"team_name"`
-	IsActive bool   `json:"is_active"`
+	UserID    string   `json:"user_id"`
+	Username  string   `json:"username"`
+	TeamName  string   `json:"team_name"`
+	TeamNames []string `json:"team_names,omitempty"`
+	IsActive  bool     `json:"is_active"`
+	// MaxOpenReviews is the user's own review limit, if one is set.
+	MaxOpenReviews *int          `json:"max_open_reviews,omitempty"`
+	Timezone       string        `json:"timezone,omitempty"`
+	WorkingHours   *WorkingHours `json:"working_hours,omitempty"`
+	Tags           []string      `json:"tags,omitempty"`
+	Level          string        `json:"level,omitempty"`
 }
 
 type GetReviewedResponse struct {
@@ -24,29 +136,64 @@ type GetReviewedResponse struct {
 }
 
 type PullRequestResponse struct {
+	Repository      string `json:"repository,omitempty"`
 	PullRequestID   string `json:"pull_request_id"`
 	PullRequestName string `json:"pull-request-name"`
 	AuthorID        string `json:"author_id"`
 	Status          string `json:"status"`
 }
 
-type ErrorResponse struct {
-	Error ErrorDetail `json:"error"`
+func ToSetIsActiveResponse(user *domain.User) SetIsActiveResponse {
+	response := SetIsActiveResponse{
+		User: toUserResponse(user),
+	}
+
+	return response
+}
+
+func ToUserEnvelopeResponse(user *domain.User) UserEnvelopeResponse {
+	return UserEnvelopeResponse{
+		User: toUserResponse(user),
+	}
 }
 
-type ErrorDetail struct {
-	Code    string `json:"code"`
-	Message string `json:"message"`
+func ToSearchUsersResponse(users []*domain.User, total int, query SearchUsersQuery) SearchUsersResponse {
+	response := SearchUsersResponse{
+		Users:  make([]UserResponse, len(users)),
+		Total:  total,
+		Limit:  query.Limit,
+		Offset: query.Offset,
+	}
+
+	for i, user := range users {
+		response.Users[i] = toUserResponse(user)
+	}
+
+	return response
 }
 
-func ToSetIsActiveResponse(user *domain.User) SetIsActiveResponse {
-	response := SetIsActiveResponse{
-		User: UserResponse{
-			UserID:   user.UserID,
-			Username: user.Username,
-			TeamName: user.TeamName,
-			IsActive: user.IsActive,
-		},
+func toUserResponse(user *domain.User) UserResponse {
+	response := UserResponse{
+		UserID:         user.UserID,
+		Username:       user.Username,
+		TeamName:       user.TeamName,
+		TeamNames:      user.TeamNames,
+		IsActive:       user.IsActive,
+		MaxOpenReviews: user.MaxOpenReviews,
+		Timezone:       user.Timezone,
+		Tags:           user.Tags,
+		Level:          user.Level,
+	}
+
+	if hours := user.WorkingHours; hours != nil {
+		response.WorkingHours = &WorkingHours{
+			Start: formatClock(hours.Start),
+			End:   formatClock(hours.End),
+			Days:  make([]string, len(hours.Days)),
+		}
+		for i, day := range hours.Days {
+			response.WorkingHours.Days[i] = weekdays[day]
+		}
 	}
 
 	return response
@@ -60,6 +207,7 @@ func ToGetReviewedResponse(userID string, prs []*domain.PullRequest) GetReviewed
 
 	for i, pr := range prs {
 		response.PullRequests[i] = PullRequestResponse{
+			Repository:      pr.Repository,
 			PullRequestID:   pr.PullRequestID,
 			PullRequestName: pr.PullRequestName,
 			AuthorID:        pr.AuthorID,
@@ -9,6 +9,12 @@ import (
 
 type UserService interface {
 	SetIsActive(ctx context.Context, userID string, isActive bool) (*domain.User, error)
+	SetUsername(ctx context.Context, userID string, username string) (*domain.User, error)
+	SetMaxOpenReviews(ctx context.Context, userID string, limit *int) (*domain.User, error)
+	SetWorkingHours(ctx context.Context, userID string, timezone string, hours *domain.WorkingHours) (*domain.User, error)
+	SetLevel(ctx context.Context, userID string, level string) (*domain.User, error)
+	GetUser(ctx context.Context, userID string) (*domain.User, error)
+	SearchUsers(ctx context.Context, search domain.UserSearch) ([]*domain.User, int, error)
 	GetPRsReviewedBy(ctx context.Context, userID string) ([]*domain.PullRequest, error)
 }
 
@@ -27,5 +33,11 @@ func (h *Handler) RegisterRoutes(router *gin.RouterGroup) {
 	{
 		usersGroup.POST("/setIsActive", h.setIsActive)
 		usersGroup.GET("/getReview", h.getReview)
+		usersGroup.POST("/setUsername", h.setUsername)
+		usersGroup.POST("/setMaxOpenReviews", h.setMaxOpenReviews)
+		usersGroup.POST("/setWorkingHours", h.setWorkingHours)
+		usersGroup.POST("/setLevel", h.setLevel)
+		usersGroup.GET("/get", h.get)
+		usersGroup.GET("/search", h.search)
 	}
 }
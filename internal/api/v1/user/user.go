@@ -1,42 +1,22 @@
 package user
 
 import (
-	"errors"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
-	serviceErr "github.com/whitxowl/pr-reviewer-assignment-service.git/internal/service/errors"
+	apiErr "github.com/whitxowl/pr-reviewer-assignment-service.git/internal/api/errors"
 )
 
 func (h *Handler) setIsActive(c *gin.Context) {
 	var req SetIsActiveRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, ErrorResponse{
-			Error: ErrorDetail{
-				Code:    "INVALID_REQUEST",
-				Message: "invalid request body: " + err.Error(),
-			},
-		})
+		c.Error(apiErr.InvalidBody(err))
 		return
 	}
 
 	user, err := h.userService.SetIsActive(c.Request.Context(), req.UserID, req.IsActive)
-	if errors.Is(err, serviceErr.ErrUserNotFound) {
-		c.JSON(http.StatusNotFound, ErrorResponse{
-			Error: ErrorDetail{
-				Code:    "USER_NOT_FOUND",
-				Message: "resource not found",
-			},
-		})
-		return
-	}
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, ErrorResponse{
-			Error: ErrorDetail{
-				Code:    "INTERNAL_ERROR",
-				Message: "internal server error",
-			},
-		})
+		c.Error(err)
 		return
 	}
 
@@ -48,23 +28,13 @@ func (h *Handler) setIsActive(c *gin.Context) {
 func (h *Handler) getReview(c *gin.Context) {
 	userID := c.Query("user_id")
 	if userID == "" {
-		c.JSON(http.StatusBadRequest, ErrorResponse{
-			Error: ErrorDetail{
-				Code:    "INVALID_REQUEST",
-				Message: "invalid request body: team_name is required",
-			},
-		})
+		c.Error(apiErr.InvalidRequest("user_id is required"))
 		return
 	}
 
 	prs, err := h.userService.GetPRsReviewedBy(c.Request.Context(), userID)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, ErrorResponse{
-			Error: ErrorDetail{
-				Code:    "INTERNAL_ERROR",
-				Message: "internal server error",
-			},
-		})
+		c.Error(err)
 		return
 	}
 
@@ -72,3 +42,117 @@ func (h *Handler) getReview(c *gin.Context) {
 
 	c.JSON(http.StatusOK, response)
 }
+
+func (h *Handler) setUsername(c *gin.Context) {
+	var req SetUsernameRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(apiErr.InvalidBody(err))
+		return
+	}
+
+	user, err := h.userService.SetUsername(c.Request.Context(), req.UserID, req.Username)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	response := ToUserEnvelopeResponse(user)
+
+	c.JSON(http.StatusOK, response)
+}
+
+func (h *Handler) setMaxOpenReviews(c *gin.Context) {
+	var req SetMaxOpenReviewsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(apiErr.InvalidBody(err))
+		return
+	}
+
+	user, err := h.userService.SetMaxOpenReviews(c.Request.Context(), req.UserID, req.MaxOpenReviews)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	response := ToUserEnvelopeResponse(user)
+
+	c.JSON(http.StatusOK, response)
+}
+
+func (h *Handler) setWorkingHours(c *gin.Context) {
+	var req SetWorkingHoursRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(apiErr.InvalidBody(err))
+		return
+	}
+
+	hours, err := req.ToDomain()
+	if err != nil {
+		c.Error(apiErr.InvalidBody(err))
+		return
+	}
+
+	user, err := h.userService.SetWorkingHours(c.Request.Context(), req.UserID, req.Timezone, hours)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	response := ToUserEnvelopeResponse(user)
+
+	c.JSON(http.StatusOK, response)
+}
+
+func (h *Handler) setLevel(c *gin.Context) {
+	var req SetLevelRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(apiErr.InvalidBody(err))
+		return
+	}
+
+	user, err := h.userService.SetLevel(c.Request.Context(), req.UserID, req.Level)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	response := ToUserEnvelopeResponse(user)
+
+	c.JSON(http.StatusOK, response)
+}
+
+func (h *Handler) get(c *gin.Context) {
+	userID := c.Query("user_id")
+	if userID == "" {
+		c.Error(apiErr.InvalidRequest("user_id is required"))
+		return
+	}
+
+	user, err := h.userService.GetUser(c.Request.Context(), userID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	response := ToUserEnvelopeResponse(user)
+
+	c.JSON(http.StatusOK, response)
+}
+
+func (h *Handler) search(c *gin.Context) {
+	var query SearchUsersQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.Error(apiErr.InvalidRequest("invalid query: " + err.Error()))
+		return
+	}
+
+	users, total, err := h.userService.SearchUsers(c.Request.Context(), query.ToDomain())
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	response := ToSearchUsersResponse(users, total, query)
+
+	c.JSON(http.StatusOK, response)
+}
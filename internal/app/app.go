@@ -3,39 +3,81 @@ package app
 import (
 	"context"
 	"log/slog"
+	"math/rand/v2"
 
 	"github.com/whitxowl/pr-reviewer-assignment-service.git/internal/app/server"
 	"github.com/whitxowl/pr-reviewer-assignment-service.git/internal/config"
+	absenceService "github.com/whitxowl/pr-reviewer-assignment-service.git/internal/service/absence"
+	healthService "github.com/whitxowl/pr-reviewer-assignment-service.git/internal/service/health"
 	prService "github.com/whitxowl/pr-reviewer-assignment-service.git/internal/service/pr"
+	repositoryService "github.com/whitxowl/pr-reviewer-assignment-service.git/internal/service/repository"
 	teamService "github.com/whitxowl/pr-reviewer-assignment-service.git/internal/service/team"
 	userService "github.com/whitxowl/pr-reviewer-assignment-service.git/internal/service/user"
-	prStorage "github.com/whitxowl/pr-reviewer-assignment-service.git/internal/storage/pr"
-	teamStorage "github.com/whitxowl/pr-reviewer-assignment-service.git/internal/storage/team"
-	userStorage "github.com/whitxowl/pr-reviewer-assignment-service.git/internal/storage/user"
-	"github.com/whitxowl/pr-reviewer-assignment-service.git/pkg/postgres"
+	"github.com/whitxowl/pr-reviewer-assignment-service.git/internal/service/validation"
+	"github.com/whitxowl/pr-reviewer-assignment-service.git/internal/worker"
+)
+
+const (
+	backfillWorker        = "reviewer_backfill"
+	absenceReassignWorker = "absence_reassign"
 )
 
 type App struct {
-	Srv *server.Server
+	Srv     *server.Server
+	Health  *healthService.Service
+	Workers []*worker.Worker
 }
 
 func New(ctx context.Context, log *slog.Logger, cfg *config.Config) *App {
-	pgPool, err := postgres.NewPool(ctx, cfg.StorageConfig.DSN(), postgres.WithMaxConnections(int32(cfg.StorageConfig.MaxConnections)))
+	stores := newStorages(ctx, log, cfg)
+
+	ids, err := validation.NewIDs(cfg.IDConfig.UserPattern, cfg.IDConfig.PRPattern)
 	if err != nil {
-		panic("failed to connect to database" + err.Error())
+		panic("invalid ID format: " + err.Error())
+	}
+
+	prOpts := prService.Options{
+		CapacityMode:       cfg.AssignmentConfig.CapacityMode,
+		PreferWorkingHours: cfg.AssignmentConfig.PreferWorkingHours,
+		PairingWindow:      cfg.AssignmentConfig.PairingWindow,
+		Order:              cfg.AssignmentConfig.Order,
+	}
+	if seed := cfg.AssignmentConfig.Seed; seed != 0 {
+		prOpts.RandSource = rand.NewPCG(seed, seed)
 	}
+	prSvc := prService.New(log.WithGroup("service.pr"), stores.user, stores.team, stores.pr, stores.repository, ids, prOpts)
+	teamSvc := teamService.New(log.WithGroup("service.team"), stores.team, stores.user, stores.pr, prSvc, ids)
+	userSvc := userService.New(log.WithGroup("service.user"), stores.user, stores.pr)
+	absenceSvc := absenceService.New(log.WithGroup("service.absence"), stores.user, stores.pr, prSvc)
+	repoSvc := repositoryService.New(log.WithGroup("service.repository"), stores.repository)
+	healthSvc := healthService.New(log.WithGroup("service.health"), stores.health, stores.migrationVersion, cfg.HealthConfig.ReadinessTimeout)
 
-	teamStore := teamStorage.New(pgPool)
-	userStore := userStorage.New(pgPool)
-	prStore := prStorage.New(pgPool)
+	backfill := worker.New(log.WithGroup("worker.backfill"), backfillWorker, cfg.AssignmentConfig.BackfillInterval,
+		func(ctx context.Context) error {
+			_, err := prSvc.FillPendingSlots(ctx)
+			return err
+		})
+	prSvc.SetWaker(backfill)
+	teamSvc.SetWaker(backfill)
+	userSvc.SetWaker(backfill)
 
-	teamSvc := teamService.New(log.WithGroup("service.team"), teamStore, userStore)
-	userSvc := userService.New(log.WithGroup("service.user"), userStore, prStore)
-	prSvc := prService.New(log.WithGroup("service.pr"), userStore, prStore)
+	absenceReassign := worker.New(log.WithGroup("worker.absence"), absenceReassignWorker, cfg.AssignmentConfig.AbsenceCheckInterval,
+		func(ctx context.Context) error {
+			_, err := absenceSvc.ReassignAbsentReviewers(ctx)
+			return err
+		})
+	absenceSvc.SetWakers(absenceReassign, backfill)
+
+	workers := []*worker.Worker{backfill, absenceReassign}
+	for _, w := range workers {
+		healthSvc.RegisterWorker(w)
+	}
 
-	srv := server.New(log, teamSvc, userSvc, prSvc, cfg.HTTPServer)
+	srv := server.New(log, teamSvc, userSvc, prSvc, repoSvc, absenceSvc, healthSvc, cfg.HTTPServer)
 
 	return &App{
-		Srv: srv,
+		Srv:     srv,
+		Health:  healthSvc,
+		Workers: workers,
 	}
 }
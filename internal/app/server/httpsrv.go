@@ -10,21 +10,31 @@ import (
 	"time"
 
 	"github.com/gin-gonic/gin"
+	apiErr "github.com/whitxowl/pr-reviewer-assignment-service.git/internal/api/errors"
+	healthHandler "github.com/whitxowl/pr-reviewer-assignment-service.git/internal/api/health"
+	absenceHandler "github.com/whitxowl/pr-reviewer-assignment-service.git/internal/api/v1/absence"
 	prHandler "github.com/whitxowl/pr-reviewer-assignment-service.git/internal/api/v1/pr"
+	repositoryHandler "github.com/whitxowl/pr-reviewer-assignment-service.git/internal/api/v1/repository"
 	teamHandler "github.com/whitxowl/pr-reviewer-assignment-service.git/internal/api/v1/team"
 	userHandler "github.com/whitxowl/pr-reviewer-assignment-service.git/internal/api/v1/user"
 	"github.com/whitxowl/pr-reviewer-assignment-service.git/internal/config"
+	absenceService "github.com/whitxowl/pr-reviewer-assignment-service.git/internal/service/absence"
+	healthService "github.com/whitxowl/pr-reviewer-assignment-service.git/internal/service/health"
 	prService "github.com/whitxowl/pr-reviewer-assignment-service.git/internal/service/pr"
+	repositoryService "github.com/whitxowl/pr-reviewer-assignment-service.git/internal/service/repository"
 	teamService "github.com/whitxowl/pr-reviewer-assignment-service.git/internal/service/team"
 	userService "github.com/whitxowl/pr-reviewer-assignment-service.git/internal/service/user"
 )
 
 type Server struct {
-	log         *slog.Logger
-	teamService *teamService.Service
-	userService *userService.Service
-	prService   *prService.Service
-	cfg         *config.HTTPServer
+	log            *slog.Logger
+	teamService    *teamService.Service
+	userService    *userService.Service
+	prService      *prService.Service
+	repoService    *repositoryService.Service
+	absenceService *absenceService.Service
+	healthService  *healthService.Service
+	cfg            *config.HTTPServer
 
 	mu     sync.Mutex
 	server *http.Server
@@ -35,14 +45,20 @@ func New(
 	teamService *teamService.Service,
 	userService *userService.Service,
 	prService *prService.Service,
+	repoService *repositoryService.Service,
+	absenceService *absenceService.Service,
+	healthService *healthService.Service,
 	cfg config.HTTPServer,
 ) *Server {
 	return &Server{
-		log:         log,
-		teamService: teamService,
-		userService: userService,
-		prService:   prService,
-		cfg:         &cfg,
+		log:            log,
+		teamService:    teamService,
+		userService:    userService,
+		prService:      prService,
+		repoService:    repoService,
+		absenceService: absenceService,
+		healthService:  healthService,
+		cfg:            &cfg,
 	}
 }
 
@@ -65,20 +81,24 @@ func (s *Server) Run(ctx context.Context) error {
 	teamHdlr := teamHandler.New(s.teamService)
 	userHdlr := userHandler.New(s.userService)
 	prHdlr := prHandler.New(s.prService)
+	repoHdlr := repositoryHandler.New(s.repoService)
+	absenceHdlr := absenceHandler.New(s.absenceService)
+	healthHdlr := healthHandler.New(s.healthService)
 
 	router := gin.New()
 	router.Use(gin.Recovery())
 	router.Use(ginLogger(s.log))
-
-	router.GET("/health", func(c *gin.Context) {
-		c.JSON(http.StatusOK, gin.H{"status": "ok"})
-	})
+	router.Use(apiErr.Middleware(s.log))
 
 	base := router.Group("/")
 
+	healthHdlr.RegisterRoutes(base)
+
 	teamHdlr.RegisterRoutes(base)
 	userHdlr.RegisterRoutes(base)
 	prHdlr.RegisterRoutes(base)
+	repoHdlr.RegisterRoutes(base)
+	absenceHdlr.RegisterRoutes(base)
 
 	srv := &http.Server{
 		Addr:         s.cfg.Address,
@@ -0,0 +1,140 @@
+package app
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/whitxowl/pr-reviewer-assignment-service.git/internal/config"
+	absenceService "github.com/whitxowl/pr-reviewer-assignment-service.git/internal/service/absence"
+	healthService "github.com/whitxowl/pr-reviewer-assignment-service.git/internal/service/health"
+	prService "github.com/whitxowl/pr-reviewer-assignment-service.git/internal/service/pr"
+	repositoryService "github.com/whitxowl/pr-reviewer-assignment-service.git/internal/service/repository"
+	teamService "github.com/whitxowl/pr-reviewer-assignment-service.git/internal/service/team"
+	userService "github.com/whitxowl/pr-reviewer-assignment-service.git/internal/service/user"
+	healthStorage "github.com/whitxowl/pr-reviewer-assignment-service.git/internal/storage/health"
+	"github.com/whitxowl/pr-reviewer-assignment-service.git/internal/storage/memory"
+	prStorage "github.com/whitxowl/pr-reviewer-assignment-service.git/internal/storage/pr"
+	repositoryStorage "github.com/whitxowl/pr-reviewer-assignment-service.git/internal/storage/repository"
+	sqliteStorage "github.com/whitxowl/pr-reviewer-assignment-service.git/internal/storage/sqlite"
+	teamStorage "github.com/whitxowl/pr-reviewer-assignment-service.git/internal/storage/team"
+	userStorage "github.com/whitxowl/pr-reviewer-assignment-service.git/internal/storage/user"
+	"github.com/whitxowl/pr-reviewer-assignment-service.git/migrations"
+	"github.com/whitxowl/pr-reviewer-assignment-service.git/pkg/migrator"
+	"github.com/whitxowl/pr-reviewer-assignment-service.git/pkg/postgres"
+	"github.com/whitxowl/pr-reviewer-assignment-service.git/pkg/sqlite"
+)
+
+type teamStore interface {
+	teamService.TeamStorage
+	prService.TeamStorage
+}
+
+type userStore interface {
+	teamService.UserStorage
+	userService.UserStorage
+	prService.UserStorage
+	absenceService.UserStorage
+}
+
+type prStore interface {
+	teamService.PRStorage
+	userService.PRStorage
+	prService.PRStorage
+	absenceService.PRStorage
+}
+
+type repositoryStore interface {
+	prService.RepositoryStorage
+	repositoryService.RepositoryStorage
+}
+
+// storages is the set of storage implementations of the selected backend.
+type storages struct {
+	team       teamStore
+	user       userStore
+	pr         prStore
+	repository repositoryStore
+	health     healthService.Storage
+
+	// migrationVersion is the schema version readiness expects the backend to report.
+	migrationVersion int64
+}
+
+func newStorages(ctx context.Context, log *slog.Logger, cfg *config.Config) *storages {
+	switch cfg.StorageDriver {
+	case config.StorageDriverMemory:
+		return newMemoryStorages()
+	case config.StorageDriverSQLite:
+		return newSQLiteStorages(ctx, log, cfg)
+	default:
+		return newPostgresStorages(ctx, log, cfg)
+	}
+}
+
+func newPostgresStorages(ctx context.Context, log *slog.Logger, cfg *config.Config) *storages {
+	pgPool, err := postgres.NewPool(ctx, cfg.StorageConfig.DSN(), postgres.WithMaxConnections(int32(cfg.StorageConfig.MaxConnections)))
+	if err != nil {
+		panic("failed to connect to database" + err.Error())
+	}
+
+	m, err := migrator.NewPostgres(pgPool, migrations.FS, log.WithGroup("migrator"))
+	if err != nil {
+		panic("failed to create migrator: " + err.Error())
+	}
+	defer m.Close()
+
+	if cfg.StorageConfig.AutoMigrate {
+		if _, err = m.Up(ctx); err != nil {
+			panic("failed to apply migrations: " + err.Error())
+		}
+	}
+
+	return &storages{
+		team:             teamStorage.New(pgPool),
+		user:             userStorage.New(pgPool),
+		pr:               prStorage.New(pgPool),
+		repository:       repositoryStorage.New(pgPool),
+		health:           healthStorage.New(pgPool),
+		migrationVersion: m.LatestVersion(),
+	}
+}
+
+func newMemoryStorages() *storages {
+	db := memory.NewDB()
+
+	return &storages{
+		team:       memory.NewTeamStorage(db),
+		user:       memory.NewUserStorage(db),
+		pr:         memory.NewPRStorage(db),
+		repository: memory.NewRepositoryStorage(db),
+		health:     memory.NewHealthStorage(),
+	}
+}
+
+func newSQLiteStorages(ctx context.Context, log *slog.Logger, cfg *config.Config) *storages {
+	db, err := sqlite.Open(ctx, cfg.SQLiteConfig.Path)
+	if err != nil {
+		panic("failed to open database: " + err.Error())
+	}
+
+	// The migrator shares db with the storages, so it is not closed here.
+	m, err := migrator.NewSQLite(db, migrations.SQLiteFS, log.WithGroup("migrator"))
+	if err != nil {
+		panic("failed to create migrator: " + err.Error())
+	}
+
+	if cfg.SQLiteConfig.AutoMigrate {
+		if _, err = m.Up(ctx); err != nil {
+			panic("failed to apply migrations: " + err.Error())
+		}
+	}
+
+	return &storages{
+		team:             sqliteStorage.NewTeamStorage(db),
+		user:             sqliteStorage.NewUserStorage(db),
+		pr:               sqliteStorage.NewPRStorage(db),
+		repository:       sqliteStorage.NewRepositoryStorage(db),
+		health:           sqliteStorage.NewHealthStorage(db),
+		migrationVersion: m.LatestVersion(),
+	}
+}
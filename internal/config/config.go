@@ -1,18 +1,32 @@
 package config
 
 import (
+	"errors"
 	"fmt"
 	"log"
 	"time"
 
 	"github.com/ilyakaznacheev/cleanenv"
 	_ "github.com/joho/godotenv/autoload"
+	"github.com/whitxowl/pr-reviewer-assignment-service.git/internal/domain"
+)
+
+const (
+	StorageDriverPostgres = "postgres"
+	StorageDriverMemory   = "memory"
+	StorageDriverSQLite   = "sqlite"
 )
 
 type Config struct {
 	Env           string `env:"ENV" env-default:"local"`
+	StorageDriver string `env:"STORAGE_DRIVER" env-default:"postgres"`
 	HTTPServer    `env-prefix:"HTTP_"`
 	StorageConfig `env-prefix:"DB_"`
+	SQLiteConfig  `env-prefix:"SQLITE_"`
+	HealthConfig  `env-prefix:"HEALTH_"`
+	IDConfig      `env-prefix:"ID_"`
+
+	AssignmentConfig `env-prefix:"ASSIGNMENT_"`
 }
 
 type HTTPServer struct {
@@ -21,14 +35,65 @@ type HTTPServer struct {
 	IdleTimeout time.Duration `env:"IDLE_TIMEOUT" env-default:"60s"`
 }
 
+type HealthConfig struct {
+	ReadinessTimeout time.Duration `env:"READINESS_TIMEOUT" env-default:"2s"`
+	DrainTimeout     time.Duration `env:"DRAIN_TIMEOUT" env-default:"5s"`
+}
+
+// IDConfig holds the regular expressions user and PR IDs must match.
+// The defaults keep the formats of the original schema.
+type IDConfig struct {
+	UserPattern string `env:"USER_PATTERN" env-default:"^u[0-9]+$"`
+	PRPattern   string `env:"PR_PATTERN" env-default:"^pr-[0-9]+$"`
+}
+
+type AssignmentConfig struct {
+	// CapacityMode decides what happens to a reviewer slot when every candidate
+	// is at capacity: assign anyway, skip (leave it empty) or queue it.
+	CapacityMode string `env:"CAPACITY_MODE" env-default:"skip"`
+	// PreferWorkingHours picks reviewers inside their working hours before
+	// the others, who are still picked when nobody else is left.
+	PreferWorkingHours bool `env:"PREFER_WORKING_HOURS" env-default:"false"`
+	// PairingWindow is how far back reviews of an author's PRs count against
+	// picking the same reviewer again; zero turns the penalty off.
+	PairingWindow time.Duration `env:"PAIRING_WINDOW" env-default:"0"`
+	// Order decides the order in which equally suited candidates are tried:
+	// random, or hash for the same reviewers given the same PR and candidates.
+	Order string `env:"ORDER" env-default:"random"`
+	// Seed seeds the random order, making it reproducible; zero seeds it randomly.
+	Seed uint64 `env:"SEED" env-default:"0"`
+	// BackfillInterval is how often queued reviewer slots are retried
+	// besides the retries triggered by changes to users and teams.
+	BackfillInterval time.Duration `env:"BACKFILL_INTERVAL" env-default:"1m"`
+	// AbsenceCheckInterval is how often started absences are checked for
+	// open reviews to hand over.
+	AbsenceCheckInterval time.Duration `env:"ABSENCE_CHECK_INTERVAL" env-default:"1m"`
+}
+
 type StorageConfig struct {
-	Host           string `env:"HOST" env-required:"true"`
-	Port           string `env:"PORT" env-required:"true"`
-	User           string `env:"USER" env-required:"true"`
-	Password       string `env:"PASSWORD" env-required:"true"`
-	Database       string `env:"NAME" env-required:"true"`
+	Host           string `env:"HOST"`
+	Port           string `env:"PORT"`
+	User           string `env:"USER"`
+	Password       string `env:"PASSWORD"`
+	Database       string `env:"NAME"`
 	SSLMode        string `env:"SSLMODE" env-default:"disable"`
 	MaxConnections int    `env:"MAX_CONNECTIONS" env-default:"25"`
+	AutoMigrate    bool   `env:"AUTO_MIGRATE" env-default:"false"`
+}
+
+type SQLiteConfig struct {
+	Path        string `env:"PATH" env-default:"./pr_reviewer.db"`
+	AutoMigrate bool   `env:"AUTO_MIGRATE" env-default:"true"`
+}
+
+// validate replaces env-required for the DB_ fields: they are only
+// mandatory when Postgres is the selected storage driver.
+func (s *StorageConfig) validate() error {
+	if s.Host == "" || s.Port == "" || s.User == "" || s.Password == "" || s.Database == "" {
+		return errors.New("DB_HOST, DB_PORT, DB_USER, DB_PASSWORD and DB_NAME are required")
+	}
+
+	return nil
 }
 
 func (s *StorageConfig) DSN() string {
@@ -48,5 +113,35 @@ func MustLoadConfig() *Config {
 		log.Fatalf("Failed to read environment variables: %s", err)
 	}
 
+	switch cfg.StorageDriver {
+	case StorageDriverPostgres:
+		if err := cfg.StorageConfig.validate(); err != nil {
+			log.Fatalf("Invalid storage config: %s", err)
+		}
+	case StorageDriverMemory, StorageDriverSQLite:
+	default:
+		log.Fatalf("Unknown storage driver: %q", cfg.StorageDriver)
+	}
+
+	switch cfg.AssignmentConfig.CapacityMode {
+	case domain.CapacityAssign, domain.CapacitySkip, domain.CapacityQueue:
+	default:
+		log.Fatalf("Unknown capacity mode: %q", cfg.AssignmentConfig.CapacityMode)
+	}
+
+	switch cfg.AssignmentConfig.Order {
+	case domain.OrderRandom, domain.OrderHash:
+	default:
+		log.Fatalf("Unknown assignment order: %q", cfg.AssignmentConfig.Order)
+	}
+
+	if cfg.AssignmentConfig.BackfillInterval <= 0 {
+		log.Fatalf("Invalid backfill interval: %s", cfg.AssignmentConfig.BackfillInterval)
+	}
+
+	if cfg.AssignmentConfig.AbsenceCheckInterval <= 0 {
+		log.Fatalf("Invalid absence check interval: %s", cfg.AssignmentConfig.AbsenceCheckInterval)
+	}
+
 	return &cfg
 }
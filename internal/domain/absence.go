@@ -0,0 +1,42 @@
+package domain
+
+import "time"
+
+// Absence is a period in which the user is not selected as a reviewer, such as a
+// vacation. It covers StartsAt up to, but not including, EndsAt.
+type Absence struct {
+	ID       int64
+	UserID   string
+	StartsAt time.Time
+	EndsAt   time.Time
+	Reason   string
+	// Reassign hands the user's open reviews over to other reviewers once the
+	// absence starts; ReassignedAt is set when that is done.
+	Reassign     bool
+	ReassignedAt *time.Time
+	// UID identifies an absence imported from a calendar, so that importing
+	// the calendar again updates it instead of adding a copy. Empty otherwise.
+	UID string
+}
+
+// ActiveAt reports whether the absence covers t.
+func (a *Absence) ActiveAt(t time.Time) bool {
+	return !t.Before(a.StartsAt) && t.Before(a.EndsAt)
+}
+
+// SkippedEvent is a calendar event that was not imported as an absence.
+type SkippedEvent struct {
+	UID     string
+	Summary string
+	Reason  string
+}
+
+// Reasons for skipping a calendar event.
+const (
+	// SkipRecurring is given for events with RRULE or RDATE: importing the first
+	// occurrence alone would be wrong, and expanding the rule is not supported.
+	SkipRecurring = "recurring"
+	// SkipTransparent is given for events with TRANSP:TRANSPARENT, which do not
+	// block the time of their owner.
+	SkipTransparent = "transparent"
+)
@@ -0,0 +1,23 @@
+package domain
+
+import "time"
+
+type HealthCheck struct {
+	Name  string
+	OK    bool
+	Error string
+}
+
+type WorkerStatus struct {
+	Name      string
+	Running   bool
+	LastRunAt *time.Time
+	LastError string
+}
+
+type Readiness struct {
+	Ready        bool
+	ShuttingDown bool
+	Checks       []HealthCheck
+	Workers      []WorkerStatus
+}
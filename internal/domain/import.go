@@ -0,0 +1,31 @@
+package domain
+
+// Import modes decide what happens to a bulk import when some of its PRs fail.
+const (
+	ImportAtomic     = "atomic"      // import nothing unless every PR can be imported
+	ImportBestEffort = "best_effort" // import every PR that can be imported
+)
+
+// ImportDraft is a PR of a bulk import. Reviewers, when not nil, are kept as its
+// reviewers instead of assigning them as on creation.
+type ImportDraft struct {
+	Draft     PRDraft
+	Reviewers []string
+}
+
+// PRImport is an open PR written by a bulk import together with its reviewers,
+// their rationales, the skill tags it requires and the reviewer slots queued
+// for it.
+type PRImport struct {
+	PR           PullRequest
+	RequiredTags []string
+	Queued       int
+}
+
+// ImportResult is the outcome of one PR of a bulk import: the imported PR, the
+// error it failed with, or neither when an atomic import was abandoned over
+// another PR.
+type ImportResult struct {
+	PR  *PullRequest
+	Err error
+}
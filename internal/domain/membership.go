@@ -0,0 +1,13 @@
+package domain
+
+// DepartedReviewer is a user who left a team while still assigned to open PRs
+// opened for that team.
+type DepartedReviewer struct {
+	UserID         string
+	FormerTeamName string
+	PullRequests   []PRRef
+	// ReplacedBy is set only when reassignment was requested. It maps every
+	// reassigned PR to the new reviewer; an empty reviewer means that no
+	// candidate was found and the departed user was only removed.
+	ReplacedBy map[PRRef]string
+}
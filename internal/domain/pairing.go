@@ -0,0 +1,21 @@
+package domain
+
+import "time"
+
+// Pairing counts the assignments of ReviewerID to PRs by AuthorID, including
+// those ReviewerID was later replaced on or removed from.
+type Pairing struct {
+	AuthorID   string
+	ReviewerID string
+	Count      int
+}
+
+// PairingMatrix counts how often the members of a team were assigned to each
+// other's PRs within Window; a zero Window counts all assignments. Pairings hold the pairs
+// of members with a non-zero count, ordered by author and reviewer.
+type PairingMatrix struct {
+	TeamName string
+	Window   time.Duration
+	Members  []string
+	Pairings []Pairing
+}
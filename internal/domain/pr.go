@@ -3,11 +3,65 @@ package domain
 import "time"
 
 type PullRequest struct {
-	PullRequestID     string
+	Repository        string // empty for PRs created without a repository
+	PullRequestID     string // unique within the repository
 	PullRequestName   string
 	AuthorID          string
+	TeamName          string // team the reviewers are picked from
 	Status            string // OPEN, MERGED
 	AssignedReviewers []string
 	CreatedAt         *time.Time
 	MergedAt          *time.Time
+
+	// Rationales explains why each reviewer was assigned.
+	Rationales []AssignmentRationale
+	// SeniorityViolations are the seniority rules the last assignment could not
+	// satisfy. They are filled only by the assignment itself.
+	SeniorityViolations []SeniorityViolation
+}
+
+// PRDraft is a PR to be created.
+type PRDraft struct {
+	Repository      string
+	PullRequestID   string
+	PullRequestName string
+	AuthorID        string
+	TeamName        string   // empty to use the repository's or the author's team
+	ChangedFiles    []string // paths relative to the repository root
+	RequiredTags    []string // tags at least one reviewer must have, where possible
+}
+
+// PRRef identifies a PR.
+type PRRef struct {
+	Repository    string
+	PullRequestID string
+}
+
+func (pr *PullRequest) Ref() PRRef {
+	return PRRef{Repository: pr.Repository, PullRequestID: pr.PullRequestID}
+}
+
+// Less orders refs by repository, then by PR ID.
+func (r PRRef) Less(other PRRef) bool {
+	if r.Repository != other.Repository {
+		return r.Repository < other.Repository
+	}
+	return r.PullRequestID < other.PullRequestID
+}
+
+// PendingSlots are reviewer slots of an open PR left empty for later assignment.
+type PendingSlots struct {
+	PR       PullRequest // without reviewers and rationales
+	Slots    int
+	QueuedAt *time.Time
+}
+
+// Reassignment replaces OldReviewerID as a reviewer of a PR.
+type Reassignment struct {
+	OldReviewerID string
+	// Replacement is the new reviewer with why it was picked, nil to leave the
+	// slot empty.
+	Replacement *AssignmentRationale
+	// QueueSlot queues the empty slot for later assignment.
+	QueueSlot bool
 }
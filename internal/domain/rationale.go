@@ -0,0 +1,76 @@
+package domain
+
+// Assignment strategies.
+const (
+	StrategyCodeOwners = "codeowners" // an owner of a code owner rule matching the changed files
+	StrategyRandom     = "random"     // a random eligible member of the PR's team
+	StrategySkill      = "skill"      // a member of the PR's team with a tag the PR requires
+	StrategySeniority  = "seniority"  // a member of the PR's team senior enough for a seniority rule
+	StrategyManual     = "manual"     // named by the caller rather than selected
+	// StrategyOverCapacity picks the least loaded candidate at capacity when
+	// every candidate is; see CapacityAssign.
+	StrategyOverCapacity = "over_capacity"
+)
+
+// Reasons a candidate was not eligible for review.
+const (
+	ExclusionAuthor   = "author"
+	ExclusionInactive = "inactive"
+	ExclusionAbsent   = "absent"   // inside a scheduled absence
+	ExclusionReplaced = "replaced" // the reviewer being replaced in a reassignment
+	ExclusionAssigned = "assigned" // already a reviewer of the PR
+	ExclusionCapacity = "capacity" // reviews as many OPEN PRs as allowed
+	// ExclusionOffHours marks candidates outside their working hours passed over
+	// for one inside them; they are still picked when nobody else is left.
+	ExclusionOffHours = "off_hours"
+)
+
+// Capacity modes decide what happens to a reviewer slot when every candidate
+// is at capacity.
+const (
+	CapacityAssign = "assign" // assign the least loaded candidate anyway
+	CapacitySkip   = "skip"   // leave the slot empty
+	CapacityQueue  = "queue"  // leave the slot empty and queue it for later assignment
+)
+
+// Pick orders decide the order in which equally suited candidates are tried.
+const (
+	OrderRandom = "random" // shuffle with the random source
+	OrderHash   = "hash"   // by a hash of the PR and candidate IDs, the same for the same inputs
+)
+
+// AssignmentRationale explains why a reviewer was assigned to a PR.
+type AssignmentRationale struct {
+	ReviewerID string
+	Strategy   string
+	// Rule is the code owner pattern, for StrategyCodeOwners; the tag, for
+	// StrategySkill; the reviewer level, for StrategySeniority.
+	Rule     string
+	PoolSize int // candidates considered, excluded ones included
+	Excluded []ExcludedCandidate
+}
+
+type ExcludedCandidate struct {
+	UserID string
+	Reason string
+}
+
+// Candidate is an eligible reviewer in the order the selection tries them.
+type Candidate struct {
+	UserID   string
+	Strategy string
+	Rule     string // as in AssignmentRationale
+}
+
+// AssignmentPreview is the outcome of a reviewer selection that was not applied.
+type AssignmentPreview struct {
+	TeamName   string
+	Candidates []Candidate
+	Picks      []AssignmentRationale
+	// Queued counts the slots that would be queued; see CapacityQueue.
+	Queued int
+	// UncoveredTags are the required tags no eligible reviewer has.
+	UncoveredTags []string
+	// SeniorityViolations are the seniority rules the reviewers would not satisfy.
+	SeniorityViolations []SeniorityViolation
+}
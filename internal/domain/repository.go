@@ -0,0 +1,30 @@
+package domain
+
+// DefaultReviewersCount is the number of reviewers a new PR gets unless its
+// repository says otherwise.
+const DefaultReviewersCount = 2
+
+// Repository is a code repository PRs are opened in.
+type Repository struct {
+	Name string
+	// TeamName owns the repository: reviewers of its PRs are picked from it.
+	// Empty means the author's team, as for PRs without a repository.
+	TeamName       string
+	ReviewersCount int
+}
+
+// RepositoryUpdate changes the settings of a repository. Nil fields are left as they are;
+// an empty TeamName removes the owning team.
+type RepositoryUpdate struct {
+	Name           string
+	TeamName       *string
+	ReviewersCount *int
+}
+
+// CodeOwnerRule assigns the files matching Pattern to owners, like a line of a
+// CODEOWNERS file. Rules of a repository are ordered; the last one matching a file wins.
+type CodeOwnerRule struct {
+	Pattern   string
+	UserIDs   []string
+	TeamNames []string
+}
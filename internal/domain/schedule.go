@@ -0,0 +1,76 @@
+package domain
+
+import (
+	"slices"
+	"time"
+	// Users' time zones must resolve on hosts without a zoneinfo database.
+	_ "time/tzdata"
+)
+
+// WorkingHours is the weekly schedule of a user in the user's time zone.
+type WorkingHours struct {
+	// Start and End are minutes after midnight. An End before Start is a shift
+	// spanning midnight, which belongs to the day it starts on.
+	Start int
+	End   int
+	Days  []time.Weekday
+}
+
+// DefaultWorkDays are the days of a schedule set without days.
+var DefaultWorkDays = []time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday}
+
+// Contains reports whether t, in the schedule's time zone, is within working hours.
+func (h *WorkingHours) Contains(t time.Time) bool {
+	minute := t.Hour()*60 + t.Minute()
+	day := t.Weekday()
+
+	if h.Start < h.End {
+		return slices.Contains(h.Days, day) && minute >= h.Start && minute < h.End
+	}
+
+	previous := (day + 6) % 7
+	return (slices.Contains(h.Days, day) && minute >= h.Start) ||
+		(slices.Contains(h.Days, previous) && minute < h.End)
+}
+
+// InWorkingHours reports whether t is within the user's working hours. A user
+// without a schedule is always available; a schedule without a time zone is
+// read in UTC.
+func (u *User) InWorkingHours(t time.Time) bool {
+	if u.WorkingHours == nil {
+		return true
+	}
+
+	return u.WorkingHours.Contains(t.In(u.Location()))
+}
+
+// Location returns the user's time zone, UTC if it is unknown.
+func (u *User) Location() *time.Location {
+	if u.Timezone != "" {
+		if loc, err := time.LoadLocation(u.Timezone); err == nil {
+			return loc
+		}
+	}
+	return time.UTC
+}
+
+// DayMask returns the days of the schedule as a bit mask with bit N set for
+// weekday N.
+func (h *WorkingHours) DayMask() int {
+	mask := 0
+	for _, day := range h.Days {
+		mask |= 1 << day
+	}
+	return mask
+}
+
+// WorkDays returns the weekdays set in a mask built by DayMask.
+func WorkDays(mask int) []time.Weekday {
+	var days []time.Weekday
+	for day := time.Sunday; day <= time.Saturday; day++ {
+		if mask&(1<<day) != 0 {
+			days = append(days, day)
+		}
+	}
+	return days
+}
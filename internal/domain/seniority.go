@@ -0,0 +1,49 @@
+package domain
+
+import "slices"
+
+// Seniority levels, from the lowest.
+const (
+	LevelJunior = "junior"
+	LevelMiddle = "middle"
+	LevelSenior = "senior"
+)
+
+var levels = []string{LevelJunior, LevelMiddle, LevelSenior}
+
+// ValidLevel reports whether level is one of the seniority levels.
+func ValidLevel(level string) bool {
+	return slices.Contains(levels, level)
+}
+
+// LevelAtLeast reports whether level is minLevel or above. An empty level is
+// below every level.
+func LevelAtLeast(level string, minLevel string) bool {
+	return level != "" && slices.Index(levels, level) >= slices.Index(levels, minLevel)
+}
+
+// AtLeast reports whether the user's level is level or above.
+func (u *User) AtLeast(level string) bool {
+	return LevelAtLeast(u.Level, level)
+}
+
+// SeniorityRule requires a PR by an author of AuthorLevel to have at least
+// MinReviewers reviewers of MinLevel or above. A rule of a junior author for one
+// middle reviewer keeps two juniors from reviewing each other's PRs alone.
+type SeniorityRule struct {
+	AuthorLevel  string
+	MinLevel     string
+	MinReviewers int
+}
+
+// Applies reports whether the rule binds PRs by author.
+func (r SeniorityRule) Applies(author *User) bool {
+	return author != nil && author.Level == r.AuthorLevel
+}
+
+// SeniorityViolation is a rule the assignment could not satisfy for lack of
+// eligible reviewers. Assigned counts the reviewers meeting its level.
+type SeniorityViolation struct {
+	Rule     SeniorityRule
+	Assigned int
+}
@@ -1,8 +1,17 @@
 package domain
 
+import "time"
+
 type Team struct {
-	TeamName string
-	Members  []*User
+	TeamName   string
+	Members    []*User
+	ArchivedAt *time.Time
+	// MaxOpenReviews is the default limit of OPEN PRs under review for users
+	// with this primary team; nil means no limit.
+	MaxOpenReviews *int
+	// SeniorityRules bind the reviewers of the team's PRs, ordered by author
+	// level, then by reviewer level.
+	SeniorityRules []SeniorityRule
 }
 
 //type TeamMember struct {
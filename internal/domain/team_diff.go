@@ -0,0 +1,75 @@
+package domain
+
+import "slices"
+
+// TeamDiff is the set of changes that turns a team's roster into the desired one.
+type TeamDiff struct {
+	TeamName    string
+	TeamCreated bool
+	// Added are users that join the team, either new or moved from another team.
+	Added []*User
+	// Updated are members whose username or activity changes.
+	Updated []*User
+	// Deactivated and Detached are members missing from the desired roster.
+	Deactivated []*User
+	Detached    []*User
+	Unchanged   []string
+}
+
+// NewTeamDiff returns the diff that turns the current roster of the team into
+// members. Current members missing from members are detached from the team if
+// detach is set, else deactivated. members get the team as their team.
+func NewTeamDiff(teamName string, current []*User, members []*User, detach bool) *TeamDiff {
+	diff := &TeamDiff{TeamName: teamName}
+
+	currentByID := make(map[string]*User, len(current))
+	for _, user := range current {
+		currentByID[user.UserID] = user
+	}
+
+	desired := make(map[string]bool, len(members))
+	for _, member := range members {
+		desired[member.UserID] = true
+		member.TeamName = teamName
+
+		cur, ok := currentByID[member.UserID]
+		switch {
+		case !ok:
+			diff.Added = append(diff.Added, member)
+		case cur.Username != member.Username || cur.IsActive != member.IsActive ||
+			member.Tags != nil && !slices.Equal(cur.Tags, member.Tags):
+			diff.Updated = append(diff.Updated, member)
+		default:
+			diff.Unchanged = append(diff.Unchanged, member.UserID)
+		}
+	}
+
+	for _, user := range current {
+		if desired[user.UserID] {
+			continue
+		}
+
+		changed := *user
+		switch {
+		case detach:
+			changed.TeamName = ""
+			diff.Detached = append(diff.Detached, &changed)
+		case user.IsActive:
+			changed.IsActive = false
+			diff.Deactivated = append(diff.Deactivated, &changed)
+		default:
+			diff.Unchanged = append(diff.Unchanged, user.UserID)
+		}
+	}
+
+	return diff
+}
+
+// Empty reports whether applying the diff changes nothing.
+func (d *TeamDiff) Empty() bool {
+	return !d.TeamCreated &&
+		len(d.Added) == 0 &&
+		len(d.Updated) == 0 &&
+		len(d.Deactivated) == 0 &&
+		len(d.Detached) == 0
+}
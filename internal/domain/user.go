@@ -3,6 +3,45 @@ package domain
 type User struct {
 	UserID   string
 	Username string
+	// TeamName is the primary team of the user, or the team the user
+	// was listed for when read through a team.
 	TeamName string
-	IsActive bool
+	// TeamNames are all teams the user is a member of. It is filled only
+	// where noted by the storage.
+	TeamNames []string
+	IsActive  bool
+	// MaxOpenReviews is the user's own limit of OPEN PRs under review; nil
+	// means the default of the primary team applies.
+	MaxOpenReviews *int
+	// Timezone is the IANA time zone of the user, empty if unknown.
+	Timezone string
+	// WorkingHours is nil for a user without a schedule.
+	WorkingHours *WorkingHours
+	// Tags are the user's skill tags, sorted. Upserting a user with nil Tags
+	// keeps the stored ones.
+	Tags []string
+	// Level is the user's seniority, one of the Level* constants; empty if unknown.
+	Level string
+
+	// OpenReviews, ReviewLimit and Absent are filled only in reviewer pools.
+	// ReviewLimit is the effective limit, nil if there is none. Absent is set
+	// while an absence of the user is in progress.
+	OpenReviews int
+	ReviewLimit *int
+	Absent      bool
+}
+
+// AtCapacity reports whether the user reviews as many OPEN PRs as allowed.
+func (u *User) AtCapacity() bool {
+	return u.ReviewLimit != nil && u.OpenReviews >= *u.ReviewLimit
+}
+
+// UserSearch selects a page of the user directory. Empty filters match every user.
+type UserSearch struct {
+	// Prefix matches the start of the username or the user_id, ignoring case.
+	Prefix   string
+	TeamName string
+	IsActive *bool
+	Limit    int
+	Offset   int
 }
@@ -0,0 +1,270 @@
+package absence
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"time"
+
+	"github.com/whitxowl/pr-reviewer-assignment-service.git/internal/domain"
+	"github.com/whitxowl/pr-reviewer-assignment-service.git/internal/service/calendar"
+	serviceErr "github.com/whitxowl/pr-reviewer-assignment-service.git/internal/service/errors"
+	storageErr "github.com/whitxowl/pr-reviewer-assignment-service.git/internal/storage/errors"
+)
+
+type UserStorage interface {
+	GetUser(ctx context.Context, userID string) (*domain.User, error)
+	SaveAbsences(ctx context.Context, userID string, absences []domain.Absence) ([]domain.Absence, error)
+	GetAbsences(ctx context.Context, userID string) ([]domain.Absence, error)
+	DeleteAbsence(ctx context.Context, userID string, absenceID int64) error
+	GetStartedAbsences(ctx context.Context) ([]domain.Absence, error)
+	MarkAbsenceReassigned(ctx context.Context, absenceID int64) error
+}
+
+type PRStorage interface {
+	GetPRsReviewedBy(ctx context.Context, userID string) ([]*domain.PullRequest, error)
+}
+
+// Reassigner replaces a reviewer of an open PR; it is implemented by the PR service.
+type Reassigner interface {
+	ReassignReviewer(
+		ctx context.Context,
+		repository string,
+		prID string,
+		oldReviewerID string,
+		dryRun bool,
+	) (*domain.PullRequest, string, error)
+}
+
+// Waker is told when a background worker has work to do.
+type Waker interface {
+	Wake()
+}
+
+type Service struct {
+	log         *slog.Logger
+	userStorage UserStorage
+	prStorage   PRStorage
+	reassigner  Reassigner
+
+	reassignWaker Waker
+	backfillWaker Waker
+}
+
+func New(log *slog.Logger, userStorage UserStorage, prStorage PRStorage, reassigner Reassigner) *Service {
+	return &Service{
+		log:         log,
+		userStorage: userStorage,
+		prStorage:   prStorage,
+		reassigner:  reassigner,
+	}
+}
+
+// SetWakers sets the workers to wake: reassign when an absence whose reviews
+// are to be handed over is in progress, backfill when a user may be available
+// for review again.
+func (s *Service) SetWakers(reassign Waker, backfill Waker) {
+	s.reassignWaker = reassign
+	s.backfillWaker = backfill
+}
+
+// AddAbsence schedules an absence of absence.UserID.
+func (s *Service) AddAbsence(ctx context.Context, absence domain.Absence) (*domain.Absence, error) {
+	const op = "service.absence.AddAbsence"
+
+	log := s.log.With(
+		slog.String("op", op),
+		slog.String("user_id", absence.UserID),
+	)
+
+	if !absence.EndsAt.After(absence.StartsAt) {
+		return nil, fmt.Errorf("%w: ends_at %s is not after starts_at %s",
+			serviceErr.ErrInvalidAbsence,
+			absence.EndsAt.Format(time.RFC3339),
+			absence.StartsAt.Format(time.RFC3339))
+	}
+
+	saved, err := s.save(ctx, absence.UserID, []domain.Absence{absence})
+	if errors.Is(err, storageErr.ErrUserNotFound) {
+		log.DebugContext(ctx, "user not found", "error", err)
+		return nil, serviceErr.ErrUserNotFound
+	}
+	if err != nil {
+		log.ErrorContext(ctx, "error saving absence", "error", err)
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.InfoContext(ctx, "absence added", "absence_id", saved[0].ID)
+
+	return &saved[0], nil
+}
+
+// GetAbsences returns the absences of the user, earliest first.
+func (s *Service) GetAbsences(ctx context.Context, userID string) ([]domain.Absence, error) {
+	const op = "service.absence.GetAbsences"
+
+	log := s.log.With(
+		slog.String("op", op),
+		slog.String("user_id", userID),
+	)
+
+	err := s.checkUser(ctx, userID)
+	if errors.Is(err, serviceErr.ErrUserNotFound) {
+		log.DebugContext(ctx, "user not found", "error", err)
+		return nil, err
+	}
+	if err != nil {
+		log.ErrorContext(ctx, "error getting user", "error", err)
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	absences, err := s.userStorage.GetAbsences(ctx, userID)
+	if err != nil {
+		log.ErrorContext(ctx, "error getting absences", "error", err)
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return absences, nil
+}
+
+func (s *Service) DeleteAbsence(ctx context.Context, userID string, absenceID int64) error {
+	const op = "service.absence.DeleteAbsence"
+
+	log := s.log.With(
+		slog.String("op", op),
+		slog.String("user_id", userID),
+		slog.Int64("absence_id", absenceID),
+	)
+
+	err := s.userStorage.DeleteAbsence(ctx, userID, absenceID)
+	if errors.Is(err, storageErr.ErrAbsenceNotFound) {
+		log.DebugContext(ctx, "absence not found", "error", err)
+		return serviceErr.ErrAbsenceNotFound
+	}
+	if err != nil {
+		log.ErrorContext(ctx, "error deleting absence", "error", err)
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.InfoContext(ctx, "absence deleted")
+
+	// The user is back if the absence was in progress.
+	if s.backfillWaker != nil {
+		s.backfillWaker.Wake()
+	}
+
+	return nil
+}
+
+// ImportCalendar adds the events of an iCalendar file as absences of the user.
+// Dates and floating times are read in the user's time zone. Events that have
+// already ended are left out. Events imported before, known by
+// their UID, are updated instead of added again. Events the calendar package
+// skips, such as recurring ones, are returned with the reason.
+func (s *Service) ImportCalendar(
+	ctx context.Context,
+	userID string,
+	r io.Reader,
+	reassign bool,
+) ([]domain.Absence, []domain.SkippedEvent, error) {
+	const op = "service.absence.ImportCalendar"
+
+	log := s.log.With(
+		slog.String("op", op),
+		slog.String("user_id", userID),
+	)
+
+	user, err := s.userStorage.GetUser(ctx, userID)
+	if errors.Is(err, storageErr.ErrUserNotFound) {
+		log.DebugContext(ctx, "user not found", "error", err)
+		return nil, nil, serviceErr.ErrUserNotFound
+	}
+	if err != nil {
+		log.ErrorContext(ctx, "error getting user", "error", err)
+		return nil, nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	events, skipped, err := calendar.Parse(r, user.Location())
+	if err != nil {
+		log.DebugContext(ctx, "invalid calendar", "error", err)
+		return nil, nil, err
+	}
+	for _, event := range skipped {
+		log.InfoContext(ctx, "calendar event skipped",
+			"uid", event.UID,
+			"reason", event.Reason)
+	}
+
+	now := time.Now()
+
+	absences := make([]domain.Absence, 0, len(events))
+	for _, event := range events {
+		if !event.End.After(now) {
+			continue
+		}
+		absences = append(absences, domain.Absence{
+			UserID:   userID,
+			StartsAt: event.Start,
+			EndsAt:   event.End,
+			Reason:   event.Summary,
+			Reassign: reassign,
+			UID:      event.UID,
+		})
+	}
+
+	saved, err := s.save(ctx, userID, absences)
+	if errors.Is(err, storageErr.ErrUserNotFound) {
+		log.DebugContext(ctx, "user not found", "error", err)
+		return nil, nil, serviceErr.ErrUserNotFound
+	}
+	if err != nil {
+		log.ErrorContext(ctx, "error saving absences", "error", err)
+		return nil, nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.InfoContext(ctx, "calendar imported",
+		"events", len(events),
+		"skipped", len(skipped),
+		"absences", len(saved))
+
+	return saved, skipped, nil
+}
+
+// save stores the absences in UTC and wakes the reassign worker if one of them
+// is to be handed over right away.
+func (s *Service) save(ctx context.Context, userID string, absences []domain.Absence) ([]domain.Absence, error) {
+	if len(absences) == 0 {
+		return []domain.Absence{}, nil
+	}
+
+	for i := range absences {
+		absences[i].StartsAt = absences[i].StartsAt.UTC()
+		absences[i].EndsAt = absences[i].EndsAt.UTC()
+	}
+
+	saved, err := s.userStorage.SaveAbsences(ctx, userID, absences)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	for _, absence := range saved {
+		if absence.Reassign && absence.ReassignedAt == nil && absence.ActiveAt(now) && s.reassignWaker != nil {
+			s.reassignWaker.Wake()
+			break
+		}
+	}
+
+	return saved, nil
+}
+
+// checkUser reports ErrUserNotFound for an unknown user.
+func (s *Service) checkUser(ctx context.Context, userID string) error {
+	_, err := s.userStorage.GetUser(ctx, userID)
+	if errors.Is(err, storageErr.ErrUserNotFound) {
+		return serviceErr.ErrUserNotFound
+	}
+	return err
+}
@@ -0,0 +1,343 @@
+package absence
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/whitxowl/pr-reviewer-assignment-service.git/internal/domain"
+	"github.com/whitxowl/pr-reviewer-assignment-service.git/internal/service/absence/mocks"
+	serviceErr "github.com/whitxowl/pr-reviewer-assignment-service.git/internal/service/errors"
+	storageErr "github.com/whitxowl/pr-reviewer-assignment-service.git/internal/storage/errors"
+)
+
+func TestService_AddAbsence(t *testing.T) {
+	ctx := context.Background()
+	log := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
+
+	now := time.Now().UTC().Truncate(time.Second)
+	berlin := time.FixedZone("CEST", 2*60*60)
+
+	tests := []struct {
+		name            string
+		absence         domain.Absence
+		setupMocks      func(*mocks.MockUserStorage)
+		expectedAbsence *domain.Absence
+		expectedWake    bool
+		expectedError   error
+	}{
+		{
+			name: "success - future absence stored in UTC",
+			absence: domain.Absence{
+				UserID:   "u1",
+				StartsAt: now.Add(24 * time.Hour).In(berlin),
+				EndsAt:   now.Add(48 * time.Hour).In(berlin),
+				Reason:   "vacation",
+				Reassign: true,
+			},
+			setupMocks: func(userStorage *mocks.MockUserStorage) {
+				userStorage.EXPECT().
+					SaveAbsences(ctx, "u1", []domain.Absence{{
+						UserID:   "u1",
+						StartsAt: now.Add(24 * time.Hour),
+						EndsAt:   now.Add(48 * time.Hour),
+						Reason:   "vacation",
+						Reassign: true,
+					}}).
+					Return([]domain.Absence{{
+						ID:       1,
+						UserID:   "u1",
+						StartsAt: now.Add(24 * time.Hour),
+						EndsAt:   now.Add(48 * time.Hour),
+						Reason:   "vacation",
+						Reassign: true,
+					}}, nil).
+					Once()
+			},
+			expectedAbsence: &domain.Absence{
+				ID:       1,
+				UserID:   "u1",
+				StartsAt: now.Add(24 * time.Hour),
+				EndsAt:   now.Add(48 * time.Hour),
+				Reason:   "vacation",
+				Reassign: true,
+			},
+		},
+		{
+			name: "success - absence in progress wakes the reassign worker",
+			absence: domain.Absence{
+				UserID:   "u1",
+				StartsAt: now.Add(-time.Hour),
+				EndsAt:   now.Add(time.Hour),
+				Reassign: true,
+			},
+			setupMocks: func(userStorage *mocks.MockUserStorage) {
+				userStorage.EXPECT().
+					SaveAbsences(ctx, "u1", mock.Anything).
+					Return([]domain.Absence{{
+						ID:       2,
+						UserID:   "u1",
+						StartsAt: now.Add(-time.Hour),
+						EndsAt:   now.Add(time.Hour),
+						Reassign: true,
+					}}, nil).
+					Once()
+			},
+			expectedAbsence: &domain.Absence{
+				ID:       2,
+				UserID:   "u1",
+				StartsAt: now.Add(-time.Hour),
+				EndsAt:   now.Add(time.Hour),
+				Reassign: true,
+			},
+			expectedWake: true,
+		},
+		{
+			name: "error - ends before it starts",
+			absence: domain.Absence{
+				UserID:   "u1",
+				StartsAt: now,
+				EndsAt:   now,
+			},
+			setupMocks:    func(*mocks.MockUserStorage) {},
+			expectedError: serviceErr.ErrInvalidAbsence,
+		},
+		{
+			name: "error - user not found",
+			absence: domain.Absence{
+				UserID:   "u404",
+				StartsAt: now,
+				EndsAt:   now.Add(time.Hour),
+			},
+			setupMocks: func(userStorage *mocks.MockUserStorage) {
+				userStorage.EXPECT().
+					SaveAbsences(ctx, "u404", mock.Anything).
+					Return(nil, storageErr.ErrUserNotFound).
+					Once()
+			},
+			expectedError: serviceErr.ErrUserNotFound,
+		},
+		{
+			name: "error - storage failure",
+			absence: domain.Absence{
+				UserID:   "u1",
+				StartsAt: now,
+				EndsAt:   now.Add(time.Hour),
+			},
+			setupMocks: func(userStorage *mocks.MockUserStorage) {
+				userStorage.EXPECT().
+					SaveAbsences(ctx, "u1", mock.Anything).
+					Return(nil, errors.New("query error")).
+					Once()
+			},
+			expectedError: errors.New("service.absence.AddAbsence: query error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			userStorage := mocks.NewMockUserStorage(t)
+			prStorage := mocks.NewMockPRStorage(t)
+			reassigner := mocks.NewMockReassigner(t)
+			reassignWaker := mocks.NewMockWaker(t)
+			backfillWaker := mocks.NewMockWaker(t)
+			tt.setupMocks(userStorage)
+			if tt.expectedWake {
+				reassignWaker.EXPECT().Wake().Once()
+			}
+
+			service := New(log, userStorage, prStorage, reassigner)
+			service.SetWakers(reassignWaker, backfillWaker)
+
+			// Act
+			absence, err := service.AddAbsence(ctx, tt.absence)
+
+			// Assert
+			if tt.expectedError != nil {
+				assert.ErrorContains(t, err, tt.expectedError.Error())
+				assert.Nil(t, absence)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedAbsence, absence)
+			}
+		})
+	}
+}
+
+func TestService_ImportCalendar(t *testing.T) {
+	ctx := context.Background()
+	log := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
+
+	next := time.Now().UTC().AddDate(1, 0, 0)
+	day := func(d int) string { return time.Date(next.Year(), 7, d, 0, 0, 0, 0, time.UTC).Format("20060102") }
+
+	ics := "BEGIN:VCALENDAR\r\n" +
+		"BEGIN:VEVENT\r\nUID:past\r\nSUMMARY:Last year\r\n" +
+		"DTSTART;VALUE=DATE:20200714\r\nDTEND;VALUE=DATE:20200720\r\nEND:VEVENT\r\n" +
+		"BEGIN:VEVENT\r\nUID:summer\r\nSUMMARY:Summer vacation\r\n" +
+		"DTSTART;VALUE=DATE:" + day(14) + "\r\nDTEND;VALUE=DATE:" + day(20) + "\r\nEND:VEVENT\r\n" +
+		"BEGIN:VEVENT\r\nUID:focus\r\nSUMMARY:Focus time\r\nDTSTART:" + day(1) + "T080000Z\r\n" +
+		"DURATION:PT2H\r\nRRULE:FREQ=WEEKLY\r\nEND:VEVENT\r\n" +
+		"END:VCALENDAR\r\n"
+
+	summer := domain.Absence{
+		UserID:   "u1",
+		StartsAt: time.Date(next.Year(), 7, 14, 0, 0, 0, 0, time.UTC),
+		EndsAt:   time.Date(next.Year(), 7, 20, 0, 0, 0, 0, time.UTC),
+		Reason:   "Summer vacation",
+		Reassign: true,
+		UID:      "summer",
+	}
+	saved := summer
+	saved.ID = 7
+
+	// The same days for a user in Berlin, on summer time two hours ahead of UTC.
+	berlinSummer := summer
+	berlinSummer.StartsAt = summer.StartsAt.Add(-2 * time.Hour)
+	berlinSummer.EndsAt = summer.EndsAt.Add(-2 * time.Hour)
+
+	tests := []struct {
+		name             string
+		userID           string
+		ics              string
+		setupMocks       func(*mocks.MockUserStorage)
+		expectedAbsences []domain.Absence
+		expectedSkipped  []domain.SkippedEvent
+		expectedError    error
+	}{
+		{
+			name:   "success - past and recurring events skipped",
+			userID: "u1",
+			ics:    ics,
+			setupMocks: func(userStorage *mocks.MockUserStorage) {
+				userStorage.EXPECT().GetUser(ctx, "u1").Return(&domain.User{UserID: "u1"}, nil).Once()
+				userStorage.EXPECT().
+					SaveAbsences(ctx, "u1", []domain.Absence{summer}).
+					Return([]domain.Absence{saved}, nil).
+					Once()
+			},
+			expectedAbsences: []domain.Absence{saved},
+			expectedSkipped:  []domain.SkippedEvent{{UID: "focus", Summary: "Focus time", Reason: domain.SkipRecurring}},
+		},
+		{
+			name:   "success - dates read in the user's time zone",
+			userID: "u1",
+			ics:    ics,
+			setupMocks: func(userStorage *mocks.MockUserStorage) {
+				userStorage.EXPECT().GetUser(ctx, "u1").
+					Return(&domain.User{UserID: "u1", Timezone: "Europe/Berlin"}, nil).
+					Once()
+				userStorage.EXPECT().
+					SaveAbsences(ctx, "u1", []domain.Absence{berlinSummer}).
+					Return([]domain.Absence{berlinSummer}, nil).
+					Once()
+			},
+			expectedAbsences: []domain.Absence{berlinSummer},
+			expectedSkipped:  []domain.SkippedEvent{{UID: "focus", Summary: "Focus time", Reason: domain.SkipRecurring}},
+		},
+		{
+			name:   "success - nothing to import",
+			userID: "u1",
+			ics:    "BEGIN:VCALENDAR\r\nEND:VCALENDAR\r\n",
+			setupMocks: func(userStorage *mocks.MockUserStorage) {
+				userStorage.EXPECT().GetUser(ctx, "u1").Return(&domain.User{UserID: "u1"}, nil).Once()
+			},
+			expectedAbsences: []domain.Absence{},
+		},
+		{
+			name:   "error - invalid calendar",
+			userID: "u1",
+			ics:    "BEGIN:VCALENDAR\r\nBEGIN:VEVENT\r\n",
+			setupMocks: func(userStorage *mocks.MockUserStorage) {
+				userStorage.EXPECT().GetUser(ctx, "u1").Return(&domain.User{UserID: "u1"}, nil).Once()
+			},
+			expectedError: serviceErr.ErrInvalidCalendar,
+		},
+		{
+			name:   "error - user not found",
+			userID: "u404",
+			ics:    ics,
+			setupMocks: func(userStorage *mocks.MockUserStorage) {
+				userStorage.EXPECT().GetUser(ctx, "u404").Return(nil, storageErr.ErrUserNotFound).Once()
+			},
+			expectedError: serviceErr.ErrUserNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			userStorage := mocks.NewMockUserStorage(t)
+			prStorage := mocks.NewMockPRStorage(t)
+			reassigner := mocks.NewMockReassigner(t)
+			tt.setupMocks(userStorage)
+
+			service := New(log, userStorage, prStorage, reassigner)
+
+			// Act
+			absences, skipped, err := service.ImportCalendar(ctx, tt.userID, strings.NewReader(tt.ics), true)
+
+			// Assert
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				assert.Nil(t, absences)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedAbsences, absences)
+				assert.Equal(t, tt.expectedSkipped, skipped)
+			}
+		})
+	}
+}
+
+func TestService_DeleteAbsence(t *testing.T) {
+	ctx := context.Background()
+	log := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
+
+	tests := []struct {
+		name          string
+		storageErr    error
+		expectedWake  bool
+		expectedError error
+	}{
+		{name: "success - the user may be available again", expectedWake: true},
+		{name: "error - absence not found", storageErr: storageErr.ErrAbsenceNotFound, expectedError: serviceErr.ErrAbsenceNotFound},
+		{
+			name:          "error - storage failure",
+			storageErr:    errors.New("query error"),
+			expectedError: errors.New("service.absence.DeleteAbsence: query error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			userStorage := mocks.NewMockUserStorage(t)
+			backfillWaker := mocks.NewMockWaker(t)
+			userStorage.EXPECT().DeleteAbsence(ctx, "u1", int64(3)).Return(tt.storageErr).Once()
+			if tt.expectedWake {
+				backfillWaker.EXPECT().Wake().Once()
+			}
+
+			service := New(log, userStorage, mocks.NewMockPRStorage(t), mocks.NewMockReassigner(t))
+			service.SetWakers(mocks.NewMockWaker(t), backfillWaker)
+
+			// Act
+			err := service.DeleteAbsence(ctx, "u1", 3)
+
+			// Assert
+			if tt.expectedError != nil {
+				assert.EqualError(t, err, tt.expectedError.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,87 @@
+package absence
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/whitxowl/pr-reviewer-assignment-service.git/internal/domain"
+	serviceErr "github.com/whitxowl/pr-reviewer-assignment-service.git/internal/service/errors"
+)
+
+const statusOpen = "OPEN"
+
+// ReassignAbsentReviewers hands the open reviews of users whose absence has
+// started over to other reviewers, for absences created with Reassign. It
+// returns the number of reviews handed over. An absence is marked done once
+// all of its reviews are; one that failed is retried on the next run.
+func (s *Service) ReassignAbsentReviewers(ctx context.Context) (int, error) {
+	const op = "service.absence.ReassignAbsentReviewers"
+
+	log := s.log.With(slog.String("op", op))
+
+	absences, err := s.userStorage.GetStartedAbsences(ctx)
+	if err != nil {
+		log.ErrorContext(ctx, "error getting started absences", "error", err)
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	reassigned := 0
+	var errs []error
+	for _, absence := range absences {
+		n, err := s.reassignReviews(ctx, absence)
+		reassigned += n
+		if err != nil {
+			log.ErrorContext(ctx, "error reassigning reviews of absent user",
+				"user_id", absence.UserID,
+				"absence_id", absence.ID,
+				"error", err)
+			errs = append(errs, err)
+			continue
+		}
+
+		if err := s.userStorage.MarkAbsenceReassigned(ctx, absence.ID); err != nil {
+			log.ErrorContext(ctx, "error marking absence reassigned", "absence_id", absence.ID, "error", err)
+			errs = append(errs, err)
+		}
+	}
+
+	if reassigned > 0 {
+		log.InfoContext(ctx, "reviews of absent users reassigned", "reviews", reassigned)
+	}
+
+	if err := errors.Join(errs...); err != nil {
+		return reassigned, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return reassigned, nil
+}
+
+func (s *Service) reassignReviews(ctx context.Context, absence domain.Absence) (int, error) {
+	prs, err := s.prStorage.GetPRsReviewedBy(ctx, absence.UserID)
+	if err != nil {
+		return 0, err
+	}
+
+	reassigned := 0
+	for _, pr := range prs {
+		if pr.Status != statusOpen {
+			continue
+		}
+
+		_, _, err := s.reassigner.ReassignReviewer(ctx, pr.Repository, pr.PullRequestID, absence.UserID, false)
+		switch {
+		case errors.Is(err, serviceErr.ErrPRMerged),
+			errors.Is(err, serviceErr.ErrPRNotFound),
+			errors.Is(err, serviceErr.ErrReviewerNotFound):
+			// Merged, deleted or reassigned since it was listed.
+		case err != nil:
+			return reassigned, err
+		default:
+			reassigned++
+		}
+	}
+
+	return reassigned, nil
+}
@@ -0,0 +1,105 @@
+package absence
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/whitxowl/pr-reviewer-assignment-service.git/internal/domain"
+	"github.com/whitxowl/pr-reviewer-assignment-service.git/internal/service/absence/mocks"
+	serviceErr "github.com/whitxowl/pr-reviewer-assignment-service.git/internal/service/errors"
+)
+
+func TestService_ReassignAbsentReviewers(t *testing.T) {
+	ctx := context.Background()
+	log := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
+
+	reviewed := func(prID string, status string) *domain.PullRequest {
+		return &domain.PullRequest{Repository: "acme/api", PullRequestID: prID, Status: status}
+	}
+
+	tests := []struct {
+		name               string
+		setupMocks         func(*mocks.MockUserStorage, *mocks.MockPRStorage, *mocks.MockReassigner)
+		expectedReassigned int
+		expectedError      error
+	}{
+		{
+			name: "success - open reviews handed over, merged ones left",
+			setupMocks: func(userStorage *mocks.MockUserStorage, prStorage *mocks.MockPRStorage, reassigner *mocks.MockReassigner) {
+				userStorage.EXPECT().
+					GetStartedAbsences(ctx).
+					Return([]domain.Absence{{ID: 1, UserID: "u1", Reassign: true}}, nil).
+					Once()
+				prStorage.EXPECT().
+					GetPRsReviewedBy(ctx, "u1").
+					Return([]*domain.PullRequest{
+						reviewed("pr-1", "OPEN"),
+						reviewed("pr-2", "MERGED"),
+						reviewed("pr-3", "OPEN"),
+					}, nil).
+					Once()
+				reassigner.EXPECT().ReassignReviewer(ctx, "acme/api", "pr-1", "u1", false).Return(nil, "u2", nil).Once()
+				reassigner.EXPECT().
+					ReassignReviewer(ctx, "acme/api", "pr-3", "u1", false).
+					Return(nil, "", serviceErr.ErrPRMerged).
+					Once()
+				userStorage.EXPECT().MarkAbsenceReassigned(ctx, int64(1)).Return(nil).Once()
+			},
+			expectedReassigned: 1,
+		},
+		{
+			name: "error - a failing absence is retried later, the others are done",
+			setupMocks: func(userStorage *mocks.MockUserStorage, prStorage *mocks.MockPRStorage, reassigner *mocks.MockReassigner) {
+				userStorage.EXPECT().
+					GetStartedAbsences(ctx).
+					Return([]domain.Absence{{ID: 1, UserID: "u1"}, {ID: 2, UserID: "u2"}}, nil).
+					Once()
+				prStorage.EXPECT().
+					GetPRsReviewedBy(ctx, "u1").
+					Return([]*domain.PullRequest{reviewed("pr-1", "OPEN")}, nil).
+					Once()
+				reassigner.EXPECT().
+					ReassignReviewer(ctx, "acme/api", "pr-1", "u1", false).
+					Return(nil, "", errors.New("query error")).
+					Once()
+				prStorage.EXPECT().GetPRsReviewedBy(ctx, "u2").Return(nil, nil).Once()
+				userStorage.EXPECT().MarkAbsenceReassigned(ctx, int64(2)).Return(nil).Once()
+			},
+			expectedError: errors.New("service.absence.ReassignAbsentReviewers: query error"),
+		},
+		{
+			name: "error - get started absences fails",
+			setupMocks: func(userStorage *mocks.MockUserStorage, _ *mocks.MockPRStorage, _ *mocks.MockReassigner) {
+				userStorage.EXPECT().GetStartedAbsences(ctx).Return(nil, errors.New("query error")).Once()
+			},
+			expectedError: errors.New("service.absence.ReassignAbsentReviewers: query error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			userStorage := mocks.NewMockUserStorage(t)
+			prStorage := mocks.NewMockPRStorage(t)
+			reassigner := mocks.NewMockReassigner(t)
+			tt.setupMocks(userStorage, prStorage, reassigner)
+
+			service := New(log, userStorage, prStorage, reassigner)
+
+			// Act
+			reassigned, err := service.ReassignAbsentReviewers(ctx)
+
+			// Assert
+			if tt.expectedError != nil {
+				assert.EqualError(t, err, tt.expectedError.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.expectedReassigned, reassigned)
+		})
+	}
+}
@@ -0,0 +1,352 @@
+// Package calendar reads the events of an iCalendar (RFC 5545) file, as exported
+// by calendar applications for out-of-office periods.
+//
+// Only what an absence needs is read from each VEVENT: UID, SUMMARY, DTSTART and
+// DTEND or DURATION. Start and end times may be:
+//   - dates (VALUE=DATE), read as all-day in the given location; a missing end
+//     means one day;
+//   - UTC times ("20250102T090000Z");
+//   - local times with a TZID parameter naming an IANA time zone;
+//   - floating local times without TZID, read in the given location.
+//
+// Cancelled events are dropped. Recurring events, rather than imported as their
+// first occurrence only, and transparent events, which leave the time free, are
+// skipped and reported with the reason.
+package calendar
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+	// Time zones named by TZID must resolve on hosts without a zoneinfo database.
+	_ "time/tzdata"
+
+	"github.com/whitxowl/pr-reviewer-assignment-service.git/internal/domain"
+	serviceErr "github.com/whitxowl/pr-reviewer-assignment-service.git/internal/service/errors"
+)
+
+// Event is a VEVENT of the calendar, covering Start up to, but not including, End.
+type Event struct {
+	UID     string
+	Summary string
+	Start   time.Time
+	End     time.Time
+}
+
+// Parse returns the events of the calendar read from r, in file order, and the
+// events skipped. Dates and floating times are read in loc.
+func Parse(r io.Reader, loc *time.Location) ([]Event, []domain.SkippedEvent, error) {
+	lines, err := unfold(r)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: %v", serviceErr.ErrInvalidCalendar, err)
+	}
+
+	var events []Event
+	var skipped []domain.SkippedEvent
+	var current *vevent
+	// depth counts the components opened inside the current VEVENT, such as
+	// VALARM, whose properties do not belong to the event.
+	depth := 0
+	inCalendar := false
+
+	for _, line := range lines {
+		prop, err := parseLine(line.text)
+		if err != nil {
+			return nil, nil, fmt.Errorf("%w: line %d: %v", serviceErr.ErrInvalidCalendar, line.number, err)
+		}
+
+		switch {
+		case prop.name == "BEGIN" && strings.EqualFold(prop.value, "VCALENDAR") && current == nil:
+			inCalendar = true
+		case prop.name == "BEGIN" && strings.EqualFold(prop.value, "VEVENT") && current == nil:
+			if !inCalendar {
+				return nil, nil, fmt.Errorf("%w: line %d: VEVENT outside of VCALENDAR", serviceErr.ErrInvalidCalendar, line.number)
+			}
+			current = &vevent{line: line.number, loc: loc}
+		case prop.name == "BEGIN" && current != nil:
+			depth++
+		case prop.name == "END" && current != nil && depth > 0:
+			depth--
+		case prop.name == "END" && current != nil:
+			if !strings.EqualFold(prop.value, "VEVENT") {
+				return nil, nil, fmt.Errorf("%w: line %d: unexpected END:%s", serviceErr.ErrInvalidCalendar, line.number, prop.value)
+			}
+			event, skip, err := current.event()
+			switch {
+			case err != nil:
+				return nil, nil, fmt.Errorf("%w: event at line %d: %v", serviceErr.ErrInvalidCalendar, current.line, err)
+			case skip == skipCancelled:
+			case skip != "":
+				skipped = append(skipped, domain.SkippedEvent{UID: event.UID, Summary: event.Summary, Reason: skip})
+			default:
+				events = append(events, event)
+			}
+			current = nil
+		case current != nil && depth == 0:
+			current.props = append(current.props, prop)
+		}
+	}
+
+	if !inCalendar {
+		return nil, nil, fmt.Errorf("%w: no VCALENDAR found", serviceErr.ErrInvalidCalendar)
+	}
+	if current != nil {
+		return nil, nil, fmt.Errorf("%w: event at line %d is not closed", serviceErr.ErrInvalidCalendar, current.line)
+	}
+
+	return events, skipped, nil
+}
+
+type line struct {
+	number int
+	text   string
+}
+
+// unfold joins folded lines: a line starting with a space or a tab continues
+// the previous one.
+func unfold(r io.Reader) ([]line, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	var lines []line
+	number := 0
+	for scanner.Scan() {
+		number++
+		text := strings.TrimSuffix(scanner.Text(), "\r")
+
+		if (strings.HasPrefix(text, " ") || strings.HasPrefix(text, "\t")) && len(lines) > 0 {
+			lines[len(lines)-1].text += text[1:]
+			continue
+		}
+		if strings.TrimSpace(text) == "" {
+			continue
+		}
+		lines = append(lines, line{number: number, text: text})
+	}
+
+	return lines, scanner.Err()
+}
+
+type property struct {
+	name   string
+	params map[string]string
+	value  string
+}
+
+// parseLine splits a content line "NAME;PARAM=value:VALUE". Parameter values
+// may be quoted and then contain ":" and ";".
+func parseLine(text string) (property, error) {
+	var prop property
+
+	inQuotes := false
+	colon := -1
+	for i, r := range text {
+		if r == '"' {
+			inQuotes = !inQuotes
+		} else if r == ':' && !inQuotes {
+			colon = i
+			break
+		}
+	}
+	if colon < 0 {
+		return prop, fmt.Errorf("%q has no value", text)
+	}
+
+	head, value := text[:colon], text[colon+1:]
+	parts := splitUnquoted(head, ';')
+
+	prop.name = strings.ToUpper(parts[0])
+	prop.value = value
+	if prop.name == "" {
+		return prop, fmt.Errorf("%q has no property name", text)
+	}
+
+	for _, part := range parts[1:] {
+		name, paramValue, ok := strings.Cut(part, "=")
+		if !ok {
+			return prop, fmt.Errorf("parameter %q has no value", part)
+		}
+		if prop.params == nil {
+			prop.params = make(map[string]string)
+		}
+		prop.params[strings.ToUpper(name)] = strings.Trim(paramValue, `"`)
+	}
+
+	return prop, nil
+}
+
+func splitUnquoted(s string, sep rune) []string {
+	var parts []string
+	inQuotes := false
+	start := 0
+	for i, r := range s {
+		if r == '"' {
+			inQuotes = !inQuotes
+		} else if r == sep && !inQuotes {
+			parts = append(parts, s[start:i])
+			start = i + 1
+		}
+	}
+	return append(parts, s[start:])
+}
+
+type vevent struct {
+	line  int
+	loc   *time.Location
+	props []property
+}
+
+// skipCancelled is the skip reason of cancelled events, which are dropped
+// without being reported.
+const skipCancelled = "cancelled"
+
+// event reads the event; skip is the reason not to import it, if any. Events
+// skipped are only read up to their UID and SUMMARY.
+func (v *vevent) event() (event Event, skip string, err error) {
+	var start, end *property
+	var duration string
+	var cancelled, recurring, transparent bool
+
+	for i := range v.props {
+		prop := &v.props[i]
+		switch prop.name {
+		case "UID":
+			event.UID = prop.value
+		case "SUMMARY":
+			event.Summary = unescape(prop.value)
+		case "DTSTART":
+			start = prop
+		case "DTEND":
+			end = prop
+		case "DURATION":
+			duration = prop.value
+		case "STATUS":
+			cancelled = strings.EqualFold(prop.value, "CANCELLED")
+		case "RRULE", "RDATE":
+			recurring = true
+		case "TRANSP":
+			transparent = strings.EqualFold(prop.value, "TRANSPARENT")
+		}
+	}
+
+	switch {
+	case cancelled:
+		return event, skipCancelled, nil
+	case recurring:
+		return event, domain.SkipRecurring, nil
+	case transparent:
+		return event, domain.SkipTransparent, nil
+	}
+
+	if start == nil {
+		return event, "", fmt.Errorf("DTSTART is missing")
+	}
+
+	var allDay bool
+	event.Start, allDay, err = parseTime(start, v.loc)
+	if err != nil {
+		return event, "", fmt.Errorf("DTSTART: %w", err)
+	}
+
+	switch {
+	case end != nil:
+		event.End, _, err = parseTime(end, v.loc)
+		if err != nil {
+			return event, "", fmt.Errorf("DTEND: %w", err)
+		}
+	case duration != "":
+		d, err := parseDuration(duration)
+		if err != nil {
+			return event, "", fmt.Errorf("DURATION: %w", err)
+		}
+		event.End = event.Start.Add(d)
+	case allDay:
+		event.End = event.Start.AddDate(0, 0, 1)
+	default:
+		return event, "", fmt.Errorf("DTEND or DURATION is missing")
+	}
+
+	if !event.End.After(event.Start) {
+		return event, "", fmt.Errorf("ends at %s, before it starts", event.End.Format(time.RFC3339))
+	}
+
+	return event, "", nil
+}
+
+// parseTime reads a DATE or DATE-TIME value, in loc unless it is in UTC or has
+// a TZID; allDay is set for dates.
+func parseTime(prop *property, loc *time.Location) (t time.Time, allDay bool, err error) {
+	value := prop.value
+
+	if strings.EqualFold(prop.params["VALUE"], "DATE") || len(value) == len("20060102") {
+		t, err = time.ParseInLocation("20060102", value, loc)
+		return t, true, err
+	}
+
+	if strings.HasSuffix(value, "Z") {
+		t, err = time.Parse("20060102T150405Z", value)
+		return t, false, err
+	}
+
+	if tzid := prop.params["TZID"]; tzid != "" {
+		loc, err = time.LoadLocation(tzid)
+		if err != nil {
+			return t, false, fmt.Errorf("unknown time zone %q", tzid)
+		}
+	}
+
+	t, err = time.ParseInLocation("20060102T150405", value, loc)
+	return t, false, err
+}
+
+// parseDuration reads a positive duration such as "P1D", "PT4H30M" or "P2W".
+func parseDuration(value string) (time.Duration, error) {
+	s := strings.TrimPrefix(value, "+")
+	if !strings.HasPrefix(s, "P") || len(s) < 3 {
+		return 0, fmt.Errorf("%q is not a positive duration", value)
+	}
+	s = s[1:]
+
+	units := map[byte]time.Duration{'W': 7 * 24 * time.Hour, 'D': 24 * time.Hour}
+	timeUnits := map[byte]time.Duration{'H': time.Hour, 'M': time.Minute, 'S': time.Second}
+
+	var d time.Duration
+	digits := ""
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c >= '0' && c <= '9':
+			digits += string(c)
+		case c == 'T':
+			if digits != "" {
+				return 0, fmt.Errorf("%q is not a valid duration", value)
+			}
+			units = timeUnits
+		default:
+			unit, ok := units[c]
+			if !ok || digits == "" {
+				return 0, fmt.Errorf("%q is not a valid duration", value)
+			}
+			n, err := strconv.Atoi(digits)
+			if err != nil {
+				return 0, fmt.Errorf("%q is not a valid duration", value)
+			}
+			d += time.Duration(n) * unit
+			digits = ""
+		}
+	}
+	if digits != "" {
+		return 0, fmt.Errorf("%q is not a valid duration", value)
+	}
+
+	return d, nil
+}
+
+var unescaper = strings.NewReplacer(`\n`, "\n", `\N`, "\n", `\,`, ",", `\;`, ";", `\\`, `\`)
+
+func unescape(value string) string {
+	return unescaper.Replace(value)
+}
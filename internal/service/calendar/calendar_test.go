@@ -0,0 +1,197 @@
+package calendar
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/whitxowl/pr-reviewer-assignment-service.git/internal/domain"
+	serviceErr "github.com/whitxowl/pr-reviewer-assignment-service.git/internal/service/errors"
+)
+
+func calendar(events ...string) string {
+	return "BEGIN:VCALENDAR\r\nVERSION:2.0\r\nPRODID:-//Test//EN\r\n" +
+		strings.Join(events, "") +
+		"END:VCALENDAR\r\n"
+}
+
+func TestParse(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		ics      string
+		expected []Event
+		skipped  []domain.SkippedEvent
+	}{
+		{
+			name: "UTC times",
+			ics: calendar("BEGIN:VEVENT\r\nUID:evt-1\r\nSUMMARY:Vacation\r\n" +
+				"DTSTART:20250714T080000Z\r\nDTEND:20250718T170000Z\r\nEND:VEVENT\r\n"),
+			expected: []Event{{
+				UID:     "evt-1",
+				Summary: "Vacation",
+				Start:   time.Date(2025, 7, 14, 8, 0, 0, 0, time.UTC),
+				End:     time.Date(2025, 7, 18, 17, 0, 0, 0, time.UTC),
+			}},
+		},
+		{
+			name: "all-day events end at the start of DTEND or after one day",
+			ics: calendar(
+				"BEGIN:VEVENT\r\nUID:evt-1\r\nDTSTART;VALUE=DATE:20250714\r\nDTEND;VALUE=DATE:20250719\r\nEND:VEVENT\r\n",
+				"BEGIN:VEVENT\r\nUID:evt-2\r\nDTSTART;VALUE=DATE:20250801\r\nEND:VEVENT\r\n",
+			),
+			expected: []Event{
+				{
+					UID:   "evt-1",
+					Start: time.Date(2025, 7, 14, 0, 0, 0, 0, time.UTC),
+					End:   time.Date(2025, 7, 19, 0, 0, 0, 0, time.UTC),
+				},
+				{
+					UID:   "evt-2",
+					Start: time.Date(2025, 8, 1, 0, 0, 0, 0, time.UTC),
+					End:   time.Date(2025, 8, 2, 0, 0, 0, 0, time.UTC),
+				},
+			},
+		},
+		{
+			name: "time zone and duration",
+			ics: calendar("BEGIN:VEVENT\r\nUID:evt-1\r\nDTSTART;TZID=\"Europe/Berlin\":20250714T090000\r\n" +
+				"DURATION:P1DT4H30M\r\nEND:VEVENT\r\n"),
+			expected: []Event{{
+				UID:   "evt-1",
+				Start: time.Date(2025, 7, 14, 9, 0, 0, 0, berlin),
+				End:   time.Date(2025, 7, 15, 13, 30, 0, 0, berlin),
+			}},
+		},
+		{
+			name: "folded and escaped summary, nested alarm, LF line endings",
+			ics: "BEGIN:VCALENDAR\nBEGIN:VEVENT\nUID:evt-1\nSUMMARY:Out of office\\, back\n  on Monday\n" +
+				"DTSTART:20250714T080000\nDTEND:20250714T120000\n" +
+				"BEGIN:VALARM\nTRIGGER:-PT15M\nDESCRIPTION:Reminder\nEND:VALARM\nEND:VEVENT\nEND:VCALENDAR\n",
+			expected: []Event{{
+				UID:     "evt-1",
+				Summary: "Out of office, back on Monday",
+				Start:   time.Date(2025, 7, 14, 8, 0, 0, 0, time.UTC),
+				End:     time.Date(2025, 7, 14, 12, 0, 0, 0, time.UTC),
+			}},
+		},
+		{
+			name: "cancelled events are dropped",
+			ics: calendar("BEGIN:VEVENT\r\nUID:evt-1\r\nSTATUS:CANCELLED\r\n" +
+				"DTSTART:20250714T080000Z\r\nDTEND:20250718T170000Z\r\nEND:VEVENT\r\n"),
+		},
+		{
+			name: "recurring and transparent events are skipped",
+			ics: calendar(
+				"BEGIN:VEVENT\r\nUID:evt-1\r\nSUMMARY:Standup\r\nDTSTART:20250714T080000Z\r\nDURATION:PT15M\r\n"+
+					"RRULE:FREQ=DAILY\r\nEND:VEVENT\r\n",
+				"BEGIN:VEVENT\r\nUID:evt-2\r\nSUMMARY:Vacation\r\n"+
+					"DTSTART:20250714T080000Z\r\nDTEND:20250718T170000Z\r\nEND:VEVENT\r\n",
+				"BEGIN:VEVENT\r\nUID:evt-3\r\nDTSTART;VALUE=DATE:20250801\r\nRDATE;VALUE=DATE:20250901\r\nEND:VEVENT\r\n",
+				"BEGIN:VEVENT\r\nUID:evt-4\r\nSUMMARY:Conference (tentative)\r\nTRANSP:TRANSPARENT\r\n"+
+					"DTSTART;VALUE=DATE:20250901\r\nEND:VEVENT\r\n",
+			),
+			expected: []Event{{
+				UID:     "evt-2",
+				Summary: "Vacation",
+				Start:   time.Date(2025, 7, 14, 8, 0, 0, 0, time.UTC),
+				End:     time.Date(2025, 7, 18, 17, 0, 0, 0, time.UTC),
+			}},
+			skipped: []domain.SkippedEvent{
+				{UID: "evt-1", Summary: "Standup", Reason: domain.SkipRecurring},
+				{UID: "evt-3", Reason: domain.SkipRecurring},
+				{UID: "evt-4", Summary: "Conference (tentative)", Reason: domain.SkipTransparent},
+			},
+		},
+		{
+			name: "calendar without events",
+			ics:  calendar(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			events, skipped, err := Parse(strings.NewReader(tt.ics), time.UTC)
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.skipped, skipped)
+			require.Len(t, events, len(tt.expected))
+			for i, expected := range tt.expected {
+				assert.Equal(t, expected.UID, events[i].UID)
+				assert.Equal(t, expected.Summary, events[i].Summary)
+				assert.True(t, expected.Start.Equal(events[i].Start), "start %s", events[i].Start)
+				assert.True(t, expected.End.Equal(events[i].End), "end %s", events[i].End)
+			}
+		})
+	}
+}
+
+func TestParse_Location(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	require.NoError(t, err)
+
+	ics := calendar(
+		"BEGIN:VEVENT\r\nUID:evt-1\r\nDTSTART;VALUE=DATE:20250714\r\nDTEND;VALUE=DATE:20250719\r\nEND:VEVENT\r\n",
+		"BEGIN:VEVENT\r\nUID:evt-2\r\nDTSTART;VALUE=DATE:20251026\r\nEND:VEVENT\r\n",
+		"BEGIN:VEVENT\r\nUID:evt-3\r\nDTSTART:20250714T090000\r\nDTEND:20250714T120000Z\r\nEND:VEVENT\r\n",
+	)
+
+	events, _, err := Parse(strings.NewReader(ics), berlin)
+
+	require.NoError(t, err)
+	require.Len(t, events, 3)
+	assert.True(t, events[0].Start.Equal(time.Date(2025, 7, 13, 22, 0, 0, 0, time.UTC)), "start %s", events[0].Start)
+	assert.True(t, events[0].End.Equal(time.Date(2025, 7, 18, 22, 0, 0, 0, time.UTC)), "end %s", events[0].End)
+	// The day clocks go back lasts 25 hours.
+	assert.Equal(t, 25*time.Hour, events[1].End.Sub(events[1].Start))
+	assert.True(t, events[2].Start.Equal(time.Date(2025, 7, 14, 7, 0, 0, 0, time.UTC)), "start %s", events[2].Start)
+	assert.True(t, events[2].End.Equal(time.Date(2025, 7, 14, 12, 0, 0, 0, time.UTC)), "end %s", events[2].End)
+}
+
+func TestParse_Invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		ics  string
+	}{
+		{name: "not a calendar", ics: "hello world\n"},
+		{name: "empty file", ics: ""},
+		{
+			name: "missing start",
+			ics:  calendar("BEGIN:VEVENT\r\nUID:evt-1\r\nDTEND:20250718T170000Z\r\nEND:VEVENT\r\n"),
+		},
+		{
+			name: "missing end",
+			ics:  calendar("BEGIN:VEVENT\r\nUID:evt-1\r\nDTSTART:20250718T170000Z\r\nEND:VEVENT\r\n"),
+		},
+		{
+			name: "ends before it starts",
+			ics: calendar("BEGIN:VEVENT\r\nUID:evt-1\r\nDTSTART:20250718T170000Z\r\n" +
+				"DTEND:20250714T080000Z\r\nEND:VEVENT\r\n"),
+		},
+		{
+			name: "unknown time zone",
+			ics: calendar("BEGIN:VEVENT\r\nUID:evt-1\r\nDTSTART;TZID=W. Europe Standard Time:20250714T090000\r\n" +
+				"DURATION:PT1H\r\nEND:VEVENT\r\n"),
+		},
+		{
+			name: "invalid duration",
+			ics:  calendar("BEGIN:VEVENT\r\nUID:evt-1\r\nDTSTART:20250714T080000Z\r\nDURATION:1H\r\nEND:VEVENT\r\n"),
+		},
+		{
+			name: "unclosed event",
+			ics:  "BEGIN:VCALENDAR\r\nBEGIN:VEVENT\r\nUID:evt-1\r\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := Parse(strings.NewReader(tt.ics), time.UTC)
+
+			assert.ErrorIs(t, err, serviceErr.ErrInvalidCalendar)
+		})
+	}
+}
@@ -0,0 +1,113 @@
+// Package codeowners matches changed files against CODEOWNERS-style rules.
+//
+// Patterns follow the CODEOWNERS subset of gitignore syntax:
+//   - "*.go" without a slash matches at any depth;
+//   - a leading "/" or a slash inside the pattern anchors it to the repository root;
+//   - a trailing "/" matches everything below the directory;
+//   - "**" matches any number of directories, "*" and "?" stay within one;
+//   - "docs/*" matches files directly in docs, not nested ones.
+package codeowners
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/whitxowl/pr-reviewer-assignment-service.git/internal/domain"
+	serviceErr "github.com/whitxowl/pr-reviewer-assignment-service.git/internal/service/errors"
+)
+
+// Validate reports a pattern that can never be matched.
+func Validate(pattern string) error {
+	segments := compile(pattern)
+	if len(segments) == 0 {
+		return fmt.Errorf("%w: %q is empty", serviceErr.ErrInvalidPattern, pattern)
+	}
+	for _, segment := range segments {
+		if _, err := path.Match(segment, ""); err != nil {
+			return fmt.Errorf("%w: %q: %v", serviceErr.ErrInvalidPattern, pattern, err)
+		}
+	}
+	return nil
+}
+
+// Match reports whether the file at filePath, relative to the repository root, matches pattern.
+func Match(pattern string, filePath string) bool {
+	segments := compile(pattern)
+	if len(segments) == 0 {
+		return false
+	}
+	return matchSegments(segments, strings.Split(strings.Trim(filePath, "/"), "/"))
+}
+
+// MatchedRules returns the rules that own at least one of files, in rule order.
+// As in CODEOWNERS, a file is owned by the last rule matching it only.
+func MatchedRules(rules []domain.CodeOwnerRule, files []string) []domain.CodeOwnerRule {
+	matched := make([]bool, len(rules))
+	for _, file := range files {
+		for i := len(rules) - 1; i >= 0; i-- {
+			if Match(rules[i].Pattern, file) {
+				matched[i] = true
+				break
+			}
+		}
+	}
+
+	var result []domain.CodeOwnerRule
+	for i, rule := range rules {
+		if matched[i] {
+			result = append(result, rule)
+		}
+	}
+	return result
+}
+
+func compile(pattern string) []string {
+	dir := strings.HasSuffix(pattern, "/")
+	anchored := strings.Contains(strings.TrimSuffix(pattern, "/"), "/")
+
+	trimmed := strings.Trim(pattern, "/")
+	if trimmed == "" {
+		return nil
+	}
+
+	segments := strings.Split(trimmed, "/")
+	if !anchored {
+		segments = append([]string{"**"}, segments...)
+	}
+
+	switch {
+	case dir:
+		// Everything below the directory, but not a file with its name.
+		segments = append(segments, "*", "**")
+	case segments[len(segments)-1] != "*":
+		// A matched directory owns its whole subtree.
+		segments = append(segments, "**")
+	}
+
+	return segments
+}
+
+func matchSegments(pattern []string, name []string) bool {
+	for len(pattern) > 0 {
+		if pattern[0] == "**" {
+			for i := 0; i <= len(name); i++ {
+				if matchSegments(pattern[1:], name[i:]) {
+					return true
+				}
+			}
+			return false
+		}
+
+		if len(name) == 0 {
+			return false
+		}
+		if ok, _ := path.Match(pattern[0], name[0]); !ok {
+			return false
+		}
+
+		pattern, name = pattern[1:], name[1:]
+	}
+
+	return len(name) == 0
+}
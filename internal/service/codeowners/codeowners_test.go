@@ -0,0 +1,68 @@
+package codeowners
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/whitxowl/pr-reviewer-assignment-service.git/internal/domain"
+	serviceErr "github.com/whitxowl/pr-reviewer-assignment-service.git/internal/service/errors"
+)
+
+func TestMatch(t *testing.T) {
+	tests := []struct {
+		pattern  string
+		path     string
+		expected bool
+	}{
+		{pattern: "*", path: "README.md", expected: true},
+		{pattern: "*", path: "internal/api/handler.go", expected: true},
+		{pattern: "*.go", path: "main.go", expected: true},
+		{pattern: "*.go", path: "internal/api/handler.go", expected: true},
+		{pattern: "*.go", path: "openapi.yml", expected: false},
+		{pattern: "/build/logs/", path: "build/logs/today.log", expected: true},
+		{pattern: "/build/logs/", path: "build/logs/2025/today.log", expected: true},
+		{pattern: "/build/logs/", path: "src/build/logs/today.log", expected: false},
+		{pattern: "/build/logs/", path: "build/logs", expected: false},
+		{pattern: "docs/*", path: "docs/getting-started.md", expected: true},
+		{pattern: "docs/*", path: "docs/build-app/troubleshooting.md", expected: false},
+		{pattern: "apps/", path: "apps/web/index.ts", expected: true},
+		{pattern: "apps/", path: "src/apps/web/index.ts", expected: true},
+		{pattern: "internal/api", path: "internal/api/v1/pr/pr.go", expected: true},
+		{pattern: "internal/api", path: "cmd/internal/api/main.go", expected: false},
+		{pattern: "/migrations/**/*.sql", path: "migrations/5_repositories.sql", expected: true},
+		{pattern: "/migrations/**/*.sql", path: "migrations/sqlite/5_repositories.sql", expected: true},
+		{pattern: "/migrations/**/*.sql", path: "migrations/migrations.go", expected: false},
+		{pattern: "**/storage", path: "internal/storage/pr/pr_storage.go", expected: true},
+		{pattern: "?.md", path: "a.md", expected: true},
+		{pattern: "?.md", path: "ab.md", expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.pattern+" "+tt.path, func(t *testing.T) {
+			assert.Equal(t, tt.expected, Match(tt.pattern, tt.path))
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	assert.NoError(t, Validate("/internal/**/*.go"))
+	assert.ErrorIs(t, Validate("/"), serviceErr.ErrInvalidPattern)
+	assert.ErrorIs(t, Validate("[a-"), serviceErr.ErrInvalidPattern)
+}
+
+func TestMatchedRules(t *testing.T) {
+	rules := []domain.CodeOwnerRule{
+		{Pattern: "*", TeamNames: []string{"backend"}},
+		{Pattern: "*.sql", UserIDs: []string{"u1"}},
+		{Pattern: "/frontend/", TeamNames: []string{"frontend"}},
+		{Pattern: "/docs/", UserIDs: []string{"u2"}},
+	}
+
+	matched := MatchedRules(rules, []string{"migrations/1_init.sql", "frontend/app.ts"})
+	assert.Equal(t, []domain.CodeOwnerRule{rules[1], rules[2]}, matched, "the last matching rule owns a file")
+
+	matched = MatchedRules(rules, []string{"main.go"})
+	assert.Equal(t, []domain.CodeOwnerRule{rules[0]}, matched)
+
+	assert.Empty(t, MatchedRules(rules, nil))
+}
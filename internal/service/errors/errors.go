@@ -5,13 +5,36 @@ import "errors"
 var (
 	ErrTeamExists   = errors.New("team already exists")
 	ErrTeamNotFound = errors.New("team not found")
+	ErrTeamNotEmpty = errors.New("team has members")
 
-	ErrUserNotFound = errors.New("user not found")
+	ErrUserNotFound  = errors.New("user not found")
+	ErrDuplicateUser = errors.New("user is listed more than once")
+	ErrInvalidUserID = errors.New("user_id does not match the configured format")
 
-	ErrPRExists   = errors.New("pull request already exists")
-	ErrPRNotFound = errors.New("pull request not found")
-	ErrPRMerged   = errors.New("pull request is already merged")
+	ErrInvalidTimezone     = errors.New("unknown time zone")
+	ErrInvalidWorkingHours = errors.New("invalid working hours")
+	ErrInvalidTag          = errors.New("invalid skill tag")
+	ErrInvalidLevel        = errors.New("unknown seniority level")
+
+	ErrInvalidSeniorityRule = errors.New("invalid seniority rule")
+
+	ErrAbsenceNotFound = errors.New("absence not found")
+	ErrInvalidAbsence  = errors.New("absence must end after it starts")
+	ErrInvalidCalendar = errors.New("invalid iCalendar file")
+
+	ErrPRExists    = errors.New("pull request already exists")
+	ErrPRNotFound  = errors.New("pull request not found")
+	ErrPRMerged    = errors.New("pull request is already merged")
+	ErrInvalidPRID = errors.New("pull_request_id does not match the configured format")
 
 	ErrAuthorNotCorrect = errors.New("author is not found or has no team")
+	ErrAuthorNotInTeam  = errors.New("author is not a member of the team")
 	ErrReviewerNotFound = errors.New("reviewer not found")
+	ErrReviewerIsAuthor = errors.New("reviewer is the author of the pull request")
+	ErrReviewerInactive = errors.New("reviewer is not active")
+	ErrReviewerAssigned = errors.New("reviewer is already assigned")
+
+	ErrRepositoryExists   = errors.New("repository already exists")
+	ErrRepositoryNotFound = errors.New("repository not found")
+	ErrInvalidPattern     = errors.New("invalid code owner pattern")
 )
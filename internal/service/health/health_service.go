@@ -0,0 +1,123 @@
+package health
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/whitxowl/pr-reviewer-assignment-service.git/internal/domain"
+)
+
+type Storage interface {
+	Ping(ctx context.Context) error
+	MigrationVersion(ctx context.Context) (int64, error)
+}
+
+type Worker interface {
+	Status() domain.WorkerStatus
+}
+
+const (
+	checkDatabase   = "database"
+	checkMigrations = "migrations"
+)
+
+type Service struct {
+	log             *slog.Logger
+	storage         Storage
+	expectedVersion int64
+	timeout         time.Duration
+
+	mu           sync.RWMutex
+	workers      []Worker
+	shuttingDown atomic.Bool
+}
+
+func New(log *slog.Logger, storage Storage, expectedVersion int64, timeout time.Duration) *Service {
+	return &Service{
+		log:             log,
+		storage:         storage,
+		expectedVersion: expectedVersion,
+		timeout:         timeout,
+	}
+}
+
+// RegisterWorker adds a background worker whose status is reported by Ready.
+func (s *Service) RegisterWorker(worker Worker) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.workers = append(s.workers, worker)
+}
+
+// SetShuttingDown makes every following readiness check fail so that
+// load balancers stop routing traffic before the server is stopped.
+func (s *Service) SetShuttingDown() {
+	s.shuttingDown.Store(true)
+}
+
+func (s *Service) Ready(ctx context.Context) *domain.Readiness {
+	const op = "service.health.Ready"
+
+	log := s.log.With(slog.String("op", op))
+
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	readiness := &domain.Readiness{
+		Ready:        true,
+		ShuttingDown: s.shuttingDown.Load(),
+	}
+	if readiness.ShuttingDown {
+		readiness.Ready = false
+	}
+
+	dbCheck := domain.HealthCheck{Name: checkDatabase, OK: true}
+	if err := s.storage.Ping(ctx); err != nil {
+		log.WarnContext(ctx, "database ping failed", "error", err)
+		dbCheck.OK = false
+		dbCheck.Error = err.Error()
+	}
+	readiness.Checks = append(readiness.Checks, dbCheck)
+
+	migrationsCheck := domain.HealthCheck{Name: checkMigrations, OK: true}
+	if !dbCheck.OK {
+		migrationsCheck.OK = false
+		migrationsCheck.Error = "database is unavailable"
+	} else {
+		version, err := s.storage.MigrationVersion(ctx)
+		if err != nil {
+			log.WarnContext(ctx, "error getting migration version", "error", err)
+			migrationsCheck.OK = false
+			migrationsCheck.Error = err.Error()
+		} else if version != s.expectedVersion {
+			migrationsCheck.OK = false
+			migrationsCheck.Error = fmt.Sprintf("migration version %d, expected %d", version, s.expectedVersion)
+		}
+	}
+	readiness.Checks = append(readiness.Checks, migrationsCheck)
+
+	for _, check := range readiness.Checks {
+		if !check.OK {
+			readiness.Ready = false
+		}
+	}
+
+	s.mu.RLock()
+	workers := make([]Worker, len(s.workers))
+	copy(workers, s.workers)
+	s.mu.RUnlock()
+
+	for _, worker := range workers {
+		status := worker.Status()
+		if !status.Running {
+			readiness.Ready = false
+		}
+		readiness.Workers = append(readiness.Workers, status)
+	}
+
+	return readiness
+}
@@ -0,0 +1,118 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/whitxowl/pr-reviewer-assignment-service.git/internal/domain"
+	"github.com/whitxowl/pr-reviewer-assignment-service.git/internal/service/health/mocks"
+)
+
+func TestService_Ready(t *testing.T) {
+	ctx := context.Background()
+	log := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
+
+	tests := []struct {
+		name           string
+		shuttingDown   bool
+		setupMocks     func(*mocks.MockStorage, *mocks.MockWorker)
+		expectedReady  bool
+		expectedChecks []domain.HealthCheck
+	}{
+		{
+			name: "success - database reachable and migrated",
+			setupMocks: func(storage *mocks.MockStorage, worker *mocks.MockWorker) {
+				storage.EXPECT().Ping(mock.Anything).Return(nil).Once()
+				storage.EXPECT().MigrationVersion(mock.Anything).Return(int64(1), nil).Once()
+				worker.EXPECT().Status().Return(domain.WorkerStatus{Name: "worker", Running: true}).Once()
+			},
+			expectedReady: true,
+			expectedChecks: []domain.HealthCheck{
+				{Name: checkDatabase, OK: true},
+				{Name: checkMigrations, OK: true},
+			},
+		},
+		{
+			name: "error - database unreachable",
+			setupMocks: func(storage *mocks.MockStorage, worker *mocks.MockWorker) {
+				storage.EXPECT().Ping(mock.Anything).Return(errors.New("connection refused")).Once()
+				worker.EXPECT().Status().Return(domain.WorkerStatus{Name: "worker", Running: true}).Once()
+			},
+			expectedReady: false,
+			expectedChecks: []domain.HealthCheck{
+				{Name: checkDatabase, OK: false, Error: "connection refused"},
+				{Name: checkMigrations, OK: false, Error: "database is unavailable"},
+			},
+		},
+		{
+			name: "error - migrations behind",
+			setupMocks: func(storage *mocks.MockStorage, worker *mocks.MockWorker) {
+				storage.EXPECT().Ping(mock.Anything).Return(nil).Once()
+				storage.EXPECT().MigrationVersion(mock.Anything).Return(int64(0), nil).Once()
+				worker.EXPECT().Status().Return(domain.WorkerStatus{Name: "worker", Running: true}).Once()
+			},
+			expectedReady: false,
+			expectedChecks: []domain.HealthCheck{
+				{Name: checkDatabase, OK: true},
+				{Name: checkMigrations, OK: false, Error: "migration version 0, expected 1"},
+			},
+		},
+		{
+			name: "error - worker stopped",
+			setupMocks: func(storage *mocks.MockStorage, worker *mocks.MockWorker) {
+				storage.EXPECT().Ping(mock.Anything).Return(nil).Once()
+				storage.EXPECT().MigrationVersion(mock.Anything).Return(int64(1), nil).Once()
+				worker.EXPECT().Status().Return(domain.WorkerStatus{Name: "worker", Running: false}).Once()
+			},
+			expectedReady: false,
+			expectedChecks: []domain.HealthCheck{
+				{Name: checkDatabase, OK: true},
+				{Name: checkMigrations, OK: true},
+			},
+		},
+		{
+			name:         "error - shutting down",
+			shuttingDown: true,
+			setupMocks: func(storage *mocks.MockStorage, worker *mocks.MockWorker) {
+				storage.EXPECT().Ping(mock.Anything).Return(nil).Once()
+				storage.EXPECT().MigrationVersion(mock.Anything).Return(int64(1), nil).Once()
+				worker.EXPECT().Status().Return(domain.WorkerStatus{Name: "worker", Running: true}).Once()
+			},
+			expectedReady: false,
+			expectedChecks: []domain.HealthCheck{
+				{Name: checkDatabase, OK: true},
+				{Name: checkMigrations, OK: true},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			storage := mocks.NewMockStorage(t)
+			worker := mocks.NewMockWorker(t)
+			tt.setupMocks(storage, worker)
+
+			service := New(log, storage, 1, time.Second)
+			service.RegisterWorker(worker)
+			if tt.shuttingDown {
+				service.SetShuttingDown()
+			}
+
+			// Act
+			result := service.Ready(ctx)
+
+			// Assert
+			assert.Equal(t, tt.expectedReady, result.Ready)
+			assert.Equal(t, tt.shuttingDown, result.ShuttingDown)
+			assert.Equal(t, tt.expectedChecks, result.Checks)
+			assert.Len(t, result.Workers, 1)
+		})
+	}
+}
@@ -0,0 +1,404 @@
+package pr
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"time"
+
+	"github.com/whitxowl/pr-reviewer-assignment-service.git/internal/domain"
+	serviceErr "github.com/whitxowl/pr-reviewer-assignment-service.git/internal/service/errors"
+	"github.com/whitxowl/pr-reviewer-assignment-service.git/internal/service/validation"
+	storageErr "github.com/whitxowl/pr-reviewer-assignment-service.git/internal/storage/errors"
+)
+
+// ImportPRs creates the open PRs of a bulk import and returns the outcome of
+// each in order. A PR keeps the reviewers given with it, which are checked as in
+// AddReviewer, or gets reviewers assigned as in CreatePR; the reviews planned for
+// the PRs before it count towards the capacity and pairings of their reviewers.
+// What the planning reads is read once for the whole import.
+//
+// Every PR is checked before any is written, and the PRs that pass are written
+// together. With atomic nothing is written unless every PR passes. Otherwise a
+// write that fails, as when a PR was created meanwhile, is retried PR by PR so
+// that the others are still imported; each PR is then planned again, counting
+// only the PRs written before it.
+func (s *Service) ImportPRs(ctx context.Context, drafts []domain.ImportDraft, atomic bool) ([]domain.ImportResult, error) {
+	const op = "service.pr.ImportPRs"
+
+	log := s.log.With(
+		slog.String("op", op),
+		slog.Int("prs", len(drafts)),
+		slog.Bool("atomic", atomic),
+	)
+
+	results := make([]domain.ImportResult, len(drafts))
+	seen := make(map[domain.PRRef]bool, len(drafts))
+	r := newImportReads(s.reads)
+	if s.opts.PairingWindow > 0 {
+		var authorIDs []string
+		for _, draft := range drafts {
+			if !slices.Contains(authorIDs, draft.Draft.AuthorID) {
+				authorIDs = append(authorIDs, draft.Draft.AuthorID)
+			}
+		}
+		if err := r.loadPairings(ctx, authorIDs, s.opts.PairingWindow); err != nil {
+			log.ErrorContext(ctx, "error getting pairings", "error", err)
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+	var imports []domain.PRImport
+	var indexes []int
+	failed := 0
+	for i, draft := range drafts {
+		imp, err := s.planImport(ctx, log, r, draft, seen)
+		if err != nil {
+			results[i].Err = err
+			failed++
+			continue
+		}
+		r.plan(&imp.PR)
+
+		imports = append(imports, *imp)
+		indexes = append(indexes, i)
+	}
+
+	if atomic && failed > 0 {
+		log.InfoContext(ctx, "import abandoned", "failed", failed)
+		return results, nil
+	}
+
+	err := s.prStorage.ImportPRs(ctx, imports)
+	switch {
+	case err == nil:
+		for j, imp := range imports {
+			results[indexes[j]].PR = s.imported(ctx, log, imp)
+		}
+	case atomic:
+		// Another request wrote meanwhile what the checks saw missing.
+		if serr := importErr(err); serr != nil {
+			log.DebugContext(ctx, "import failed", "error", err)
+			return nil, serr
+		}
+		log.ErrorContext(ctx, "error importing prs", "error", err)
+		return nil, fmt.Errorf("%s: %w", op, err)
+	default:
+		log.InfoContext(ctx, "import failed, importing prs one by one", "error", err)
+
+		r.unplan()
+		seen = make(map[domain.PRRef]bool, len(indexes))
+		for _, i := range indexes {
+			results[i].PR, results[i].Err = s.importOne(ctx, log, r, drafts[i], seen)
+			if results[i].Err != nil {
+				failed++
+			}
+		}
+	}
+
+	log.InfoContext(ctx, "prs imported", "imported", len(drafts)-failed, "failed", failed)
+
+	return results, nil
+}
+
+// planImport checks the draft and returns the PR it imports with its reviewers.
+// seen holds the PRs planned so far.
+func (s *Service) planImport(
+	ctx context.Context,
+	log *slog.Logger,
+	r *importReads,
+	imp domain.ImportDraft,
+	seen map[domain.PRRef]bool,
+) (*domain.PRImport, error) {
+	const op = "service.pr.planImport"
+
+	draft := imp.Draft
+	log = log.With(
+		slog.String("repository", draft.Repository),
+		slog.String("prID", draft.PullRequestID),
+	)
+
+	if err := s.ids.PRID(draft.PullRequestID); err != nil {
+		log.DebugContext(ctx, "invalid pr id", "error", err)
+		return nil, err
+	}
+
+	ref := domain.PRRef{Repository: draft.Repository, PullRequestID: draft.PullRequestID}
+	if seen[ref] {
+		log.DebugContext(ctx, "pr listed more than once")
+		return nil, serviceErr.ErrPRExists
+	}
+	seen[ref] = true
+
+	_, err := s.prStorage.GetPR(ctx, draft.Repository, draft.PullRequestID)
+	if err == nil {
+		log.DebugContext(ctx, "pr already exists")
+		return nil, serviceErr.ErrPRExists
+	}
+	if !errors.Is(err, storageErr.ErrPRNotFound) {
+		log.ErrorContext(ctx, "error getting pr", "error", err)
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	requiredTags, err := validation.Tags(draft.RequiredTags)
+	if err != nil {
+		log.DebugContext(ctx, "invalid required tag", "error", err)
+		return nil, err
+	}
+
+	repo, teamName, author, err := s.resolveTeam(ctx, log, op, r, draft)
+	if err != nil {
+		return nil, err
+	}
+
+	pr := domain.PullRequest{
+		Repository:      draft.Repository,
+		PullRequestID:   draft.PullRequestID,
+		PullRequestName: draft.PullRequestName,
+		AuthorID:        draft.AuthorID,
+		TeamName:        teamName,
+		Status:          statusOpen,
+	}
+
+	if imp.Reviewers != nil {
+		for _, reviewerID := range imp.Reviewers {
+			if err = s.checkNamedReviewer(ctx, log, r, &pr, reviewerID); err != nil {
+				return nil, err
+			}
+			pr.AssignedReviewers = append(pr.AssignedReviewers, reviewerID)
+			pr.Rationales = append(pr.Rationales, manualRationale(reviewerID))
+		}
+
+		return &domain.PRImport{PR: pr, RequiredTags: requiredTags}, nil
+	}
+
+	selected, err := s.selectReviewers(ctx, r, repo, draft.PullRequestID, teamName, author, draft.ChangedFiles, requiredTags)
+	if err != nil {
+		log.ErrorContext(ctx, "error selecting reviewers", "error", err)
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	for _, rationale := range selected.rationales {
+		pr.AssignedReviewers = append(pr.AssignedReviewers, rationale.ReviewerID)
+	}
+	pr.Rationales = selected.rationales
+	pr.SeniorityViolations = selected.violations
+
+	return &domain.PRImport{PR: pr, RequiredTags: requiredTags, Queued: selected.queued}, nil
+}
+
+// importOne plans and writes a PR alone, for an import whose PRs could not be
+// written together. Its reviewers count in r once it is written.
+func (s *Service) importOne(
+	ctx context.Context,
+	log *slog.Logger,
+	r *importReads,
+	draft domain.ImportDraft,
+	seen map[domain.PRRef]bool,
+) (*domain.PullRequest, error) {
+	const op = "service.pr.importOne"
+
+	imp, err := s.planImport(ctx, log, r, draft, seen)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.prStorage.ImportPRs(ctx, []domain.PRImport{*imp})
+	if err != nil {
+		if serr := importErr(err); serr != nil {
+			return nil, serr
+		}
+		log.ErrorContext(ctx, "error importing pr", "prID", imp.PR.PullRequestID, "error", err)
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	r.plan(&imp.PR)
+
+	return s.imported(ctx, log, *imp), nil
+}
+
+// imported audits the reviewers of an imported PR and returns it.
+func (s *Service) imported(ctx context.Context, log *slog.Logger, imp domain.PRImport) *domain.PullRequest {
+	log = log.With(
+		slog.String("repository", imp.PR.Repository),
+		slog.String("prID", imp.PR.PullRequestID),
+	)
+	for _, rationale := range imp.PR.Rationales {
+		audit(ctx, log, rationale)
+	}
+	if imp.Queued > 0 {
+		log.InfoContext(ctx, "reviewer slots queued for later assignment", "slots", imp.Queued)
+	}
+
+	pr := imp.PR
+	return &pr
+}
+
+// importErr returns the service error for an error of PRStorage.ImportPRs caused
+// by a concurrent write, nil for any other.
+func importErr(err error) error {
+	switch {
+	case errors.Is(err, storageErr.ErrPRExists):
+		return serviceErr.ErrPRExists
+	case errors.Is(err, storageErr.ErrUserNotFound):
+		return serviceErr.ErrUserNotFound
+	default:
+		return nil
+	}
+}
+
+// importReads serves the reads of planning a bulk import. Each user, repository,
+// pool and rule set is read once for the whole import and the pairings of its
+// authors at once before planning. The reviewers planned so far count as open
+// reviews of theirs and as pairings with the author of their PR, so that the
+// selection for the PRs after them sees them.
+type importReads struct {
+	reads
+	users      map[string]*domain.User
+	repos      map[string]*domain.Repository
+	pools      map[string][]*domain.User         // by team
+	owners     map[string][]domain.CodeOwnerRule // by repository
+	ownerPools map[string][]*domain.User         // by the owners of a rule
+	rules      map[string][]domain.SeniorityRule // by team
+	// pairings count the assignments stored before the import.
+	pairings map[pair]int
+	// planned counts the reviews planned per reviewer.
+	planned map[string]int
+	// paired counts the reviews planned per author and reviewer.
+	paired map[pair]int
+}
+
+type pair struct {
+	authorID   string
+	reviewerID string
+}
+
+func newImportReads(r reads) *importReads {
+	return &importReads{
+		reads:      r,
+		users:      make(map[string]*domain.User),
+		repos:      make(map[string]*domain.Repository),
+		pools:      make(map[string][]*domain.User),
+		owners:     make(map[string][]domain.CodeOwnerRule),
+		ownerPools: make(map[string][]*domain.User),
+		rules:      make(map[string][]domain.SeniorityRule),
+		pairings:   make(map[pair]int),
+		planned:    make(map[string]int),
+		paired:     make(map[pair]int),
+	}
+}
+
+// loadPairings reads the pairings of the authors within window, which
+// GetPairings serves afterwards.
+func (r *importReads) loadPairings(ctx context.Context, authorIDs []string, window time.Duration) error {
+	pairings, err := r.reads.GetPairings(ctx, authorIDs, window)
+	if err != nil {
+		return err
+	}
+
+	for _, pairing := range pairings {
+		r.pairings[pair{authorID: pairing.AuthorID, reviewerID: pairing.ReviewerID}] = pairing.Count
+	}
+	return nil
+}
+
+// plan records the reviewers of a planned PR.
+func (r *importReads) plan(pr *domain.PullRequest) {
+	for _, reviewerID := range pr.AssignedReviewers {
+		r.planned[reviewerID]++
+		r.paired[pair{authorID: pr.AuthorID, reviewerID: reviewerID}]++
+	}
+}
+
+// unplan forgets the reviewers planned so far. The reads kept are those from
+// before anything was written, so the PRs written afterwards are counted only
+// through plan.
+func (r *importReads) unplan() {
+	clear(r.planned)
+	clear(r.paired)
+}
+
+func (r *importReads) GetUser(ctx context.Context, userID string) (*domain.User, error) {
+	return cached(r.users, userID, func() (*domain.User, error) { return r.reads.GetUser(ctx, userID) })
+}
+
+func (r *importReads) GetRepository(ctx context.Context, name string) (*domain.Repository, error) {
+	return cached(r.repos, name, func() (*domain.Repository, error) { return r.reads.GetRepository(ctx, name) })
+}
+
+func (r *importReads) GetReviewerPool(ctx context.Context, teamName string) ([]*domain.User, error) {
+	pool, err := cached(r.pools, teamName, func() ([]*domain.User, error) { return r.reads.GetReviewerPool(ctx, teamName) })
+	if err != nil {
+		return nil, err
+	}
+	return r.withPlanned(pool), nil
+}
+
+func (r *importReads) GetCodeOwnerPool(ctx context.Context, userIDs []string, teamNames []string) ([]*domain.User, error) {
+	key := fmt.Sprintf("%q %q", userIDs, teamNames)
+	pool, err := cached(r.ownerPools, key, func() ([]*domain.User, error) {
+		return r.reads.GetCodeOwnerPool(ctx, userIDs, teamNames)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return r.withPlanned(pool), nil
+}
+
+func (r *importReads) GetCodeOwners(ctx context.Context, repository string) ([]domain.CodeOwnerRule, error) {
+	return cached(r.owners, repository, func() ([]domain.CodeOwnerRule, error) { return r.reads.GetCodeOwners(ctx, repository) })
+}
+
+// GetSeniorityRules returns a copy of the rules, which the caller filters.
+func (r *importReads) GetSeniorityRules(ctx context.Context, teamName string) ([]domain.SeniorityRule, error) {
+	rules, err := cached(r.rules, teamName, func() ([]domain.SeniorityRule, error) {
+		return r.reads.GetSeniorityRules(ctx, teamName)
+	})
+	return slices.Clone(rules), err
+}
+
+// GetPairings adds the planned reviews to the pairings loaded by loadPairings;
+// window is that of loadPairings.
+func (r *importReads) GetPairings(_ context.Context, authorIDs []string, _ time.Duration) ([]domain.Pairing, error) {
+	counts := make(map[pair]int)
+	for _, from := range []map[pair]int{r.pairings, r.paired} {
+		for key, count := range from {
+			if slices.Contains(authorIDs, key.authorID) {
+				counts[key] += count
+			}
+		}
+	}
+
+	pairings := make([]domain.Pairing, 0, len(counts))
+	for key, count := range counts {
+		pairings = append(pairings, domain.Pairing{AuthorID: key.authorID, ReviewerID: key.reviewerID, Count: count})
+	}
+	return pairings, nil
+}
+
+// withPlanned returns copies of the users of pool with their planned reviews
+// counted as open.
+func (r *importReads) withPlanned(pool []*domain.User) []*domain.User {
+	users := make([]*domain.User, len(pool))
+	for i, user := range pool {
+		u := *user
+		u.OpenReviews += r.planned[user.UserID]
+		users[i] = &u
+	}
+	return users
+}
+
+// cached returns the value of key in cache, reading and keeping it on a miss.
+// Failed reads are not kept.
+func cached[V any](cache map[string]V, key string, read func() (V, error)) (V, error) {
+	if v, ok := cache[key]; ok {
+		return v, nil
+	}
+
+	v, err := read()
+	if err != nil {
+		return v, err
+	}
+	cache[key] = v
+	return v, nil
+}
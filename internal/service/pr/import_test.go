@@ -0,0 +1,244 @@
+package pr
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/whitxowl/pr-reviewer-assignment-service.git/internal/domain"
+	serviceErr "github.com/whitxowl/pr-reviewer-assignment-service.git/internal/service/errors"
+	"github.com/whitxowl/pr-reviewer-assignment-service.git/internal/service/pr/mocks"
+	storageErr "github.com/whitxowl/pr-reviewer-assignment-service.git/internal/storage/errors"
+)
+
+func TestService_ImportPRs(t *testing.T) {
+	ctx := context.Background()
+	log := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
+
+	author := &domain.User{UserID: "u1", TeamName: "backend", TeamNames: []string{"backend"}, IsActive: true}
+	draft := func(prID string, reviewers ...string) domain.ImportDraft {
+		return domain.ImportDraft{
+			Draft:     domain.PRDraft{PullRequestID: prID, PullRequestName: "PR " + prID, AuthorID: "u1"},
+			Reviewers: reviewers,
+		}
+	}
+	kept := func(prID string, reviewers ...string) domain.ImportDraft {
+		d := draft(prID)
+		d.Reviewers = append([]string{}, reviewers...)
+		return d
+	}
+	imported := func(prID string, rationales []domain.AssignmentRationale) domain.PRImport {
+		pr := domain.PullRequest{
+			PullRequestID:   prID,
+			PullRequestName: "PR " + prID,
+			AuthorID:        "u1",
+			TeamName:        "backend",
+			Status:          "OPEN",
+			Rationales:      rationales,
+		}
+		for _, r := range rationales {
+			pr.AssignedReviewers = append(pr.AssignedReviewers, r.ReviewerID)
+		}
+		return domain.PRImport{PR: pr}
+	}
+	manual := func(ids ...string) []domain.AssignmentRationale {
+		var rationales []domain.AssignmentRationale
+		for _, id := range ids {
+			rationales = append(rationales, manualRationale(id))
+		}
+		return rationales
+	}
+	// plan expects the check that a PR is new.
+	plan := func(prStorage *mocks.MockPRStorage, prID string) {
+		prStorage.EXPECT().GetPR(ctx, "", prID).Return(nil, storageErr.ErrPRNotFound).Once()
+	}
+	// The author is read once for the whole import.
+	readAuthor := func(userStorage *mocks.MockUserStorage) {
+		userStorage.EXPECT().GetUser(ctx, "u1").Return(author, nil).Once()
+	}
+
+	tests := []struct {
+		name           string
+		drafts         []domain.ImportDraft
+		atomic         bool
+		setupMocks     func(*mocks.MockUserStorage, *mocks.MockPRStorage)
+		expectedErrors []error
+		expectedError  error
+	}{
+		{
+			name:   "success - reviewers assigned or kept, failures reported per pr",
+			drafts: []domain.ImportDraft{draft("pr-1"), kept("pr-2", "u12"), draft("pr-3"), draft("pr-1"), kept("pr-4", "u1")},
+			setupMocks: func(userStorage *mocks.MockUserStorage, prStorage *mocks.MockPRStorage) {
+				readAuthor(userStorage)
+				plan(prStorage, "pr-1")
+				userStorage.EXPECT().GetReviewerPool(ctx, "backend").Return(activeUsers("u11"), nil).Once()
+
+				plan(prStorage, "pr-2")
+				userStorage.EXPECT().GetUser(ctx, "u12").Return(&domain.User{UserID: "u12", IsActive: true}, nil).Once()
+
+				prStorage.EXPECT().GetPR(ctx, "", "pr-3").Return(&domain.PullRequest{PullRequestID: "pr-3"}, nil).Once()
+
+				plan(prStorage, "pr-4")
+
+				// The team is one reviewer short.
+				assigned := imported("pr-1", randomRationales("u11"))
+				assigned.Queued = 1
+				prStorage.EXPECT().
+					ImportPRs(ctx, []domain.PRImport{assigned, imported("pr-2", manual("u12"))}).
+					Return(nil).
+					Once()
+			},
+			expectedErrors: []error{nil, nil, serviceErr.ErrPRExists, serviceErr.ErrPRExists, serviceErr.ErrReviewerIsAuthor},
+		},
+		{
+			name:   "success - reviewers planned earlier in the import count towards capacity",
+			drafts: []domain.ImportDraft{draft("pr-1"), draft("pr-2")},
+			setupMocks: func(userStorage *mocks.MockUserStorage, prStorage *mocks.MockPRStorage) {
+				limit := 1
+				pool := []*domain.User{{UserID: "u11", IsActive: true, ReviewLimit: &limit}}
+
+				readAuthor(userStorage)
+				plan(prStorage, "pr-1")
+				plan(prStorage, "pr-2")
+				userStorage.EXPECT().GetReviewerPool(ctx, "backend").Return(pool, nil).Once()
+
+				// u11 reaches the limit with pr-1, so pr-2 gets nobody.
+				first := imported("pr-1", randomRationales("u11"))
+				first.Queued = 1
+				second := imported("pr-2", nil)
+				second.Queued = 1
+				prStorage.EXPECT().ImportPRs(ctx, []domain.PRImport{first, second}).Return(nil).Once()
+			},
+			expectedErrors: []error{nil, nil},
+		},
+		{
+			name:   "success - empty reviewers are kept",
+			drafts: []domain.ImportDraft{kept("pr-1")},
+			setupMocks: func(userStorage *mocks.MockUserStorage, prStorage *mocks.MockPRStorage) {
+				readAuthor(userStorage)
+				plan(prStorage, "pr-1")
+				prStorage.EXPECT().ImportPRs(ctx, []domain.PRImport{imported("pr-1", nil)}).Return(nil).Once()
+			},
+			expectedErrors: []error{nil},
+		},
+		{
+			name:   "atomic - nothing written when a pr fails",
+			drafts: []domain.ImportDraft{kept("pr-1", "u12"), kept("pr-2", "u13")},
+			atomic: true,
+			setupMocks: func(userStorage *mocks.MockUserStorage, prStorage *mocks.MockPRStorage) {
+				readAuthor(userStorage)
+				plan(prStorage, "pr-1")
+				userStorage.EXPECT().GetUser(ctx, "u12").Return(&domain.User{UserID: "u12", IsActive: true}, nil).Once()
+				plan(prStorage, "pr-2")
+				userStorage.EXPECT().GetUser(ctx, "u13").Return(&domain.User{UserID: "u13"}, nil).Once()
+			},
+			expectedErrors: []error{nil, serviceErr.ErrReviewerInactive},
+		},
+		{
+			name:   "atomic - failed write fails the import",
+			drafts: []domain.ImportDraft{kept("pr-1", "u12")},
+			atomic: true,
+			setupMocks: func(userStorage *mocks.MockUserStorage, prStorage *mocks.MockPRStorage) {
+				readAuthor(userStorage)
+				plan(prStorage, "pr-1")
+				userStorage.EXPECT().GetUser(ctx, "u12").Return(&domain.User{UserID: "u12", IsActive: true}, nil).Once()
+				prStorage.EXPECT().
+					ImportPRs(ctx, []domain.PRImport{imported("pr-1", manual("u12"))}).
+					Return(storageErr.ErrPRExists).
+					Once()
+			},
+			expectedError: serviceErr.ErrPRExists,
+		},
+		{
+			name:   "best effort - failed write retried pr by pr",
+			drafts: []domain.ImportDraft{kept("pr-1"), kept("pr-2")},
+			setupMocks: func(userStorage *mocks.MockUserStorage, prStorage *mocks.MockPRStorage) {
+				readAuthor(userStorage)
+				plan(prStorage, "pr-1")
+				plan(prStorage, "pr-2")
+				both := []domain.PRImport{imported("pr-1", nil), imported("pr-2", nil)}
+				prStorage.EXPECT().ImportPRs(ctx, both).Return(storageErr.ErrPRExists).Once()
+
+				// Each pr is planned again before it is written alone.
+				plan(prStorage, "pr-1")
+				prStorage.EXPECT().ImportPRs(ctx, both[:1]).Return(storageErr.ErrPRExists).Once()
+				plan(prStorage, "pr-2")
+				prStorage.EXPECT().ImportPRs(ctx, both[1:]).Return(nil).Once()
+			},
+			expectedErrors: []error{serviceErr.ErrPRExists, nil},
+		},
+		{
+			name:   "best effort - prs not written do not count towards capacity",
+			drafts: []domain.ImportDraft{draft("pr-1"), draft("pr-2")},
+			setupMocks: func(userStorage *mocks.MockUserStorage, prStorage *mocks.MockPRStorage) {
+				limit := 1
+				pool := []*domain.User{{UserID: "u11", IsActive: true, ReviewLimit: &limit}}
+
+				readAuthor(userStorage)
+				plan(prStorage, "pr-1")
+				plan(prStorage, "pr-2")
+				userStorage.EXPECT().GetReviewerPool(ctx, "backend").Return(pool, nil).Once()
+
+				first := imported("pr-1", randomRationales("u11"))
+				first.Queued = 1
+				second := imported("pr-2", nil)
+				second.Queued = 1
+				prStorage.EXPECT().ImportPRs(ctx, []domain.PRImport{first, second}).Return(storageErr.ErrPRExists).Once()
+
+				// pr-1 was created meanwhile, so u11 is free for pr-2.
+				prStorage.EXPECT().GetPR(ctx, "", "pr-1").Return(&domain.PullRequest{PullRequestID: "pr-1"}, nil).Once()
+				plan(prStorage, "pr-2")
+				retried := imported("pr-2", randomRationales("u11"))
+				retried.Queued = 1
+				prStorage.EXPECT().ImportPRs(ctx, []domain.PRImport{retried}).Return(nil).Once()
+			},
+			expectedErrors: []error{serviceErr.ErrPRExists, nil},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			userStorage := mocks.NewMockUserStorage(t)
+			prStorage := mocks.NewMockPRStorage(t)
+			tt.setupMocks(userStorage, prStorage)
+
+			service := New(log, userStorage, mocks.NewMockTeamStorage(t), prStorage, mocks.NewMockRepositoryStorage(t), testIDs, Options{CapacityMode: domain.CapacitySkip})
+
+			// Act
+			results, err := service.ImportPRs(ctx, tt.drafts, tt.atomic)
+
+			// Assert
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				assert.Nil(t, results)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Len(t, results, len(tt.expectedErrors))
+			failed := false
+			for _, result := range results {
+				failed = failed || result.Err != nil
+			}
+			for i, result := range results {
+				expected := tt.expectedErrors[i]
+				switch {
+				case expected != nil:
+					assert.True(t, errors.Is(result.Err, expected), "result %d: %v", i, result.Err)
+					assert.Nil(t, result.PR)
+				case tt.atomic && failed:
+					assert.NoError(t, result.Err, "result %d", i)
+					assert.Nil(t, result.PR, "result %d is not imported", i)
+				default:
+					assert.NoError(t, result.Err, "result %d", i)
+					assert.Equal(t, tt.drafts[i].Draft.PullRequestID, result.PR.PullRequestID)
+				}
+			}
+		})
+	}
+}
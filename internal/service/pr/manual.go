@@ -0,0 +1,243 @@
+package pr
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+
+	"github.com/whitxowl/pr-reviewer-assignment-service.git/internal/domain"
+	serviceErr "github.com/whitxowl/pr-reviewer-assignment-service.git/internal/service/errors"
+	storageErr "github.com/whitxowl/pr-reviewer-assignment-service.git/internal/storage/errors"
+)
+
+// AddReviewer assigns reviewerID to the open PR on top of its reviewers. The
+// reviewer need not be a member of the PR's team but must be active and not the
+// author; a queued reviewer slot of the PR is taken by them.
+func (s *Service) AddReviewer(ctx context.Context, repository string, prID string, reviewerID string) (*domain.PullRequest, error) {
+	const op = "service.pr.AddReviewer"
+
+	log := s.log.With(
+		slog.String("op", op),
+		slog.String("repository", repository),
+		slog.String("prID", prID),
+		slog.String("reviewerID", reviewerID),
+	)
+
+	pr, err := s.prStorage.GetPR(ctx, repository, prID)
+	if errors.Is(err, storageErr.ErrPRNotFound) {
+		log.DebugContext(ctx, "pr not found", "error", err)
+		return nil, serviceErr.ErrPRNotFound
+	}
+	if err != nil {
+		log.ErrorContext(ctx, "error getting pr", "error", err)
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err = s.checkNamedReviewer(ctx, log, s.reads, pr, reviewerID); err != nil {
+		return nil, err
+	}
+
+	err = s.prStorage.FillReviewerSlots(ctx, repository, prID, []string{reviewerID})
+	if errors.Is(err, storageErr.ErrPRNotFound) {
+		log.DebugContext(ctx, "pr not found", "error", err)
+		return nil, serviceErr.ErrPRNotFound
+	}
+	if errors.Is(err, storageErr.ErrPRMerged) {
+		log.DebugContext(ctx, "pr already merged", "error", err)
+		return nil, serviceErr.ErrPRMerged
+	}
+	if errors.Is(err, storageErr.ErrReviewerAssigned) {
+		log.DebugContext(ctx, "reviewer assigned in the meantime", "error", err)
+		return nil, serviceErr.ErrReviewerAssigned
+	}
+	if err != nil {
+		log.ErrorContext(ctx, "error adding reviewer", "error", err)
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	rationale := manualRationale(reviewerID)
+	err = s.prStorage.SaveAssignmentRationales(ctx, repository, prID, []domain.AssignmentRationale{rationale})
+	if err != nil {
+		log.ErrorContext(ctx, "error saving assignment rationale", "error", err)
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	audit(ctx, log, rationale)
+
+	return s.GetPR(ctx, repository, prID)
+}
+
+// RemoveReviewer unassigns reviewerID from the open PR without a replacement.
+func (s *Service) RemoveReviewer(ctx context.Context, repository string, prID string, reviewerID string) (*domain.PullRequest, error) {
+	const op = "service.pr.RemoveReviewer"
+
+	log := s.log.With(
+		slog.String("op", op),
+		slog.String("repository", repository),
+		slog.String("prID", prID),
+		slog.String("reviewerID", reviewerID),
+	)
+
+	_, err := s.prStorage.ReassignReviewer(ctx, repository, prID, domain.Reassignment{OldReviewerID: reviewerID}, false)
+	if errors.Is(err, storageErr.ErrPRNotFound) {
+		log.DebugContext(ctx, "pr not found", "error", err)
+		return nil, serviceErr.ErrPRNotFound
+	}
+	if errors.Is(err, storageErr.ErrPRMerged) {
+		log.DebugContext(ctx, "pr already merged", "error", err)
+		return nil, serviceErr.ErrPRMerged
+	}
+	if errors.Is(err, storageErr.ErrReviewerNotFound) {
+		log.DebugContext(ctx, "reviewer not assigned to this pr", "error", err)
+		return nil, serviceErr.ErrReviewerNotFound
+	}
+	if err != nil {
+		log.ErrorContext(ctx, "error removing reviewer", "error", err)
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.InfoContext(ctx, "reviewer removed")
+
+	// The reviewer has one open review less.
+	s.wake()
+
+	return s.GetPR(ctx, repository, prID)
+}
+
+// ReassignReviewerTo replaces oldReviewerID with newReviewerID, which is
+// checked as in AddReviewer instead of being selected. With dryRun nothing is
+// written and the PR is returned as it would have been.
+func (s *Service) ReassignReviewerTo(
+	ctx context.Context,
+	repository string,
+	prID string,
+	oldReviewerID string,
+	newReviewerID string,
+	dryRun bool,
+) (*domain.PullRequest, error) {
+	const op = "service.pr.ReassignReviewerTo"
+
+	log := s.log.With(
+		slog.String("op", op),
+		slog.String("repository", repository),
+		slog.String("prID", prID),
+		slog.String("oldReviewerID", oldReviewerID),
+		slog.String("newReviewerID", newReviewerID),
+		slog.Bool("dryRun", dryRun),
+	)
+
+	current, err := s.prStorage.GetPR(ctx, repository, prID)
+	if errors.Is(err, storageErr.ErrPRNotFound) {
+		log.DebugContext(ctx, "pr not found", "error", err)
+		return nil, serviceErr.ErrPRNotFound
+	}
+	if err != nil {
+		log.ErrorContext(ctx, "error getting pr", "error", err)
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if !slices.Contains(current.AssignedReviewers, oldReviewerID) {
+		log.DebugContext(ctx, "reviewer not assigned to this pr")
+		return nil, serviceErr.ErrReviewerNotFound
+	}
+
+	if err = s.checkNamedReviewer(ctx, log, s.reads, current, newReviewerID); err != nil {
+		return nil, err
+	}
+
+	rationale := manualRationale(newReviewerID)
+	reassignment := domain.Reassignment{OldReviewerID: oldReviewerID, Replacement: &rationale}
+
+	pr, err := s.prStorage.ReassignReviewer(ctx, repository, prID, reassignment, dryRun)
+	if errors.Is(err, storageErr.ErrPRNotFound) {
+		log.DebugContext(ctx, "pr not found", "error", err)
+		return nil, serviceErr.ErrPRNotFound
+	}
+	if errors.Is(err, storageErr.ErrPRMerged) {
+		log.DebugContext(ctx, "pr already merged", "error", err)
+		return nil, serviceErr.ErrPRMerged
+	}
+	if errors.Is(err, storageErr.ErrReviewerNotFound) {
+		log.DebugContext(ctx, "reviewer not assigned to this pr", "error", err)
+		return nil, serviceErr.ErrReviewerNotFound
+	}
+	if errors.Is(err, storageErr.ErrReviewerAssigned) {
+		log.DebugContext(ctx, "reviewer assigned in the meantime", "error", err)
+		return nil, serviceErr.ErrReviewerAssigned
+	}
+	if err != nil {
+		log.ErrorContext(ctx, "error reassigning reviewer", "error", err)
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	pr.Rationales = []domain.AssignmentRationale{rationale}
+
+	if dryRun {
+		return pr, nil
+	}
+
+	audit(ctx, log, rationale)
+
+	log.InfoContext(ctx, "reviewer reassigned successfully",
+		"oldReviewer", oldReviewerID,
+		"newReviewer", newReviewerID)
+
+	// The old reviewer has one open review less.
+	s.wake()
+
+	return pr, nil
+}
+
+// checkNamedReviewer checks that reviewerID may be added to the PR: the PR is
+// open and the reviewer is an active user other than the author who is not
+// already assigned.
+func (s *Service) checkNamedReviewer(
+	ctx context.Context,
+	log *slog.Logger,
+	r reads,
+	pr *domain.PullRequest,
+	reviewerID string,
+) error {
+	const op = "service.pr.checkNamedReviewer"
+
+	switch {
+	case pr.Status != statusOpen:
+		log.DebugContext(ctx, "pr already merged")
+		return serviceErr.ErrPRMerged
+	case reviewerID == pr.AuthorID:
+		log.DebugContext(ctx, "reviewer is the author")
+		return serviceErr.ErrReviewerIsAuthor
+	case slices.Contains(pr.AssignedReviewers, reviewerID):
+		log.DebugContext(ctx, "reviewer already assigned")
+		return serviceErr.ErrReviewerAssigned
+	}
+
+	user, err := r.GetUser(ctx, reviewerID)
+	if errors.Is(err, storageErr.ErrUserNotFound) {
+		log.DebugContext(ctx, "reviewer not found", "error", err)
+		return serviceErr.ErrUserNotFound
+	}
+	if err != nil {
+		log.ErrorContext(ctx, "error getting reviewer", "error", err)
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if !user.IsActive {
+		log.DebugContext(ctx, "reviewer is not active")
+		return serviceErr.ErrReviewerInactive
+	}
+
+	return nil
+}
+
+// manualRationale explains the assignment of a reviewer named by the caller,
+// the only candidate considered.
+func manualRationale(reviewerID string) domain.AssignmentRationale {
+	return domain.AssignmentRationale{
+		ReviewerID: reviewerID,
+		Strategy:   domain.StrategyManual,
+		PoolSize:   1,
+	}
+}
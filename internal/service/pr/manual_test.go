@@ -0,0 +1,310 @@
+package pr
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/whitxowl/pr-reviewer-assignment-service.git/internal/domain"
+	serviceErr "github.com/whitxowl/pr-reviewer-assignment-service.git/internal/service/errors"
+	"github.com/whitxowl/pr-reviewer-assignment-service.git/internal/service/pr/mocks"
+	storageErr "github.com/whitxowl/pr-reviewer-assignment-service.git/internal/storage/errors"
+)
+
+func TestService_AddReviewer(t *testing.T) {
+	ctx := context.Background()
+	log := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
+
+	openPR := &domain.PullRequest{
+		PullRequestID:     "pr-1",
+		AuthorID:          "u1",
+		TeamName:          "backend",
+		Status:            "OPEN",
+		AssignedReviewers: []string{"u11"},
+	}
+	manual := []domain.AssignmentRationale{{ReviewerID: "u20", Strategy: domain.StrategyManual, PoolSize: 1}}
+
+	tests := []struct {
+		name          string
+		reviewerID    string
+		setupMocks    func(*mocks.MockUserStorage, *mocks.MockPRStorage)
+		expectedError error
+	}{
+		{
+			name:       "success - reviewer from another team added",
+			reviewerID: "u20",
+			setupMocks: func(userStorage *mocks.MockUserStorage, prStorage *mocks.MockPRStorage) {
+				prStorage.EXPECT().GetPR(ctx, "", "pr-1").Return(openPR, nil).Once()
+				userStorage.EXPECT().GetUser(ctx, "u20").Return(&domain.User{UserID: "u20", TeamName: "frontend", IsActive: true}, nil).Once()
+				prStorage.EXPECT().FillReviewerSlots(ctx, "", "pr-1", []string{"u20"}).Return(nil).Once()
+				prStorage.EXPECT().SaveAssignmentRationales(ctx, "", "pr-1", manual).Return(nil).Once()
+
+				prStorage.EXPECT().
+					GetPR(ctx, "", "pr-1").
+					Return(&domain.PullRequest{PullRequestID: "pr-1", Status: "OPEN", AssignedReviewers: []string{"u11", "u20"}}, nil).
+					Once()
+				prStorage.EXPECT().GetAssignmentRationales(ctx, "", "pr-1").Return(manual, nil).Once()
+			},
+		},
+		{
+			name:       "error - pr not found",
+			reviewerID: "u20",
+			setupMocks: func(userStorage *mocks.MockUserStorage, prStorage *mocks.MockPRStorage) {
+				prStorage.EXPECT().GetPR(ctx, "", "pr-1").Return(nil, storageErr.ErrPRNotFound).Once()
+			},
+			expectedError: serviceErr.ErrPRNotFound,
+		},
+		{
+			name:       "error - pr merged",
+			reviewerID: "u20",
+			setupMocks: func(userStorage *mocks.MockUserStorage, prStorage *mocks.MockPRStorage) {
+				prStorage.EXPECT().
+					GetPR(ctx, "", "pr-1").
+					Return(&domain.PullRequest{PullRequestID: "pr-1", AuthorID: "u1", Status: "MERGED"}, nil).
+					Once()
+			},
+			expectedError: serviceErr.ErrPRMerged,
+		},
+		{
+			name:       "error - reviewer is the author",
+			reviewerID: "u1",
+			setupMocks: func(userStorage *mocks.MockUserStorage, prStorage *mocks.MockPRStorage) {
+				prStorage.EXPECT().GetPR(ctx, "", "pr-1").Return(openPR, nil).Once()
+			},
+			expectedError: serviceErr.ErrReviewerIsAuthor,
+		},
+		{
+			name:       "error - reviewer already assigned",
+			reviewerID: "u11",
+			setupMocks: func(userStorage *mocks.MockUserStorage, prStorage *mocks.MockPRStorage) {
+				prStorage.EXPECT().GetPR(ctx, "", "pr-1").Return(openPR, nil).Once()
+			},
+			expectedError: serviceErr.ErrReviewerAssigned,
+		},
+		{
+			name:       "error - reviewer not found",
+			reviewerID: "u20",
+			setupMocks: func(userStorage *mocks.MockUserStorage, prStorage *mocks.MockPRStorage) {
+				prStorage.EXPECT().GetPR(ctx, "", "pr-1").Return(openPR, nil).Once()
+				userStorage.EXPECT().GetUser(ctx, "u20").Return(nil, storageErr.ErrUserNotFound).Once()
+			},
+			expectedError: serviceErr.ErrUserNotFound,
+		},
+		{
+			name:       "error - reviewer inactive",
+			reviewerID: "u20",
+			setupMocks: func(userStorage *mocks.MockUserStorage, prStorage *mocks.MockPRStorage) {
+				prStorage.EXPECT().GetPR(ctx, "", "pr-1").Return(openPR, nil).Once()
+				userStorage.EXPECT().GetUser(ctx, "u20").Return(&domain.User{UserID: "u20"}, nil).Once()
+			},
+			expectedError: serviceErr.ErrReviewerInactive,
+		},
+		{
+			name:       "error - pr merged in the meantime",
+			reviewerID: "u20",
+			setupMocks: func(userStorage *mocks.MockUserStorage, prStorage *mocks.MockPRStorage) {
+				prStorage.EXPECT().GetPR(ctx, "", "pr-1").Return(openPR, nil).Once()
+				userStorage.EXPECT().GetUser(ctx, "u20").Return(&domain.User{UserID: "u20", IsActive: true}, nil).Once()
+				prStorage.EXPECT().FillReviewerSlots(ctx, "", "pr-1", []string{"u20"}).Return(storageErr.ErrPRMerged).Once()
+			},
+			expectedError: serviceErr.ErrPRMerged,
+		},
+		{
+			name:       "error - reviewer added concurrently",
+			reviewerID: "u20",
+			setupMocks: func(userStorage *mocks.MockUserStorage, prStorage *mocks.MockPRStorage) {
+				prStorage.EXPECT().GetPR(ctx, "", "pr-1").Return(openPR, nil).Once()
+				userStorage.EXPECT().GetUser(ctx, "u20").Return(&domain.User{UserID: "u20", IsActive: true}, nil).Once()
+				prStorage.EXPECT().FillReviewerSlots(ctx, "", "pr-1", []string{"u20"}).Return(storageErr.ErrReviewerAssigned).Once()
+			},
+			expectedError: serviceErr.ErrReviewerAssigned,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			userStorage := mocks.NewMockUserStorage(t)
+			prStorage := mocks.NewMockPRStorage(t)
+			tt.setupMocks(userStorage, prStorage)
+
+			service := New(log, userStorage, mocks.NewMockTeamStorage(t), prStorage, mocks.NewMockRepositoryStorage(t), testIDs, Options{})
+
+			// Act
+			result, err := service.AddReviewer(ctx, "", "pr-1", tt.reviewerID)
+
+			// Assert
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				assert.Nil(t, result)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, []string{"u11", "u20"}, result.AssignedReviewers)
+			assert.Equal(t, manual, result.Rationales)
+		})
+	}
+}
+
+func TestService_RemoveReviewer(t *testing.T) {
+	ctx := context.Background()
+	log := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
+
+	tests := []struct {
+		name          string
+		storageErr    error
+		expectedError error
+	}{
+		{name: "success - reviewer removed"},
+		{name: "error - pr not found", storageErr: storageErr.ErrPRNotFound, expectedError: serviceErr.ErrPRNotFound},
+		{name: "error - pr merged", storageErr: storageErr.ErrPRMerged, expectedError: serviceErr.ErrPRMerged},
+		{name: "error - reviewer not assigned", storageErr: storageErr.ErrReviewerNotFound, expectedError: serviceErr.ErrReviewerNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			prStorage := mocks.NewMockPRStorage(t)
+			prStorage.EXPECT().
+				ReassignReviewer(ctx, "", "pr-1", domain.Reassignment{OldReviewerID: "u11"}, false).
+				Return(&domain.PullRequest{PullRequestID: "pr-1", AssignedReviewers: []string{"u12"}}, tt.storageErr).
+				Once()
+			if tt.storageErr == nil {
+				prStorage.EXPECT().
+					GetPR(ctx, "", "pr-1").
+					Return(&domain.PullRequest{PullRequestID: "pr-1", AssignedReviewers: []string{"u12"}}, nil).
+					Once()
+				prStorage.EXPECT().GetAssignmentRationales(ctx, "", "pr-1").Return(randomRationales("u12"), nil).Once()
+			}
+
+			service := New(log, mocks.NewMockUserStorage(t), mocks.NewMockTeamStorage(t), prStorage, mocks.NewMockRepositoryStorage(t), testIDs, Options{})
+
+			// Act
+			result, err := service.RemoveReviewer(ctx, "", "pr-1", "u11")
+
+			// Assert
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				assert.Nil(t, result)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, []string{"u12"}, result.AssignedReviewers)
+		})
+	}
+}
+
+func TestService_ReassignReviewerTo(t *testing.T) {
+	ctx := context.Background()
+	log := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
+
+	openPR := &domain.PullRequest{
+		PullRequestID:     "pr-1",
+		AuthorID:          "u1",
+		TeamName:          "backend",
+		Status:            "OPEN",
+		AssignedReviewers: []string{"u11", "u12"},
+	}
+	reassigned := &domain.PullRequest{PullRequestID: "pr-1", Status: "OPEN", AssignedReviewers: []string{"u12", "u20"}}
+	toU20 := domain.Reassignment{
+		OldReviewerID: "u11",
+		Replacement:   &domain.AssignmentRationale{ReviewerID: "u20", Strategy: domain.StrategyManual, PoolSize: 1},
+	}
+
+	tests := []struct {
+		name          string
+		oldReviewerID string
+		newReviewerID string
+		dryRun        bool
+		setupMocks    func(*mocks.MockUserStorage, *mocks.MockPRStorage)
+		expectedError error
+	}{
+		{
+			name:          "success - reviewer replaced by the named one",
+			oldReviewerID: "u11",
+			newReviewerID: "u20",
+			setupMocks: func(userStorage *mocks.MockUserStorage, prStorage *mocks.MockPRStorage) {
+				prStorage.EXPECT().GetPR(ctx, "", "pr-1").Return(openPR, nil).Once()
+				userStorage.EXPECT().GetUser(ctx, "u20").Return(&domain.User{UserID: "u20", IsActive: true}, nil).Once()
+				prStorage.EXPECT().ReassignReviewer(ctx, "", "pr-1", toU20, false).Return(reassigned, nil).Once()
+			},
+		},
+		{
+			name:          "success - dry run writes nothing",
+			oldReviewerID: "u11",
+			newReviewerID: "u20",
+			dryRun:        true,
+			setupMocks: func(userStorage *mocks.MockUserStorage, prStorage *mocks.MockPRStorage) {
+				prStorage.EXPECT().GetPR(ctx, "", "pr-1").Return(openPR, nil).Once()
+				userStorage.EXPECT().GetUser(ctx, "u20").Return(&domain.User{UserID: "u20", IsActive: true}, nil).Once()
+				prStorage.EXPECT().ReassignReviewer(ctx, "", "pr-1", toU20, true).Return(reassigned, nil).Once()
+			},
+		},
+		{
+			name:          "error - old reviewer not assigned",
+			oldReviewerID: "u13",
+			newReviewerID: "u20",
+			setupMocks: func(userStorage *mocks.MockUserStorage, prStorage *mocks.MockPRStorage) {
+				prStorage.EXPECT().GetPR(ctx, "", "pr-1").Return(openPR, nil).Once()
+			},
+			expectedError: serviceErr.ErrReviewerNotFound,
+		},
+		{
+			name:          "error - new reviewer already assigned",
+			oldReviewerID: "u11",
+			newReviewerID: "u12",
+			setupMocks: func(userStorage *mocks.MockUserStorage, prStorage *mocks.MockPRStorage) {
+				prStorage.EXPECT().GetPR(ctx, "", "pr-1").Return(openPR, nil).Once()
+			},
+			expectedError: serviceErr.ErrReviewerAssigned,
+		},
+		{
+			name:          "error - new reviewer is the author",
+			oldReviewerID: "u11",
+			newReviewerID: "u1",
+			setupMocks: func(userStorage *mocks.MockUserStorage, prStorage *mocks.MockPRStorage) {
+				prStorage.EXPECT().GetPR(ctx, "", "pr-1").Return(openPR, nil).Once()
+			},
+			expectedError: serviceErr.ErrReviewerIsAuthor,
+		},
+		{
+			name:          "error - new reviewer inactive",
+			oldReviewerID: "u11",
+			newReviewerID: "u20",
+			setupMocks: func(userStorage *mocks.MockUserStorage, prStorage *mocks.MockPRStorage) {
+				prStorage.EXPECT().GetPR(ctx, "", "pr-1").Return(openPR, nil).Once()
+				userStorage.EXPECT().GetUser(ctx, "u20").Return(&domain.User{UserID: "u20"}, nil).Once()
+			},
+			expectedError: serviceErr.ErrReviewerInactive,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			userStorage := mocks.NewMockUserStorage(t)
+			prStorage := mocks.NewMockPRStorage(t)
+			tt.setupMocks(userStorage, prStorage)
+
+			service := New(log, userStorage, mocks.NewMockTeamStorage(t), prStorage, mocks.NewMockRepositoryStorage(t), testIDs, Options{})
+
+			// Act
+			result, err := service.ReassignReviewerTo(ctx, "", "pr-1", tt.oldReviewerID, tt.newReviewerID, tt.dryRun)
+
+			// Assert
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				assert.Nil(t, result)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, []string{"u12", "u20"}, result.AssignedReviewers)
+			assert.Equal(t, []domain.AssignmentRationale{*toU20.Replacement}, result.Rationales)
+		})
+	}
+}
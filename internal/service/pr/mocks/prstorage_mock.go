@@ -6,6 +6,7 @@ package mocks
 
 import (
 	"context"
+	"time"
 
 	mock "github.com/stretchr/testify/mock"
 	"github.com/whitxowl/pr-reviewer-assignment-service.git/internal/domain"
@@ -38,37 +39,37 @@ func (_m *MockPRStorage) EXPECT() *MockPRStorage_Expecter {
 	return &MockPRStorage_Expecter{mock: &_m.Mock}
 }
 
-// AssignReviewers provides a mock function for the type MockPRStorage
-func (_mock *MockPRStorage) AssignReviewers(ctx context.Context, prID string, reviewersIDs []string) error {
-	ret := _mock.Called(ctx, prID, reviewersIDs)
+// DropReviewerSlots provides a mock function for the type MockPRStorage
+func (_mock *MockPRStorage) DropReviewerSlots(ctx context.Context, repository string, prID string) error {
+	ret := _mock.Called(ctx, repository, prID)
 
 	if len(ret) == 0 {
-		panic("no return value specified for AssignReviewers")
+		panic("no return value specified for DropReviewerSlots")
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, []string) error); ok {
-		r0 = returnFunc(ctx, prID, reviewersIDs)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = returnFunc(ctx, repository, prID)
 	} else {
 		r0 = ret.Error(0)
 	}
 	return r0
 }
 
-// MockPRStorage_AssignReviewers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AssignReviewers'
-type MockPRStorage_AssignReviewers_Call struct {
+// MockPRStorage_DropReviewerSlots_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DropReviewerSlots'
+type MockPRStorage_DropReviewerSlots_Call struct {
 	*mock.Call
 }
 
-// AssignReviewers is a helper method to define mock.On call
+// DropReviewerSlots is a helper method to define mock.On call
 //   - ctx context.Context
+//   - repository string
 //   - prID string
-//   - reviewersIDs []string
-func (_e *MockPRStorage_Expecter) AssignReviewers(ctx interface{}, prID interface{}, reviewersIDs interface{}) *MockPRStorage_AssignReviewers_Call {
-	return &MockPRStorage_AssignReviewers_Call{Call: _e.mock.On("AssignReviewers", ctx, prID, reviewersIDs)}
+func (_e *MockPRStorage_Expecter) DropReviewerSlots(ctx interface{}, repository interface{}, prID interface{}) *MockPRStorage_DropReviewerSlots_Call {
+	return &MockPRStorage_DropReviewerSlots_Call{Call: _e.mock.On("DropReviewerSlots", ctx, repository, prID)}
 }
 
-func (_c *MockPRStorage_AssignReviewers_Call) Run(run func(ctx context.Context, prID string, reviewersIDs []string)) *MockPRStorage_AssignReviewers_Call {
+func (_c *MockPRStorage_DropReviewerSlots_Call) Run(run func(ctx context.Context, repository string, prID string)) *MockPRStorage_DropReviewerSlots_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -78,9 +79,9 @@ func (_c *MockPRStorage_AssignReviewers_Call) Run(run func(ctx context.Context,
 		if args[1] != nil {
 			arg1 = args[1].(string)
 		}
-		var arg2 []string
+		var arg2 string
 		if args[2] != nil {
-			arg2 = args[2].([]string)
+			arg2 = args[2].(string)
 		}
 		run(
 			arg0,
@@ -91,48 +92,48 @@ func (_c *MockPRStorage_AssignReviewers_Call) Run(run func(ctx context.Context,
 	return _c
 }
 
-func (_c *MockPRStorage_AssignReviewers_Call) Return(err error) *MockPRStorage_AssignReviewers_Call {
+func (_c *MockPRStorage_DropReviewerSlots_Call) Return(err error) *MockPRStorage_DropReviewerSlots_Call {
 	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockPRStorage_AssignReviewers_Call) RunAndReturn(run func(ctx context.Context, prID string, reviewersIDs []string) error) *MockPRStorage_AssignReviewers_Call {
+func (_c *MockPRStorage_DropReviewerSlots_Call) RunAndReturn(run func(ctx context.Context, repository string, prID string) error) *MockPRStorage_DropReviewerSlots_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// CreatePR provides a mock function for the type MockPRStorage
-func (_mock *MockPRStorage) CreatePR(ctx context.Context, prID string, prName string, authorID string) error {
-	ret := _mock.Called(ctx, prID, prName, authorID)
+// FillReviewerSlots provides a mock function for the type MockPRStorage
+func (_mock *MockPRStorage) FillReviewerSlots(ctx context.Context, repository string, prID string, reviewerIDs []string) error {
+	ret := _mock.Called(ctx, repository, prID, reviewerIDs)
 
 	if len(ret) == 0 {
-		panic("no return value specified for CreatePR")
+		panic("no return value specified for FillReviewerSlots")
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string) error); ok {
-		r0 = returnFunc(ctx, prID, prName, authorID)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, []string) error); ok {
+		r0 = returnFunc(ctx, repository, prID, reviewerIDs)
 	} else {
 		r0 = ret.Error(0)
 	}
 	return r0
 }
 
-// MockPRStorage_CreatePR_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreatePR'
-type MockPRStorage_CreatePR_Call struct {
+// MockPRStorage_FillReviewerSlots_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FillReviewerSlots'
+type MockPRStorage_FillReviewerSlots_Call struct {
 	*mock.Call
 }
 
-// CreatePR is a helper method to define mock.On call
+// FillReviewerSlots is a helper method to define mock.On call
 //   - ctx context.Context
+//   - repository string
 //   - prID string
-//   - prName string
-//   - authorID string
-func (_e *MockPRStorage_Expecter) CreatePR(ctx interface{}, prID interface{}, prName interface{}, authorID interface{}) *MockPRStorage_CreatePR_Call {
-	return &MockPRStorage_CreatePR_Call{Call: _e.mock.On("CreatePR", ctx, prID, prName, authorID)}
+//   - reviewerIDs []string
+func (_e *MockPRStorage_Expecter) FillReviewerSlots(ctx interface{}, repository interface{}, prID interface{}, reviewerIDs interface{}) *MockPRStorage_FillReviewerSlots_Call {
+	return &MockPRStorage_FillReviewerSlots_Call{Call: _e.mock.On("FillReviewerSlots", ctx, repository, prID, reviewerIDs)}
 }
 
-func (_c *MockPRStorage_CreatePR_Call) Run(run func(ctx context.Context, prID string, prName string, authorID string)) *MockPRStorage_CreatePR_Call {
+func (_c *MockPRStorage_FillReviewerSlots_Call) Run(run func(ctx context.Context, repository string, prID string, reviewerIDs []string)) *MockPRStorage_FillReviewerSlots_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -146,9 +147,9 @@ func (_c *MockPRStorage_CreatePR_Call) Run(run func(ctx context.Context, prID st
 		if args[2] != nil {
 			arg2 = args[2].(string)
 		}
-		var arg3 string
+		var arg3 []string
 		if args[3] != nil {
-			arg3 = args[3].(string)
+			arg3 = args[3].([]string)
 		}
 		run(
 			arg0,
@@ -160,55 +161,132 @@ func (_c *MockPRStorage_CreatePR_Call) Run(run func(ctx context.Context, prID st
 	return _c
 }
 
-func (_c *MockPRStorage_CreatePR_Call) Return(err error) *MockPRStorage_CreatePR_Call {
+func (_c *MockPRStorage_FillReviewerSlots_Call) Return(err error) *MockPRStorage_FillReviewerSlots_Call {
 	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockPRStorage_CreatePR_Call) RunAndReturn(run func(ctx context.Context, prID string, prName string, authorID string) error) *MockPRStorage_CreatePR_Call {
+func (_c *MockPRStorage_FillReviewerSlots_Call) RunAndReturn(run func(ctx context.Context, repository string, prID string, reviewerIDs []string) error) *MockPRStorage_FillReviewerSlots_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAssignmentRationales provides a mock function for the type MockPRStorage
+func (_mock *MockPRStorage) GetAssignmentRationales(ctx context.Context, repository string, prID string) ([]domain.AssignmentRationale, error) {
+	ret := _mock.Called(ctx, repository, prID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAssignmentRationales")
+	}
+
+	var r0 []domain.AssignmentRationale
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) ([]domain.AssignmentRationale, error)); ok {
+		return returnFunc(ctx, repository, prID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) []domain.AssignmentRationale); ok {
+		r0 = returnFunc(ctx, repository, prID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.AssignmentRationale)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = returnFunc(ctx, repository, prID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockPRStorage_GetAssignmentRationales_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAssignmentRationales'
+type MockPRStorage_GetAssignmentRationales_Call struct {
+	*mock.Call
+}
+
+// GetAssignmentRationales is a helper method to define mock.On call
+//   - ctx context.Context
+//   - repository string
+//   - prID string
+func (_e *MockPRStorage_Expecter) GetAssignmentRationales(ctx interface{}, repository interface{}, prID interface{}) *MockPRStorage_GetAssignmentRationales_Call {
+	return &MockPRStorage_GetAssignmentRationales_Call{Call: _e.mock.On("GetAssignmentRationales", ctx, repository, prID)}
+}
+
+func (_c *MockPRStorage_GetAssignmentRationales_Call) Run(run func(ctx context.Context, repository string, prID string)) *MockPRStorage_GetAssignmentRationales_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockPRStorage_GetAssignmentRationales_Call) Return(assignmentRationales []domain.AssignmentRationale, err error) *MockPRStorage_GetAssignmentRationales_Call {
+	_c.Call.Return(assignmentRationales, err)
+	return _c
+}
+
+func (_c *MockPRStorage_GetAssignmentRationales_Call) RunAndReturn(run func(ctx context.Context, repository string, prID string) ([]domain.AssignmentRationale, error)) *MockPRStorage_GetAssignmentRationales_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetPRAuthorID provides a mock function for the type MockPRStorage
-func (_mock *MockPRStorage) GetPRAuthorID(ctx context.Context, prID string) (string, error) {
-	ret := _mock.Called(ctx, prID)
+// GetPR provides a mock function for the type MockPRStorage
+func (_mock *MockPRStorage) GetPR(ctx context.Context, repository string, prID string) (*domain.PullRequest, error) {
+	ret := _mock.Called(ctx, repository, prID)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetPRAuthorID")
+		panic("no return value specified for GetPR")
 	}
 
-	var r0 string
+	var r0 *domain.PullRequest
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (string, error)); ok {
-		return returnFunc(ctx, prID)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) (*domain.PullRequest, error)); ok {
+		return returnFunc(ctx, repository, prID)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) string); ok {
-		r0 = returnFunc(ctx, prID)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) *domain.PullRequest); ok {
+		r0 = returnFunc(ctx, repository, prID)
 	} else {
-		r0 = ret.Get(0).(string)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.PullRequest)
+		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = returnFunc(ctx, prID)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = returnFunc(ctx, repository, prID)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockPRStorage_GetPRAuthorID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPRAuthorID'
-type MockPRStorage_GetPRAuthorID_Call struct {
+// MockPRStorage_GetPR_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPR'
+type MockPRStorage_GetPR_Call struct {
 	*mock.Call
 }
 
-// GetPRAuthorID is a helper method to define mock.On call
+// GetPR is a helper method to define mock.On call
 //   - ctx context.Context
+//   - repository string
 //   - prID string
-func (_e *MockPRStorage_Expecter) GetPRAuthorID(ctx interface{}, prID interface{}) *MockPRStorage_GetPRAuthorID_Call {
-	return &MockPRStorage_GetPRAuthorID_Call{Call: _e.mock.On("GetPRAuthorID", ctx, prID)}
+func (_e *MockPRStorage_Expecter) GetPR(ctx interface{}, repository interface{}, prID interface{}) *MockPRStorage_GetPR_Call {
+	return &MockPRStorage_GetPR_Call{Call: _e.mock.On("GetPR", ctx, repository, prID)}
 }
 
-func (_c *MockPRStorage_GetPRAuthorID_Call) Run(run func(ctx context.Context, prID string)) *MockPRStorage_GetPRAuthorID_Call {
+func (_c *MockPRStorage_GetPR_Call) Run(run func(ctx context.Context, repository string, prID string)) *MockPRStorage_GetPR_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -218,27 +296,299 @@ func (_c *MockPRStorage_GetPRAuthorID_Call) Run(run func(ctx context.Context, pr
 		if args[1] != nil {
 			arg1 = args[1].(string)
 		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
 		run(
 			arg0,
 			arg1,
+			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *MockPRStorage_GetPRAuthorID_Call) Return(s string, err error) *MockPRStorage_GetPRAuthorID_Call {
-	_c.Call.Return(s, err)
+func (_c *MockPRStorage_GetPR_Call) Return(pullRequest *domain.PullRequest, err error) *MockPRStorage_GetPR_Call {
+	_c.Call.Return(pullRequest, err)
 	return _c
 }
 
-func (_c *MockPRStorage_GetPRAuthorID_Call) RunAndReturn(run func(ctx context.Context, prID string) (string, error)) *MockPRStorage_GetPRAuthorID_Call {
+func (_c *MockPRStorage_GetPR_Call) RunAndReturn(run func(ctx context.Context, repository string, prID string) (*domain.PullRequest, error)) *MockPRStorage_GetPR_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetPairings provides a mock function for the type MockPRStorage
+func (_mock *MockPRStorage) GetPairings(ctx context.Context, authorIDs []string, window time.Duration) ([]domain.Pairing, error) {
+	ret := _mock.Called(ctx, authorIDs, window)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPairings")
+	}
+
+	var r0 []domain.Pairing
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []string, time.Duration) ([]domain.Pairing, error)); ok {
+		return returnFunc(ctx, authorIDs, window)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []string, time.Duration) []domain.Pairing); ok {
+		r0 = returnFunc(ctx, authorIDs, window)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Pairing)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, []string, time.Duration) error); ok {
+		r1 = returnFunc(ctx, authorIDs, window)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockPRStorage_GetPairings_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPairings'
+type MockPRStorage_GetPairings_Call struct {
+	*mock.Call
+}
+
+// GetPairings is a helper method to define mock.On call
+//   - ctx context.Context
+//   - authorIDs []string
+//   - window time.Duration
+func (_e *MockPRStorage_Expecter) GetPairings(ctx interface{}, authorIDs interface{}, window interface{}) *MockPRStorage_GetPairings_Call {
+	return &MockPRStorage_GetPairings_Call{Call: _e.mock.On("GetPairings", ctx, authorIDs, window)}
+}
+
+func (_c *MockPRStorage_GetPairings_Call) Run(run func(ctx context.Context, authorIDs []string, window time.Duration)) *MockPRStorage_GetPairings_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 []string
+		if args[1] != nil {
+			arg1 = args[1].([]string)
+		}
+		var arg2 time.Duration
+		if args[2] != nil {
+			arg2 = args[2].(time.Duration)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockPRStorage_GetPairings_Call) Return(pairings []domain.Pairing, err error) *MockPRStorage_GetPairings_Call {
+	_c.Call.Return(pairings, err)
+	return _c
+}
+
+func (_c *MockPRStorage_GetPairings_Call) RunAndReturn(run func(ctx context.Context, authorIDs []string, window time.Duration) ([]domain.Pairing, error)) *MockPRStorage_GetPairings_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetPendingSlots provides a mock function for the type MockPRStorage
+func (_mock *MockPRStorage) GetPendingSlots(ctx context.Context) ([]domain.PendingSlots, error) {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPendingSlots")
+	}
+
+	var r0 []domain.PendingSlots
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) ([]domain.PendingSlots, error)); ok {
+		return returnFunc(ctx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) []domain.PendingSlots); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.PendingSlots)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockPRStorage_GetPendingSlots_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPendingSlots'
+type MockPRStorage_GetPendingSlots_Call struct {
+	*mock.Call
+}
+
+// GetPendingSlots is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockPRStorage_Expecter) GetPendingSlots(ctx interface{}) *MockPRStorage_GetPendingSlots_Call {
+	return &MockPRStorage_GetPendingSlots_Call{Call: _e.mock.On("GetPendingSlots", ctx)}
+}
+
+func (_c *MockPRStorage_GetPendingSlots_Call) Run(run func(ctx context.Context)) *MockPRStorage_GetPendingSlots_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockPRStorage_GetPendingSlots_Call) Return(pendingSlotss []domain.PendingSlots, err error) *MockPRStorage_GetPendingSlots_Call {
+	_c.Call.Return(pendingSlotss, err)
+	return _c
+}
+
+func (_c *MockPRStorage_GetPendingSlots_Call) RunAndReturn(run func(ctx context.Context) ([]domain.PendingSlots, error)) *MockPRStorage_GetPendingSlots_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetRequiredTags provides a mock function for the type MockPRStorage
+func (_mock *MockPRStorage) GetRequiredTags(ctx context.Context, repository string, prID string) ([]string, error) {
+	ret := _mock.Called(ctx, repository, prID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRequiredTags")
+	}
+
+	var r0 []string
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) ([]string, error)); ok {
+		return returnFunc(ctx, repository, prID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) []string); ok {
+		r0 = returnFunc(ctx, repository, prID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = returnFunc(ctx, repository, prID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockPRStorage_GetRequiredTags_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetRequiredTags'
+type MockPRStorage_GetRequiredTags_Call struct {
+	*mock.Call
+}
+
+// GetRequiredTags is a helper method to define mock.On call
+//   - ctx context.Context
+//   - repository string
+//   - prID string
+func (_e *MockPRStorage_Expecter) GetRequiredTags(ctx interface{}, repository interface{}, prID interface{}) *MockPRStorage_GetRequiredTags_Call {
+	return &MockPRStorage_GetRequiredTags_Call{Call: _e.mock.On("GetRequiredTags", ctx, repository, prID)}
+}
+
+func (_c *MockPRStorage_GetRequiredTags_Call) Run(run func(ctx context.Context, repository string, prID string)) *MockPRStorage_GetRequiredTags_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockPRStorage_GetRequiredTags_Call) Return(strings []string, err error) *MockPRStorage_GetRequiredTags_Call {
+	_c.Call.Return(strings, err)
+	return _c
+}
+
+func (_c *MockPRStorage_GetRequiredTags_Call) RunAndReturn(run func(ctx context.Context, repository string, prID string) ([]string, error)) *MockPRStorage_GetRequiredTags_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ImportPRs provides a mock function for the type MockPRStorage
+func (_mock *MockPRStorage) ImportPRs(ctx context.Context, prs []domain.PRImport) error {
+	ret := _mock.Called(ctx, prs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ImportPRs")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []domain.PRImport) error); ok {
+		r0 = returnFunc(ctx, prs)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockPRStorage_ImportPRs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ImportPRs'
+type MockPRStorage_ImportPRs_Call struct {
+	*mock.Call
+}
+
+// ImportPRs is a helper method to define mock.On call
+//   - ctx context.Context
+//   - prs []domain.PRImport
+func (_e *MockPRStorage_Expecter) ImportPRs(ctx interface{}, prs interface{}) *MockPRStorage_ImportPRs_Call {
+	return &MockPRStorage_ImportPRs_Call{Call: _e.mock.On("ImportPRs", ctx, prs)}
+}
+
+func (_c *MockPRStorage_ImportPRs_Call) Run(run func(ctx context.Context, prs []domain.PRImport)) *MockPRStorage_ImportPRs_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 []domain.PRImport
+		if args[1] != nil {
+			arg1 = args[1].([]domain.PRImport)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockPRStorage_ImportPRs_Call) Return(err error) *MockPRStorage_ImportPRs_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockPRStorage_ImportPRs_Call) RunAndReturn(run func(ctx context.Context, prs []domain.PRImport) error) *MockPRStorage_ImportPRs_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
 // ReassignReviewer provides a mock function for the type MockPRStorage
-func (_mock *MockPRStorage) ReassignReviewer(ctx context.Context, prID string, oldReviewerID string, newReviewerID string) (*domain.PullRequest, error) {
-	ret := _mock.Called(ctx, prID, oldReviewerID, newReviewerID)
+func (_mock *MockPRStorage) ReassignReviewer(ctx context.Context, repository string, prID string, reassignment domain.Reassignment, dryRun bool) (*domain.PullRequest, error) {
+	ret := _mock.Called(ctx, repository, prID, reassignment, dryRun)
 
 	if len(ret) == 0 {
 		panic("no return value specified for ReassignReviewer")
@@ -246,18 +596,18 @@ func (_mock *MockPRStorage) ReassignReviewer(ctx context.Context, prID string, o
 
 	var r0 *domain.PullRequest
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string) (*domain.PullRequest, error)); ok {
-		return returnFunc(ctx, prID, oldReviewerID, newReviewerID)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, domain.Reassignment, bool) (*domain.PullRequest, error)); ok {
+		return returnFunc(ctx, repository, prID, reassignment, dryRun)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string) *domain.PullRequest); ok {
-		r0 = returnFunc(ctx, prID, oldReviewerID, newReviewerID)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, domain.Reassignment, bool) *domain.PullRequest); ok {
+		r0 = returnFunc(ctx, repository, prID, reassignment, dryRun)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*domain.PullRequest)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string, string) error); ok {
-		r1 = returnFunc(ctx, prID, oldReviewerID, newReviewerID)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string, domain.Reassignment, bool) error); ok {
+		r1 = returnFunc(ctx, repository, prID, reassignment, dryRun)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -271,14 +621,15 @@ type MockPRStorage_ReassignReviewer_Call struct {
 
 // ReassignReviewer is a helper method to define mock.On call
 //   - ctx context.Context
+//   - repository string
 //   - prID string
-//   - oldReviewerID string
-//   - newReviewerID string
-func (_e *MockPRStorage_Expecter) ReassignReviewer(ctx interface{}, prID interface{}, oldReviewerID interface{}, newReviewerID interface{}) *MockPRStorage_ReassignReviewer_Call {
-	return &MockPRStorage_ReassignReviewer_Call{Call: _e.mock.On("ReassignReviewer", ctx, prID, oldReviewerID, newReviewerID)}
+//   - reassignment domain.Reassignment
+//   - dryRun bool
+func (_e *MockPRStorage_Expecter) ReassignReviewer(ctx interface{}, repository interface{}, prID interface{}, reassignment interface{}, dryRun interface{}) *MockPRStorage_ReassignReviewer_Call {
+	return &MockPRStorage_ReassignReviewer_Call{Call: _e.mock.On("ReassignReviewer", ctx, repository, prID, reassignment, dryRun)}
 }
 
-func (_c *MockPRStorage_ReassignReviewer_Call) Run(run func(ctx context.Context, prID string, oldReviewerID string, newReviewerID string)) *MockPRStorage_ReassignReviewer_Call {
+func (_c *MockPRStorage_ReassignReviewer_Call) Run(run func(ctx context.Context, repository string, prID string, reassignment domain.Reassignment, dryRun bool)) *MockPRStorage_ReassignReviewer_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -292,15 +643,20 @@ func (_c *MockPRStorage_ReassignReviewer_Call) Run(run func(ctx context.Context,
 		if args[2] != nil {
 			arg2 = args[2].(string)
 		}
-		var arg3 string
+		var arg3 domain.Reassignment
 		if args[3] != nil {
-			arg3 = args[3].(string)
+			arg3 = args[3].(domain.Reassignment)
+		}
+		var arg4 bool
+		if args[4] != nil {
+			arg4 = args[4].(bool)
 		}
 		run(
 			arg0,
 			arg1,
 			arg2,
 			arg3,
+			arg4,
 		)
 	})
 	return _c
@@ -311,14 +667,83 @@ func (_c *MockPRStorage_ReassignReviewer_Call) Return(pullRequest *domain.PullRe
 	return _c
 }
 
-func (_c *MockPRStorage_ReassignReviewer_Call) RunAndReturn(run func(ctx context.Context, prID string, oldReviewerID string, newReviewerID string) (*domain.PullRequest, error)) *MockPRStorage_ReassignReviewer_Call {
+func (_c *MockPRStorage_ReassignReviewer_Call) RunAndReturn(run func(ctx context.Context, repository string, prID string, reassignment domain.Reassignment, dryRun bool) (*domain.PullRequest, error)) *MockPRStorage_ReassignReviewer_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SaveAssignmentRationales provides a mock function for the type MockPRStorage
+func (_mock *MockPRStorage) SaveAssignmentRationales(ctx context.Context, repository string, prID string, rationales []domain.AssignmentRationale) error {
+	ret := _mock.Called(ctx, repository, prID, rationales)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveAssignmentRationales")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, []domain.AssignmentRationale) error); ok {
+		r0 = returnFunc(ctx, repository, prID, rationales)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockPRStorage_SaveAssignmentRationales_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SaveAssignmentRationales'
+type MockPRStorage_SaveAssignmentRationales_Call struct {
+	*mock.Call
+}
+
+// SaveAssignmentRationales is a helper method to define mock.On call
+//   - ctx context.Context
+//   - repository string
+//   - prID string
+//   - rationales []domain.AssignmentRationale
+func (_e *MockPRStorage_Expecter) SaveAssignmentRationales(ctx interface{}, repository interface{}, prID interface{}, rationales interface{}) *MockPRStorage_SaveAssignmentRationales_Call {
+	return &MockPRStorage_SaveAssignmentRationales_Call{Call: _e.mock.On("SaveAssignmentRationales", ctx, repository, prID, rationales)}
+}
+
+func (_c *MockPRStorage_SaveAssignmentRationales_Call) Run(run func(ctx context.Context, repository string, prID string, rationales []domain.AssignmentRationale)) *MockPRStorage_SaveAssignmentRationales_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 []domain.AssignmentRationale
+		if args[3] != nil {
+			arg3 = args[3].([]domain.AssignmentRationale)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockPRStorage_SaveAssignmentRationales_Call) Return(err error) *MockPRStorage_SaveAssignmentRationales_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockPRStorage_SaveAssignmentRationales_Call) RunAndReturn(run func(ctx context.Context, repository string, prID string, rationales []domain.AssignmentRationale) error) *MockPRStorage_SaveAssignmentRationales_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
 // SetStatusMerged provides a mock function for the type MockPRStorage
-func (_mock *MockPRStorage) SetStatusMerged(ctx context.Context, prID string) (*domain.PullRequest, error) {
-	ret := _mock.Called(ctx, prID)
+func (_mock *MockPRStorage) SetStatusMerged(ctx context.Context, repository string, prID string) (*domain.PullRequest, error) {
+	ret := _mock.Called(ctx, repository, prID)
 
 	if len(ret) == 0 {
 		panic("no return value specified for SetStatusMerged")
@@ -326,18 +751,18 @@ func (_mock *MockPRStorage) SetStatusMerged(ctx context.Context, prID string) (*
 
 	var r0 *domain.PullRequest
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*domain.PullRequest, error)); ok {
-		return returnFunc(ctx, prID)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) (*domain.PullRequest, error)); ok {
+		return returnFunc(ctx, repository, prID)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *domain.PullRequest); ok {
-		r0 = returnFunc(ctx, prID)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) *domain.PullRequest); ok {
+		r0 = returnFunc(ctx, repository, prID)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*domain.PullRequest)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = returnFunc(ctx, prID)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = returnFunc(ctx, repository, prID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -351,12 +776,13 @@ type MockPRStorage_SetStatusMerged_Call struct {
 
 // SetStatusMerged is a helper method to define mock.On call
 //   - ctx context.Context
+//   - repository string
 //   - prID string
-func (_e *MockPRStorage_Expecter) SetStatusMerged(ctx interface{}, prID interface{}) *MockPRStorage_SetStatusMerged_Call {
-	return &MockPRStorage_SetStatusMerged_Call{Call: _e.mock.On("SetStatusMerged", ctx, prID)}
+func (_e *MockPRStorage_Expecter) SetStatusMerged(ctx interface{}, repository interface{}, prID interface{}) *MockPRStorage_SetStatusMerged_Call {
+	return &MockPRStorage_SetStatusMerged_Call{Call: _e.mock.On("SetStatusMerged", ctx, repository, prID)}
 }
 
-func (_c *MockPRStorage_SetStatusMerged_Call) Run(run func(ctx context.Context, prID string)) *MockPRStorage_SetStatusMerged_Call {
+func (_c *MockPRStorage_SetStatusMerged_Call) Run(run func(ctx context.Context, repository string, prID string)) *MockPRStorage_SetStatusMerged_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -366,9 +792,14 @@ func (_c *MockPRStorage_SetStatusMerged_Call) Run(run func(ctx context.Context,
 		if args[1] != nil {
 			arg1 = args[1].(string)
 		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
 		run(
 			arg0,
 			arg1,
+			arg2,
 		)
 	})
 	return _c
@@ -379,7 +810,7 @@ func (_c *MockPRStorage_SetStatusMerged_Call) Return(pullRequest *domain.PullReq
 	return _c
 }
 
-func (_c *MockPRStorage_SetStatusMerged_Call) RunAndReturn(run func(ctx context.Context, prID string) (*domain.PullRequest, error)) *MockPRStorage_SetStatusMerged_Call {
+func (_c *MockPRStorage_SetStatusMerged_Call) RunAndReturn(run func(ctx context.Context, repository string, prID string) (*domain.PullRequest, error)) *MockPRStorage_SetStatusMerged_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -8,6 +8,7 @@ import (
 	"context"
 
 	mock "github.com/stretchr/testify/mock"
+	"github.com/whitxowl/pr-reviewer-assignment-service.git/internal/domain"
 )
 
 // NewMockUserStorage creates a new instance of MockUserStorage. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
@@ -37,103 +38,167 @@ func (_m *MockUserStorage) EXPECT() *MockUserStorage_Expecter {
 	return &MockUserStorage_Expecter{mock: &_m.Mock}
 }
 
-// GetPotentialReviewersIDs provides a mock function for the type MockUserStorage
-func (_mock *MockUserStorage) GetPotentialReviewersIDs(ctx context.Context, authorID string, userID string, limit int) ([]string, error) {
-	ret := _mock.Called(ctx, authorID, userID, limit)
+// GetCodeOwnerPool provides a mock function for the type MockUserStorage
+func (_mock *MockUserStorage) GetCodeOwnerPool(ctx context.Context, userIDs []string, teamNames []string) ([]*domain.User, error) {
+	ret := _mock.Called(ctx, userIDs, teamNames)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetPotentialReviewersIDs")
+		panic("no return value specified for GetCodeOwnerPool")
 	}
 
-	var r0 []string
+	var r0 []*domain.User
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, int) ([]string, error)); ok {
-		return returnFunc(ctx, authorID, userID, limit)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []string, []string) ([]*domain.User, error)); ok {
+		return returnFunc(ctx, userIDs, teamNames)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, int) []string); ok {
-		r0 = returnFunc(ctx, authorID, userID, limit)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []string, []string) []*domain.User); ok {
+		r0 = returnFunc(ctx, userIDs, teamNames)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]string)
+			r0 = ret.Get(0).([]*domain.User)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string, int) error); ok {
-		r1 = returnFunc(ctx, authorID, userID, limit)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, []string, []string) error); ok {
+		r1 = returnFunc(ctx, userIDs, teamNames)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockUserStorage_GetPotentialReviewersIDs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPotentialReviewersIDs'
-type MockUserStorage_GetPotentialReviewersIDs_Call struct {
+// MockUserStorage_GetCodeOwnerPool_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetCodeOwnerPool'
+type MockUserStorage_GetCodeOwnerPool_Call struct {
 	*mock.Call
 }
 
-// GetPotentialReviewersIDs is a helper method to define mock.On call
+// GetCodeOwnerPool is a helper method to define mock.On call
 //   - ctx context.Context
-//   - authorID string
-//   - userID string
-//   - limit int
-func (_e *MockUserStorage_Expecter) GetPotentialReviewersIDs(ctx interface{}, authorID interface{}, userID interface{}, limit interface{}) *MockUserStorage_GetPotentialReviewersIDs_Call {
-	return &MockUserStorage_GetPotentialReviewersIDs_Call{Call: _e.mock.On("GetPotentialReviewersIDs", ctx, authorID, userID, limit)}
+//   - userIDs []string
+//   - teamNames []string
+func (_e *MockUserStorage_Expecter) GetCodeOwnerPool(ctx interface{}, userIDs interface{}, teamNames interface{}) *MockUserStorage_GetCodeOwnerPool_Call {
+	return &MockUserStorage_GetCodeOwnerPool_Call{Call: _e.mock.On("GetCodeOwnerPool", ctx, userIDs, teamNames)}
 }
 
-func (_c *MockUserStorage_GetPotentialReviewersIDs_Call) Run(run func(ctx context.Context, authorID string, userID string, limit int)) *MockUserStorage_GetPotentialReviewersIDs_Call {
+func (_c *MockUserStorage_GetCodeOwnerPool_Call) Run(run func(ctx context.Context, userIDs []string, teamNames []string)) *MockUserStorage_GetCodeOwnerPool_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 string
+		var arg1 []string
 		if args[1] != nil {
-			arg1 = args[1].(string)
+			arg1 = args[1].([]string)
 		}
-		var arg2 string
+		var arg2 []string
 		if args[2] != nil {
-			arg2 = args[2].(string)
-		}
-		var arg3 int
-		if args[3] != nil {
-			arg3 = args[3].(int)
+			arg2 = args[2].([]string)
 		}
 		run(
 			arg0,
 			arg1,
 			arg2,
-			arg3,
 		)
 	})
 	return _c
 }
 
-func (_c *MockUserStorage_GetPotentialReviewersIDs_Call) Return(strings []string, err error) *MockUserStorage_GetPotentialReviewersIDs_Call {
-	_c.Call.Return(strings, err)
+func (_c *MockUserStorage_GetCodeOwnerPool_Call) Return(users []*domain.User, err error) *MockUserStorage_GetCodeOwnerPool_Call {
+	_c.Call.Return(users, err)
 	return _c
 }
 
-func (_c *MockUserStorage_GetPotentialReviewersIDs_Call) RunAndReturn(run func(ctx context.Context, authorID string, userID string, limit int) ([]string, error)) *MockUserStorage_GetPotentialReviewersIDs_Call {
+func (_c *MockUserStorage_GetCodeOwnerPool_Call) RunAndReturn(run func(ctx context.Context, userIDs []string, teamNames []string) ([]*domain.User, error)) *MockUserStorage_GetCodeOwnerPool_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// UserExistsAndHasTeam provides a mock function for the type MockUserStorage
-func (_mock *MockUserStorage) UserExistsAndHasTeam(ctx context.Context, userID string) (bool, error) {
+// GetReviewerPool provides a mock function for the type MockUserStorage
+func (_mock *MockUserStorage) GetReviewerPool(ctx context.Context, teamName string) ([]*domain.User, error) {
+	ret := _mock.Called(ctx, teamName)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetReviewerPool")
+	}
+
+	var r0 []*domain.User
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) ([]*domain.User, error)); ok {
+		return returnFunc(ctx, teamName)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) []*domain.User); ok {
+		r0 = returnFunc(ctx, teamName)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.User)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, teamName)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockUserStorage_GetReviewerPool_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetReviewerPool'
+type MockUserStorage_GetReviewerPool_Call struct {
+	*mock.Call
+}
+
+// GetReviewerPool is a helper method to define mock.On call
+//   - ctx context.Context
+//   - teamName string
+func (_e *MockUserStorage_Expecter) GetReviewerPool(ctx interface{}, teamName interface{}) *MockUserStorage_GetReviewerPool_Call {
+	return &MockUserStorage_GetReviewerPool_Call{Call: _e.mock.On("GetReviewerPool", ctx, teamName)}
+}
+
+func (_c *MockUserStorage_GetReviewerPool_Call) Run(run func(ctx context.Context, teamName string)) *MockUserStorage_GetReviewerPool_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUserStorage_GetReviewerPool_Call) Return(users []*domain.User, err error) *MockUserStorage_GetReviewerPool_Call {
+	_c.Call.Return(users, err)
+	return _c
+}
+
+func (_c *MockUserStorage_GetReviewerPool_Call) RunAndReturn(run func(ctx context.Context, teamName string) ([]*domain.User, error)) *MockUserStorage_GetReviewerPool_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetUser provides a mock function for the type MockUserStorage
+func (_mock *MockUserStorage) GetUser(ctx context.Context, userID string) (*domain.User, error) {
 	ret := _mock.Called(ctx, userID)
 
 	if len(ret) == 0 {
-		panic("no return value specified for UserExistsAndHasTeam")
+		panic("no return value specified for GetUser")
 	}
 
-	var r0 bool
+	var r0 *domain.User
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (bool, error)); ok {
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*domain.User, error)); ok {
 		return returnFunc(ctx, userID)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) bool); ok {
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *domain.User); ok {
 		r0 = returnFunc(ctx, userID)
 	} else {
-		r0 = ret.Get(0).(bool)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.User)
+		}
 	}
 	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
 		r1 = returnFunc(ctx, userID)
@@ -143,19 +208,19 @@ func (_mock *MockUserStorage) UserExistsAndHasTeam(ctx context.Context, userID s
 	return r0, r1
 }
 
-// MockUserStorage_UserExistsAndHasTeam_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UserExistsAndHasTeam'
-type MockUserStorage_UserExistsAndHasTeam_Call struct {
+// MockUserStorage_GetUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUser'
+type MockUserStorage_GetUser_Call struct {
 	*mock.Call
 }
 
-// UserExistsAndHasTeam is a helper method to define mock.On call
+// GetUser is a helper method to define mock.On call
 //   - ctx context.Context
 //   - userID string
-func (_e *MockUserStorage_Expecter) UserExistsAndHasTeam(ctx interface{}, userID interface{}) *MockUserStorage_UserExistsAndHasTeam_Call {
-	return &MockUserStorage_UserExistsAndHasTeam_Call{Call: _e.mock.On("UserExistsAndHasTeam", ctx, userID)}
+func (_e *MockUserStorage_Expecter) GetUser(ctx interface{}, userID interface{}) *MockUserStorage_GetUser_Call {
+	return &MockUserStorage_GetUser_Call{Call: _e.mock.On("GetUser", ctx, userID)}
 }
 
-func (_c *MockUserStorage_UserExistsAndHasTeam_Call) Run(run func(ctx context.Context, userID string)) *MockUserStorage_UserExistsAndHasTeam_Call {
+func (_c *MockUserStorage_GetUser_Call) Run(run func(ctx context.Context, userID string)) *MockUserStorage_GetUser_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -173,12 +238,12 @@ func (_c *MockUserStorage_UserExistsAndHasTeam_Call) Run(run func(ctx context.Co
 	return _c
 }
 
-func (_c *MockUserStorage_UserExistsAndHasTeam_Call) Return(b bool, err error) *MockUserStorage_UserExistsAndHasTeam_Call {
-	_c.Call.Return(b, err)
+func (_c *MockUserStorage_GetUser_Call) Return(user *domain.User, err error) *MockUserStorage_GetUser_Call {
+	_c.Call.Return(user, err)
 	return _c
 }
 
-func (_c *MockUserStorage_UserExistsAndHasTeam_Call) RunAndReturn(run func(ctx context.Context, userID string) (bool, error)) *MockUserStorage_UserExistsAndHasTeam_Call {
+func (_c *MockUserStorage_GetUser_Call) RunAndReturn(run func(ctx context.Context, userID string) (*domain.User, error)) *MockUserStorage_GetUser_Call {
 	_c.Call.Return(run)
 	return _c
 }
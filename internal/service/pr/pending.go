@@ -0,0 +1,197 @@
+package pr
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+
+	"github.com/whitxowl/pr-reviewer-assignment-service.git/internal/domain"
+	storageErr "github.com/whitxowl/pr-reviewer-assignment-service.git/internal/storage/errors"
+)
+
+// GetPendingSlots returns the open PRs still missing reviewers, longest waiting first.
+func (s *Service) GetPendingSlots(ctx context.Context) ([]domain.PendingSlots, error) {
+	const op = "service.pr.GetPendingSlots"
+
+	log := s.log.With(slog.String("op", op))
+
+	pending, err := s.prStorage.GetPendingSlots(ctx)
+	if err != nil {
+		log.ErrorContext(ctx, "error getting pending reviewer slots", "error", err)
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return pending, nil
+}
+
+// FillPendingSlots assigns reviewers to the queued slots of open PRs, longest
+// waiting first, and returns how many were assigned. Reviewers are picked from
+// the PR's team as on creation, covering the PR's required tags and seniority
+// rules first; slots nobody can take yet stay queued. A PR that
+// fails is skipped so that it does not hold up the others.
+func (s *Service) FillPendingSlots(ctx context.Context) (int, error) {
+	const op = "service.pr.FillPendingSlots"
+
+	log := s.log.With(slog.String("op", op))
+
+	pending, err := s.prStorage.GetPendingSlots(ctx)
+	if err != nil {
+		log.ErrorContext(ctx, "error getting pending reviewer slots", "error", err)
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	filled := 0
+	var errs []error
+	for _, slots := range pending {
+		n, err := s.fillSlots(ctx, log, slots)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		filled += n
+	}
+
+	if filled > 0 {
+		log.InfoContext(ctx, "pending reviewer slots filled", "reviewers", filled)
+	}
+
+	if err := errors.Join(errs...); err != nil {
+		return filled, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return filled, nil
+}
+
+func (s *Service) fillSlots(ctx context.Context, log *slog.Logger, pending domain.PendingSlots) (int, error) {
+	repository, prID := pending.PR.Repository, pending.PR.PullRequestID
+
+	log = log.With(
+		slog.String("repository", repository),
+		slog.String("prID", prID),
+	)
+
+	if pending.PR.TeamName == "" {
+		// Nobody can ever take the slots, so they would stay queued forever.
+		if err := s.prStorage.DropReviewerSlots(ctx, repository, prID); err != nil {
+			log.ErrorContext(ctx, "error dropping reviewer slots", "error", err)
+			return 0, err
+		}
+		log.InfoContext(ctx, "pr team deleted, pending reviewer slots dropped", "slots", pending.Slots)
+		return 0, nil
+	}
+
+	pr, err := s.prStorage.GetPR(ctx, repository, prID)
+	if err != nil {
+		log.ErrorContext(ctx, "error getting pr", "error", err)
+		return 0, err
+	}
+
+	author, err := s.userStorage.GetUser(ctx, pr.AuthorID)
+	if err != nil {
+		log.ErrorContext(ctx, "error getting author", "error", err)
+		return 0, err
+	}
+
+	rules, err := s.seniorityRules(ctx, s.reads, pr.TeamName, author)
+	if err != nil {
+		log.ErrorContext(ctx, "error getting seniority rules", "error", err)
+		return 0, err
+	}
+
+	requiredTags, err := s.prStorage.GetRequiredTags(ctx, repository, prID)
+	if err != nil {
+		log.ErrorContext(ctx, "error getting required tags", "error", err)
+		return 0, err
+	}
+
+	pool, err := s.userStorage.GetReviewerPool(ctx, pr.TeamName)
+	if err != nil {
+		log.ErrorContext(ctx, "error finding potential reviewers", "error", err)
+		return 0, err
+	}
+	s.order(pr.Ref(), pool)
+
+	pairings, err := s.pairingCounts(ctx, s.reads, pr.AuthorID)
+	if err != nil {
+		log.ErrorContext(ctx, "error getting pairings", "error", err)
+		return 0, err
+	}
+
+	// Reviewers who are no longer members of the team have no tags or level.
+	var reviewers []*domain.User
+	for _, user := range pool {
+		if slices.Contains(pr.AssignedReviewers, user.UserID) {
+			reviewers = append(reviewers, user)
+		}
+	}
+
+	picks := &teamPicks{
+		authorID: pr.AuthorID,
+		pairings: pairings,
+		selected: slices.Clone(pr.AssignedReviewers),
+		picked:   reviewers,
+		limit:    pending.Slots,
+		result:   &selection{},
+	}
+	s.pickTags(picks, pool, requiredTags)
+	s.pickLevels(picks, pool, rules)
+
+	eligible, excluded := screen(pool, pr.AuthorID, "", picks.selected)
+	eligible = preferFresh(eligible, pairings)
+	eligible, excludedFor := s.preferInHours(pool, eligible, excluded)
+
+	pick := func(ids []string, strategy string, excludedFor func(string) []domain.ExcludedCandidate) {
+		for _, id := range ids[:picks.room(len(ids))] {
+			picks.result.rationales = append(picks.result.rationales, domain.AssignmentRationale{
+				ReviewerID: id,
+				Strategy:   strategy,
+				PoolSize:   len(pool),
+				Excluded:   excludedFor(id),
+			})
+		}
+	}
+
+	pick(eligible, domain.StrategyRandom, excludedFor)
+	if s.opts.CapacityMode == domain.CapacityAssign {
+		pick(overCapacity(pool, excluded), domain.StrategyOverCapacity, func(string) []domain.ExcludedCandidate { return excluded })
+	}
+	rationales := picks.result.rationales
+
+	if len(rationales) == 0 {
+		return 0, nil
+	}
+
+	reviewerIDs := make([]string, len(rationales))
+	for i, rationale := range rationales {
+		reviewerIDs[i] = rationale.ReviewerID
+	}
+
+	err = s.prStorage.FillReviewerSlots(ctx, repository, prID, reviewerIDs)
+	if errors.Is(err, storageErr.ErrPRMerged) || errors.Is(err, storageErr.ErrPRNotFound) {
+		log.DebugContext(ctx, "pr merged before its slots were filled", "error", err)
+		return 0, nil
+	}
+	if errors.Is(err, storageErr.ErrReviewerAssigned) {
+		// A reviewer was added by hand meanwhile; the next pass picks again.
+		log.DebugContext(ctx, "reviewer assigned in the meantime", "error", err)
+		return 0, nil
+	}
+	if err != nil {
+		log.ErrorContext(ctx, "error filling reviewer slots", "error", err)
+		return 0, err
+	}
+
+	err = s.prStorage.SaveAssignmentRationales(ctx, repository, prID, rationales)
+	if err != nil {
+		log.ErrorContext(ctx, "error saving assignment rationales", "error", err)
+		return 0, err
+	}
+
+	for _, rationale := range rationales {
+		audit(ctx, log, rationale)
+	}
+
+	return len(rationales), nil
+}
@@ -0,0 +1,232 @@
+package pr
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/whitxowl/pr-reviewer-assignment-service.git/internal/domain"
+	"github.com/whitxowl/pr-reviewer-assignment-service.git/internal/service/pr/mocks"
+	storageErr "github.com/whitxowl/pr-reviewer-assignment-service.git/internal/storage/errors"
+)
+
+func TestService_FillPendingSlots(t *testing.T) {
+	ctx := context.Background()
+	log := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
+
+	limit := 1
+	author := &domain.User{UserID: "u1", TeamName: "backend", IsActive: true}
+	pending := func(prID string, teamName string, slots int) domain.PendingSlots {
+		return domain.PendingSlots{
+			PR:    domain.PullRequest{PullRequestID: prID, AuthorID: "u1", TeamName: teamName, Status: "OPEN"},
+			Slots: slots,
+		}
+	}
+	openPR := func(prID string, reviewers ...string) *domain.PullRequest {
+		return &domain.PullRequest{
+			PullRequestID:     prID,
+			AuthorID:          "u1",
+			TeamName:          "backend",
+			Status:            "OPEN",
+			AssignedReviewers: reviewers,
+		}
+	}
+
+	tests := []struct {
+		name           string
+		capacityMode   string
+		setupMocks     func(*mocks.MockUserStorage, *mocks.MockTeamStorage, *mocks.MockPRStorage)
+		expectedFilled int
+		expectedError  error
+	}{
+		{
+			name:         "success - slots filled from the PR's team",
+			capacityMode: domain.CapacitySkip,
+			setupMocks: func(userStorage *mocks.MockUserStorage, _ *mocks.MockTeamStorage, prStorage *mocks.MockPRStorage) {
+				prStorage.EXPECT().
+					GetPendingSlots(ctx).
+					Return([]domain.PendingSlots{pending("pr-1", "backend", 2)}, nil).
+					Once()
+				prStorage.EXPECT().GetPR(ctx, "", "pr-1").Return(openPR("pr-1", "u11"), nil).Once()
+				userStorage.EXPECT().GetUser(ctx, "u1").Return(author, nil).Once()
+				prStorage.EXPECT().GetRequiredTags(ctx, "", "pr-1").Return(nil, nil).Once()
+				userStorage.EXPECT().
+					GetReviewerPool(ctx, "backend").
+					Return(activeUsers("u1", "u11", "u12"), nil).
+					Once()
+
+				rationales := []domain.AssignmentRationale{{
+					ReviewerID: "u12",
+					Strategy:   domain.StrategyRandom,
+					PoolSize:   3,
+					Excluded: []domain.ExcludedCandidate{
+						{UserID: "u1", Reason: domain.ExclusionAuthor},
+						{UserID: "u11", Reason: domain.ExclusionAssigned},
+					},
+				}}
+				prStorage.EXPECT().FillReviewerSlots(ctx, "", "pr-1", []string{"u12"}).Return(nil).Once()
+				prStorage.EXPECT().SaveAssignmentRationales(ctx, "", "pr-1", rationales).Return(nil).Once()
+			},
+			expectedFilled: 1,
+		},
+		{
+			name:         "success - required tags and seniority rules are covered first",
+			capacityMode: domain.CapacitySkip,
+			setupMocks: func(userStorage *mocks.MockUserStorage, teamStorage *mocks.MockTeamStorage, prStorage *mocks.MockPRStorage) {
+				junior := &domain.User{UserID: "u1", TeamName: "backend", IsActive: true, Level: domain.LevelJunior}
+				rule := domain.SeniorityRule{AuthorLevel: domain.LevelJunior, MinLevel: domain.LevelSenior, MinReviewers: 1}
+
+				prStorage.EXPECT().
+					GetPendingSlots(ctx).
+					Return([]domain.PendingSlots{pending("pr-1", "backend", 2)}, nil).
+					Once()
+				prStorage.EXPECT().GetPR(ctx, "", "pr-1").Return(openPR("pr-1", "u11"), nil).Once()
+				userStorage.EXPECT().GetUser(ctx, "u1").Return(junior, nil).Once()
+				teamStorage.EXPECT().GetSeniorityRules(ctx, "backend").Return([]domain.SeniorityRule{rule}, nil).Once()
+				prStorage.EXPECT().GetRequiredTags(ctx, "", "pr-1").Return([]string{"go"}, nil).Once()
+				userStorage.EXPECT().
+					GetReviewerPool(ctx, "backend").
+					Return([]*domain.User{
+						junior,
+						{UserID: "u11", IsActive: true, Level: domain.LevelJunior},
+						{UserID: "u12", IsActive: true},
+						{UserID: "u13", IsActive: true, Tags: []string{"go"}},
+						{UserID: "u14", IsActive: true, Level: domain.LevelSenior},
+					}, nil).
+					Once()
+
+				rationales := []domain.AssignmentRationale{
+					{ReviewerID: "u13", Strategy: domain.StrategySkill, Rule: "go", PoolSize: 1},
+					{ReviewerID: "u14", Strategy: domain.StrategySeniority, Rule: domain.LevelSenior, PoolSize: 1},
+				}
+				prStorage.EXPECT().FillReviewerSlots(ctx, "", "pr-1", []string{"u13", "u14"}).Return(nil).Once()
+				prStorage.EXPECT().SaveAssignmentRationales(ctx, "", "pr-1", rationales).Return(nil).Once()
+			},
+			expectedFilled: 2,
+		},
+		{
+			name:         "success - nobody available yet",
+			capacityMode: domain.CapacitySkip,
+			setupMocks: func(userStorage *mocks.MockUserStorage, _ *mocks.MockTeamStorage, prStorage *mocks.MockPRStorage) {
+				prStorage.EXPECT().
+					GetPendingSlots(ctx).
+					Return([]domain.PendingSlots{pending("pr-1", "backend", 1)}, nil).
+					Once()
+				prStorage.EXPECT().GetPR(ctx, "", "pr-1").Return(openPR("pr-1"), nil).Once()
+				userStorage.EXPECT().GetUser(ctx, "u1").Return(author, nil).Once()
+				prStorage.EXPECT().GetRequiredTags(ctx, "", "pr-1").Return(nil, nil).Once()
+				userStorage.EXPECT().
+					GetReviewerPool(ctx, "backend").
+					Return([]*domain.User{
+						{UserID: "u1", IsActive: true},
+						{UserID: "u11"},
+						{UserID: "u12", IsActive: true, OpenReviews: 1, ReviewLimit: &limit},
+					}, nil).
+					Once()
+			},
+		},
+		{
+			name:         "success - users at capacity assigned anyway",
+			capacityMode: domain.CapacityAssign,
+			setupMocks: func(userStorage *mocks.MockUserStorage, _ *mocks.MockTeamStorage, prStorage *mocks.MockPRStorage) {
+				prStorage.EXPECT().
+					GetPendingSlots(ctx).
+					Return([]domain.PendingSlots{pending("pr-1", "backend", 1)}, nil).
+					Once()
+				prStorage.EXPECT().GetPR(ctx, "", "pr-1").Return(openPR("pr-1"), nil).Once()
+				userStorage.EXPECT().GetUser(ctx, "u1").Return(author, nil).Once()
+				prStorage.EXPECT().GetRequiredTags(ctx, "", "pr-1").Return(nil, nil).Once()
+				userStorage.EXPECT().
+					GetReviewerPool(ctx, "backend").
+					Return([]*domain.User{{UserID: "u12", IsActive: true, OpenReviews: 1, ReviewLimit: &limit}}, nil).
+					Once()
+
+				rationales := []domain.AssignmentRationale{{
+					ReviewerID: "u12",
+					Strategy:   domain.StrategyOverCapacity,
+					PoolSize:   1,
+					Excluded:   []domain.ExcludedCandidate{{UserID: "u12", Reason: domain.ExclusionCapacity}},
+				}}
+				prStorage.EXPECT().FillReviewerSlots(ctx, "", "pr-1", []string{"u12"}).Return(nil).Once()
+				prStorage.EXPECT().SaveAssignmentRationales(ctx, "", "pr-1", rationales).Return(nil).Once()
+			},
+			expectedFilled: 1,
+		},
+		{
+			name:         "success - slots of deleted teams dropped, PRs merged meanwhile skipped",
+			capacityMode: domain.CapacitySkip,
+			setupMocks: func(userStorage *mocks.MockUserStorage, _ *mocks.MockTeamStorage, prStorage *mocks.MockPRStorage) {
+				prStorage.EXPECT().
+					GetPendingSlots(ctx).
+					Return([]domain.PendingSlots{pending("pr-1", "", 1), pending("pr-2", "backend", 1)}, nil).
+					Once()
+				prStorage.EXPECT().DropReviewerSlots(ctx, "", "pr-1").Return(nil).Once()
+				prStorage.EXPECT().GetPR(ctx, "", "pr-2").Return(openPR("pr-2"), nil).Once()
+				userStorage.EXPECT().GetUser(ctx, "u1").Return(author, nil).Once()
+				prStorage.EXPECT().GetRequiredTags(ctx, "", "pr-2").Return(nil, nil).Once()
+				userStorage.EXPECT().GetReviewerPool(ctx, "backend").Return(activeUsers("u11"), nil).Once()
+				prStorage.EXPECT().
+					FillReviewerSlots(ctx, "", "pr-2", []string{"u11"}).
+					Return(storageErr.ErrPRMerged).
+					Once()
+			},
+		},
+		{
+			name:         "error - a failing PR does not hold up the others",
+			capacityMode: domain.CapacitySkip,
+			setupMocks: func(userStorage *mocks.MockUserStorage, _ *mocks.MockTeamStorage, prStorage *mocks.MockPRStorage) {
+				prStorage.EXPECT().
+					GetPendingSlots(ctx).
+					Return([]domain.PendingSlots{pending("pr-1", "backend", 1), pending("pr-2", "backend", 1)}, nil).
+					Once()
+				prStorage.EXPECT().GetPR(ctx, "", "pr-1").Return(nil, errors.New("query error")).Once()
+				prStorage.EXPECT().GetPR(ctx, "", "pr-2").Return(openPR("pr-2"), nil).Once()
+				userStorage.EXPECT().GetUser(ctx, "u1").Return(author, nil).Once()
+				prStorage.EXPECT().GetRequiredTags(ctx, "", "pr-2").Return(nil, nil).Once()
+				userStorage.EXPECT().GetReviewerPool(ctx, "backend").Return(activeUsers("u11"), nil).Once()
+				prStorage.EXPECT().FillReviewerSlots(ctx, "", "pr-2", []string{"u11"}).Return(nil).Once()
+				prStorage.EXPECT().
+					SaveAssignmentRationales(ctx, "", "pr-2", randomRationales("u11")).
+					Return(nil).
+					Once()
+			},
+			expectedFilled: 1,
+			expectedError:  errors.New("service.pr.FillPendingSlots: query error"),
+		},
+		{
+			name:         "error - get pending slots fails",
+			capacityMode: domain.CapacitySkip,
+			setupMocks: func(_ *mocks.MockUserStorage, _ *mocks.MockTeamStorage, prStorage *mocks.MockPRStorage) {
+				prStorage.EXPECT().GetPendingSlots(ctx).Return(nil, errors.New("query error")).Once()
+			},
+			expectedError: errors.New("service.pr.FillPendingSlots: query error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			userStorage := mocks.NewMockUserStorage(t)
+			prStorage := mocks.NewMockPRStorage(t)
+			repositoryStorage := mocks.NewMockRepositoryStorage(t)
+			teamStorage := mocks.NewMockTeamStorage(t)
+			tt.setupMocks(userStorage, teamStorage, prStorage)
+
+			service := New(log, userStorage, teamStorage, prStorage, repositoryStorage, testIDs, Options{CapacityMode: tt.capacityMode})
+
+			// Act
+			filled, err := service.FillPendingSlots(ctx)
+
+			// Assert
+			if tt.expectedError != nil {
+				assert.EqualError(t, err, tt.expectedError.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.expectedFilled, filled)
+		})
+	}
+}
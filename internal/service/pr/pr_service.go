@@ -5,109 +5,389 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"math/rand/v2"
+	"slices"
+	"sync"
+	"time"
 
 	"github.com/whitxowl/pr-reviewer-assignment-service.git/internal/domain"
 	serviceErr "github.com/whitxowl/pr-reviewer-assignment-service.git/internal/service/errors"
+	"github.com/whitxowl/pr-reviewer-assignment-service.git/internal/service/validation"
 	storageErr "github.com/whitxowl/pr-reviewer-assignment-service.git/internal/storage/errors"
 )
 
 type UserStorage interface {
-	UserExistsAndHasTeam(ctx context.Context, userID string) (bool, error)
-	GetPotentialReviewersIDs(ctx context.Context, authorID string, userID string, limit int) ([]string, error)
+	GetUser(ctx context.Context, userID string) (*domain.User, error)
+	GetReviewerPool(ctx context.Context, teamName string) ([]*domain.User, error)
+	GetCodeOwnerPool(ctx context.Context, userIDs []string, teamNames []string) ([]*domain.User, error)
+}
+
+type TeamStorage interface {
+	GetSeniorityRules(ctx context.Context, teamName string) ([]domain.SeniorityRule, error)
 }
 
 type PRStorage interface {
-	CreatePR(ctx context.Context, prID string, prName string, authorID string) error
-	AssignReviewers(ctx context.Context, prID string, reviewersIDs []string) error
-	SetStatusMerged(ctx context.Context, prID string) (*domain.PullRequest, error)
-	GetPRAuthorID(ctx context.Context, prID string) (string, error)
-	ReassignReviewer(ctx context.Context, prID string, oldReviewerID string, newReviewerID string) (*domain.PullRequest, error)
+	SetStatusMerged(ctx context.Context, repository string, prID string) (*domain.PullRequest, error)
+	GetPR(ctx context.Context, repository string, prID string) (*domain.PullRequest, error)
+	ReassignReviewer(
+		ctx context.Context,
+		repository string,
+		prID string,
+		reassignment domain.Reassignment,
+		dryRun bool,
+	) (*domain.PullRequest, error)
+	SaveAssignmentRationales(ctx context.Context, repository string, prID string, rationales []domain.AssignmentRationale) error
+	GetAssignmentRationales(ctx context.Context, repository string, prID string) ([]domain.AssignmentRationale, error)
+	GetPendingSlots(ctx context.Context) ([]domain.PendingSlots, error)
+	FillReviewerSlots(ctx context.Context, repository string, prID string, reviewerIDs []string) error
+	DropReviewerSlots(ctx context.Context, repository string, prID string) error
+	GetRequiredTags(ctx context.Context, repository string, prID string) ([]string, error)
+	GetPairings(ctx context.Context, authorIDs []string, window time.Duration) ([]domain.Pairing, error)
+	ImportPRs(ctx context.Context, prs []domain.PRImport) error
+}
+
+type RepositoryStorage interface {
+	GetRepository(ctx context.Context, name string) (*domain.Repository, error)
+	GetCodeOwners(ctx context.Context, repository string) ([]domain.CodeOwnerRule, error)
+}
+
+// reads are the storage reads of resolving the team of a new PR and picking its
+// reviewers. A bulk
+// import serves them through importReads.
+type reads interface {
+	GetUser(ctx context.Context, userID string) (*domain.User, error)
+	GetRepository(ctx context.Context, name string) (*domain.Repository, error)
+	GetReviewerPool(ctx context.Context, teamName string) ([]*domain.User, error)
+	GetCodeOwnerPool(ctx context.Context, userIDs []string, teamNames []string) ([]*domain.User, error)
+	GetCodeOwners(ctx context.Context, repository string) ([]domain.CodeOwnerRule, error)
+	GetSeniorityRules(ctx context.Context, teamName string) ([]domain.SeniorityRule, error)
+	GetPairings(ctx context.Context, authorIDs []string, window time.Duration) ([]domain.Pairing, error)
+}
+
+// storageReads serves the reads from the storage.
+type storageReads struct {
+	UserStorage
+	TeamStorage
+	PRStorage
+	RepositoryStorage
+}
+
+// Waker is told when a reviewer may have become available for queued slots.
+type Waker interface {
+	Wake()
 }
 
 const (
-	statusOpen       = "OPEN"
-	firstAssignLimit = 2
-	reassignLimit    = 1
+	statusOpen = "OPEN"
 )
 
+// Options tune how reviewers are picked.
+type Options struct {
+	// CapacityMode is one of the domain.Capacity* modes.
+	CapacityMode string
+	// PreferWorkingHours picks reviewers inside their working hours before the others.
+	PreferWorkingHours bool
+	// PairingWindow is how far back reviews of the author's PRs count against
+	// picking the same reviewer again; zero picks regardless of pairings.
+	PairingWindow time.Duration
+	// Order is one of the domain.Order* modes; empty keeps candidates in the
+	// order of the storage, by user ID.
+	Order string
+	// RandSource shuffles candidates in the random order; a randomly seeded
+	// source when nil.
+	RandSource rand.Source
+}
+
 type Service struct {
-	log         *slog.Logger
-	userStorage UserStorage
-	prStorage   PRStorage
+	log               *slog.Logger
+	userStorage       UserStorage
+	teamStorage       TeamStorage
+	prStorage         PRStorage
+	repositoryStorage RepositoryStorage
+	reads             reads
+	ids               *validation.IDs
+	opts              Options
+	waker             Waker
+	now               func() time.Time
+
+	randMu sync.Mutex
+	rand   *rand.Rand
 }
 
-func New(log *slog.Logger, userStorage UserStorage, prStorage PRStorage) *Service {
+func New(
+	log *slog.Logger,
+	userStorage UserStorage,
+	teamStorage TeamStorage,
+	prStorage PRStorage,
+	repositoryStorage RepositoryStorage,
+	ids *validation.IDs,
+	opts Options,
+) *Service {
+	src := opts.RandSource
+	if src == nil {
+		src = rand.NewPCG(rand.Uint64(), rand.Uint64())
+	}
+
 	return &Service{
-		log:         log,
-		userStorage: userStorage,
-		prStorage:   prStorage}
+		log:               log,
+		userStorage:       userStorage,
+		teamStorage:       teamStorage,
+		prStorage:         prStorage,
+		repositoryStorage: repositoryStorage,
+		reads:             storageReads{userStorage, teamStorage, prStorage, repositoryStorage},
+		ids:               ids,
+		opts:              opts,
+		now:               time.Now,
+		rand:              rand.New(src),
+	}
 }
 
-func (s *Service) CreatePR(
-	ctx context.Context,
-	prID string,
-	prName string,
-	authorID string,
-) (*domain.PullRequest, error) {
+// SetClock sets the source of the current time, which decides who is inside
+// their working hours.
+func (s *Service) SetClock(now func() time.Time) {
+	s.now = now
+}
+
+// SetWaker sets the worker to wake when a reviewer may have become available.
+func (s *Service) SetWaker(waker Waker) {
+	s.waker = waker
+}
+
+func (s *Service) wake() {
+	if s.waker != nil {
+		s.waker.Wake()
+	}
+}
+
+// CreatePR creates the PR for one of the author's teams and assigns reviewers from it.
+// The team is the draft's if given, else the team owning the repository, else the
+// author's primary team. The repository also sets how many reviewers are assigned
+// and which code owners must review the changed files.
+func (s *Service) CreatePR(ctx context.Context, draft domain.PRDraft) (*domain.PullRequest, error) {
 	const op = "service.pr.CreatePR"
 
+	repository, prID, authorID, teamName := draft.Repository, draft.PullRequestID, draft.AuthorID, draft.TeamName
+
 	log := s.log.With(
 		slog.String("op", op),
+		slog.String("repository", repository),
 		slog.String("prID", prID),
-		slog.String("prName", prName),
+		slog.String("prName", draft.PullRequestName),
 		slog.String("authorID", authorID),
+		slog.String("teamName", teamName),
 	)
 
-	userCorrect, err := s.userStorage.UserExistsAndHasTeam(ctx, authorID)
+	if err := s.ids.PRID(prID); err != nil {
+		log.DebugContext(ctx, "invalid pr id", "error", err)
+		return nil, err
+	}
+
+	requiredTags, err := validation.Tags(draft.RequiredTags)
 	if err != nil {
-		log.ErrorContext(ctx, "error checking if user exists", "error", err)
+		log.DebugContext(ctx, "invalid required tag", "error", err)
+		return nil, err
+	}
+
+	repo, teamName, author, err := s.resolveTeam(ctx, log, op, s.reads, draft)
+	if err != nil {
+		return nil, err
+	}
+
+	selected, err := s.selectReviewers(ctx, s.reads, repo, draft.PullRequestID, teamName, author, draft.ChangedFiles, requiredTags)
+	if err != nil {
+		log.ErrorContext(ctx, "error selecting reviewers", "error", err)
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
-	if !userCorrect {
-		log.DebugContext(ctx, "author or team not found", "error", err)
-		return nil, serviceErr.ErrAuthorNotCorrect
+	rationales := selected.rationales
+
+	if len(selected.uncoveredTags) > 0 {
+		log.InfoContext(ctx, "no eligible reviewer for required tags", "tags", selected.uncoveredTags)
+	}
+	for _, violation := range selected.violations {
+		log.InfoContext(ctx, "seniority rule not satisfied",
+			slog.String("authorLevel", violation.Rule.AuthorLevel),
+			slog.String("minLevel", violation.Rule.MinLevel),
+			slog.Int("minReviewers", violation.Rule.MinReviewers),
+			slog.Int("assigned", violation.Assigned),
+		)
+	}
+
+	var reviewers []string
+	for _, rationale := range rationales {
+		reviewers = append(reviewers, rationale.ReviewerID)
 	}
 
-	err = s.prStorage.CreatePR(ctx, prID, prName, authorID)
+	pr := domain.PullRequest{
+		Repository:          repository,
+		PullRequestID:       prID,
+		PullRequestName:     draft.PullRequestName,
+		AuthorID:            authorID,
+		TeamName:            teamName,
+		Status:              statusOpen,
+		AssignedReviewers:   reviewers,
+		Rationales:          rationales,
+		SeniorityViolations: selected.violations,
+	}
+
+	// The PR is written with its reviewers, rationales, required tags and queued
+	// slots in one transaction, as a one-PR import. Reviewers picked later for
+	// queued slots cover the required tags too.
+	err = s.prStorage.ImportPRs(ctx, []domain.PRImport{{PR: pr, RequiredTags: requiredTags, Queued: selected.queued}})
 	if errors.Is(err, storageErr.ErrPRExists) {
 		log.DebugContext(ctx, "pr already exists", "error", err)
 		return nil, serviceErr.ErrPRExists
 	}
+	if errors.Is(err, storageErr.ErrUserNotFound) {
+		log.DebugContext(ctx, "author or reviewer deleted in the meantime", "error", err)
+		return nil, serviceErr.ErrUserNotFound
+	}
 	if err != nil {
 		log.ErrorContext(ctx, "error creating pr", "error", err)
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
-	reviewers, err := s.userStorage.GetPotentialReviewersIDs(ctx, authorID, authorID, firstAssignLimit)
+	for _, rationale := range rationales {
+		audit(ctx, log, rationale)
+	}
+
+	if selected.queued > 0 {
+		log.InfoContext(ctx, "reviewer slots queued for later assignment", "slots", selected.queued)
+	}
+
+	return &pr, nil
+}
+
+// resolveTeam returns the repository of draft, with the default settings if it
+// has none, the team its reviewers come from and its author.
+func (s *Service) resolveTeam(
+	ctx context.Context,
+	log *slog.Logger,
+	op string,
+	r reads,
+	draft domain.PRDraft,
+) (*domain.Repository, string, *domain.User, error) {
+	repo := &domain.Repository{ReviewersCount: domain.DefaultReviewersCount}
+	if draft.Repository != "" {
+		var err error
+		repo, err = r.GetRepository(ctx, draft.Repository)
+		if errors.Is(err, storageErr.ErrRepositoryNotFound) {
+			log.DebugContext(ctx, "repository not found", "error", err)
+			return nil, "", nil, serviceErr.ErrRepositoryNotFound
+		}
+		if err != nil {
+			log.ErrorContext(ctx, "error getting repository", "error", err)
+			return nil, "", nil, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	author, err := r.GetUser(ctx, draft.AuthorID)
+	if errors.Is(err, storageErr.ErrUserNotFound) {
+		log.DebugContext(ctx, "author not found", "error", err)
+		return nil, "", nil, serviceErr.ErrAuthorNotCorrect
+	}
 	if err != nil {
-		log.ErrorContext(ctx, "error finding potential reviewers", "error", err)
-		return nil, fmt.Errorf("%s: %w", op, err)
+		log.ErrorContext(ctx, "error getting author", "error", err)
+		return nil, "", nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	teamName := draft.TeamName
+	switch {
+	case teamName != "":
+		if !slices.Contains(author.TeamNames, teamName) {
+			log.DebugContext(ctx, "author is not a member of the team")
+			return nil, "", nil, serviceErr.ErrAuthorNotInTeam
+		}
+	case repo.TeamName != "":
+		teamName = repo.TeamName
+	default:
+		teamName = author.TeamName
+	}
+	if teamName == "" {
+		log.DebugContext(ctx, "author has no team")
+		return nil, "", nil, serviceErr.ErrAuthorNotCorrect
+	}
+
+	return repo, teamName, author, nil
+}
+
+// PreviewAssignment runs the reviewer selection of CreatePR for a hypothetical PR
+// without writing anything.
+func (s *Service) PreviewAssignment(ctx context.Context, draft domain.PRDraft) (*domain.AssignmentPreview, error) {
+	const op = "service.pr.PreviewAssignment"
+
+	log := s.log.With(
+		slog.String("op", op),
+		slog.String("repository", draft.Repository),
+		slog.String("authorID", draft.AuthorID),
+		slog.String("teamName", draft.TeamName),
+	)
+
+	repo, teamName, author, err := s.resolveTeam(ctx, log, op, s.reads, draft)
+	if err != nil {
+		return nil, err
 	}
 
-	err = s.prStorage.AssignReviewers(ctx, prID, reviewers)
+	requiredTags, err := validation.Tags(draft.RequiredTags)
 	if err != nil {
-		log.ErrorContext(ctx, "error assigning reviewers", "error", err)
+		log.DebugContext(ctx, "invalid required tag", "error", err)
+		return nil, err
+	}
+
+	selected, err := s.selectReviewers(ctx, s.reads, repo, draft.PullRequestID, teamName, author, draft.ChangedFiles, requiredTags)
+	if err != nil {
+		log.ErrorContext(ctx, "error selecting reviewers", "error", err)
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
-	return &domain.PullRequest{
-		PullRequestID:     prID,
-		PullRequestName:   prName,
-		AuthorID:          authorID,
-		Status:            statusOpen,
-		AssignedReviewers: reviewers,
+	return &domain.AssignmentPreview{
+		TeamName:            teamName,
+		Candidates:          selected.candidates,
+		Picks:               selected.rationales,
+		Queued:              selected.queued,
+		UncoveredTags:       selected.uncoveredTags,
+		SeniorityViolations: selected.violations,
 	}, nil
 }
 
-func (s *Service) SetStatusMerged(ctx context.Context, prID string) (*domain.PullRequest, error) {
+// GetPR returns the PR with the rationales of its current reviewers.
+func (s *Service) GetPR(ctx context.Context, repository string, prID string) (*domain.PullRequest, error) {
+	const op = "service.pr.GetPR"
+
+	log := s.log.With(
+		slog.String("op", op),
+		slog.String("repository", repository),
+		slog.String("prID", prID),
+	)
+
+	pr, err := s.prStorage.GetPR(ctx, repository, prID)
+	if errors.Is(err, storageErr.ErrPRNotFound) {
+		log.DebugContext(ctx, "pr not found", "error", err)
+		return nil, serviceErr.ErrPRNotFound
+	}
+	if err != nil {
+		log.ErrorContext(ctx, "error getting pr", "error", err)
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	pr.Rationales, err = s.prStorage.GetAssignmentRationales(ctx, repository, prID)
+	if err != nil {
+		log.ErrorContext(ctx, "error getting assignment rationales", "error", err)
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return pr, nil
+}
+
+func (s *Service) SetStatusMerged(ctx context.Context, repository string, prID string) (*domain.PullRequest, error) {
 	const op = "service.pr.SetStatusMerged"
 
 	log := s.log.With(
 		slog.String("op", op),
+		slog.String("repository", repository),
 		slog.String("prID", prID),
 	)
 
-	pr, err := s.prStorage.SetStatusMerged(ctx, prID)
+	pr, err := s.prStorage.SetStatusMerged(ctx, repository, prID)
 	if errors.Is(err, storageErr.ErrPRNotFound) {
 		log.DebugContext(ctx, "pr not found", "error", err)
 		return nil, serviceErr.ErrPRNotFound
@@ -117,23 +397,38 @@ func (s *Service) SetStatusMerged(ctx context.Context, prID string) (*domain.Pul
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
+	// The reviewers have one open review less.
+	s.wake()
+
 	return pr, nil
 }
 
+// ReassignReviewer replaces oldReviewerID with an eligible member of the PR's
+// team and returns the new reviewer, empty if there was none. A reviewer picked
+// for a code owner rule no other reviewer owns is replaced by another eligible
+// owner of the rule if there is one. Among the team, members with the required
+// tags the other reviewers lack come first, then members meeting the seniority
+// rules they leave unmet; the rules still unmet after the reassignment are
+// returned with the PR. With dryRun nothing is written and the PR is returned as
+// it would have been.
 func (s *Service) ReassignReviewer(
 	ctx context.Context,
+	repository string,
 	prID string,
 	oldReviewerID string,
+	dryRun bool,
 ) (*domain.PullRequest, string, error) {
 	const op = "service.pr.ReassignReviewer"
 
 	log := s.log.With(
 		slog.String("op", op),
+		slog.String("repository", repository),
 		slog.String("prID", prID),
 		slog.String("oldReviewerID", oldReviewerID),
+		slog.Bool("dryRun", dryRun),
 	)
 
-	authorID, err := s.prStorage.GetPRAuthorID(ctx, prID)
+	current, err := s.prStorage.GetPR(ctx, repository, prID)
 	if errors.Is(err, storageErr.ErrPRNotFound) {
 		log.DebugContext(ctx, "pr not found", "error", err)
 		return nil, "", serviceErr.ErrPRNotFound
@@ -143,20 +438,118 @@ func (s *Service) ReassignReviewer(
 		return nil, "", fmt.Errorf("%s: %w", op, err)
 	}
 
-	reviewers, err := s.userStorage.GetPotentialReviewersIDs(ctx, authorID, oldReviewerID, reassignLimit)
+	author, err := s.userStorage.GetUser(ctx, current.AuthorID)
+	if err != nil {
+		log.ErrorContext(ctx, "error getting author", "error", err)
+		return nil, "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	rules, err := s.seniorityRules(ctx, s.reads, current.TeamName, author)
+	if err != nil {
+		log.ErrorContext(ctx, "error getting seniority rules", "error", err)
+		return nil, "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	pool, err := s.userStorage.GetReviewerPool(ctx, current.TeamName)
 	if err != nil {
 		log.ErrorContext(ctx, "error finding potential reviewers", "error", err)
 		return nil, "", fmt.Errorf("%s: %w", op, err)
 	}
+	s.order(current.Ref(), pool)
+
+	pairings, err := s.pairingCounts(ctx, s.reads, current.AuthorID)
+	if err != nil {
+		log.ErrorContext(ctx, "error getting pairings", "error", err)
+		return nil, "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	requiredTags, err := s.prStorage.GetRequiredTags(ctx, repository, prID)
+	if err != nil {
+		log.ErrorContext(ctx, "error getting required tags", "error", err)
+		return nil, "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	// Reviewers who are no longer members of the team have no tags or level.
+	var reviewers []*domain.User
+	for _, user := range pool {
+		if user.UserID != oldReviewerID && slices.Contains(current.AssignedReviewers, user.UserID) {
+			reviewers = append(reviewers, user)
+		}
+	}
+	unmet, violations := unmetRules(rules, reviewers)
+	uncovered := uncoveredTags(requiredTags, reviewers)
+
+	rule, owners, err := s.replacedOwnerRule(ctx, current, oldReviewerID)
+	if err != nil {
+		log.ErrorContext(ctx, "error getting code owners", "error", err)
+		return nil, "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	var newReviewer *domain.User
+	var rationale domain.AssignmentRationale
+	queue := false
+	if rule != nil {
+		// Another owner of the rule keeps it covered.
+		s.order(current.Ref(), owners)
+		eligible, excluded := screen(owners, current.AuthorID, oldReviewerID, current.AssignedReviewers)
+		eligible = preferFresh(eligible, pairings)
+		eligible, excludedFor := s.preferInHours(owners, eligible, excluded)
+		strategy := domain.StrategyCodeOwners
+		if len(eligible) == 0 && s.opts.CapacityMode == domain.CapacityAssign {
+			eligible, strategy = overCapacity(owners, excluded), domain.StrategyOverCapacity
+		}
+		if len(eligible) > 0 {
+			newReviewer = findUser(owners, eligible[0])
+			rationale = domain.AssignmentRationale{
+				ReviewerID: eligible[0],
+				Strategy:   strategy,
+				Rule:       rule.Pattern,
+				PoolSize:   len(owners),
+				Excluded:   excludedFor(eligible[0]),
+			}
+		}
+	}
+
+	if newReviewer == nil {
+		eligible, excluded := screen(pool, current.AuthorID, oldReviewerID, current.AssignedReviewers)
+		eligible = preferFresh(eligible, pairings)
+		eligible, excludedFor := s.preferInHours(pool, eligible, excluded)
+		eligible = preferSenior(pool, eligible, unmet)
+		eligible, tag := preferTagged(pool, eligible, uncovered)
+
+		rationale = domain.AssignmentRationale{Strategy: domain.StrategyRandom, PoolSize: len(pool), Excluded: excluded}
+		switch full := overCapacity(pool, excluded); {
+		case len(eligible) > 0:
+			newReviewer = findUser(pool, eligible[0])
+			rationale.Excluded = excludedFor(eligible[0])
+			if tag != "" {
+				rationale.Strategy, rationale.Rule = domain.StrategySkill, tag
+			} else if level := metLevel(newReviewer, unmet); level != "" {
+				rationale.Strategy, rationale.Rule = domain.StrategySeniority, level
+			}
+		case len(full) > 0 && s.opts.CapacityMode == domain.CapacityAssign:
+			full, tag = preferTagged(pool, full, uncovered)
+			newReviewer = findUser(pool, full[0])
+			rationale.Strategy, rationale.Rule = domain.StrategyOverCapacity, tag
+		case len(full) > 0 && s.opts.CapacityMode == domain.CapacitySkip:
+			// The slot stays empty.
+		default:
+			queue = true
+		}
+	}
+
+	// The replacement, its rationale and the queued slot are written with the
+	// removal in one transaction.
+	reassignment := domain.Reassignment{OldReviewerID: oldReviewerID, QueueSlot: queue}
 
 	var newReviewerID string
-	if len(reviewers) == 0 {
-		newReviewerID = ""
-	} else {
-		newReviewerID = reviewers[0]
+	if newReviewer != nil {
+		newReviewerID = newReviewer.UserID
+		rationale.ReviewerID = newReviewerID
+		reassignment.Replacement = &rationale
 	}
 
-	pr, err := s.prStorage.ReassignReviewer(ctx, prID, oldReviewerID, newReviewerID)
+	pr, err := s.prStorage.ReassignReviewer(ctx, repository, prID, reassignment, dryRun)
 	if errors.Is(err, storageErr.ErrPRNotFound) {
 		log.DebugContext(ctx, "pr not found", "error", err)
 		return nil, "", serviceErr.ErrPRNotFound
@@ -169,14 +562,102 @@ func (s *Service) ReassignReviewer(
 		log.DebugContext(ctx, "reviewer not assigned to this pr", "error", err)
 		return nil, "", serviceErr.ErrReviewerNotFound
 	}
+	if errors.Is(err, storageErr.ErrReviewerAssigned) {
+		log.DebugContext(ctx, "reviewer assigned in the meantime", "error", err)
+		return nil, "", serviceErr.ErrReviewerAssigned
+	}
 	if err != nil {
 		log.ErrorContext(ctx, "error reassigning reviewer", "error", err)
 		return nil, "", fmt.Errorf("%s: %w", op, err)
 	}
 
+	if newReviewer != nil {
+		reviewers = append(reviewers, newReviewer)
+		_, violations = unmetRules(rules, reviewers)
+	}
+	pr.SeniorityViolations = violations
+	if uncovered = uncoveredTags(uncovered, reviewers); len(uncovered) > 0 {
+		log.InfoContext(ctx, "no eligible reviewer for required tags", "tags", uncovered)
+	}
+
+	if dryRun {
+		if newReviewerID != "" {
+			pr.Rationales = []domain.AssignmentRationale{rationale}
+		}
+
+		return pr, newReviewerID, nil
+	}
+
+	if newReviewerID != "" {
+		pr.Rationales = []domain.AssignmentRationale{rationale}
+		audit(ctx, log, rationale)
+	}
+
+	if queue {
+		log.InfoContext(ctx, "reviewer slot queued for later assignment")
+	}
+
+	if len(violations) > 0 {
+		log.InfoContext(ctx, "seniority rules not satisfied", "rules", len(violations))
+	}
+
 	log.InfoContext(ctx, "reviewer reassigned successfully",
 		"oldReviewer", oldReviewerID,
 		"newReviewer", newReviewerID)
 
+	// The old reviewer has one open review less.
+	s.wake()
+
 	return pr, newReviewerID, nil
 }
+
+// replacedOwnerRule returns the code owner rule oldReviewerID was picked for on
+// the PR and its owners. The rule is nil when the reviewer was not picked for
+// one, the repository no longer has it, or another reviewer owns it too.
+func (s *Service) replacedOwnerRule(
+	ctx context.Context,
+	pr *domain.PullRequest,
+	oldReviewerID string,
+) (*domain.CodeOwnerRule, []*domain.User, error) {
+	if pr.Repository == "" {
+		return nil, nil, nil
+	}
+
+	rationales, err := s.prStorage.GetAssignmentRationales(ctx, pr.Repository, pr.PullRequestID)
+	if err != nil {
+		return nil, nil, err
+	}
+	i := slices.IndexFunc(rationales, func(r domain.AssignmentRationale) bool { return r.ReviewerID == oldReviewerID })
+	// An owner assigned over capacity keeps the pattern of the rule.
+	if i < 0 || rationales[i].Rule == "" ||
+		rationales[i].Strategy != domain.StrategyCodeOwners && rationales[i].Strategy != domain.StrategyOverCapacity {
+		return nil, nil, nil
+	}
+
+	rules, err := s.repositoryStorage.GetCodeOwners(ctx, pr.Repository)
+	if err != nil {
+		return nil, nil, err
+	}
+	j := slices.IndexFunc(rules, func(rule domain.CodeOwnerRule) bool { return rule.Pattern == rationales[i].Rule })
+	if j < 0 {
+		return nil, nil, nil
+	}
+	rule := rules[j]
+
+	owners, err := s.userStorage.GetCodeOwnerPool(ctx, rule.UserIDs, rule.TeamNames)
+	if err != nil {
+		return nil, nil, err
+	}
+	if rationales[i].Strategy == domain.StrategyOverCapacity &&
+		!slices.ContainsFunc(owners, func(user *domain.User) bool { return user.UserID == oldReviewerID }) {
+		// An over capacity pick for a tag or level named like the pattern.
+		return nil, nil, nil
+	}
+	if slices.ContainsFunc(owners, func(user *domain.User) bool {
+		return user.UserID != oldReviewerID && slices.Contains(pr.AssignedReviewers, user.UserID)
+	}) {
+		return nil, nil, nil
+	}
+
+	return &rule, owners, nil
+}
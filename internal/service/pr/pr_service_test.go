@@ -12,9 +12,60 @@ import (
 	"github.com/whitxowl/pr-reviewer-assignment-service.git/internal/domain"
 	serviceErr "github.com/whitxowl/pr-reviewer-assignment-service.git/internal/service/errors"
 	"github.com/whitxowl/pr-reviewer-assignment-service.git/internal/service/pr/mocks"
+	"github.com/whitxowl/pr-reviewer-assignment-service.git/internal/service/validation"
 	storageErr "github.com/whitxowl/pr-reviewer-assignment-service.git/internal/storage/errors"
 )
 
+// testIDs accepts the ID formats of the default configuration.
+var testIDs = func() *validation.IDs {
+	ids, err := validation.NewIDs(`^u[0-9]+$`, `^pr-[0-9]+$`)
+	if err != nil {
+		panic(err)
+	}
+	return ids
+}()
+
+// activeUsers returns a reviewer pool of active users.
+func activeUsers(ids ...string) []*domain.User {
+	users := make([]*domain.User, len(ids))
+	for i, id := range ids {
+		users[i] = &domain.User{UserID: id, IsActive: true}
+	}
+	return users
+}
+
+// randomRationales returns the rationales of reviewers drawn from a pool of
+// exactly those reviewers.
+func randomRationales(ids ...string) []domain.AssignmentRationale {
+	var rationales []domain.AssignmentRationale
+	for _, id := range ids {
+		rationales = append(rationales, domain.AssignmentRationale{
+			ReviewerID: id,
+			Strategy:   domain.StrategyRandom,
+			PoolSize:   len(ids),
+		})
+	}
+	return rationales
+}
+
+// createdPR returns the one-PR import CreatePR writes for a PR without required
+// tags whose reviewers are drawn from a pool of exactly those reviewers.
+func createdPR(repository, prID, prName, authorID, teamName string, queued int, reviewers ...string) []domain.PRImport {
+	return []domain.PRImport{{
+		PR: domain.PullRequest{
+			Repository:        repository,
+			PullRequestID:     prID,
+			PullRequestName:   prName,
+			AuthorID:          authorID,
+			TeamName:          teamName,
+			Status:            statusOpen,
+			AssignedReviewers: reviewers,
+			Rationales:        randomRationales(reviewers...),
+		},
+		Queued: queued,
+	}}
+}
+
 func TestService_CreatePR(t *testing.T) {
 	ctx := context.Background()
 	log := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
@@ -24,6 +75,7 @@ func TestService_CreatePR(t *testing.T) {
 		prID          string
 		prName        string
 		authorID      string
+		teamName      string
 		setupMocks    func(*mocks.MockUserStorage, *mocks.MockPRStorage)
 		expectedPR    *domain.PullRequest
 		expectedError error
@@ -35,22 +87,17 @@ func TestService_CreatePR(t *testing.T) {
 			authorID: "u1",
 			setupMocks: func(userStorage *mocks.MockUserStorage, prStorage *mocks.MockPRStorage) {
 				userStorage.EXPECT().
-					UserExistsAndHasTeam(ctx, "u1").
-					Return(true, nil).
-					Once()
-
-				prStorage.EXPECT().
-					CreatePR(ctx, "pr-123", "Add new feature", "u1").
-					Return(nil).
+					GetUser(ctx, "u1").
+					Return(&domain.User{UserID: "u1", TeamName: "backend", TeamNames: []string{"backend"}}, nil).
 					Once()
 
 				userStorage.EXPECT().
-					GetPotentialReviewersIDs(ctx, "u1", "u1", 2).
-					Return([]string{"u11", "u12"}, nil).
+					GetReviewerPool(ctx, "backend").
+					Return(activeUsers("u11", "u12"), nil).
 					Once()
 
 				prStorage.EXPECT().
-					AssignReviewers(ctx, "pr-123", []string{"u11", "u12"}).
+					ImportPRs(ctx, createdPR("", "pr-123", "Add new feature", "u1", "backend", 0, "u11", "u12")).
 					Return(nil).
 					Once()
 			},
@@ -58,34 +105,38 @@ func TestService_CreatePR(t *testing.T) {
 				PullRequestID:     "pr-123",
 				PullRequestName:   "Add new feature",
 				AuthorID:          "u1",
+				TeamName:          "backend",
 				Status:            "OPEN",
 				AssignedReviewers: []string{"u11", "u12"},
 			},
 			expectedError: nil,
 		},
 		{
-			name:     "success - PR created with one reviewer",
+			name:          "error - invalid pr id",
+			prID:          "feature-1",
+			prName:        "Add new feature",
+			authorID:      "u1",
+			setupMocks:    func(*mocks.MockUserStorage, *mocks.MockPRStorage) {},
+			expectedError: serviceErr.ErrInvalidPRID,
+		},
+		{
+			name:     "success - PR created with one reviewer, the other slot queued",
 			prID:     "pr-456",
 			prName:   "Fix bug",
 			authorID: "u2",
 			setupMocks: func(userStorage *mocks.MockUserStorage, prStorage *mocks.MockPRStorage) {
 				userStorage.EXPECT().
-					UserExistsAndHasTeam(ctx, "u2").
-					Return(true, nil).
-					Once()
-
-				prStorage.EXPECT().
-					CreatePR(ctx, "pr-456", "Fix bug", "u2").
-					Return(nil).
+					GetUser(ctx, "u2").
+					Return(&domain.User{UserID: "u2", TeamName: "backend", TeamNames: []string{"backend"}}, nil).
 					Once()
 
 				userStorage.EXPECT().
-					GetPotentialReviewersIDs(ctx, "u2", "u2", 2).
-					Return([]string{"u13"}, nil).
+					GetReviewerPool(ctx, "backend").
+					Return(activeUsers("u13"), nil).
 					Once()
 
 				prStorage.EXPECT().
-					AssignReviewers(ctx, "pr-456", []string{"u13"}).
+					ImportPRs(ctx, createdPR("", "pr-456", "Fix bug", "u2", "backend", 1, "u13")).
 					Return(nil).
 					Once()
 			},
@@ -93,11 +144,73 @@ func TestService_CreatePR(t *testing.T) {
 				PullRequestID:     "pr-456",
 				PullRequestName:   "Fix bug",
 				AuthorID:          "u2",
+				TeamName:          "backend",
 				Status:            "OPEN",
 				AssignedReviewers: []string{"u13"},
 			},
 			expectedError: nil,
 		},
+		{
+			name:     "success - PR created for a chosen team",
+			prID:     "pr-124",
+			prName:   "Add dashboard",
+			authorID: "u1",
+			teamName: "frontend",
+			setupMocks: func(userStorage *mocks.MockUserStorage, prStorage *mocks.MockPRStorage) {
+				userStorage.EXPECT().
+					GetUser(ctx, "u1").
+					Return(&domain.User{UserID: "u1", TeamName: "backend", TeamNames: []string{"backend", "frontend"}}, nil).
+					Once()
+
+				userStorage.EXPECT().
+					GetReviewerPool(ctx, "frontend").
+					Return(activeUsers("u21"), nil).
+					Once()
+
+				prStorage.EXPECT().
+					ImportPRs(ctx, createdPR("", "pr-124", "Add dashboard", "u1", "frontend", 1, "u21")).
+					Return(nil).
+					Once()
+			},
+			expectedPR: &domain.PullRequest{
+				PullRequestID:     "pr-124",
+				PullRequestName:   "Add dashboard",
+				AuthorID:          "u1",
+				TeamName:          "frontend",
+				Status:            "OPEN",
+				AssignedReviewers: []string{"u21"},
+			},
+			expectedError: nil,
+		},
+		{
+			name:     "error - author not in the chosen team",
+			prID:     "pr-125",
+			prName:   "Add dashboard",
+			authorID: "u1",
+			teamName: "payments",
+			setupMocks: func(userStorage *mocks.MockUserStorage, prStorage *mocks.MockPRStorage) {
+				userStorage.EXPECT().
+					GetUser(ctx, "u1").
+					Return(&domain.User{UserID: "u1", TeamName: "backend", TeamNames: []string{"backend", "frontend"}}, nil).
+					Once()
+			},
+			expectedPR:    nil,
+			expectedError: serviceErr.ErrAuthorNotInTeam,
+		},
+		{
+			name:     "error - author has no team",
+			prID:     "pr-126",
+			prName:   "Update docs",
+			authorID: "u8",
+			setupMocks: func(userStorage *mocks.MockUserStorage, prStorage *mocks.MockPRStorage) {
+				userStorage.EXPECT().
+					GetUser(ctx, "u8").
+					Return(&domain.User{UserID: "u8", TeamNames: []string{}}, nil).
+					Once()
+			},
+			expectedPR:    nil,
+			expectedError: serviceErr.ErrAuthorNotCorrect,
+		},
 		{
 			name:     "error - author not correct",
 			prID:     "pr-789",
@@ -105,22 +218,22 @@ func TestService_CreatePR(t *testing.T) {
 			authorID: "invalid_author",
 			setupMocks: func(userStorage *mocks.MockUserStorage, prStorage *mocks.MockPRStorage) {
 				userStorage.EXPECT().
-					UserExistsAndHasTeam(ctx, "invalid_author").
-					Return(false, nil).
+					GetUser(ctx, "invalid_author").
+					Return(nil, storageErr.ErrUserNotFound).
 					Once()
 			},
 			expectedPR:    nil,
 			expectedError: serviceErr.ErrAuthorNotCorrect,
 		},
 		{
-			name:     "error - user exists check fails",
+			name:     "error - get author fails",
 			prID:     "pr-101",
 			prName:   "Refactor code",
 			authorID: "u3",
 			setupMocks: func(userStorage *mocks.MockUserStorage, prStorage *mocks.MockPRStorage) {
 				userStorage.EXPECT().
-					UserExistsAndHasTeam(ctx, "u3").
-					Return(false, errors.New("database error")).
+					GetUser(ctx, "u3").
+					Return(nil, errors.New("database error")).
 					Once()
 			},
 			expectedPR:    nil,
@@ -133,12 +246,17 @@ func TestService_CreatePR(t *testing.T) {
 			authorID: "u4",
 			setupMocks: func(userStorage *mocks.MockUserStorage, prStorage *mocks.MockPRStorage) {
 				userStorage.EXPECT().
-					UserExistsAndHasTeam(ctx, "u4").
-					Return(true, nil).
+					GetUser(ctx, "u4").
+					Return(&domain.User{UserID: "u4", TeamName: "backend", TeamNames: []string{"backend"}}, nil).
+					Once()
+
+				userStorage.EXPECT().
+					GetReviewerPool(ctx, "backend").
+					Return(activeUsers("u11"), nil).
 					Once()
 
 				prStorage.EXPECT().
-					CreatePR(ctx, "pr-202", "New feature", "u4").
+					ImportPRs(ctx, createdPR("", "pr-202", "New feature", "u4", "backend", 1, "u11")).
 					Return(storageErr.ErrPRExists).
 					Once()
 			},
@@ -152,12 +270,17 @@ func TestService_CreatePR(t *testing.T) {
 			authorID: "u5",
 			setupMocks: func(userStorage *mocks.MockUserStorage, prStorage *mocks.MockPRStorage) {
 				userStorage.EXPECT().
-					UserExistsAndHasTeam(ctx, "u5").
-					Return(true, nil).
+					GetUser(ctx, "u5").
+					Return(&domain.User{UserID: "u5", TeamName: "backend", TeamNames: []string{"backend"}}, nil).
+					Once()
+
+				userStorage.EXPECT().
+					GetReviewerPool(ctx, "backend").
+					Return(activeUsers("u11", "u12"), nil).
 					Once()
 
 				prStorage.EXPECT().
-					CreatePR(ctx, "pr-303", "Hot fix", "u5").
+					ImportPRs(ctx, createdPR("", "pr-303", "Hot fix", "u5", "backend", 0, "u11", "u12")).
 					Return(errors.New("insert failed")).
 					Once()
 			},
@@ -171,17 +294,12 @@ func TestService_CreatePR(t *testing.T) {
 			authorID: "u6",
 			setupMocks: func(userStorage *mocks.MockUserStorage, prStorage *mocks.MockPRStorage) {
 				userStorage.EXPECT().
-					UserExistsAndHasTeam(ctx, "u6").
-					Return(true, nil).
-					Once()
-
-				prStorage.EXPECT().
-					CreatePR(ctx, "pr-404", "Performance improvement", "u6").
-					Return(nil).
+					GetUser(ctx, "u6").
+					Return(&domain.User{UserID: "u6", TeamName: "backend", TeamNames: []string{"backend"}}, nil).
 					Once()
 
 				userStorage.EXPECT().
-					GetPotentialReviewersIDs(ctx, "u6", "u6", 2).
+					GetReviewerPool(ctx, "backend").
 					Return(nil, errors.New("query error")).
 					Once()
 			},
@@ -189,33 +307,28 @@ func TestService_CreatePR(t *testing.T) {
 			expectedError: errors.New("service.pr.CreatePR: query error"),
 		},
 		{
-			name:     "error - assign reviewers fails",
+			name:     "error - reviewer deleted in the meantime",
 			prID:     "pr-505",
 			prName:   "Security patch",
 			authorID: "u7",
 			setupMocks: func(userStorage *mocks.MockUserStorage, prStorage *mocks.MockPRStorage) {
 				userStorage.EXPECT().
-					UserExistsAndHasTeam(ctx, "u7").
-					Return(true, nil).
-					Once()
-
-				prStorage.EXPECT().
-					CreatePR(ctx, "pr-505", "Security patch", "u7").
-					Return(nil).
+					GetUser(ctx, "u7").
+					Return(&domain.User{UserID: "u7", TeamName: "backend", TeamNames: []string{"backend"}}, nil).
 					Once()
 
 				userStorage.EXPECT().
-					GetPotentialReviewersIDs(ctx, "u7", "u7", 2).
-					Return([]string{"u14", "u15"}, nil).
+					GetReviewerPool(ctx, "backend").
+					Return(activeUsers("u14", "u15"), nil).
 					Once()
 
 				prStorage.EXPECT().
-					AssignReviewers(ctx, "pr-505", []string{"u14", "u15"}).
-					Return(errors.New("assignment failed")).
+					ImportPRs(ctx, createdPR("", "pr-505", "Security patch", "u7", "backend", 0, "u14", "u15")).
+					Return(storageErr.ErrUserNotFound).
 					Once()
 			},
 			expectedPR:    nil,
-			expectedError: errors.New("service.pr.CreatePR: assignment failed"),
+			expectedError: serviceErr.ErrUserNotFound,
 		},
 	}
 
@@ -224,12 +337,18 @@ func TestService_CreatePR(t *testing.T) {
 			// Arrange
 			userStorage := mocks.NewMockUserStorage(t)
 			prStorage := mocks.NewMockPRStorage(t)
+			repositoryStorage := mocks.NewMockRepositoryStorage(t)
 			tt.setupMocks(userStorage, prStorage)
 
-			service := New(log, userStorage, prStorage)
+			service := New(log, userStorage, mocks.NewMockTeamStorage(t), prStorage, repositoryStorage, testIDs, Options{CapacityMode: domain.CapacitySkip})
 
 			// Act
-			result, err := service.CreatePR(ctx, tt.prID, tt.prName, tt.authorID)
+			result, err := service.CreatePR(ctx, domain.PRDraft{
+				PullRequestID:   tt.prID,
+				PullRequestName: tt.prName,
+				AuthorID:        tt.authorID,
+				TeamName:        tt.teamName,
+			})
 
 			// Assert
 			if tt.expectedError != nil {
@@ -251,6 +370,154 @@ func TestService_CreatePR(t *testing.T) {
 	}
 }
 
+func TestService_CreatePR_Repository(t *testing.T) {
+	ctx := context.Background()
+	log := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
+
+	author := &domain.User{UserID: "u1", TeamName: "frontend", TeamNames: []string{"frontend"}}
+
+	tests := []struct {
+		name          string
+		teamName      string
+		setupMocks    func(*mocks.MockUserStorage, *mocks.MockPRStorage, *mocks.MockRepositoryStorage)
+		expectedPR    *domain.PullRequest
+		expectedError error
+	}{
+		{
+			name: "success - reviewers from the owning team",
+			setupMocks: func(userStorage *mocks.MockUserStorage, prStorage *mocks.MockPRStorage, repositoryStorage *mocks.MockRepositoryStorage) {
+				repositoryStorage.EXPECT().
+					GetRepository(ctx, "acme/api").
+					Return(&domain.Repository{Name: "acme/api", TeamName: "backend", ReviewersCount: 3}, nil).
+					Once()
+
+				userStorage.EXPECT().GetUser(ctx, "u1").Return(author, nil).Once()
+
+				userStorage.EXPECT().
+					GetReviewerPool(ctx, "backend").
+					Return(activeUsers("u11", "u12", "u13"), nil).
+					Once()
+
+				prStorage.EXPECT().
+					ImportPRs(ctx, createdPR("acme/api", "pr-1", "Fix API", "u1", "backend", 0, "u11", "u12", "u13")).
+					Return(nil).
+					Once()
+			},
+			expectedPR: &domain.PullRequest{
+				Repository:        "acme/api",
+				PullRequestID:     "pr-1",
+				PullRequestName:   "Fix API",
+				AuthorID:          "u1",
+				TeamName:          "backend",
+				Status:            "OPEN",
+				AssignedReviewers: []string{"u11", "u12", "u13"},
+				Rationales:        randomRationales("u11", "u12", "u13"),
+			},
+		},
+		{
+			name:     "success - chosen team overrides the owning team",
+			teamName: "frontend",
+			setupMocks: func(userStorage *mocks.MockUserStorage, prStorage *mocks.MockPRStorage, repositoryStorage *mocks.MockRepositoryStorage) {
+				repositoryStorage.EXPECT().
+					GetRepository(ctx, "acme/api").
+					Return(&domain.Repository{Name: "acme/api", TeamName: "backend", ReviewersCount: 1}, nil).
+					Once()
+
+				userStorage.EXPECT().GetUser(ctx, "u1").Return(author, nil).Once()
+
+				userStorage.EXPECT().
+					GetReviewerPool(ctx, "frontend").
+					Return(activeUsers("u21"), nil).
+					Once()
+
+				prStorage.EXPECT().
+					ImportPRs(ctx, createdPR("acme/api", "pr-1", "Fix API", "u1", "frontend", 0, "u21")).
+					Return(nil).
+					Once()
+			},
+			expectedPR: &domain.PullRequest{
+				Repository:        "acme/api",
+				PullRequestID:     "pr-1",
+				PullRequestName:   "Fix API",
+				AuthorID:          "u1",
+				TeamName:          "frontend",
+				Status:            "OPEN",
+				AssignedReviewers: []string{"u21"},
+				Rationales:        randomRationales("u21"),
+			},
+		},
+		{
+			name: "success - repository without team uses the author's team",
+			setupMocks: func(userStorage *mocks.MockUserStorage, prStorage *mocks.MockPRStorage, repositoryStorage *mocks.MockRepositoryStorage) {
+				repositoryStorage.EXPECT().
+					GetRepository(ctx, "acme/api").
+					Return(&domain.Repository{Name: "acme/api", ReviewersCount: 2}, nil).
+					Once()
+
+				userStorage.EXPECT().GetUser(ctx, "u1").Return(author, nil).Once()
+
+				userStorage.EXPECT().
+					GetReviewerPool(ctx, "frontend").
+					Return(nil, nil).
+					Once()
+
+				prStorage.EXPECT().
+					ImportPRs(ctx, createdPR("acme/api", "pr-1", "Fix API", "u1", "frontend", 2)).
+					Return(nil).
+					Once()
+			},
+			expectedPR: &domain.PullRequest{
+				Repository:      "acme/api",
+				PullRequestID:   "pr-1",
+				PullRequestName: "Fix API",
+				AuthorID:        "u1",
+				TeamName:        "frontend",
+				Status:          "OPEN",
+			},
+		},
+		{
+			name: "error - repository not found",
+			setupMocks: func(_ *mocks.MockUserStorage, _ *mocks.MockPRStorage, repositoryStorage *mocks.MockRepositoryStorage) {
+				repositoryStorage.EXPECT().
+					GetRepository(ctx, "acme/api").
+					Return(nil, storageErr.ErrRepositoryNotFound).
+					Once()
+			},
+			expectedError: serviceErr.ErrRepositoryNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			userStorage := mocks.NewMockUserStorage(t)
+			prStorage := mocks.NewMockPRStorage(t)
+			repositoryStorage := mocks.NewMockRepositoryStorage(t)
+			tt.setupMocks(userStorage, prStorage, repositoryStorage)
+
+			service := New(log, userStorage, mocks.NewMockTeamStorage(t), prStorage, repositoryStorage, testIDs, Options{CapacityMode: domain.CapacitySkip})
+
+			// Act
+			result, err := service.CreatePR(ctx, domain.PRDraft{
+				Repository:      "acme/api",
+				PullRequestID:   "pr-1",
+				PullRequestName: "Fix API",
+				AuthorID:        "u1",
+				TeamName:        tt.teamName,
+			})
+
+			// Assert
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				assert.Nil(t, result)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedPR, result)
+			}
+		})
+	}
+}
+
 func TestService_SetStatusMerged(t *testing.T) {
 	ctx := context.Background()
 	log := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
@@ -269,7 +536,7 @@ func TestService_SetStatusMerged(t *testing.T) {
 			prID: "pr-123",
 			setupMocks: func(prStorage *mocks.MockPRStorage) {
 				prStorage.EXPECT().
-					SetStatusMerged(ctx, "pr-123").
+					SetStatusMerged(ctx, "", "pr-123").
 					Return(&domain.PullRequest{
 						PullRequestID:     "pr-123",
 						PullRequestName:   "Add feature",
@@ -297,7 +564,7 @@ func TestService_SetStatusMerged(t *testing.T) {
 			prID: "pr-999",
 			setupMocks: func(prStorage *mocks.MockPRStorage) {
 				prStorage.EXPECT().
-					SetStatusMerged(ctx, "pr-999").
+					SetStatusMerged(ctx, "", "pr-999").
 					Return(nil, storageErr.ErrPRNotFound).
 					Once()
 			},
@@ -309,7 +576,7 @@ func TestService_SetStatusMerged(t *testing.T) {
 			prID: "pr-456",
 			setupMocks: func(prStorage *mocks.MockPRStorage) {
 				prStorage.EXPECT().
-					SetStatusMerged(ctx, "pr-456").
+					SetStatusMerged(ctx, "", "pr-456").
 					Return(nil, errors.New("update failed")).
 					Once()
 			},
@@ -323,12 +590,13 @@ func TestService_SetStatusMerged(t *testing.T) {
 			// Arrange
 			userStorage := mocks.NewMockUserStorage(t)
 			prStorage := mocks.NewMockPRStorage(t)
+			repositoryStorage := mocks.NewMockRepositoryStorage(t)
 			tt.setupMocks(prStorage)
 
-			service := New(log, userStorage, prStorage)
+			service := New(log, userStorage, mocks.NewMockTeamStorage(t), prStorage, repositoryStorage, testIDs, Options{CapacityMode: domain.CapacitySkip})
 
 			// Act
-			result, err := service.SetStatusMerged(ctx, tt.prID)
+			result, err := service.SetStatusMerged(ctx, "", tt.prID)
 
 			// Assert
 			if tt.expectedError != nil {
@@ -341,6 +609,7 @@ func TestService_SetStatusMerged(t *testing.T) {
 				assert.NoError(t, err)
 				assert.NotNil(t, result)
 				assert.Equal(t, tt.expectedPR.PullRequestID, result.PullRequestID)
+				assert.Equal(t, tt.expectedPR.TeamName, result.TeamName)
 				assert.Equal(t, tt.expectedPR.Status, result.Status)
 			}
 		})
@@ -352,11 +621,18 @@ func TestService_ReassignReviewer(t *testing.T) {
 	log := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
 
 	now := time.Now()
+	limit := 1
+	fullUsers := []*domain.User{
+		{UserID: "u13", IsActive: true, OpenReviews: 3, ReviewLimit: &limit},
+		{UserID: "u12", IsActive: true, OpenReviews: 1, ReviewLimit: &limit},
+	}
 
 	tests := []struct {
 		name          string
 		prID          string
 		oldReviewerID string
+		dryRun        bool
+		capacityMode  string
 		setupMocks    func(*mocks.MockUserStorage, *mocks.MockPRStorage)
 		expectedPR    *domain.PullRequest
 		expectedNewID string
@@ -368,17 +644,42 @@ func TestService_ReassignReviewer(t *testing.T) {
 			oldReviewerID: "u11",
 			setupMocks: func(userStorage *mocks.MockUserStorage, prStorage *mocks.MockPRStorage) {
 				prStorage.EXPECT().
-					GetPRAuthorID(ctx, "pr-123").
-					Return("u1", nil).
+					GetPR(ctx, "", "pr-123").
+					Return(&domain.PullRequest{
+						PullRequestID:     "pr-123",
+						AuthorID:          "u1",
+						TeamName:          "backend",
+						AssignedReviewers: []string{"u11", "u12"},
+					}, nil).
 					Once()
+				userStorage.EXPECT().GetUser(ctx, "u1").Return(&domain.User{UserID: "u1"}, nil).Once()
+				prStorage.EXPECT().GetRequiredTags(ctx, "", "pr-123").Return(nil, nil).Once()
 
+				pool := append(activeUsers("u1", "u11", "u12", "u13"),
+					&domain.User{UserID: "u10"},
+					&domain.User{UserID: "u14", IsActive: true, Absent: true},
+				)
 				userStorage.EXPECT().
-					GetPotentialReviewersIDs(ctx, "u1", "u11", 1).
-					Return([]string{"u13"}, nil).
+					GetReviewerPool(ctx, "backend").
+					Return(pool, nil).
 					Once()
 
 				prStorage.EXPECT().
-					ReassignReviewer(ctx, "pr-123", "u11", "u13").
+					ReassignReviewer(ctx, "", "pr-123", domain.Reassignment{
+						OldReviewerID: "u11",
+						Replacement: &domain.AssignmentRationale{
+							ReviewerID: "u13",
+							Strategy:   domain.StrategyRandom,
+							PoolSize:   6,
+							Excluded: []domain.ExcludedCandidate{
+								{UserID: "u1", Reason: domain.ExclusionAuthor},
+								{UserID: "u10", Reason: domain.ExclusionInactive},
+								{UserID: "u11", Reason: domain.ExclusionReplaced},
+								{UserID: "u12", Reason: domain.ExclusionAssigned},
+								{UserID: "u14", Reason: domain.ExclusionAbsent},
+							},
+						},
+					}, false).
 					Return(&domain.PullRequest{
 						PullRequestID:     "pr-123",
 						PullRequestName:   "Feature",
@@ -401,22 +702,59 @@ func TestService_ReassignReviewer(t *testing.T) {
 			expectedError: nil,
 		},
 		{
-			name:          "success - no replacement found",
+			name:          "success - dry run writes nothing",
+			prID:          "pr-123",
+			oldReviewerID: "u11",
+			dryRun:        true,
+			setupMocks: func(userStorage *mocks.MockUserStorage, prStorage *mocks.MockPRStorage) {
+				prStorage.EXPECT().
+					GetPR(ctx, "", "pr-123").
+					Return(&domain.PullRequest{PullRequestID: "pr-123", AuthorID: "u1", TeamName: "backend"}, nil).
+					Once()
+				userStorage.EXPECT().GetUser(ctx, "u1").Return(&domain.User{UserID: "u1"}, nil).Once()
+				prStorage.EXPECT().GetRequiredTags(ctx, "", "pr-123").Return(nil, nil).Once()
+
+				userStorage.EXPECT().
+					GetReviewerPool(ctx, "backend").
+					Return(activeUsers("u13"), nil).
+					Once()
+
+				prStorage.EXPECT().
+					ReassignReviewer(ctx, "", "pr-123", domain.Reassignment{OldReviewerID: "u11", Replacement: &randomRationales("u13")[0]}, true).
+					Return(&domain.PullRequest{
+						PullRequestID:     "pr-123",
+						Status:            "OPEN",
+						AssignedReviewers: []string{"u12", "u13"},
+					}, nil).
+					Once()
+			},
+			expectedPR: &domain.PullRequest{
+				PullRequestID:     "pr-123",
+				Status:            "OPEN",
+				AssignedReviewers: []string{"u12", "u13"},
+				Rationales:        randomRationales("u13"),
+			},
+			expectedNewID: "u13",
+		},
+		{
+			name:          "success - no replacement found, the slot is queued",
 			prID:          "pr-456",
 			oldReviewerID: "u15",
 			setupMocks: func(userStorage *mocks.MockUserStorage, prStorage *mocks.MockPRStorage) {
 				prStorage.EXPECT().
-					GetPRAuthorID(ctx, "pr-456").
-					Return("u2", nil).
+					GetPR(ctx, "", "pr-456").
+					Return(&domain.PullRequest{PullRequestID: "pr-456", AuthorID: "u2", TeamName: "backend"}, nil).
 					Once()
+				userStorage.EXPECT().GetUser(ctx, "u2").Return(&domain.User{UserID: "u2"}, nil).Once()
+				prStorage.EXPECT().GetRequiredTags(ctx, "", "pr-456").Return(nil, nil).Once()
 
 				userStorage.EXPECT().
-					GetPotentialReviewersIDs(ctx, "u2", "u15", 1).
-					Return([]string{}, nil).
+					GetReviewerPool(ctx, "backend").
+					Return(nil, nil).
 					Once()
 
 				prStorage.EXPECT().
-					ReassignReviewer(ctx, "pr-456", "u15", "").
+					ReassignReviewer(ctx, "", "pr-456", domain.Reassignment{OldReviewerID: "u15", QueueSlot: true}, false).
 					Return(&domain.PullRequest{
 						PullRequestID:     "pr-456",
 						PullRequestName:   "Bug fix",
@@ -439,13 +777,76 @@ func TestService_ReassignReviewer(t *testing.T) {
 			expectedError: nil,
 		},
 		{
-			name:          "error - PR not found on get author",
+			name:          "success - every candidate at capacity, assigned anyway",
+			prID:          "pr-123",
+			oldReviewerID: "u11",
+			capacityMode:  domain.CapacityAssign,
+			setupMocks: func(userStorage *mocks.MockUserStorage, prStorage *mocks.MockPRStorage) {
+				prStorage.EXPECT().
+					GetPR(ctx, "", "pr-123").
+					Return(&domain.PullRequest{PullRequestID: "pr-123", AuthorID: "u1", TeamName: "backend"}, nil).
+					Once()
+				userStorage.EXPECT().GetUser(ctx, "u1").Return(&domain.User{UserID: "u1"}, nil).Once()
+				prStorage.EXPECT().GetRequiredTags(ctx, "", "pr-123").Return(nil, nil).Once()
+
+				userStorage.EXPECT().
+					GetReviewerPool(ctx, "backend").
+					Return(fullUsers, nil).
+					Once()
+
+				prStorage.EXPECT().
+					ReassignReviewer(ctx, "", "pr-123", domain.Reassignment{
+						OldReviewerID: "u11",
+						Replacement: &domain.AssignmentRationale{
+							ReviewerID: "u12",
+							Strategy:   domain.StrategyOverCapacity,
+							PoolSize:   2,
+							Excluded: []domain.ExcludedCandidate{
+								{UserID: "u12", Reason: domain.ExclusionCapacity},
+								{UserID: "u13", Reason: domain.ExclusionCapacity},
+							},
+						},
+					}, false).
+					Return(&domain.PullRequest{PullRequestID: "pr-123", Status: "OPEN"}, nil).
+					Once()
+			},
+			expectedPR:    &domain.PullRequest{PullRequestID: "pr-123", Status: "OPEN"},
+			expectedNewID: "u12",
+		},
+		{
+			name:          "success - every candidate at capacity, slot queued",
+			prID:          "pr-123",
+			oldReviewerID: "u11",
+			capacityMode:  domain.CapacityQueue,
+			setupMocks: func(userStorage *mocks.MockUserStorage, prStorage *mocks.MockPRStorage) {
+				prStorage.EXPECT().
+					GetPR(ctx, "", "pr-123").
+					Return(&domain.PullRequest{PullRequestID: "pr-123", AuthorID: "u1", TeamName: "backend"}, nil).
+					Once()
+				userStorage.EXPECT().GetUser(ctx, "u1").Return(&domain.User{UserID: "u1"}, nil).Once()
+				prStorage.EXPECT().GetRequiredTags(ctx, "", "pr-123").Return(nil, nil).Once()
+
+				userStorage.EXPECT().
+					GetReviewerPool(ctx, "backend").
+					Return(fullUsers, nil).
+					Once()
+
+				prStorage.EXPECT().
+					ReassignReviewer(ctx, "", "pr-123", domain.Reassignment{OldReviewerID: "u11", QueueSlot: true}, false).
+					Return(&domain.PullRequest{PullRequestID: "pr-123", Status: "OPEN"}, nil).
+					Once()
+			},
+			expectedPR:    &domain.PullRequest{PullRequestID: "pr-123", Status: "OPEN"},
+			expectedNewID: "",
+		},
+		{
+			name:          "error - PR not found on get",
 			prID:          "pr-999",
 			oldReviewerID: "u11",
 			setupMocks: func(userStorage *mocks.MockUserStorage, prStorage *mocks.MockPRStorage) {
 				prStorage.EXPECT().
-					GetPRAuthorID(ctx, "pr-999").
-					Return("", storageErr.ErrPRNotFound).
+					GetPR(ctx, "", "pr-999").
+					Return(nil, storageErr.ErrPRNotFound).
 					Once()
 			},
 			expectedPR:    nil,
@@ -453,13 +854,13 @@ func TestService_ReassignReviewer(t *testing.T) {
 			expectedError: serviceErr.ErrPRNotFound,
 		},
 		{
-			name:          "error - get author storage error",
+			name:          "error - get PR storage error",
 			prID:          "pr-789",
 			oldReviewerID: "u12",
 			setupMocks: func(userStorage *mocks.MockUserStorage, prStorage *mocks.MockPRStorage) {
 				prStorage.EXPECT().
-					GetPRAuthorID(ctx, "pr-789").
-					Return("", errors.New("query failed")).
+					GetPR(ctx, "", "pr-789").
+					Return(nil, errors.New("query failed")).
 					Once()
 			},
 			expectedPR:    nil,
@@ -472,12 +873,13 @@ func TestService_ReassignReviewer(t *testing.T) {
 			oldReviewerID: "u13",
 			setupMocks: func(userStorage *mocks.MockUserStorage, prStorage *mocks.MockPRStorage) {
 				prStorage.EXPECT().
-					GetPRAuthorID(ctx, "pr-321").
-					Return("u3", nil).
+					GetPR(ctx, "", "pr-321").
+					Return(&domain.PullRequest{PullRequestID: "pr-321", AuthorID: "u3", TeamName: "backend"}, nil).
 					Once()
+				userStorage.EXPECT().GetUser(ctx, "u3").Return(&domain.User{UserID: "u3"}, nil).Once()
 
 				userStorage.EXPECT().
-					GetPotentialReviewersIDs(ctx, "u3", "u13", 1).
+					GetReviewerPool(ctx, "backend").
 					Return(nil, errors.New("database error")).
 					Once()
 			},
@@ -491,17 +893,19 @@ func TestService_ReassignReviewer(t *testing.T) {
 			oldReviewerID: "u14",
 			setupMocks: func(userStorage *mocks.MockUserStorage, prStorage *mocks.MockPRStorage) {
 				prStorage.EXPECT().
-					GetPRAuthorID(ctx, "pr-654").
-					Return("u4", nil).
+					GetPR(ctx, "", "pr-654").
+					Return(&domain.PullRequest{PullRequestID: "pr-654", AuthorID: "u4", TeamName: "backend"}, nil).
 					Once()
+				userStorage.EXPECT().GetUser(ctx, "u4").Return(&domain.User{UserID: "u4"}, nil).Once()
+				prStorage.EXPECT().GetRequiredTags(ctx, "", "pr-654").Return(nil, nil).Once()
 
 				userStorage.EXPECT().
-					GetPotentialReviewersIDs(ctx, "u4", "u14", 1).
-					Return([]string{"u16"}, nil).
+					GetReviewerPool(ctx, "backend").
+					Return(activeUsers("u16"), nil).
 					Once()
 
 				prStorage.EXPECT().
-					ReassignReviewer(ctx, "pr-654", "u14", "u16").
+					ReassignReviewer(ctx, "", "pr-654", domain.Reassignment{OldReviewerID: "u14", Replacement: &randomRationales("u16")[0]}, false).
 					Return(nil, storageErr.ErrPRNotFound).
 					Once()
 			},
@@ -515,17 +919,19 @@ func TestService_ReassignReviewer(t *testing.T) {
 			oldReviewerID: "u17",
 			setupMocks: func(userStorage *mocks.MockUserStorage, prStorage *mocks.MockPRStorage) {
 				prStorage.EXPECT().
-					GetPRAuthorID(ctx, "pr-987").
-					Return("u5", nil).
+					GetPR(ctx, "", "pr-987").
+					Return(&domain.PullRequest{PullRequestID: "pr-987", AuthorID: "u5", TeamName: "backend"}, nil).
 					Once()
+				userStorage.EXPECT().GetUser(ctx, "u5").Return(&domain.User{UserID: "u5"}, nil).Once()
+				prStorage.EXPECT().GetRequiredTags(ctx, "", "pr-987").Return(nil, nil).Once()
 
 				userStorage.EXPECT().
-					GetPotentialReviewersIDs(ctx, "u5", "u17", 1).
-					Return([]string{"u18"}, nil).
+					GetReviewerPool(ctx, "backend").
+					Return(activeUsers("u18"), nil).
 					Once()
 
 				prStorage.EXPECT().
-					ReassignReviewer(ctx, "pr-987", "u17", "u18").
+					ReassignReviewer(ctx, "", "pr-987", domain.Reassignment{OldReviewerID: "u17", Replacement: &randomRationales("u18")[0]}, false).
 					Return(nil, storageErr.ErrPRMerged).
 					Once()
 			},
@@ -539,17 +945,19 @@ func TestService_ReassignReviewer(t *testing.T) {
 			oldReviewerID: "u19",
 			setupMocks: func(userStorage *mocks.MockUserStorage, prStorage *mocks.MockPRStorage) {
 				prStorage.EXPECT().
-					GetPRAuthorID(ctx, "pr-555").
-					Return("u6", nil).
+					GetPR(ctx, "", "pr-555").
+					Return(&domain.PullRequest{PullRequestID: "pr-555", AuthorID: "u6", TeamName: "backend"}, nil).
 					Once()
+				userStorage.EXPECT().GetUser(ctx, "u6").Return(&domain.User{UserID: "u6"}, nil).Once()
+				prStorage.EXPECT().GetRequiredTags(ctx, "", "pr-555").Return(nil, nil).Once()
 
 				userStorage.EXPECT().
-					GetPotentialReviewersIDs(ctx, "u6", "u19", 1).
-					Return([]string{"u110"}, nil).
+					GetReviewerPool(ctx, "backend").
+					Return(activeUsers("u110"), nil).
 					Once()
 
 				prStorage.EXPECT().
-					ReassignReviewer(ctx, "pr-555", "u19", "u110").
+					ReassignReviewer(ctx, "", "pr-555", domain.Reassignment{OldReviewerID: "u19", Replacement: &randomRationales("u110")[0]}, false).
 					Return(nil, storageErr.ErrReviewerNotFound).
 					Once()
 			},
@@ -563,17 +971,19 @@ func TestService_ReassignReviewer(t *testing.T) {
 			oldReviewerID: "u111",
 			setupMocks: func(userStorage *mocks.MockUserStorage, prStorage *mocks.MockPRStorage) {
 				prStorage.EXPECT().
-					GetPRAuthorID(ctx, "pr-888").
-					Return("u7", nil).
+					GetPR(ctx, "", "pr-888").
+					Return(&domain.PullRequest{PullRequestID: "pr-888", AuthorID: "u7", TeamName: "backend"}, nil).
 					Once()
+				userStorage.EXPECT().GetUser(ctx, "u7").Return(&domain.User{UserID: "u7"}, nil).Once()
+				prStorage.EXPECT().GetRequiredTags(ctx, "", "pr-888").Return(nil, nil).Once()
 
 				userStorage.EXPECT().
-					GetPotentialReviewersIDs(ctx, "u7", "u111", 1).
-					Return([]string{"u112"}, nil).
+					GetReviewerPool(ctx, "backend").
+					Return(activeUsers("u112"), nil).
 					Once()
 
 				prStorage.EXPECT().
-					ReassignReviewer(ctx, "pr-888", "u111", "u112").
+					ReassignReviewer(ctx, "", "pr-888", domain.Reassignment{OldReviewerID: "u111", Replacement: &randomRationales("u112")[0]}, false).
 					Return(nil, errors.New("update failed")).
 					Once()
 			},
@@ -588,12 +998,17 @@ func TestService_ReassignReviewer(t *testing.T) {
 			// Arrange
 			userStorage := mocks.NewMockUserStorage(t)
 			prStorage := mocks.NewMockPRStorage(t)
+			repositoryStorage := mocks.NewMockRepositoryStorage(t)
 			tt.setupMocks(userStorage, prStorage)
 
-			service := New(log, userStorage, prStorage)
+			capacityMode := tt.capacityMode
+			if capacityMode == "" {
+				capacityMode = domain.CapacitySkip
+			}
+			service := New(log, userStorage, mocks.NewMockTeamStorage(t), prStorage, repositoryStorage, testIDs, Options{CapacityMode: capacityMode})
 
 			// Act
-			resultPR, resultNewID, err := service.ReassignReviewer(ctx, tt.prID, tt.oldReviewerID)
+			resultPR, resultNewID, err := service.ReassignReviewer(ctx, "", tt.prID, tt.oldReviewerID, tt.dryRun)
 
 			// Assert
 			if tt.expectedError != nil {
@@ -609,7 +1024,272 @@ func TestService_ReassignReviewer(t *testing.T) {
 				assert.Equal(t, tt.expectedPR.PullRequestID, resultPR.PullRequestID)
 				assert.Equal(t, tt.expectedPR.Status, resultPR.Status)
 				assert.Equal(t, tt.expectedNewID, resultNewID)
+				if tt.dryRun {
+					assert.Equal(t, tt.expectedPR.Rationales, resultPR.Rationales)
+				}
 			}
 		})
 	}
 }
+
+func TestService_ReassignReviewer_Seniority(t *testing.T) {
+	ctx := context.Background()
+	log := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
+
+	needsSenior := domain.SeniorityRule{AuthorLevel: domain.LevelJunior, MinLevel: domain.LevelSenior, MinReviewers: 1}
+	leveled := func(id string, level string) *domain.User {
+		return &domain.User{UserID: id, IsActive: true, Level: level}
+	}
+	excluded := []domain.ExcludedCandidate{
+		{UserID: "u1", Reason: domain.ExclusionAuthor},
+		{UserID: "u11", Reason: domain.ExclusionReplaced},
+		{UserID: "u12", Reason: domain.ExclusionAssigned},
+	}
+
+	tests := []struct {
+		name               string
+		pool               []*domain.User
+		expectedRationale  domain.AssignmentRationale
+		expectedViolations []domain.SeniorityViolation
+	}{
+		{
+			name: "success - a senior replaces the only senior",
+			pool: []*domain.User{
+				leveled("u1", domain.LevelJunior),
+				leveled("u11", domain.LevelSenior),
+				leveled("u12", domain.LevelJunior),
+				leveled("u13", domain.LevelJunior),
+				leveled("u14", domain.LevelSenior),
+			},
+			expectedRationale: domain.AssignmentRationale{
+				ReviewerID: "u14",
+				Strategy:   domain.StrategySeniority,
+				Rule:       domain.LevelSenior,
+				PoolSize:   5,
+				Excluded:   excluded,
+			},
+		},
+		{
+			name: "success - no senior left, the violation is reported",
+			pool: []*domain.User{
+				leveled("u1", domain.LevelJunior),
+				leveled("u11", domain.LevelSenior),
+				leveled("u12", domain.LevelJunior),
+				leveled("u13", domain.LevelMiddle),
+			},
+			expectedRationale: domain.AssignmentRationale{
+				ReviewerID: "u13",
+				Strategy:   domain.StrategyRandom,
+				PoolSize:   4,
+				Excluded:   excluded,
+			},
+			expectedViolations: []domain.SeniorityViolation{{Rule: needsSenior}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			userStorage := mocks.NewMockUserStorage(t)
+			teamStorage := mocks.NewMockTeamStorage(t)
+			prStorage := mocks.NewMockPRStorage(t)
+
+			newID := tt.expectedRationale.ReviewerID
+			prStorage.EXPECT().
+				GetPR(ctx, "", "pr-1").
+				Return(&domain.PullRequest{
+					PullRequestID:     "pr-1",
+					AuthorID:          "u1",
+					TeamName:          "backend",
+					AssignedReviewers: []string{"u11", "u12"},
+				}, nil).
+				Once()
+			userStorage.EXPECT().GetUser(ctx, "u1").Return(leveled("u1", domain.LevelJunior), nil).Once()
+			teamStorage.EXPECT().GetSeniorityRules(ctx, "backend").Return([]domain.SeniorityRule{needsSenior}, nil).Once()
+			userStorage.EXPECT().GetReviewerPool(ctx, "backend").Return(tt.pool, nil).Once()
+			prStorage.EXPECT().GetRequiredTags(ctx, "", "pr-1").Return(nil, nil).Once()
+			prStorage.EXPECT().
+				ReassignReviewer(ctx, "", "pr-1", domain.Reassignment{OldReviewerID: "u11", Replacement: &tt.expectedRationale}, false).
+				Return(&domain.PullRequest{PullRequestID: "pr-1", AssignedReviewers: []string{"u12", newID}}, nil).
+				Once()
+
+			service := New(log, userStorage, teamStorage, prStorage, mocks.NewMockRepositoryStorage(t), testIDs, Options{CapacityMode: domain.CapacitySkip})
+
+			// Act
+			result, resultNewID, err := service.ReassignReviewer(ctx, "", "pr-1", "u11", false)
+
+			// Assert
+			assert.NoError(t, err)
+			assert.Equal(t, newID, resultNewID)
+			assert.Equal(t, tt.expectedViolations, result.SeniorityViolations)
+		})
+	}
+}
+
+func TestService_ReassignReviewer_RequiredTags(t *testing.T) {
+	ctx := context.Background()
+	log := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
+
+	tagged := func(id string, tags ...string) *domain.User {
+		return &domain.User{UserID: id, IsActive: true, Tags: tags}
+	}
+	excluded := []domain.ExcludedCandidate{
+		{UserID: "u1", Reason: domain.ExclusionAuthor},
+		{UserID: "u11", Reason: domain.ExclusionReplaced},
+		{UserID: "u12", Reason: domain.ExclusionAssigned},
+	}
+
+	tests := []struct {
+		name              string
+		pool              []*domain.User
+		expectedRationale domain.AssignmentRationale
+	}{
+		{
+			name:              "success - a tag holder replaces the only one",
+			pool:              []*domain.User{tagged("u1"), tagged("u11", "security"), tagged("u12"), tagged("u13"), tagged("u14", "security")},
+			expectedRationale: domain.AssignmentRationale{ReviewerID: "u14", Strategy: domain.StrategySkill, Rule: "security", PoolSize: 5, Excluded: excluded},
+		},
+		{
+			name:              "success - the tag stays covered by another reviewer",
+			pool:              []*domain.User{tagged("u1"), tagged("u11", "security"), tagged("u12", "security"), tagged("u13"), tagged("u14", "security")},
+			expectedRationale: domain.AssignmentRationale{ReviewerID: "u13", Strategy: domain.StrategyRandom, PoolSize: 5, Excluded: excluded},
+		},
+		{
+			name:              "success - nobody else has the tag",
+			pool:              []*domain.User{tagged("u1"), tagged("u11", "security"), tagged("u12"), tagged("u13")},
+			expectedRationale: domain.AssignmentRationale{ReviewerID: "u13", Strategy: domain.StrategyRandom, PoolSize: 4, Excluded: excluded},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			userStorage := mocks.NewMockUserStorage(t)
+			prStorage := mocks.NewMockPRStorage(t)
+
+			newID := tt.expectedRationale.ReviewerID
+			prStorage.EXPECT().
+				GetPR(ctx, "", "pr-1").
+				Return(&domain.PullRequest{
+					PullRequestID:     "pr-1",
+					AuthorID:          "u1",
+					TeamName:          "backend",
+					AssignedReviewers: []string{"u11", "u12"},
+				}, nil).
+				Once()
+			userStorage.EXPECT().GetUser(ctx, "u1").Return(tagged("u1"), nil).Once()
+			userStorage.EXPECT().GetReviewerPool(ctx, "backend").Return(tt.pool, nil).Once()
+			prStorage.EXPECT().GetRequiredTags(ctx, "", "pr-1").Return([]string{"security"}, nil).Once()
+			prStorage.EXPECT().
+				ReassignReviewer(ctx, "", "pr-1", domain.Reassignment{OldReviewerID: "u11", Replacement: &tt.expectedRationale}, false).
+				Return(&domain.PullRequest{PullRequestID: "pr-1", AssignedReviewers: []string{"u12", newID}}, nil).
+				Once()
+
+			service := New(log, userStorage, mocks.NewMockTeamStorage(t), prStorage, mocks.NewMockRepositoryStorage(t), testIDs, Options{CapacityMode: domain.CapacitySkip})
+
+			// Act
+			result, resultNewID, err := service.ReassignReviewer(ctx, "", "pr-1", "u11", false)
+
+			// Assert
+			assert.NoError(t, err)
+			assert.Equal(t, newID, resultNewID)
+			assert.Equal(t, []domain.AssignmentRationale{tt.expectedRationale}, result.Rationales)
+		})
+	}
+}
+
+func TestService_ReassignReviewer_CodeOwners(t *testing.T) {
+	ctx := context.Background()
+	log := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
+
+	webRule := domain.CodeOwnerRule{Pattern: "/web/", UserIDs: []string{"u11", "u21", "u22"}}
+	ownerRationale := domain.AssignmentRationale{ReviewerID: "u11", Strategy: domain.StrategyCodeOwners, Rule: "/web/", PoolSize: 3}
+	teamExcluded := []domain.ExcludedCandidate{
+		{UserID: "u1", Reason: domain.ExclusionAuthor},
+		{UserID: "u11", Reason: domain.ExclusionReplaced},
+		{UserID: "u12", Reason: domain.ExclusionAssigned},
+	}
+
+	tests := []struct {
+		name              string
+		rationales        []domain.AssignmentRationale
+		owners            []*domain.User
+		expectedRationale domain.AssignmentRationale
+	}{
+		{
+			name:       "success - another owner replaces the owner",
+			rationales: []domain.AssignmentRationale{ownerRationale},
+			owners:     append(activeUsers("u11", "u21"), &domain.User{UserID: "u22"}),
+			expectedRationale: domain.AssignmentRationale{
+				ReviewerID: "u21",
+				Strategy:   domain.StrategyCodeOwners,
+				Rule:       "/web/",
+				PoolSize:   3,
+				Excluded: []domain.ExcludedCandidate{
+					{UserID: "u11", Reason: domain.ExclusionReplaced},
+					{UserID: "u22", Reason: domain.ExclusionInactive},
+				},
+			},
+		},
+		{
+			name:              "success - no other owner can review, the team fills in",
+			rationales:        []domain.AssignmentRationale{ownerRationale},
+			owners:            append(activeUsers("u11"), &domain.User{UserID: "u22"}),
+			expectedRationale: domain.AssignmentRationale{ReviewerID: "u13", Strategy: domain.StrategyRandom, PoolSize: 4, Excluded: teamExcluded},
+		},
+		{
+			name:              "success - another reviewer owns the rule too",
+			rationales:        []domain.AssignmentRationale{ownerRationale},
+			owners:            activeUsers("u11", "u12", "u21"),
+			expectedRationale: domain.AssignmentRationale{ReviewerID: "u13", Strategy: domain.StrategyRandom, PoolSize: 4, Excluded: teamExcluded},
+		},
+		{
+			name:              "success - the reviewer was not picked for a rule",
+			rationales:        randomRationales("u11", "u12"),
+			expectedRationale: domain.AssignmentRationale{ReviewerID: "u13", Strategy: domain.StrategyRandom, PoolSize: 4, Excluded: teamExcluded},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			userStorage := mocks.NewMockUserStorage(t)
+			prStorage := mocks.NewMockPRStorage(t)
+			repositoryStorage := mocks.NewMockRepositoryStorage(t)
+
+			newID := tt.expectedRationale.ReviewerID
+			prStorage.EXPECT().
+				GetPR(ctx, "acme/web", "pr-1").
+				Return(&domain.PullRequest{
+					Repository:        "acme/web",
+					PullRequestID:     "pr-1",
+					AuthorID:          "u1",
+					TeamName:          "backend",
+					AssignedReviewers: []string{"u11", "u12"},
+				}, nil).
+				Once()
+			userStorage.EXPECT().GetUser(ctx, "u1").Return(&domain.User{UserID: "u1"}, nil).Once()
+			userStorage.EXPECT().GetReviewerPool(ctx, "backend").Return(activeUsers("u1", "u11", "u12", "u13"), nil).Once()
+			prStorage.EXPECT().GetRequiredTags(ctx, "acme/web", "pr-1").Return(nil, nil).Once()
+			prStorage.EXPECT().GetAssignmentRationales(ctx, "acme/web", "pr-1").Return(tt.rationales, nil).Once()
+			if tt.owners != nil {
+				repositoryStorage.EXPECT().GetCodeOwners(ctx, "acme/web").Return([]domain.CodeOwnerRule{webRule}, nil).Once()
+				userStorage.EXPECT().GetCodeOwnerPool(ctx, webRule.UserIDs, []string(nil)).Return(tt.owners, nil).Once()
+			}
+			prStorage.EXPECT().
+				ReassignReviewer(ctx, "acme/web", "pr-1", domain.Reassignment{OldReviewerID: "u11", Replacement: &tt.expectedRationale}, false).
+				Return(&domain.PullRequest{PullRequestID: "pr-1", AssignedReviewers: []string{"u12", newID}}, nil).
+				Once()
+
+			service := New(log, userStorage, mocks.NewMockTeamStorage(t), prStorage, repositoryStorage, testIDs, Options{CapacityMode: domain.CapacitySkip})
+
+			// Act
+			result, resultNewID, err := service.ReassignReviewer(ctx, "acme/web", "pr-1", "u11", false)
+
+			// Assert
+			assert.NoError(t, err)
+			assert.Equal(t, newID, resultNewID)
+			assert.Equal(t, []domain.AssignmentRationale{tt.expectedRationale}, result.Rationales)
+		})
+	}
+}
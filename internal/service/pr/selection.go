@@ -0,0 +1,563 @@
+package pr
+
+import (
+	"context"
+	"hash/fnv"
+	"log/slog"
+	"slices"
+	"sort"
+
+	"github.com/whitxowl/pr-reviewer-assignment-service.git/internal/domain"
+	"github.com/whitxowl/pr-reviewer-assignment-service.git/internal/service/codeowners"
+)
+
+// selection is the outcome of selectReviewers.
+type selection struct {
+	// candidates are the eligible reviewers in the order they were tried.
+	candidates []domain.Candidate
+	rationales []domain.AssignmentRationale
+	// queued counts the team slots left empty for later assignment.
+	queued int
+	// uncoveredTags are the required tags no eligible team member has.
+	uncoveredTags []string
+	// violations are the seniority rules the picks do not satisfy.
+	violations []domain.SeniorityViolation
+}
+
+// selectReviewers picks the reviewers of a new PR. Every code owner rule of repo
+// matching changedFiles gets at least one of its owners, every tag of
+// requiredTags at least one team member with the tag and every seniority rule of
+// the team binding the author as many team members of its level as it requires,
+// even past the reviewers count; the remaining slots are filled from the team.
+// Candidates assigned to the author less within the pairing window go first.
+// Everything the selection reads comes from r.
+// Candidates at capacity are passed over; what happens when nobody else is left
+// depends on the capacity mode. Team slots nobody could fill are queued, except
+// those the capacity mode skips.
+func (s *Service) selectReviewers(
+	ctx context.Context,
+	r reads,
+	repo *domain.Repository,
+	prID string,
+	teamName string,
+	author *domain.User,
+	changedFiles []string,
+	requiredTags []string,
+) (*selection, error) {
+	authorID := author.UserID
+	ref := domain.PRRef{Repository: repo.Name, PullRequestID: prID}
+
+	pairings, err := s.pairingCounts(ctx, r, authorID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &selection{}
+	var selected []string
+	// picked are the selected users, whose tags cover the required tags.
+	var picked []*domain.User
+
+	if repo.Name != "" && len(changedFiles) > 0 {
+		rules, err := r.GetCodeOwners(ctx, repo.Name)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, rule := range codeowners.MatchedRules(rules, changedFiles) {
+			if len(rule.UserIDs) == 0 && len(rule.TeamNames) == 0 {
+				continue
+			}
+
+			pool, err := r.GetCodeOwnerPool(ctx, rule.UserIDs, rule.TeamNames)
+			if err != nil {
+				return nil, err
+			}
+			s.order(ref, pool)
+
+			eligible, excluded := screen(pool, authorID, "", nil)
+			eligible = preferFresh(eligible, pairings)
+			eligible, excludedFor := s.preferInHours(pool, eligible, excluded)
+			strategy := domain.StrategyCodeOwners
+			if len(eligible) == 0 && s.opts.CapacityMode == domain.CapacityAssign {
+				eligible, strategy = overCapacity(pool, excluded), domain.StrategyOverCapacity
+			}
+			result.candidates = appendCandidates(result.candidates, eligible, strategy, rule.Pattern)
+
+			if slices.ContainsFunc(pool, func(user *domain.User) bool {
+				return slices.Contains(selected, user.UserID)
+			}) {
+				// An owner picked for an earlier rule reviews for this one too.
+				continue
+			}
+			if len(eligible) == 0 {
+				continue
+			}
+
+			selected = append(selected, eligible[0])
+			picked = append(picked, findUser(pool, eligible[0]))
+			result.rationales = append(result.rationales, domain.AssignmentRationale{
+				ReviewerID: eligible[0],
+				Strategy:   strategy,
+				Rule:       rule.Pattern,
+				PoolSize:   len(pool),
+				Excluded:   excludedFor(eligible[0]),
+			})
+		}
+	}
+
+	rules, err := s.seniorityRules(ctx, r, teamName, author)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(requiredTags) == 0 && len(rules) == 0 && len(selected) >= repo.ReviewersCount {
+		return result, nil
+	}
+
+	pool, err := r.GetReviewerPool(ctx, teamName)
+	if err != nil {
+		return nil, err
+	}
+	s.order(ref, pool)
+
+	picks := &teamPicks{authorID: authorID, pairings: pairings, selected: selected, picked: picked, limit: -1, result: result}
+	s.pickTags(picks, pool, requiredTags)
+	s.pickLevels(picks, pool, rules)
+	selected = picks.selected
+
+	remaining := repo.ReviewersCount - len(selected)
+	if remaining <= 0 {
+		return result, nil
+	}
+
+	eligible, excluded := screen(pool, authorID, "", selected)
+	eligible = preferFresh(eligible, pairings)
+	eligible, excludedFor := s.preferInHours(pool, eligible, excluded)
+	result.candidates = appendCandidates(result.candidates, eligible, domain.StrategyRandom, "")
+	for _, id := range eligible[:min(remaining, len(eligible))] {
+		result.rationales = append(result.rationales, domain.AssignmentRationale{
+			ReviewerID: id,
+			Strategy:   domain.StrategyRandom,
+			PoolSize:   len(pool),
+			Excluded:   excludedFor(id),
+		})
+	}
+
+	remaining -= min(remaining, len(eligible))
+	full := overCapacity(pool, excluded)
+	blocked := min(remaining, len(full))
+
+	switch s.opts.CapacityMode {
+	case domain.CapacityAssign:
+		result.candidates = appendCandidates(result.candidates, full, domain.StrategyOverCapacity, "")
+		for _, id := range full[:blocked] {
+			result.rationales = append(result.rationales, domain.AssignmentRationale{
+				ReviewerID: id,
+				Strategy:   domain.StrategyOverCapacity,
+				PoolSize:   len(pool),
+				Excluded:   excluded,
+			})
+		}
+		result.queued = remaining - blocked
+	case domain.CapacityQueue:
+		result.queued = remaining
+	default:
+		result.queued = remaining - blocked
+	}
+
+	return result, nil
+}
+
+// teamPicks is the state of picking the reviewers of a PR from its team.
+type teamPicks struct {
+	authorID string
+	pairings map[string]int
+	// selected are the reviewers of the PR so far, who are not picked again.
+	selected []string
+	// picked are the reviewers whose tags and levels count towards the required
+	// tags and the seniority rules.
+	picked []*domain.User
+	// limit caps the rationales of result, none if negative.
+	limit  int
+	result *selection
+}
+
+// room returns how many of n more picks fit under the limit.
+func (p *teamPicks) room(n int) int {
+	if p.limit < 0 {
+		return n
+	}
+	return max(0, min(n, p.limit-len(p.result.rationales)))
+}
+
+func (p *teamPicks) add(user *domain.User, rationale domain.AssignmentRationale) {
+	p.selected = append(p.selected, user.UserID)
+	p.picked = append(p.picked, user)
+	p.result.rationales = append(p.result.rationales, rationale)
+}
+
+// pickTags picks a member of pool with every required tag no reviewer picked so
+// far has. Tags nobody eligible has are recorded as uncovered.
+func (s *Service) pickTags(p *teamPicks, pool []*domain.User, requiredTags []string) {
+	for _, tag := range requiredTags {
+		if p.room(1) == 0 {
+			return
+		}
+		if slices.ContainsFunc(p.picked, func(user *domain.User) bool { return slices.Contains(user.Tags, tag) }) {
+			// A reviewer picked earlier has the tag.
+			continue
+		}
+
+		var tagPool []*domain.User
+		for _, user := range pool {
+			if slices.Contains(user.Tags, tag) {
+				tagPool = append(tagPool, user)
+			}
+		}
+
+		eligible, excluded := screen(tagPool, p.authorID, "", p.selected)
+		eligible = preferFresh(eligible, p.pairings)
+		eligible, excludedFor := s.preferInHours(tagPool, eligible, excluded)
+		strategy := domain.StrategySkill
+		if len(eligible) == 0 && s.opts.CapacityMode == domain.CapacityAssign {
+			eligible, strategy = overCapacity(tagPool, excluded), domain.StrategyOverCapacity
+		}
+		p.result.candidates = appendCandidates(p.result.candidates, eligible, strategy, tag)
+
+		if len(eligible) == 0 {
+			p.result.uncoveredTags = append(p.result.uncoveredTags, tag)
+			continue
+		}
+
+		p.add(findUser(tagPool, eligible[0]), domain.AssignmentRationale{
+			ReviewerID: eligible[0],
+			Strategy:   strategy,
+			Rule:       tag,
+			PoolSize:   len(tagPool),
+			Excluded:   excludedFor(eligible[0]),
+		})
+	}
+}
+
+// pickLevels picks as many members of pool as every seniority rule lacks
+// reviewers of its level. Rules still unmet are recorded as violations.
+func (s *Service) pickLevels(p *teamPicks, pool []*domain.User, rules []domain.SeniorityRule) {
+	for _, rule := range rules {
+		assigned := countAtLeast(p.picked, rule.MinLevel)
+		if assigned >= rule.MinReviewers {
+			continue
+		}
+
+		var levelPool []*domain.User
+		for _, user := range pool {
+			if user.AtLeast(rule.MinLevel) {
+				levelPool = append(levelPool, user)
+			}
+		}
+
+		eligible, excluded := screen(levelPool, p.authorID, "", p.selected)
+		eligible = preferFresh(eligible, p.pairings)
+		eligible, excludedFor := s.preferInHours(levelPool, eligible, excluded)
+		strategy := domain.StrategySeniority
+		if len(eligible) == 0 && s.opts.CapacityMode == domain.CapacityAssign {
+			eligible, strategy = overCapacity(levelPool, excluded), domain.StrategyOverCapacity
+		}
+		p.result.candidates = appendCandidates(p.result.candidates, eligible, strategy, rule.MinLevel)
+
+		for _, id := range eligible[:p.room(min(rule.MinReviewers-assigned, len(eligible)))] {
+			p.add(findUser(levelPool, id), domain.AssignmentRationale{
+				ReviewerID: id,
+				Strategy:   strategy,
+				Rule:       rule.MinLevel,
+				PoolSize:   len(levelPool),
+				Excluded:   excludedFor(id),
+			})
+		}
+
+		if assigned = countAtLeast(p.picked, rule.MinLevel); assigned < rule.MinReviewers {
+			p.result.violations = append(p.result.violations, domain.SeniorityViolation{Rule: rule, Assigned: assigned})
+		}
+	}
+}
+
+// seniorityRules returns the seniority rules of the team binding PRs by author.
+// An author without a level is bound by none.
+func (s *Service) seniorityRules(ctx context.Context, r reads, teamName string, author *domain.User) ([]domain.SeniorityRule, error) {
+	if author.Level == "" {
+		return nil, nil
+	}
+
+	rules, err := r.GetSeniorityRules(ctx, teamName)
+	if err != nil {
+		return nil, err
+	}
+
+	return slices.DeleteFunc(rules, func(rule domain.SeniorityRule) bool { return !rule.Applies(author) }), nil
+}
+
+// order puts the pool of the PR in the order its candidates are tried: shuffled
+// with the random source in the random order, by a hash of the PR and user IDs
+// in the hash order.
+func (s *Service) order(ref domain.PRRef, pool []*domain.User) {
+	switch s.opts.Order {
+	case domain.OrderRandom:
+		s.randMu.Lock()
+		defer s.randMu.Unlock()
+
+		s.rand.Shuffle(len(pool), func(i, j int) { pool[i], pool[j] = pool[j], pool[i] })
+	case domain.OrderHash:
+		hashes := make(map[string]uint64, len(pool))
+		for _, user := range pool {
+			h := fnv.New64a()
+			for _, part := range []string{ref.Repository, ref.PullRequestID, user.UserID} {
+				h.Write([]byte(part))
+				h.Write([]byte{0})
+			}
+			hashes[user.UserID] = h.Sum64()
+		}
+
+		sort.SliceStable(pool, func(i, j int) bool { return hashes[pool[i].UserID] < hashes[pool[j].UserID] })
+	}
+}
+
+// pairingCounts returns how often each user was assigned to the author's PRs
+// within the pairing window, nil when the service does not track pairings.
+func (s *Service) pairingCounts(ctx context.Context, r reads, authorID string) (map[string]int, error) {
+	if s.opts.PairingWindow <= 0 {
+		return nil, nil
+	}
+
+	pairings, err := r.GetPairings(ctx, []string{authorID}, s.opts.PairingWindow)
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make(map[string]int, len(pairings))
+	for _, pairing := range pairings {
+		counts[pairing.ReviewerID] = pairing.Count
+	}
+	return counts, nil
+}
+
+// preferFresh moves the eligible users with fewer pairings ahead of the others,
+// keeping the pool order among users with as many.
+func preferFresh(eligible []string, pairings map[string]int) []string {
+	if len(pairings) == 0 {
+		return eligible
+	}
+
+	ordered := slices.Clone(eligible)
+	sort.SliceStable(ordered, func(i, j int) bool { return pairings[ordered[i]] < pairings[ordered[j]] })
+	return ordered
+}
+
+// unmetRules returns the rules that reviewers do not satisfy, and violations
+// the same rules with the number of reviewers meeting their level.
+func unmetRules(rules []domain.SeniorityRule, reviewers []*domain.User) (unmet []domain.SeniorityRule, violations []domain.SeniorityViolation) {
+	for _, rule := range rules {
+		if assigned := countAtLeast(reviewers, rule.MinLevel); assigned < rule.MinReviewers {
+			unmet = append(unmet, rule)
+			violations = append(violations, domain.SeniorityViolation{Rule: rule, Assigned: assigned})
+		}
+	}
+	return unmet, violations
+}
+
+// preferSenior moves the eligible users meeting the levels of more unmet rules
+// ahead of the others.
+func preferSenior(pool []*domain.User, eligible []string, unmet []domain.SeniorityRule) []string {
+	if len(unmet) == 0 {
+		return eligible
+	}
+
+	met := func(id string) int {
+		user := findUser(pool, id)
+		return len(slices.DeleteFunc(slices.Clone(unmet), func(rule domain.SeniorityRule) bool { return !user.AtLeast(rule.MinLevel) }))
+	}
+
+	ordered := slices.Clone(eligible)
+	sort.SliceStable(ordered, func(i, j int) bool { return met(ordered[i]) > met(ordered[j]) })
+	return ordered
+}
+
+// metLevel returns the highest level of the unmet rules the user meets, empty if
+// it meets none.
+func metLevel(user *domain.User, unmet []domain.SeniorityRule) string {
+	level := ""
+	for _, rule := range unmet {
+		if user.AtLeast(rule.MinLevel) && domain.LevelAtLeast(rule.MinLevel, level) {
+			level = rule.MinLevel
+		}
+	}
+	return level
+}
+
+// uncoveredTags returns the required tags none of reviewers has.
+func uncoveredTags(requiredTags []string, reviewers []*domain.User) []string {
+	var uncovered []string
+	for _, tag := range requiredTags {
+		if !slices.ContainsFunc(reviewers, func(user *domain.User) bool { return slices.Contains(user.Tags, tag) }) {
+			uncovered = append(uncovered, tag)
+		}
+	}
+	return uncovered
+}
+
+// preferTagged moves the eligible users with more of the uncovered tags ahead of
+// the others. tag is the first uncovered tag the first one has, empty if it has
+// none.
+func preferTagged(pool []*domain.User, eligible []string, uncovered []string) (ordered []string, tag string) {
+	if len(uncovered) == 0 || len(eligible) == 0 {
+		return eligible, ""
+	}
+
+	has := func(id string) []string {
+		user := findUser(pool, id)
+		return slices.DeleteFunc(slices.Clone(uncovered), func(tag string) bool { return !slices.Contains(user.Tags, tag) })
+	}
+
+	ordered = slices.Clone(eligible)
+	sort.SliceStable(ordered, func(i, j int) bool { return len(has(ordered[i])) > len(has(ordered[j])) })
+
+	if tags := has(ordered[0]); len(tags) > 0 {
+		tag = tags[0]
+	}
+	return ordered, tag
+}
+
+// countAtLeast counts the users of level or above.
+func countAtLeast(users []*domain.User, level string) int {
+	n := 0
+	for _, user := range users {
+		if user.AtLeast(level) {
+			n++
+		}
+	}
+	return n
+}
+
+// overCapacity returns the users of pool excluded only for being at capacity,
+// least loaded first.
+func overCapacity(pool []*domain.User, excluded []domain.ExcludedCandidate) []string {
+	var full []*domain.User
+	for _, user := range pool {
+		if slices.Contains(excluded, domain.ExcludedCandidate{UserID: user.UserID, Reason: domain.ExclusionCapacity}) {
+			full = append(full, user)
+		}
+	}
+
+	sort.SliceStable(full, func(i, j int) bool { return full[i].OpenReviews < full[j].OpenReviews })
+
+	ids := make([]string, len(full))
+	for i, user := range full {
+		ids[i] = user.UserID
+	}
+	return ids
+}
+
+// findUser returns the user of pool with the ID, which must be there.
+func findUser(pool []*domain.User, id string) *domain.User {
+	return pool[slices.IndexFunc(pool, func(user *domain.User) bool { return user.UserID == id })]
+}
+
+func appendCandidates(candidates []domain.Candidate, ids []string, strategy string, rule string) []domain.Candidate {
+	for _, id := range ids {
+		candidates = append(candidates, domain.Candidate{UserID: id, Strategy: strategy, Rule: rule})
+	}
+	return candidates
+}
+
+// screen splits pool into the IDs of the users that may review, in pool order,
+// and the excluded ones with the reason, ordered by user ID. replacedID is the
+// reviewer being replaced, if any, and assigned are the PR's current reviewers.
+func screen(
+	pool []*domain.User,
+	authorID string,
+	replacedID string,
+	assigned []string,
+) ([]string, []domain.ExcludedCandidate) {
+	var eligible []string
+	var excluded []domain.ExcludedCandidate
+
+	for _, user := range pool {
+		var reason string
+		switch {
+		case user.UserID == authorID:
+			reason = domain.ExclusionAuthor
+		case user.UserID == replacedID:
+			reason = domain.ExclusionReplaced
+		case slices.Contains(assigned, user.UserID):
+			reason = domain.ExclusionAssigned
+		case !user.IsActive:
+			reason = domain.ExclusionInactive
+		case user.Absent:
+			reason = domain.ExclusionAbsent
+		case user.AtCapacity():
+			reason = domain.ExclusionCapacity
+		default:
+			eligible = append(eligible, user.UserID)
+			continue
+		}
+		excluded = append(excluded, domain.ExcludedCandidate{UserID: user.UserID, Reason: reason})
+	}
+
+	sort.Slice(excluded, func(i, j int) bool { return excluded[i].UserID < excluded[j].UserID })
+
+	return eligible, excluded
+}
+
+// preferInHours moves the eligible users outside their working hours behind the
+// others when the service prefers working hours. excludedFor returns the
+// exclusions of a pick: picks inside working hours passed over the users
+// outside them, fallback picks passed over nobody more.
+func (s *Service) preferInHours(
+	pool []*domain.User,
+	eligible []string,
+	excluded []domain.ExcludedCandidate,
+) (ordered []string, excludedFor func(id string) []domain.ExcludedCandidate) {
+	all := func(string) []domain.ExcludedCandidate { return excluded }
+	if !s.opts.PreferWorkingHours {
+		return eligible, all
+	}
+
+	now := s.now()
+	var inHours, offHours []string
+	var passedOver []domain.ExcludedCandidate
+	for _, id := range eligible {
+		if findUser(pool, id).InWorkingHours(now) {
+			inHours = append(inHours, id)
+			continue
+		}
+		offHours = append(offHours, id)
+		passedOver = append(passedOver, domain.ExcludedCandidate{UserID: id, Reason: domain.ExclusionOffHours})
+	}
+	if len(offHours) == 0 {
+		return eligible, all
+	}
+
+	withOffHours := append(slices.Clone(excluded), passedOver...)
+	sort.Slice(withOffHours, func(i, j int) bool { return withOffHours[i].UserID < withOffHours[j].UserID })
+
+	return append(inHours, offHours...), func(id string) []domain.ExcludedCandidate {
+		if slices.Contains(inHours, id) {
+			return withOffHours
+		}
+		return excluded
+	}
+}
+
+// audit records an assignment decision in the log.
+func audit(ctx context.Context, log *slog.Logger, rationale domain.AssignmentRationale) {
+	excluded := make([]any, len(rationale.Excluded))
+	for i, candidate := range rationale.Excluded {
+		excluded[i] = slog.String(candidate.UserID, candidate.Reason)
+	}
+
+	log.InfoContext(ctx, "reviewer assigned",
+		slog.String("reviewer", rationale.ReviewerID),
+		slog.String("strategy", rationale.Strategy),
+		slog.String("rule", rationale.Rule),
+		slog.Int("poolSize", rationale.PoolSize),
+		slog.Group("excluded", excluded...),
+	)
+}
@@ -0,0 +1,1019 @@
+package pr
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"math/rand/v2"
+	"os"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/whitxowl/pr-reviewer-assignment-service.git/internal/domain"
+	serviceErr "github.com/whitxowl/pr-reviewer-assignment-service.git/internal/service/errors"
+	"github.com/whitxowl/pr-reviewer-assignment-service.git/internal/service/pr/mocks"
+	"github.com/whitxowl/pr-reviewer-assignment-service.git/internal/service/validation"
+)
+
+func TestService_CreatePR_CodeOwners(t *testing.T) {
+	ctx := context.Background()
+	log := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
+
+	author := &domain.User{UserID: "u1", TeamName: "backend", TeamNames: []string{"backend"}, IsActive: true}
+
+	tests := []struct {
+		name               string
+		changedFiles       []string
+		setupMocks         func(*mocks.MockUserStorage, *mocks.MockRepositoryStorage)
+		expectedRationales []domain.AssignmentRationale
+		expectedError      error
+	}{
+		{
+			name:         "success - an owner per matched rule",
+			changedFiles: []string{"web/app.ts", "README.md"},
+			setupMocks: func(userStorage *mocks.MockUserStorage, repositoryStorage *mocks.MockRepositoryStorage) {
+				repositoryStorage.EXPECT().
+					GetCodeOwners(ctx, "acme/api").
+					Return([]domain.CodeOwnerRule{
+						{Pattern: "*", TeamNames: []string{"backend"}},
+						{Pattern: "/web/", UserIDs: []string{"u5"}},
+						{Pattern: "docs/", UserIDs: []string{"u6"}},
+					}, nil).
+					Once()
+
+				userStorage.EXPECT().
+					GetCodeOwnerPool(ctx, []string(nil), []string{"backend"}).
+					Return(append(activeUsers("u1", "u11", "u12"), &domain.User{UserID: "u10"}), nil).
+					Once()
+				userStorage.EXPECT().
+					GetCodeOwnerPool(ctx, []string{"u5"}, []string(nil)).
+					Return(activeUsers("u5"), nil).
+					Once()
+			},
+			expectedRationales: []domain.AssignmentRationale{
+				{
+					ReviewerID: "u11",
+					Strategy:   domain.StrategyCodeOwners,
+					Rule:       "*",
+					PoolSize:   4,
+					Excluded: []domain.ExcludedCandidate{
+						{UserID: "u1", Reason: domain.ExclusionAuthor},
+						{UserID: "u10", Reason: domain.ExclusionInactive},
+					},
+				},
+				{ReviewerID: "u5", Strategy: domain.StrategyCodeOwners, Rule: "/web/", PoolSize: 1},
+			},
+		},
+		{
+			name:         "success - a rule is covered by an owner already picked, the team fills the rest",
+			changedFiles: []string{"main.go", "api/handler.ts"},
+			setupMocks: func(userStorage *mocks.MockUserStorage, repositoryStorage *mocks.MockRepositoryStorage) {
+				repositoryStorage.EXPECT().
+					GetCodeOwners(ctx, "acme/api").
+					Return([]domain.CodeOwnerRule{
+						{Pattern: "*.go", UserIDs: []string{"u11"}},
+						{Pattern: "api/", TeamNames: []string{"backend"}},
+					}, nil).
+					Once()
+
+				userStorage.EXPECT().
+					GetCodeOwnerPool(ctx, []string{"u11"}, []string(nil)).
+					Return(activeUsers("u11"), nil).
+					Once()
+				userStorage.EXPECT().
+					GetCodeOwnerPool(ctx, []string(nil), []string{"backend"}).
+					Return(activeUsers("u12", "u11"), nil).
+					Once()
+
+				userStorage.EXPECT().
+					GetReviewerPool(ctx, "backend").
+					Return(activeUsers("u11", "u1", "u12"), nil).
+					Once()
+			},
+			expectedRationales: []domain.AssignmentRationale{
+				{ReviewerID: "u11", Strategy: domain.StrategyCodeOwners, Rule: "*.go", PoolSize: 1},
+				{
+					ReviewerID: "u12",
+					Strategy:   domain.StrategyRandom,
+					PoolSize:   3,
+					Excluded: []domain.ExcludedCandidate{
+						{UserID: "u1", Reason: domain.ExclusionAuthor},
+						{UserID: "u11", Reason: domain.ExclusionAssigned},
+					},
+				},
+			},
+		},
+		{
+			name:         "success - owners go past the reviewers count",
+			changedFiles: []string{"a.go", "b.sql", "c.md"},
+			setupMocks: func(userStorage *mocks.MockUserStorage, repositoryStorage *mocks.MockRepositoryStorage) {
+				repositoryStorage.EXPECT().
+					GetCodeOwners(ctx, "acme/api").
+					Return([]domain.CodeOwnerRule{
+						{Pattern: "*.go", UserIDs: []string{"u11"}},
+						{Pattern: "*.sql", UserIDs: []string{"u12"}},
+						{Pattern: "*.md", UserIDs: []string{"u13"}},
+					}, nil).
+					Once()
+
+				for _, id := range []string{"u11", "u12", "u13"} {
+					userStorage.EXPECT().
+						GetCodeOwnerPool(ctx, []string{id}, []string(nil)).
+						Return(activeUsers(id), nil).
+						Once()
+				}
+			},
+			expectedRationales: []domain.AssignmentRationale{
+				{ReviewerID: "u11", Strategy: domain.StrategyCodeOwners, Rule: "*.go", PoolSize: 1},
+				{ReviewerID: "u12", Strategy: domain.StrategyCodeOwners, Rule: "*.sql", PoolSize: 1},
+				{ReviewerID: "u13", Strategy: domain.StrategyCodeOwners, Rule: "*.md", PoolSize: 1},
+			},
+		},
+		{
+			name:         "success - rules without available owners are skipped",
+			changedFiles: []string{"docs/index.md"},
+			setupMocks: func(userStorage *mocks.MockUserStorage, repositoryStorage *mocks.MockRepositoryStorage) {
+				repositoryStorage.EXPECT().
+					GetCodeOwners(ctx, "acme/api").
+					Return([]domain.CodeOwnerRule{
+						{Pattern: "*", UserIDs: []string{"u1"}},
+						{Pattern: "docs/", UserIDs: []string{}, TeamNames: []string{}},
+					}, nil).
+					Once()
+
+				userStorage.EXPECT().
+					GetReviewerPool(ctx, "backend").
+					Return(activeUsers("u11", "u12"), nil).
+					Once()
+			},
+			expectedRationales: randomRationales("u11", "u12"),
+		},
+		{
+			name: "success - no changed files",
+			setupMocks: func(userStorage *mocks.MockUserStorage, _ *mocks.MockRepositoryStorage) {
+				userStorage.EXPECT().
+					GetReviewerPool(ctx, "backend").
+					Return(activeUsers("u11", "u12"), nil).
+					Once()
+			},
+			expectedRationales: randomRationales("u11", "u12"),
+		},
+		{
+			name:         "error - get code owners fails",
+			changedFiles: []string{"main.go"},
+			setupMocks: func(_ *mocks.MockUserStorage, repositoryStorage *mocks.MockRepositoryStorage) {
+				repositoryStorage.EXPECT().
+					GetCodeOwners(ctx, "acme/api").
+					Return(nil, errors.New("query error")).
+					Once()
+			},
+			expectedError: errors.New("service.pr.CreatePR: query error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			userStorage := mocks.NewMockUserStorage(t)
+			prStorage := mocks.NewMockPRStorage(t)
+			repositoryStorage := mocks.NewMockRepositoryStorage(t)
+
+			repositoryStorage.EXPECT().
+				GetRepository(ctx, "acme/api").
+				Return(&domain.Repository{Name: "acme/api", ReviewersCount: 2}, nil).
+				Once()
+			userStorage.EXPECT().GetUser(ctx, "u1").Return(author, nil).Once()
+			tt.setupMocks(userStorage, repositoryStorage)
+
+			var reviewers []string
+			for _, rationale := range tt.expectedRationales {
+				reviewers = append(reviewers, rationale.ReviewerID)
+			}
+			if tt.expectedError == nil {
+				prStorage.EXPECT().
+					ImportPRs(ctx, []domain.PRImport{{PR: domain.PullRequest{
+						Repository:        "acme/api",
+						PullRequestID:     "pr-1",
+						PullRequestName:   "Fix API",
+						AuthorID:          "u1",
+						TeamName:          "backend",
+						Status:            statusOpen,
+						AssignedReviewers: reviewers,
+						Rationales:        tt.expectedRationales,
+					}}}).
+					Return(nil).
+					Once()
+			}
+
+			service := New(log, userStorage, mocks.NewMockTeamStorage(t), prStorage, repositoryStorage, testIDs, Options{CapacityMode: domain.CapacitySkip})
+
+			// Act
+			result, err := service.CreatePR(ctx, domain.PRDraft{
+				Repository:      "acme/api",
+				PullRequestID:   "pr-1",
+				PullRequestName: "Fix API",
+				AuthorID:        "u1",
+				ChangedFiles:    tt.changedFiles,
+			})
+
+			// Assert
+			if tt.expectedError != nil {
+				assert.EqualError(t, err, tt.expectedError.Error())
+				assert.Nil(t, result)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, reviewers, result.AssignedReviewers)
+				assert.Equal(t, tt.expectedRationales, result.Rationales)
+			}
+		})
+	}
+}
+
+func TestService_CreatePR_Capacity(t *testing.T) {
+	ctx := context.Background()
+	log := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
+
+	author := &domain.User{UserID: "u1", TeamName: "backend", TeamNames: []string{"backend"}, IsActive: true}
+	limit := 2
+	pool := []*domain.User{
+		{UserID: "u1", IsActive: true},
+		{UserID: "u13", IsActive: true, OpenReviews: 3, ReviewLimit: &limit},
+		{UserID: "u11", IsActive: true, OpenReviews: 1, ReviewLimit: &limit},
+		{UserID: "u12", IsActive: true, OpenReviews: 2, ReviewLimit: &limit},
+	}
+	excluded := []domain.ExcludedCandidate{
+		{UserID: "u1", Reason: domain.ExclusionAuthor},
+		{UserID: "u12", Reason: domain.ExclusionCapacity},
+		{UserID: "u13", Reason: domain.ExclusionCapacity},
+	}
+	picked := domain.AssignmentRationale{
+		ReviewerID: "u11",
+		Strategy:   domain.StrategyRandom,
+		PoolSize:   4,
+		Excluded:   excluded,
+	}
+
+	tests := []struct {
+		name               string
+		capacityMode       string
+		expectedRationales []domain.AssignmentRationale
+		expectedQueued     int
+	}{
+		{
+			name:               "skip - the slot is left empty",
+			capacityMode:       domain.CapacitySkip,
+			expectedRationales: []domain.AssignmentRationale{picked},
+		},
+		{
+			name:         "assign - the least loaded user at capacity is picked",
+			capacityMode: domain.CapacityAssign,
+			expectedRationales: []domain.AssignmentRationale{
+				picked,
+				{ReviewerID: "u12", Strategy: domain.StrategyOverCapacity, PoolSize: 4, Excluded: excluded},
+			},
+		},
+		{
+			name:               "queue - the slot is queued",
+			capacityMode:       domain.CapacityQueue,
+			expectedRationales: []domain.AssignmentRationale{picked},
+			expectedQueued:     1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			userStorage := mocks.NewMockUserStorage(t)
+			prStorage := mocks.NewMockPRStorage(t)
+			repositoryStorage := mocks.NewMockRepositoryStorage(t)
+
+			repositoryStorage.EXPECT().
+				GetRepository(ctx, "acme/api").
+				Return(&domain.Repository{Name: "acme/api", ReviewersCount: 2}, nil).
+				Once()
+			userStorage.EXPECT().GetUser(ctx, "u1").Return(author, nil).Once()
+			userStorage.EXPECT().GetReviewerPool(ctx, "backend").Return(pool, nil).Once()
+
+			var reviewers []string
+			for _, rationale := range tt.expectedRationales {
+				reviewers = append(reviewers, rationale.ReviewerID)
+			}
+			prStorage.EXPECT().
+				ImportPRs(ctx, []domain.PRImport{{
+					PR: domain.PullRequest{
+						Repository:        "acme/api",
+						PullRequestID:     "pr-1",
+						PullRequestName:   "Fix API",
+						AuthorID:          "u1",
+						TeamName:          "backend",
+						Status:            statusOpen,
+						AssignedReviewers: reviewers,
+						Rationales:        tt.expectedRationales,
+					},
+					Queued: tt.expectedQueued,
+				}}).
+				Return(nil).
+				Once()
+
+			service := New(log, userStorage, mocks.NewMockTeamStorage(t), prStorage, repositoryStorage, testIDs, Options{CapacityMode: tt.capacityMode})
+
+			// Act
+			result, err := service.CreatePR(ctx, domain.PRDraft{
+				Repository:      "acme/api",
+				PullRequestID:   "pr-1",
+				PullRequestName: "Fix API",
+				AuthorID:        "u1",
+			})
+
+			// Assert
+			assert.NoError(t, err)
+			assert.Equal(t, reviewers, result.AssignedReviewers)
+			assert.Equal(t, tt.expectedRationales, result.Rationales)
+		})
+	}
+}
+
+func TestService_CreatePR_RequiredTags(t *testing.T) {
+	ctx := context.Background()
+	log := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
+
+	author := &domain.User{UserID: "u1", TeamName: "backend", TeamNames: []string{"backend"}, IsActive: true}
+	tagged := func(id string, active bool, tags ...string) *domain.User {
+		return &domain.User{UserID: id, IsActive: active, Tags: tags}
+	}
+	authorExcluded := []domain.ExcludedCandidate{{UserID: "u1", Reason: domain.ExclusionAuthor}}
+	noRust := []domain.ExcludedCandidate{
+		{UserID: "u1", Reason: domain.ExclusionAuthor},
+		{UserID: "u14", Reason: domain.ExclusionInactive},
+	}
+
+	tests := []struct {
+		name               string
+		requiredTags       []string
+		pool               []*domain.User
+		expectedRationales []domain.AssignmentRationale
+		expectedError      error
+	}{
+		{
+			name:         "success - a reviewer per required tag",
+			requiredTags: []string{"Postgres", "go"},
+			pool: []*domain.User{
+				tagged("u1", true, "go"),
+				tagged("u11", true, "go"),
+				tagged("u12", true, "go", "postgres"),
+				tagged("u13", true),
+			},
+			expectedRationales: []domain.AssignmentRationale{
+				{ReviewerID: "u11", Strategy: domain.StrategySkill, Rule: "go", PoolSize: 3, Excluded: authorExcluded},
+				{ReviewerID: "u12", Strategy: domain.StrategySkill, Rule: "postgres", PoolSize: 1},
+			},
+		},
+		{
+			name:         "success - tags need more reviewers than the count",
+			requiredTags: []string{"go", "postgres", "security"},
+			pool: []*domain.User{
+				tagged("u11", true, "go"),
+				tagged("u12", true, "postgres"),
+				tagged("u13", true, "security"),
+			},
+			expectedRationales: []domain.AssignmentRationale{
+				{ReviewerID: "u11", Strategy: domain.StrategySkill, Rule: "go", PoolSize: 1},
+				{ReviewerID: "u12", Strategy: domain.StrategySkill, Rule: "postgres", PoolSize: 1},
+				{ReviewerID: "u13", Strategy: domain.StrategySkill, Rule: "security", PoolSize: 1},
+			},
+		},
+		{
+			name:         "success - one reviewer covers several tags, the team fills the rest",
+			requiredTags: []string{"go", "postgres"},
+			pool: []*domain.User{
+				tagged("u1", true),
+				tagged("u11", true, "go", "postgres"),
+				tagged("u12", true),
+			},
+			expectedRationales: []domain.AssignmentRationale{
+				{ReviewerID: "u11", Strategy: domain.StrategySkill, Rule: "go", PoolSize: 1},
+				{
+					ReviewerID: "u12",
+					Strategy:   domain.StrategyRandom,
+					PoolSize:   3,
+					Excluded:   append(slices.Clone(authorExcluded), domain.ExcludedCandidate{UserID: "u11", Reason: domain.ExclusionAssigned}),
+				},
+			},
+		},
+		{
+			name:         "success - nobody eligible has the tag",
+			requiredTags: []string{"rust"},
+			pool: []*domain.User{
+				tagged("u1", true, "rust"),
+				tagged("u11", true),
+				tagged("u12", true),
+				tagged("u14", false, "rust"),
+			},
+			expectedRationales: []domain.AssignmentRationale{
+				{ReviewerID: "u11", Strategy: domain.StrategyRandom, PoolSize: 4, Excluded: noRust},
+				{ReviewerID: "u12", Strategy: domain.StrategyRandom, PoolSize: 4, Excluded: noRust},
+			},
+		},
+		{
+			name:          "error - invalid tag",
+			requiredTags:  []string{"c sharp"},
+			expectedError: serviceErr.ErrInvalidTag,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			userStorage := mocks.NewMockUserStorage(t)
+			prStorage := mocks.NewMockPRStorage(t)
+
+			var reviewers []string
+			for _, rationale := range tt.expectedRationales {
+				reviewers = append(reviewers, rationale.ReviewerID)
+			}
+			if tt.expectedError == nil {
+				userStorage.EXPECT().GetUser(ctx, "u1").Return(author, nil).Once()
+				userStorage.EXPECT().GetReviewerPool(ctx, "backend").Return(tt.pool, nil).Once()
+				requiredTags, _ := validation.Tags(tt.requiredTags)
+				prStorage.EXPECT().
+					ImportPRs(ctx, []domain.PRImport{{
+						PR: domain.PullRequest{
+							PullRequestID:     "pr-1",
+							PullRequestName:   "Fix API",
+							AuthorID:          "u1",
+							TeamName:          "backend",
+							Status:            statusOpen,
+							AssignedReviewers: reviewers,
+							Rationales:        tt.expectedRationales,
+						},
+						RequiredTags: requiredTags,
+					}}).
+					Return(nil).
+					Once()
+			}
+
+			service := New(log, userStorage, mocks.NewMockTeamStorage(t), prStorage, mocks.NewMockRepositoryStorage(t), testIDs, Options{CapacityMode: domain.CapacitySkip})
+
+			// Act
+			result, err := service.CreatePR(ctx, domain.PRDraft{
+				PullRequestID:   "pr-1",
+				PullRequestName: "Fix API",
+				AuthorID:        "u1",
+				RequiredTags:    tt.requiredTags,
+			})
+
+			// Assert
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				assert.Nil(t, result)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, reviewers, result.AssignedReviewers)
+				assert.Equal(t, tt.expectedRationales, result.Rationales)
+			}
+		})
+	}
+}
+
+func TestService_CreatePR_Seniority(t *testing.T) {
+	ctx := context.Background()
+	log := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
+
+	author := &domain.User{
+		UserID:    "u1",
+		TeamName:  "backend",
+		TeamNames: []string{"backend"},
+		IsActive:  true,
+		Level:     domain.LevelJunior,
+	}
+	leveled := func(id string, active bool, level string) *domain.User {
+		return &domain.User{UserID: id, IsActive: active, Level: level}
+	}
+	needsSenior := domain.SeniorityRule{AuthorLevel: domain.LevelJunior, MinLevel: domain.LevelSenior, MinReviewers: 1}
+	needsMiddle := domain.SeniorityRule{AuthorLevel: domain.LevelJunior, MinLevel: domain.LevelMiddle, MinReviewers: 1}
+	authorExcluded := []domain.ExcludedCandidate{{UserID: "u1", Reason: domain.ExclusionAuthor}}
+
+	tests := []struct {
+		name               string
+		rules              []domain.SeniorityRule
+		pool               []*domain.User
+		expectedRationales []domain.AssignmentRationale
+		expectedViolations []domain.SeniorityViolation
+	}{
+		{
+			name:  "success - a senior reviews a junior's PR",
+			rules: []domain.SeniorityRule{needsSenior},
+			pool: []*domain.User{
+				leveled("u1", true, domain.LevelJunior),
+				leveled("u11", true, domain.LevelJunior),
+				leveled("u12", true, domain.LevelSenior),
+			},
+			expectedRationales: []domain.AssignmentRationale{
+				{ReviewerID: "u12", Strategy: domain.StrategySeniority, Rule: domain.LevelSenior, PoolSize: 1},
+				{
+					ReviewerID: "u11",
+					Strategy:   domain.StrategyRandom,
+					PoolSize:   3,
+					Excluded:   append(slices.Clone(authorExcluded), domain.ExcludedCandidate{UserID: "u12", Reason: domain.ExclusionAssigned}),
+				},
+			},
+		},
+		{
+			name:  "success - two juniors do not review alone",
+			rules: []domain.SeniorityRule{needsMiddle},
+			pool: []*domain.User{
+				leveled("u11", true, domain.LevelJunior),
+				leveled("u12", true, domain.LevelJunior),
+				leveled("u13", true, domain.LevelSenior),
+			},
+			expectedRationales: []domain.AssignmentRationale{
+				{ReviewerID: "u13", Strategy: domain.StrategySeniority, Rule: domain.LevelMiddle, PoolSize: 1},
+				{
+					ReviewerID: "u11",
+					Strategy:   domain.StrategyRandom,
+					PoolSize:   3,
+					Excluded:   []domain.ExcludedCandidate{{UserID: "u13", Reason: domain.ExclusionAssigned}},
+				},
+			},
+		},
+		{
+			name:  "success - a senior picked for one rule satisfies the other",
+			rules: []domain.SeniorityRule{needsMiddle, needsSenior},
+			pool: []*domain.User{
+				leveled("u11", true, domain.LevelSenior),
+				leveled("u12", true, domain.LevelMiddle),
+			},
+			expectedRationales: []domain.AssignmentRationale{
+				{ReviewerID: "u11", Strategy: domain.StrategySeniority, Rule: domain.LevelMiddle, PoolSize: 2},
+				{
+					ReviewerID: "u12",
+					Strategy:   domain.StrategyRandom,
+					PoolSize:   2,
+					Excluded:   []domain.ExcludedCandidate{{UserID: "u11", Reason: domain.ExclusionAssigned}},
+				},
+			},
+		},
+		{
+			name:  "success - rules of other author levels do not apply",
+			rules: []domain.SeniorityRule{{AuthorLevel: domain.LevelMiddle, MinLevel: domain.LevelSenior, MinReviewers: 1}},
+			pool: []*domain.User{
+				leveled("u11", true, domain.LevelJunior),
+				leveled("u12", true, domain.LevelJunior),
+			},
+			expectedRationales: randomRationales("u11", "u12"),
+		},
+		{
+			name:  "success - no eligible senior, the violation is reported",
+			rules: []domain.SeniorityRule{needsSenior},
+			pool: []*domain.User{
+				leveled("u11", true, domain.LevelJunior),
+				leveled("u12", true, domain.LevelMiddle),
+				leveled("u13", false, domain.LevelSenior),
+			},
+			expectedRationales: []domain.AssignmentRationale{
+				{
+					ReviewerID: "u11",
+					Strategy:   domain.StrategyRandom,
+					PoolSize:   3,
+					Excluded:   []domain.ExcludedCandidate{{UserID: "u13", Reason: domain.ExclusionInactive}},
+				},
+				{
+					ReviewerID: "u12",
+					Strategy:   domain.StrategyRandom,
+					PoolSize:   3,
+					Excluded:   []domain.ExcludedCandidate{{UserID: "u13", Reason: domain.ExclusionInactive}},
+				},
+			},
+			expectedViolations: []domain.SeniorityViolation{{Rule: needsSenior}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			userStorage := mocks.NewMockUserStorage(t)
+			teamStorage := mocks.NewMockTeamStorage(t)
+			prStorage := mocks.NewMockPRStorage(t)
+
+			var reviewers []string
+			for _, rationale := range tt.expectedRationales {
+				reviewers = append(reviewers, rationale.ReviewerID)
+			}
+			userStorage.EXPECT().GetUser(ctx, "u1").Return(author, nil).Once()
+			teamStorage.EXPECT().GetSeniorityRules(ctx, "backend").Return(tt.rules, nil).Once()
+			userStorage.EXPECT().GetReviewerPool(ctx, "backend").Return(tt.pool, nil).Once()
+			prStorage.EXPECT().
+				ImportPRs(ctx, []domain.PRImport{{PR: domain.PullRequest{
+					PullRequestID:       "pr-1",
+					PullRequestName:     "Fix API",
+					AuthorID:            "u1",
+					TeamName:            "backend",
+					Status:              statusOpen,
+					AssignedReviewers:   reviewers,
+					Rationales:          tt.expectedRationales,
+					SeniorityViolations: tt.expectedViolations,
+				}}}).
+				Return(nil).
+				Once()
+
+			service := New(log, userStorage, teamStorage, prStorage, mocks.NewMockRepositoryStorage(t), testIDs, Options{CapacityMode: domain.CapacitySkip})
+
+			// Act
+			result, err := service.CreatePR(ctx, domain.PRDraft{PullRequestID: "pr-1", PullRequestName: "Fix API", AuthorID: "u1"})
+
+			// Assert
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedRationales, result.Rationales)
+			assert.Equal(t, tt.expectedViolations, result.SeniorityViolations)
+		})
+	}
+}
+
+func TestService_CreatePR_Pairings(t *testing.T) {
+	ctx := context.Background()
+	log := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
+
+	author := &domain.User{UserID: "u1", TeamName: "backend", TeamNames: []string{"backend"}, IsActive: true}
+	window := 30 * 24 * time.Hour
+
+	tests := []struct {
+		name              string
+		pairings          []domain.Pairing
+		pool              []*domain.User
+		expectedReviewers []string
+	}{
+		{
+			name: "success - reviewers paired with the author less go first",
+			pairings: []domain.Pairing{
+				{AuthorID: "u1", ReviewerID: "u11", Count: 3},
+				{AuthorID: "u1", ReviewerID: "u12", Count: 1},
+			},
+			pool:              activeUsers("u11", "u12", "u13"),
+			expectedReviewers: []string{"u13", "u12"},
+		},
+		{
+			name: "success - reviewers paired as often keep the pool order",
+			pairings: []domain.Pairing{
+				{AuthorID: "u1", ReviewerID: "u11", Count: 2},
+				{AuthorID: "u1", ReviewerID: "u12", Count: 2},
+				{AuthorID: "u1", ReviewerID: "u13", Count: 2},
+			},
+			pool:              activeUsers("u12", "u11", "u13"),
+			expectedReviewers: []string{"u12", "u11"},
+		},
+		{
+			name:              "success - no pairings within the window",
+			pool:              activeUsers("u12", "u11"),
+			expectedReviewers: []string{"u12", "u11"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			userStorage := mocks.NewMockUserStorage(t)
+			prStorage := mocks.NewMockPRStorage(t)
+
+			var rationales []domain.AssignmentRationale
+			for _, id := range tt.expectedReviewers {
+				rationales = append(rationales, domain.AssignmentRationale{ReviewerID: id, Strategy: domain.StrategyRandom, PoolSize: len(tt.pool)})
+			}
+			userStorage.EXPECT().GetUser(ctx, "u1").Return(author, nil).Once()
+			prStorage.EXPECT().GetPairings(ctx, []string{"u1"}, window).Return(tt.pairings, nil).Once()
+			userStorage.EXPECT().GetReviewerPool(ctx, "backend").Return(tt.pool, nil).Once()
+			prStorage.EXPECT().
+				ImportPRs(ctx, []domain.PRImport{{PR: domain.PullRequest{
+					PullRequestID:     "pr-1",
+					PullRequestName:   "Fix API",
+					AuthorID:          "u1",
+					TeamName:          "backend",
+					Status:            statusOpen,
+					AssignedReviewers: tt.expectedReviewers,
+					Rationales:        rationales,
+				}}}).
+				Return(nil).
+				Once()
+
+			service := New(log, userStorage, mocks.NewMockTeamStorage(t), prStorage, mocks.NewMockRepositoryStorage(t), testIDs, Options{
+				CapacityMode:  domain.CapacitySkip,
+				PairingWindow: window,
+			})
+
+			// Act
+			result, err := service.CreatePR(ctx, domain.PRDraft{PullRequestID: "pr-1", PullRequestName: "Fix API", AuthorID: "u1"})
+
+			// Assert
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedReviewers, result.AssignedReviewers)
+		})
+	}
+}
+
+func TestService_PreviewAssignment(t *testing.T) {
+	ctx := context.Background()
+	log := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
+
+	author := &domain.User{UserID: "u1", TeamName: "backend", TeamNames: []string{"backend", "frontend"}, IsActive: true}
+
+	tests := []struct {
+		name            string
+		draft           domain.PRDraft
+		setupMocks      func(*mocks.MockUserStorage, *mocks.MockRepositoryStorage)
+		expectedPreview *domain.AssignmentPreview
+		expectedError   error
+	}{
+		{
+			name:  "success - code owners ranked before the team",
+			draft: domain.PRDraft{Repository: "acme/api", AuthorID: "u1", ChangedFiles: []string{"api/handler.go"}},
+			setupMocks: func(userStorage *mocks.MockUserStorage, repositoryStorage *mocks.MockRepositoryStorage) {
+				repositoryStorage.EXPECT().
+					GetRepository(ctx, "acme/api").
+					Return(&domain.Repository{Name: "acme/api", TeamName: "backend", ReviewersCount: 2}, nil).
+					Once()
+				userStorage.EXPECT().GetUser(ctx, "u1").Return(author, nil).Once()
+
+				repositoryStorage.EXPECT().
+					GetCodeOwners(ctx, "acme/api").
+					Return([]domain.CodeOwnerRule{{Pattern: "api/", UserIDs: []string{"u5", "u6"}}}, nil).
+					Once()
+				userStorage.EXPECT().
+					GetCodeOwnerPool(ctx, []string{"u5", "u6"}, []string(nil)).
+					Return(activeUsers("u6", "u5"), nil).
+					Once()
+
+				userStorage.EXPECT().
+					GetReviewerPool(ctx, "backend").
+					Return(activeUsers("u12", "u6", "u11"), nil).
+					Once()
+			},
+			expectedPreview: &domain.AssignmentPreview{
+				TeamName: "backend",
+				Candidates: []domain.Candidate{
+					{UserID: "u6", Strategy: domain.StrategyCodeOwners, Rule: "api/"},
+					{UserID: "u5", Strategy: domain.StrategyCodeOwners, Rule: "api/"},
+					{UserID: "u12", Strategy: domain.StrategyRandom},
+					{UserID: "u11", Strategy: domain.StrategyRandom},
+				},
+				Picks: []domain.AssignmentRationale{
+					{ReviewerID: "u6", Strategy: domain.StrategyCodeOwners, Rule: "api/", PoolSize: 2},
+					{
+						ReviewerID: "u12",
+						Strategy:   domain.StrategyRandom,
+						PoolSize:   3,
+						Excluded:   []domain.ExcludedCandidate{{UserID: "u6", Reason: domain.ExclusionAssigned}},
+					},
+				},
+			},
+		},
+		{
+			name:  "success - hypothetical team",
+			draft: domain.PRDraft{AuthorID: "u1", TeamName: "frontend"},
+			setupMocks: func(userStorage *mocks.MockUserStorage, _ *mocks.MockRepositoryStorage) {
+				userStorage.EXPECT().GetUser(ctx, "u1").Return(author, nil).Once()
+				userStorage.EXPECT().
+					GetReviewerPool(ctx, "frontend").
+					Return(activeUsers("u1", "u21"), nil).
+					Once()
+			},
+			expectedPreview: &domain.AssignmentPreview{
+				TeamName:   "frontend",
+				Candidates: []domain.Candidate{{UserID: "u21", Strategy: domain.StrategyRandom}},
+				Picks: []domain.AssignmentRationale{{
+					ReviewerID: "u21",
+					Strategy:   domain.StrategyRandom,
+					PoolSize:   2,
+					Excluded:   []domain.ExcludedCandidate{{UserID: "u1", Reason: domain.ExclusionAuthor}},
+				}},
+				Queued: 1,
+			},
+		},
+		{
+			name:  "error - author not in team",
+			draft: domain.PRDraft{AuthorID: "u1", TeamName: "docs"},
+			setupMocks: func(userStorage *mocks.MockUserStorage, _ *mocks.MockRepositoryStorage) {
+				userStorage.EXPECT().GetUser(ctx, "u1").Return(author, nil).Once()
+			},
+			expectedError: serviceErr.ErrAuthorNotInTeam,
+		},
+		{
+			name:  "error - reviewer pool fails",
+			draft: domain.PRDraft{AuthorID: "u1"},
+			setupMocks: func(userStorage *mocks.MockUserStorage, _ *mocks.MockRepositoryStorage) {
+				userStorage.EXPECT().GetUser(ctx, "u1").Return(author, nil).Once()
+				userStorage.EXPECT().
+					GetReviewerPool(ctx, "backend").
+					Return(nil, errors.New("query error")).
+					Once()
+			},
+			expectedError: errors.New("service.pr.PreviewAssignment: query error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			userStorage := mocks.NewMockUserStorage(t)
+			repositoryStorage := mocks.NewMockRepositoryStorage(t)
+			tt.setupMocks(userStorage, repositoryStorage)
+
+			// The PR storage has no expectations: a preview writes nothing.
+			service := New(log, userStorage, mocks.NewMockTeamStorage(t), mocks.NewMockPRStorage(t), repositoryStorage, testIDs, Options{CapacityMode: domain.CapacitySkip})
+
+			// Act
+			result, err := service.PreviewAssignment(ctx, tt.draft)
+
+			// Assert
+			if tt.expectedError != nil {
+				assert.EqualError(t, err, tt.expectedError.Error())
+				assert.Nil(t, result)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedPreview, result)
+			}
+		})
+	}
+}
+
+func TestService_PreviewAssignment_WorkingHours(t *testing.T) {
+	ctx := context.Background()
+	log := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
+
+	author := &domain.User{UserID: "u1", TeamName: "backend", TeamNames: []string{"backend"}, IsActive: true}
+	// Monday 10:00 UTC: noon in Berlin, evening in Tokyo.
+	now := time.Date(2026, time.October, 19, 10, 0, 0, 0, time.UTC)
+	nineToFive := &domain.WorkingHours{Start: 9 * 60, End: 17 * 60, Days: domain.DefaultWorkDays}
+
+	user := func(id string, timezone string, hours *domain.WorkingHours) *domain.User {
+		return &domain.User{UserID: id, IsActive: true, Timezone: timezone, WorkingHours: hours}
+	}
+	authorExcluded := domain.ExcludedCandidate{UserID: "u1", Reason: domain.ExclusionAuthor}
+
+	tests := []struct {
+		name               string
+		prefer             bool
+		pool               []*domain.User
+		expectedCandidates []string
+		expectedRationales []domain.AssignmentRationale
+	}{
+		{
+			name:   "success - reviewers inside their working hours first",
+			prefer: true,
+			pool: []*domain.User{
+				user("u1", "", nil),
+				user("u11", "Asia/Tokyo", nineToFive),
+				user("u12", "", nil),
+				user("u13", "Europe/Berlin", nineToFive),
+			},
+			expectedCandidates: []string{"u12", "u13", "u11"},
+			expectedRationales: []domain.AssignmentRationale{
+				{
+					ReviewerID: "u12",
+					Strategy:   domain.StrategyRandom,
+					PoolSize:   4,
+					Excluded:   []domain.ExcludedCandidate{authorExcluded, {UserID: "u11", Reason: domain.ExclusionOffHours}},
+				},
+				{
+					ReviewerID: "u13",
+					Strategy:   domain.StrategyRandom,
+					PoolSize:   4,
+					Excluded:   []domain.ExcludedCandidate{authorExcluded, {UserID: "u11", Reason: domain.ExclusionOffHours}},
+				},
+			},
+		},
+		{
+			name:   "success - falls back to reviewers outside their working hours",
+			prefer: true,
+			pool: []*domain.User{
+				user("u1", "", nil),
+				user("u11", "Asia/Tokyo", nineToFive),
+				user("u13", "Europe/Berlin", nineToFive),
+				user("u14", "", &domain.WorkingHours{Start: 0, End: 8 * 60, Days: domain.DefaultWorkDays}),
+			},
+			expectedCandidates: []string{"u13", "u11", "u14"},
+			expectedRationales: []domain.AssignmentRationale{
+				{
+					ReviewerID: "u13",
+					Strategy:   domain.StrategyRandom,
+					PoolSize:   4,
+					Excluded: []domain.ExcludedCandidate{
+						authorExcluded,
+						{UserID: "u11", Reason: domain.ExclusionOffHours},
+						{UserID: "u14", Reason: domain.ExclusionOffHours},
+					},
+				},
+				{
+					ReviewerID: "u11",
+					Strategy:   domain.StrategyRandom,
+					PoolSize:   4,
+					Excluded:   []domain.ExcludedCandidate{authorExcluded},
+				},
+			},
+		},
+		{
+			name: "success - working hours ignored unless preferred",
+			pool: []*domain.User{
+				user("u1", "", nil),
+				user("u11", "Asia/Tokyo", nineToFive),
+				user("u13", "Europe/Berlin", nineToFive),
+			},
+			expectedCandidates: []string{"u11", "u13"},
+			expectedRationales: []domain.AssignmentRationale{
+				{
+					ReviewerID: "u11",
+					Strategy:   domain.StrategyRandom,
+					PoolSize:   3,
+					Excluded:   []domain.ExcludedCandidate{authorExcluded},
+				},
+				{
+					ReviewerID: "u13",
+					Strategy:   domain.StrategyRandom,
+					PoolSize:   3,
+					Excluded:   []domain.ExcludedCandidate{authorExcluded},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			userStorage := mocks.NewMockUserStorage(t)
+			userStorage.EXPECT().GetUser(ctx, "u1").Return(author, nil).Once()
+			userStorage.EXPECT().GetReviewerPool(ctx, "backend").Return(tt.pool, nil).Once()
+
+			opts := Options{CapacityMode: domain.CapacitySkip, PreferWorkingHours: tt.prefer}
+			service := New(log, userStorage, mocks.NewMockTeamStorage(t), mocks.NewMockPRStorage(t), mocks.NewMockRepositoryStorage(t), testIDs, opts)
+			service.SetClock(func() time.Time { return now })
+
+			// Act
+			result, err := service.PreviewAssignment(ctx, domain.PRDraft{AuthorID: "u1"})
+
+			// Assert
+			assert.NoError(t, err)
+			candidates := make([]string, len(result.Candidates))
+			for i, candidate := range result.Candidates {
+				candidates[i] = candidate.UserID
+			}
+			assert.Equal(t, tt.expectedCandidates, candidates)
+			assert.Equal(t, tt.expectedRationales, result.Picks)
+		})
+	}
+}
+
+func TestService_PreviewAssignment_Order(t *testing.T) {
+	ctx := context.Background()
+	log := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
+
+	author := &domain.User{UserID: "u1", TeamName: "backend", TeamNames: []string{"backend"}, IsActive: true}
+
+	// preview returns the candidates the service tries for the PR, given the pool
+	// in the order of ids.
+	preview := func(t *testing.T, service *Service, userStorage *mocks.MockUserStorage, prID string, ids ...string) []string {
+		userStorage.EXPECT().GetUser(ctx, "u1").Return(author, nil).Once()
+		userStorage.EXPECT().GetReviewerPool(ctx, "backend").Return(activeUsers(ids...), nil).Once()
+
+		result, err := service.PreviewAssignment(ctx, domain.PRDraft{PullRequestID: prID, AuthorID: "u1"})
+		assert.NoError(t, err)
+
+		candidates := make([]string, len(result.Candidates))
+		for i, candidate := range result.Candidates {
+			candidates[i] = candidate.UserID
+		}
+		return candidates
+	}
+	newService := func(t *testing.T, opts Options) (*Service, *mocks.MockUserStorage) {
+		userStorage := mocks.NewMockUserStorage(t)
+		opts.CapacityMode = domain.CapacitySkip
+		return New(log, userStorage, mocks.NewMockTeamStorage(t), mocks.NewMockPRStorage(t), mocks.NewMockRepositoryStorage(t), testIDs, opts), userStorage
+	}
+
+	t.Run("success - the hash order depends on the PR and candidates only", func(t *testing.T) {
+		service, userStorage := newService(t, Options{Order: domain.OrderHash})
+		other, otherStorage := newService(t, Options{Order: domain.OrderHash})
+
+		first := preview(t, service, userStorage, "pr-1", "u11", "u12", "u13", "u14", "u15")
+
+		assert.ElementsMatch(t, []string{"u11", "u12", "u13", "u14", "u15"}, first)
+		assert.Equal(t, first, preview(t, service, userStorage, "pr-1", "u15", "u14", "u13", "u12", "u11"))
+		assert.Equal(t, first, preview(t, other, otherStorage, "pr-1", "u13", "u11", "u15", "u12", "u14"))
+	})
+
+	t.Run("success - the random order is reproducible with the same seed", func(t *testing.T) {
+		service, userStorage := newService(t, Options{Order: domain.OrderRandom, RandSource: rand.NewPCG(1, 2)})
+		other, otherStorage := newService(t, Options{Order: domain.OrderRandom, RandSource: rand.NewPCG(1, 2)})
+
+		for _, prID := range []string{"pr-1", "pr-2", "pr-3"} {
+			assert.Equal(t,
+				preview(t, service, userStorage, prID, "u11", "u12", "u13", "u14", "u15"),
+				preview(t, other, otherStorage, prID, "u11", "u12", "u13", "u14", "u15"))
+		}
+	})
+
+	t.Run("success - no order keeps the storage order", func(t *testing.T) {
+		service, userStorage := newService(t, Options{})
+
+		assert.Equal(t, []string{"u13", "u11", "u12"}, preview(t, service, userStorage, "pr-1", "u13", "u11", "u12"))
+	})
+}
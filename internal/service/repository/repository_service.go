@@ -0,0 +1,207 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+
+	"github.com/whitxowl/pr-reviewer-assignment-service.git/internal/domain"
+	"github.com/whitxowl/pr-reviewer-assignment-service.git/internal/service/codeowners"
+	serviceErr "github.com/whitxowl/pr-reviewer-assignment-service.git/internal/service/errors"
+	storageErr "github.com/whitxowl/pr-reviewer-assignment-service.git/internal/storage/errors"
+)
+
+type RepositoryStorage interface {
+	CreateRepository(ctx context.Context, repo *domain.Repository) error
+	GetRepository(ctx context.Context, name string) (*domain.Repository, error)
+	UpdateRepository(ctx context.Context, repo *domain.Repository) error
+	SetCodeOwners(ctx context.Context, repository string, rules []domain.CodeOwnerRule) error
+	GetCodeOwners(ctx context.Context, repository string) ([]domain.CodeOwnerRule, error)
+}
+
+type Service struct {
+	log               *slog.Logger
+	repositoryStorage RepositoryStorage
+}
+
+func New(log *slog.Logger, repositoryStorage RepositoryStorage) *Service {
+	return &Service{
+		log:               log,
+		repositoryStorage: repositoryStorage,
+	}
+}
+
+// CreateRepository registers a repository. A zero ReviewersCount means domain.DefaultReviewersCount.
+func (s *Service) CreateRepository(ctx context.Context, repo domain.Repository) (*domain.Repository, error) {
+	const op = "service.repository.CreateRepository"
+
+	log := s.log.With(
+		slog.String("op", op),
+		slog.String("repository", repo.Name),
+		slog.String("teamName", repo.TeamName),
+	)
+
+	if repo.ReviewersCount == 0 {
+		repo.ReviewersCount = domain.DefaultReviewersCount
+	}
+
+	err := s.repositoryStorage.CreateRepository(ctx, &repo)
+	if errors.Is(err, storageErr.ErrRepositoryExists) {
+		log.DebugContext(ctx, "repository already exists")
+		return nil, serviceErr.ErrRepositoryExists
+	}
+	if errors.Is(err, storageErr.ErrTeamNotFound) {
+		log.DebugContext(ctx, "owning team not found")
+		return nil, serviceErr.ErrTeamNotFound
+	}
+	if err != nil {
+		log.ErrorContext(ctx, "error creating repository", "error", err)
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.InfoContext(ctx, "repository created successfully")
+
+	return &repo, nil
+}
+
+func (s *Service) GetRepository(ctx context.Context, name string) (*domain.Repository, error) {
+	const op = "service.repository.GetRepository"
+
+	log := s.log.With(
+		slog.String("op", op),
+		slog.String("repository", name),
+	)
+
+	repo, err := s.repositoryStorage.GetRepository(ctx, name)
+	if errors.Is(err, storageErr.ErrRepositoryNotFound) {
+		return nil, serviceErr.ErrRepositoryNotFound
+	}
+	if err != nil {
+		log.ErrorContext(ctx, "error getting repository", "error", err)
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return repo, nil
+}
+
+// UpdateRepository applies update to the stored repository and returns the result.
+func (s *Service) UpdateRepository(ctx context.Context, update domain.RepositoryUpdate) (*domain.Repository, error) {
+	const op = "service.repository.UpdateRepository"
+
+	log := s.log.With(
+		slog.String("op", op),
+		slog.String("repository", update.Name),
+	)
+
+	repo, err := s.GetRepository(ctx, update.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	if update.TeamName != nil {
+		repo.TeamName = *update.TeamName
+	}
+	if update.ReviewersCount != nil {
+		repo.ReviewersCount = *update.ReviewersCount
+	}
+
+	err = s.repositoryStorage.UpdateRepository(ctx, repo)
+	if errors.Is(err, storageErr.ErrRepositoryNotFound) {
+		log.DebugContext(ctx, "repository not found")
+		return nil, serviceErr.ErrRepositoryNotFound
+	}
+	if errors.Is(err, storageErr.ErrTeamNotFound) {
+		log.DebugContext(ctx, "owning team not found")
+		return nil, serviceErr.ErrTeamNotFound
+	}
+	if err != nil {
+		log.ErrorContext(ctx, "error updating repository", "error", err)
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.InfoContext(ctx, "repository updated successfully")
+
+	return repo, nil
+}
+
+// SetCodeOwners replaces the code owner rules of the repository. Later rules take
+// precedence over earlier ones; a rule without owners leaves its files unowned.
+func (s *Service) SetCodeOwners(
+	ctx context.Context,
+	repository string,
+	rules []domain.CodeOwnerRule,
+) ([]domain.CodeOwnerRule, error) {
+	const op = "service.repository.SetCodeOwners"
+
+	log := s.log.With(
+		slog.String("op", op),
+		slog.String("repository", repository),
+		slog.Int("rules", len(rules)),
+	)
+
+	normalized := make([]domain.CodeOwnerRule, len(rules))
+	for i, rule := range rules {
+		if err := codeowners.Validate(rule.Pattern); err != nil {
+			log.DebugContext(ctx, "invalid pattern", "error", err)
+			return nil, err
+		}
+
+		normalized[i] = domain.CodeOwnerRule{
+			Pattern:   rule.Pattern,
+			UserIDs:   uniqueSorted(rule.UserIDs),
+			TeamNames: uniqueSorted(rule.TeamNames),
+		}
+	}
+
+	err := s.repositoryStorage.SetCodeOwners(ctx, repository, normalized)
+	if errors.Is(err, storageErr.ErrRepositoryNotFound) {
+		log.DebugContext(ctx, "repository not found")
+		return nil, serviceErr.ErrRepositoryNotFound
+	}
+	if errors.Is(err, storageErr.ErrUserNotFound) {
+		log.DebugContext(ctx, "owner not found", "error", err)
+		return nil, serviceErr.ErrUserNotFound
+	}
+	if errors.Is(err, storageErr.ErrTeamNotFound) {
+		log.DebugContext(ctx, "owning team not found", "error", err)
+		return nil, serviceErr.ErrTeamNotFound
+	}
+	if err != nil {
+		log.ErrorContext(ctx, "error setting code owners", "error", err)
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.InfoContext(ctx, "code owners set successfully")
+
+	return normalized, nil
+}
+
+func (s *Service) GetCodeOwners(ctx context.Context, repository string) ([]domain.CodeOwnerRule, error) {
+	const op = "service.repository.GetCodeOwners"
+
+	log := s.log.With(
+		slog.String("op", op),
+		slog.String("repository", repository),
+	)
+
+	if _, err := s.GetRepository(ctx, repository); err != nil {
+		return nil, err
+	}
+
+	rules, err := s.repositoryStorage.GetCodeOwners(ctx, repository)
+	if err != nil {
+		log.ErrorContext(ctx, "error getting code owners", "error", err)
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return rules, nil
+}
+
+// uniqueSorted returns the distinct values of values in order, never nil.
+func uniqueSorted(values []string) []string {
+	result := append([]string{}, values...)
+	slices.Sort(result)
+	return slices.Compact(result)
+}
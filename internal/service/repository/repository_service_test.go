@@ -0,0 +1,291 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/whitxowl/pr-reviewer-assignment-service.git/internal/domain"
+	serviceErr "github.com/whitxowl/pr-reviewer-assignment-service.git/internal/service/errors"
+	"github.com/whitxowl/pr-reviewer-assignment-service.git/internal/service/repository/mocks"
+	storageErr "github.com/whitxowl/pr-reviewer-assignment-service.git/internal/storage/errors"
+)
+
+func TestService_CreateRepository(t *testing.T) {
+	ctx := context.Background()
+	log := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
+
+	tests := []struct {
+		name          string
+		repo          domain.Repository
+		setupMocks    func(*mocks.MockRepositoryStorage)
+		expectedRepo  *domain.Repository
+		expectedError error
+	}{
+		{
+			name: "success - default reviewers count",
+			repo: domain.Repository{Name: "acme/api", TeamName: "backend"},
+			setupMocks: func(repositoryStorage *mocks.MockRepositoryStorage) {
+				repositoryStorage.EXPECT().
+					CreateRepository(ctx, &domain.Repository{Name: "acme/api", TeamName: "backend", ReviewersCount: 2}).
+					Return(nil).
+					Once()
+			},
+			expectedRepo: &domain.Repository{Name: "acme/api", TeamName: "backend", ReviewersCount: 2},
+		},
+		{
+			name: "success - explicit reviewers count",
+			repo: domain.Repository{Name: "acme/api", ReviewersCount: 1},
+			setupMocks: func(repositoryStorage *mocks.MockRepositoryStorage) {
+				repositoryStorage.EXPECT().
+					CreateRepository(ctx, &domain.Repository{Name: "acme/api", ReviewersCount: 1}).
+					Return(nil).
+					Once()
+			},
+			expectedRepo: &domain.Repository{Name: "acme/api", ReviewersCount: 1},
+		},
+		{
+			name: "error - repository exists",
+			repo: domain.Repository{Name: "acme/api"},
+			setupMocks: func(repositoryStorage *mocks.MockRepositoryStorage) {
+				repositoryStorage.EXPECT().
+					CreateRepository(ctx, &domain.Repository{Name: "acme/api", ReviewersCount: 2}).
+					Return(storageErr.ErrRepositoryExists).
+					Once()
+			},
+			expectedError: serviceErr.ErrRepositoryExists,
+		},
+		{
+			name: "error - team not found",
+			repo: domain.Repository{Name: "acme/api", TeamName: "missing"},
+			setupMocks: func(repositoryStorage *mocks.MockRepositoryStorage) {
+				repositoryStorage.EXPECT().
+					CreateRepository(ctx, &domain.Repository{Name: "acme/api", TeamName: "missing", ReviewersCount: 2}).
+					Return(storageErr.ErrTeamNotFound).
+					Once()
+			},
+			expectedError: serviceErr.ErrTeamNotFound,
+		},
+		{
+			name: "error - storage error",
+			repo: domain.Repository{Name: "acme/api"},
+			setupMocks: func(repositoryStorage *mocks.MockRepositoryStorage) {
+				repositoryStorage.EXPECT().
+					CreateRepository(ctx, &domain.Repository{Name: "acme/api", ReviewersCount: 2}).
+					Return(errors.New("database connection error")).
+					Once()
+			},
+			expectedError: errors.New("service.repository.CreateRepository: database connection error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			repositoryStorage := mocks.NewMockRepositoryStorage(t)
+			tt.setupMocks(repositoryStorage)
+
+			service := New(log, repositoryStorage)
+
+			// Act
+			result, err := service.CreateRepository(ctx, tt.repo)
+
+			// Assert
+			if tt.expectedError != nil {
+				assert.Error(t, err)
+				assert.Nil(t, result)
+				if !errors.Is(err, tt.expectedError) {
+					assert.Contains(t, err.Error(), tt.expectedError.Error())
+				}
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedRepo, result)
+			}
+		})
+	}
+}
+
+func TestService_UpdateRepository(t *testing.T) {
+	ctx := context.Background()
+	log := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
+
+	noTeam := ""
+	missing := "missing"
+	three := 3
+
+	tests := []struct {
+		name          string
+		update        domain.RepositoryUpdate
+		setupMocks    func(*mocks.MockRepositoryStorage)
+		expectedRepo  *domain.Repository
+		expectedError error
+	}{
+		{
+			name:   "success - only reviewers count changed",
+			update: domain.RepositoryUpdate{Name: "acme/api", ReviewersCount: &three},
+			setupMocks: func(repositoryStorage *mocks.MockRepositoryStorage) {
+				repositoryStorage.EXPECT().
+					GetRepository(ctx, "acme/api").
+					Return(&domain.Repository{Name: "acme/api", TeamName: "backend", ReviewersCount: 2}, nil).
+					Once()
+				repositoryStorage.EXPECT().
+					UpdateRepository(ctx, &domain.Repository{Name: "acme/api", TeamName: "backend", ReviewersCount: 3}).
+					Return(nil).
+					Once()
+			},
+			expectedRepo: &domain.Repository{Name: "acme/api", TeamName: "backend", ReviewersCount: 3},
+		},
+		{
+			name:   "success - owning team removed",
+			update: domain.RepositoryUpdate{Name: "acme/api", TeamName: &noTeam},
+			setupMocks: func(repositoryStorage *mocks.MockRepositoryStorage) {
+				repositoryStorage.EXPECT().
+					GetRepository(ctx, "acme/api").
+					Return(&domain.Repository{Name: "acme/api", TeamName: "backend", ReviewersCount: 2}, nil).
+					Once()
+				repositoryStorage.EXPECT().
+					UpdateRepository(ctx, &domain.Repository{Name: "acme/api", ReviewersCount: 2}).
+					Return(nil).
+					Once()
+			},
+			expectedRepo: &domain.Repository{Name: "acme/api", ReviewersCount: 2},
+		},
+		{
+			name:   "error - repository not found",
+			update: domain.RepositoryUpdate{Name: "acme/api", ReviewersCount: &three},
+			setupMocks: func(repositoryStorage *mocks.MockRepositoryStorage) {
+				repositoryStorage.EXPECT().
+					GetRepository(ctx, "acme/api").
+					Return(nil, storageErr.ErrRepositoryNotFound).
+					Once()
+			},
+			expectedError: serviceErr.ErrRepositoryNotFound,
+		},
+		{
+			name:   "error - team not found",
+			update: domain.RepositoryUpdate{Name: "acme/api", TeamName: &missing},
+			setupMocks: func(repositoryStorage *mocks.MockRepositoryStorage) {
+				repositoryStorage.EXPECT().
+					GetRepository(ctx, "acme/api").
+					Return(&domain.Repository{Name: "acme/api", TeamName: "backend", ReviewersCount: 2}, nil).
+					Once()
+				repositoryStorage.EXPECT().
+					UpdateRepository(ctx, &domain.Repository{Name: "acme/api", TeamName: "missing", ReviewersCount: 2}).
+					Return(storageErr.ErrTeamNotFound).
+					Once()
+			},
+			expectedError: serviceErr.ErrTeamNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			repositoryStorage := mocks.NewMockRepositoryStorage(t)
+			tt.setupMocks(repositoryStorage)
+
+			service := New(log, repositoryStorage)
+
+			// Act
+			result, err := service.UpdateRepository(ctx, tt.update)
+
+			// Assert
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				assert.Nil(t, result)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedRepo, result)
+			}
+		})
+	}
+}
+
+func TestService_SetCodeOwners(t *testing.T) {
+	ctx := context.Background()
+	log := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
+
+	tests := []struct {
+		name          string
+		rules         []domain.CodeOwnerRule
+		setupMocks    func(*mocks.MockRepositoryStorage)
+		expectedRules []domain.CodeOwnerRule
+		expectedError error
+	}{
+		{
+			name: "success - owners deduplicated",
+			rules: []domain.CodeOwnerRule{
+				{Pattern: "*.go", UserIDs: []string{"u2", "u1", "u2"}},
+				{Pattern: "/docs/", TeamNames: []string{"writers", "writers"}},
+			},
+			setupMocks: func(repositoryStorage *mocks.MockRepositoryStorage) {
+				repositoryStorage.EXPECT().
+					SetCodeOwners(ctx, "acme/api", []domain.CodeOwnerRule{
+						{Pattern: "*.go", UserIDs: []string{"u1", "u2"}, TeamNames: []string{}},
+						{Pattern: "/docs/", UserIDs: []string{}, TeamNames: []string{"writers"}},
+					}).
+					Return(nil).
+					Once()
+			},
+			expectedRules: []domain.CodeOwnerRule{
+				{Pattern: "*.go", UserIDs: []string{"u1", "u2"}, TeamNames: []string{}},
+				{Pattern: "/docs/", UserIDs: []string{}, TeamNames: []string{"writers"}},
+			},
+		},
+		{
+			name:          "error - invalid pattern",
+			rules:         []domain.CodeOwnerRule{{Pattern: "[", UserIDs: []string{"u1"}}},
+			setupMocks:    func(*mocks.MockRepositoryStorage) {},
+			expectedError: serviceErr.ErrInvalidPattern,
+		},
+		{
+			name:  "error - owner not found",
+			rules: []domain.CodeOwnerRule{{Pattern: "*", UserIDs: []string{"u404"}}},
+			setupMocks: func(repositoryStorage *mocks.MockRepositoryStorage) {
+				repositoryStorage.EXPECT().
+					SetCodeOwners(ctx, "acme/api", []domain.CodeOwnerRule{
+						{Pattern: "*", UserIDs: []string{"u404"}, TeamNames: []string{}},
+					}).
+					Return(storageErr.ErrUserNotFound).
+					Once()
+			},
+			expectedError: serviceErr.ErrUserNotFound,
+		},
+		{
+			name:  "error - repository not found",
+			rules: nil,
+			setupMocks: func(repositoryStorage *mocks.MockRepositoryStorage) {
+				repositoryStorage.EXPECT().
+					SetCodeOwners(ctx, "acme/api", []domain.CodeOwnerRule{}).
+					Return(storageErr.ErrRepositoryNotFound).
+					Once()
+			},
+			expectedError: serviceErr.ErrRepositoryNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			repositoryStorage := mocks.NewMockRepositoryStorage(t)
+			tt.setupMocks(repositoryStorage)
+
+			service := New(log, repositoryStorage)
+
+			// Act
+			result, err := service.SetCodeOwners(ctx, "acme/api", tt.rules)
+
+			// Assert
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				assert.Nil(t, result)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedRules, result)
+			}
+		})
+	}
+}
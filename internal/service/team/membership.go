@@ -0,0 +1,242 @@
+package team
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+
+	"github.com/whitxowl/pr-reviewer-assignment-service.git/internal/domain"
+	serviceErr "github.com/whitxowl/pr-reviewer-assignment-service.git/internal/service/errors"
+	"github.com/whitxowl/pr-reviewer-assignment-service.git/internal/service/validation"
+	storageErr "github.com/whitxowl/pr-reviewer-assignment-service.git/internal/storage/errors"
+)
+
+// AddMembers creates or updates the given users as members of the team, like /team/add
+// does for a new team. Other members are not touched, and users keep their other teams.
+func (s *Service) AddMembers(ctx context.Context, teamName string, members []*domain.User) (*domain.Team, error) {
+	const op = "service.team.AddMembers"
+
+	log := s.log.With(
+		slog.String("op", op),
+		slog.String("teamName", teamName),
+	)
+
+	if err := s.ids.Users(members); err != nil {
+		log.DebugContext(ctx, "invalid user id", "error", err)
+		return nil, err
+	}
+
+	if err := validation.UserTags(members); err != nil {
+		log.DebugContext(ctx, "invalid tag", "error", err)
+		return nil, err
+	}
+
+	if err := s.checkTeamExists(ctx, teamName); err != nil {
+		return nil, err
+	}
+
+	for _, member := range members {
+		member.TeamName = teamName
+	}
+
+	if err := s.userStorage.UpsertUsers(ctx, members); err != nil {
+		log.ErrorContext(ctx, "error upserting users", "error", err)
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	team, err := s.GetTeam(ctx, teamName)
+	if err != nil {
+		return nil, err
+	}
+
+	log.InfoContext(ctx, "members added successfully", "count", len(members))
+
+	s.wake()
+
+	return team, nil
+}
+
+// RemoveMembers removes the given members from the team; they stay in their other teams,
+// or in the user directory without a team. Every user must be a member of the team.
+func (s *Service) RemoveMembers(
+	ctx context.Context,
+	teamName string,
+	userIDs []string,
+	reassign bool,
+) (*domain.Team, []domain.DepartedReviewer, error) {
+	const op = "service.team.RemoveMembers"
+
+	log := s.log.With(
+		slog.String("op", op),
+		slog.String("teamName", teamName),
+	)
+
+	if err := s.checkTeamExists(ctx, teamName); err != nil {
+		return nil, nil, err
+	}
+
+	err := s.userStorage.RemoveMemberships(ctx, teamName, userIDs)
+	if errors.Is(err, storageErr.ErrUserNotFound) {
+		log.DebugContext(ctx, "user is not a member of the team", "error", err)
+		return nil, nil, serviceErr.ErrUserNotFound
+	}
+	if err != nil {
+		log.ErrorContext(ctx, "error removing members", "error", err)
+		return nil, nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	departed, err := s.departedReviewers(ctx, userIDs, teamName, reassign)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	team, err := s.GetTeam(ctx, teamName)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	log.InfoContext(ctx, "members removed successfully", "count", len(userIDs))
+
+	return team, departed, nil
+}
+
+// MoveUser moves the user from fromTeamName to teamName. Without fromTeamName the user
+// leaves its primary team, if any. Other teams of the user are kept.
+func (s *Service) MoveUser(
+	ctx context.Context,
+	userID string,
+	fromTeamName string,
+	teamName string,
+	reassign bool,
+) (*domain.User, []domain.DepartedReviewer, error) {
+	const op = "service.team.MoveUser"
+
+	log := s.log.With(
+		slog.String("op", op),
+		slog.String("userID", userID),
+		slog.String("fromTeamName", fromTeamName),
+		slog.String("teamName", teamName),
+	)
+
+	if err := s.checkTeamExists(ctx, teamName); err != nil {
+		return nil, nil, err
+	}
+
+	user, err := s.userStorage.GetUser(ctx, userID)
+	if errors.Is(err, storageErr.ErrUserNotFound) {
+		log.DebugContext(ctx, "user not found", "error", err)
+		return nil, nil, serviceErr.ErrUserNotFound
+	}
+	if err != nil {
+		log.ErrorContext(ctx, "error getting user", "error", err)
+		return nil, nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if fromTeamName == "" {
+		fromTeamName = user.TeamName
+	} else if !slices.Contains(user.TeamNames, fromTeamName) {
+		log.DebugContext(ctx, "user is not a member of the former team")
+		return nil, nil, serviceErr.ErrUserNotFound
+	}
+	if fromTeamName == teamName {
+		return user, nil, nil
+	}
+
+	err = s.userStorage.MoveMembership(ctx, userID, fromTeamName, teamName)
+	if errors.Is(err, storageErr.ErrUserNotFound) {
+		return nil, nil, serviceErr.ErrUserNotFound
+	}
+	if err != nil {
+		log.ErrorContext(ctx, "error moving user", "error", err)
+		return nil, nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	var departed []domain.DepartedReviewer
+	if fromTeamName != "" {
+		departed, err = s.departedReviewers(ctx, []string{userID}, fromTeamName, reassign)
+		if err != nil {
+			return nil, nil, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	user, err = s.userStorage.GetUser(ctx, userID)
+	if err != nil {
+		log.ErrorContext(ctx, "error getting user", "error", err)
+		return nil, nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.InfoContext(ctx, "user moved successfully")
+
+	s.wake()
+
+	return user, departed, nil
+}
+
+func (s *Service) checkTeamExists(ctx context.Context, teamName string) error {
+	const op = "service.team.checkTeamExists"
+
+	_, err := s.teamStorage.GetTeam(ctx, teamName)
+	if errors.Is(err, storageErr.ErrTeamNotFound) {
+		return serviceErr.ErrTeamNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// departedReviewers reports users that left formerTeamName while still reviewing
+// open PRs opened for it. With reassign set, those reviews are handed to another member of the team.
+func (s *Service) departedReviewers(
+	ctx context.Context,
+	userIDs []string,
+	formerTeamName string,
+	reassign bool,
+) ([]domain.DepartedReviewer, error) {
+	const op = "service.team.departedReviewers"
+
+	log := s.log.With(slog.String("op", op))
+
+	var departed []domain.DepartedReviewer
+	for _, userID := range userIDs {
+		prs, err := s.prStorage.GetOpenPRsByReviewerAndTeam(ctx, userID, formerTeamName)
+		if err != nil {
+			log.ErrorContext(ctx, "error getting open prs of former team", "error", err)
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		if len(prs) == 0 {
+			continue
+		}
+
+		reviewer := domain.DepartedReviewer{
+			UserID:         userID,
+			FormerTeamName: formerTeamName,
+			PullRequests:   prs,
+		}
+
+		if reassign {
+			reviewer.ReplacedBy = make(map[domain.PRRef]string, len(prs))
+			for _, ref := range prs {
+				// The membership change is already stored, so a PR that fails to be
+				// reassigned is only logged and left out of ReplacedBy.
+				_, newReviewerID, err := s.reassigner.ReassignReviewer(ctx, ref.Repository, ref.PullRequestID, userID, false)
+				if err != nil {
+					log.WarnContext(ctx, "error reassigning review of departed user",
+						"repository", ref.Repository,
+						"prID", ref.PullRequestID,
+						"userID", userID,
+						"error", err)
+					continue
+				}
+				reviewer.ReplacedBy[ref] = newReviewerID
+			}
+		}
+
+		departed = append(departed, reviewer)
+	}
+
+	return departed, nil
+}
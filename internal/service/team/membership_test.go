@@ -0,0 +1,320 @@
+package team
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/whitxowl/pr-reviewer-assignment-service.git/internal/domain"
+	serviceErr "github.com/whitxowl/pr-reviewer-assignment-service.git/internal/service/errors"
+	"github.com/whitxowl/pr-reviewer-assignment-service.git/internal/service/team/mocks"
+	storageErr "github.com/whitxowl/pr-reviewer-assignment-service.git/internal/storage/errors"
+)
+
+type membershipMocks struct {
+	team       *mocks.MockTeamStorage
+	user       *mocks.MockUserStorage
+	pr         *mocks.MockPRStorage
+	reassigner *mocks.MockReassigner
+}
+
+func newMembershipMocks(t *testing.T) membershipMocks {
+	return membershipMocks{
+		team:       mocks.NewMockTeamStorage(t),
+		user:       mocks.NewMockUserStorage(t),
+		pr:         mocks.NewMockPRStorage(t),
+		reassigner: mocks.NewMockReassigner(t),
+	}
+}
+
+func (m membershipMocks) service(log *slog.Logger) *Service {
+	return New(log, m.team, m.user, m.pr, m.reassigner, testIDs)
+}
+
+func TestService_AddMembers(t *testing.T) {
+	ctx := context.Background()
+	log := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
+
+	tests := []struct {
+		name          string
+		setupMocks    func(m membershipMocks)
+		expectedError error
+	}{
+		{
+			name: "success - members added",
+			setupMocks: func(m membershipMocks) {
+				m.team.EXPECT().GetTeam(ctx, "backend").Return(&domain.Team{TeamName: "backend"}, nil).Twice()
+				m.user.EXPECT().
+					UpsertUsers(ctx, mock.MatchedBy(func(users []*domain.User) bool {
+						return len(users) == 2 && users[0].TeamName == "backend" && users[1].TeamName == "backend"
+					})).
+					Return(nil).
+					Once()
+				m.user.EXPECT().GetUsersByTeamName(ctx, "backend").Return(nil, nil).Once()
+			},
+		},
+		{
+			name: "error - team not found",
+			setupMocks: func(m membershipMocks) {
+				m.team.EXPECT().GetTeam(ctx, "backend").Return(nil, storageErr.ErrTeamNotFound).Once()
+			},
+			expectedError: serviceErr.ErrTeamNotFound,
+		},
+		{
+			name: "error - storage error on upsert users",
+			setupMocks: func(m membershipMocks) {
+				m.team.EXPECT().GetTeam(ctx, "backend").Return(&domain.Team{TeamName: "backend"}, nil).Once()
+				m.user.EXPECT().UpsertUsers(ctx, mock.Anything).Return(errors.New("upsert error")).Once()
+			},
+			expectedError: errors.New("service.team.AddMembers: upsert error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			m := newMembershipMocks(t)
+			tt.setupMocks(m)
+
+			members := []*domain.User{
+				{UserID: "u1", Username: "Alice", IsActive: true},
+				{UserID: "u2", Username: "Bob", IsActive: true},
+			}
+
+			// Act
+			team, err := m.service(log).AddMembers(ctx, "backend", members)
+
+			// Assert
+			if tt.expectedError != nil {
+				assert.Error(t, err)
+				assert.Nil(t, team)
+				if !errors.Is(err, tt.expectedError) {
+					assert.Contains(t, err.Error(), tt.expectedError.Error())
+				}
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, "backend", team.TeamName)
+			}
+		})
+	}
+}
+
+func TestService_RemoveMembers(t *testing.T) {
+	ctx := context.Background()
+	log := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
+
+	tests := []struct {
+		name             string
+		reassign         bool
+		setupMocks       func(m membershipMocks)
+		expectedDeparted []domain.DepartedReviewer
+		expectedError    error
+	}{
+		{
+			name: "success - members removed",
+			setupMocks: func(m membershipMocks) {
+				m.team.EXPECT().GetTeam(ctx, "backend").Return(&domain.Team{TeamName: "backend"}, nil).Twice()
+				m.user.EXPECT().RemoveMemberships(ctx, "backend", []string{"u1", "u2"}).Return(nil).Once()
+				m.pr.EXPECT().GetOpenPRsByReviewerAndTeam(ctx, "u1", "backend").Return(nil, nil).Once()
+				m.pr.EXPECT().GetOpenPRsByReviewerAndTeam(ctx, "u2", "backend").Return([]domain.PRRef{{PullRequestID: "pr-1"}}, nil).Once()
+				m.user.EXPECT().GetUsersByTeamName(ctx, "backend").Return(nil, nil).Once()
+			},
+			expectedDeparted: []domain.DepartedReviewer{
+				{UserID: "u2", FormerTeamName: "backend", PullRequests: []domain.PRRef{{PullRequestID: "pr-1"}}},
+			},
+		},
+		{
+			name:     "success - reviews of departed member reassigned",
+			reassign: true,
+			setupMocks: func(m membershipMocks) {
+				m.team.EXPECT().GetTeam(ctx, "backend").Return(&domain.Team{TeamName: "backend"}, nil).Twice()
+				m.user.EXPECT().RemoveMemberships(ctx, "backend", []string{"u1", "u2"}).Return(nil).Once()
+				m.pr.EXPECT().GetOpenPRsByReviewerAndTeam(ctx, "u1", "backend").Return(nil, nil).Once()
+				m.pr.EXPECT().
+					GetOpenPRsByReviewerAndTeam(ctx, "u2", "backend").
+					Return([]domain.PRRef{{PullRequestID: "pr-1"}, {PullRequestID: "pr-2"}, {Repository: "acme/api", PullRequestID: "pr-3"}}, nil).
+					Once()
+				m.reassigner.EXPECT().ReassignReviewer(ctx, "", "pr-1", "u2", false).Return(&domain.PullRequest{}, "u10", nil).Once()
+				m.reassigner.EXPECT().ReassignReviewer(ctx, "", "pr-2", "u2", false).Return(&domain.PullRequest{}, "", nil).Once()
+				m.reassigner.EXPECT().ReassignReviewer(ctx, "acme/api", "pr-3", "u2", false).Return(nil, "", serviceErr.ErrPRMerged).Once()
+				m.user.EXPECT().GetUsersByTeamName(ctx, "backend").Return(nil, nil).Once()
+			},
+			expectedDeparted: []domain.DepartedReviewer{
+				{
+					UserID:         "u2",
+					FormerTeamName: "backend",
+					PullRequests:   []domain.PRRef{{PullRequestID: "pr-1"}, {PullRequestID: "pr-2"}, {Repository: "acme/api", PullRequestID: "pr-3"}},
+					ReplacedBy:     map[domain.PRRef]string{{PullRequestID: "pr-1"}: "u10", {PullRequestID: "pr-2"}: ""},
+				},
+			},
+		},
+		{
+			name: "error - user is not a member",
+			setupMocks: func(m membershipMocks) {
+				m.team.EXPECT().GetTeam(ctx, "backend").Return(&domain.Team{TeamName: "backend"}, nil).Once()
+				m.user.EXPECT().
+					RemoveMemberships(ctx, "backend", []string{"u1", "u2"}).
+					Return(storageErr.ErrUserNotFound).
+					Once()
+			},
+			expectedError: serviceErr.ErrUserNotFound,
+		},
+		{
+			name: "error - team not found",
+			setupMocks: func(m membershipMocks) {
+				m.team.EXPECT().GetTeam(ctx, "backend").Return(nil, storageErr.ErrTeamNotFound).Once()
+			},
+			expectedError: serviceErr.ErrTeamNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			m := newMembershipMocks(t)
+			tt.setupMocks(m)
+
+			// Act
+			team, departed, err := m.service(log).RemoveMembers(ctx, "backend", []string{"u1", "u2"}, tt.reassign)
+
+			// Assert
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				assert.Nil(t, team)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, "backend", team.TeamName)
+				assert.Equal(t, tt.expectedDeparted, departed)
+			}
+		})
+	}
+}
+
+func TestService_MoveUser(t *testing.T) {
+	ctx := context.Background()
+	log := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
+
+	tests := []struct {
+		name             string
+		fromTeamName     string
+		setupMocks       func(m membershipMocks)
+		expectedUser     *domain.User
+		expectedDeparted []domain.DepartedReviewer
+		expectedError    error
+	}{
+		{
+			name: "success - user moved from primary team",
+			setupMocks: func(m membershipMocks) {
+				m.team.EXPECT().GetTeam(ctx, "frontend").Return(&domain.Team{TeamName: "frontend"}, nil).Once()
+				m.user.EXPECT().
+					GetUser(ctx, "u1").
+					Return(&domain.User{UserID: "u1", TeamName: "backend", TeamNames: []string{"backend"}}, nil).
+					Once()
+				m.user.EXPECT().MoveMembership(ctx, "u1", "backend", "frontend").Return(nil).Once()
+				m.pr.EXPECT().GetOpenPRsByReviewerAndTeam(ctx, "u1", "backend").Return([]domain.PRRef{{PullRequestID: "pr-1"}}, nil).Once()
+				m.user.EXPECT().
+					GetUser(ctx, "u1").
+					Return(&domain.User{UserID: "u1", TeamName: "frontend", TeamNames: []string{"frontend"}}, nil).
+					Once()
+			},
+			expectedUser: &domain.User{UserID: "u1", TeamName: "frontend", TeamNames: []string{"frontend"}},
+			expectedDeparted: []domain.DepartedReviewer{
+				{UserID: "u1", FormerTeamName: "backend", PullRequests: []domain.PRRef{{PullRequestID: "pr-1"}}},
+			},
+		},
+		{
+			name:         "success - user moved from another team",
+			fromTeamName: "payments",
+			setupMocks: func(m membershipMocks) {
+				m.team.EXPECT().GetTeam(ctx, "frontend").Return(&domain.Team{TeamName: "frontend"}, nil).Once()
+				m.user.EXPECT().
+					GetUser(ctx, "u1").
+					Return(&domain.User{UserID: "u1", TeamName: "backend", TeamNames: []string{"backend", "payments"}}, nil).
+					Once()
+				m.user.EXPECT().MoveMembership(ctx, "u1", "payments", "frontend").Return(nil).Once()
+				m.pr.EXPECT().GetOpenPRsByReviewerAndTeam(ctx, "u1", "payments").Return(nil, nil).Once()
+				m.user.EXPECT().
+					GetUser(ctx, "u1").
+					Return(&domain.User{UserID: "u1", TeamName: "backend", TeamNames: []string{"backend", "frontend"}}, nil).
+					Once()
+			},
+			expectedUser: &domain.User{UserID: "u1", TeamName: "backend", TeamNames: []string{"backend", "frontend"}},
+		},
+		{
+			name: "success - user without team joins",
+			setupMocks: func(m membershipMocks) {
+				m.team.EXPECT().GetTeam(ctx, "frontend").Return(&domain.Team{TeamName: "frontend"}, nil).Once()
+				m.user.EXPECT().GetUser(ctx, "u1").Return(&domain.User{UserID: "u1", TeamNames: []string{}}, nil).Once()
+				m.user.EXPECT().MoveMembership(ctx, "u1", "", "frontend").Return(nil).Once()
+				m.user.EXPECT().
+					GetUser(ctx, "u1").
+					Return(&domain.User{UserID: "u1", TeamName: "frontend", TeamNames: []string{"frontend"}}, nil).
+					Once()
+			},
+			expectedUser: &domain.User{UserID: "u1", TeamName: "frontend", TeamNames: []string{"frontend"}},
+		},
+		{
+			name: "success - user already in team",
+			setupMocks: func(m membershipMocks) {
+				m.team.EXPECT().GetTeam(ctx, "frontend").Return(&domain.Team{TeamName: "frontend"}, nil).Once()
+				m.user.EXPECT().
+					GetUser(ctx, "u1").
+					Return(&domain.User{UserID: "u1", TeamName: "frontend", TeamNames: []string{"frontend"}}, nil).
+					Once()
+			},
+			expectedUser: &domain.User{UserID: "u1", TeamName: "frontend", TeamNames: []string{"frontend"}},
+		},
+		{
+			name:         "error - user is not a member of the former team",
+			fromTeamName: "payments",
+			setupMocks: func(m membershipMocks) {
+				m.team.EXPECT().GetTeam(ctx, "frontend").Return(&domain.Team{TeamName: "frontend"}, nil).Once()
+				m.user.EXPECT().
+					GetUser(ctx, "u1").
+					Return(&domain.User{UserID: "u1", TeamName: "backend", TeamNames: []string{"backend"}}, nil).
+					Once()
+			},
+			expectedError: serviceErr.ErrUserNotFound,
+		},
+		{
+			name: "error - user not found",
+			setupMocks: func(m membershipMocks) {
+				m.team.EXPECT().GetTeam(ctx, "frontend").Return(&domain.Team{TeamName: "frontend"}, nil).Once()
+				m.user.EXPECT().GetUser(ctx, "u1").Return(nil, storageErr.ErrUserNotFound).Once()
+			},
+			expectedError: serviceErr.ErrUserNotFound,
+		},
+		{
+			name: "error - team not found",
+			setupMocks: func(m membershipMocks) {
+				m.team.EXPECT().GetTeam(ctx, "frontend").Return(nil, storageErr.ErrTeamNotFound).Once()
+			},
+			expectedError: serviceErr.ErrTeamNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			m := newMembershipMocks(t)
+			tt.setupMocks(m)
+
+			// Act
+			user, departed, err := m.service(log).MoveUser(ctx, "u1", tt.fromTeamName, "frontend", false)
+
+			// Assert
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				assert.Nil(t, user)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedUser, user)
+				assert.Equal(t, tt.expectedDeparted, departed)
+			}
+		})
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"context"
 
 	mock "github.com/stretchr/testify/mock"
+	"github.com/whitxowl/pr-reviewer-assignment-service.git/internal/domain"
 )
 
 // NewMockTeamStorage creates a new instance of MockTeamStorage. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
@@ -94,23 +95,82 @@ func (_c *MockTeamStorage_CreateTeam_Call) RunAndReturn(run func(ctx context.Con
 	return _c
 }
 
-// TeamExists provides a mock function for the type MockTeamStorage
-func (_mock *MockTeamStorage) TeamExists(ctx context.Context, teamName string) (bool, error) {
+// DeleteTeam provides a mock function for the type MockTeamStorage
+func (_mock *MockTeamStorage) DeleteTeam(ctx context.Context, teamName string) error {
 	ret := _mock.Called(ctx, teamName)
 
 	if len(ret) == 0 {
-		panic("no return value specified for TeamExists")
+		panic("no return value specified for DeleteTeam")
 	}
 
-	var r0 bool
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = returnFunc(ctx, teamName)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockTeamStorage_DeleteTeam_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteTeam'
+type MockTeamStorage_DeleteTeam_Call struct {
+	*mock.Call
+}
+
+// DeleteTeam is a helper method to define mock.On call
+//   - ctx context.Context
+//   - teamName string
+func (_e *MockTeamStorage_Expecter) DeleteTeam(ctx interface{}, teamName interface{}) *MockTeamStorage_DeleteTeam_Call {
+	return &MockTeamStorage_DeleteTeam_Call{Call: _e.mock.On("DeleteTeam", ctx, teamName)}
+}
+
+func (_c *MockTeamStorage_DeleteTeam_Call) Run(run func(ctx context.Context, teamName string)) *MockTeamStorage_DeleteTeam_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTeamStorage_DeleteTeam_Call) Return(err error) *MockTeamStorage_DeleteTeam_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockTeamStorage_DeleteTeam_Call) RunAndReturn(run func(ctx context.Context, teamName string) error) *MockTeamStorage_DeleteTeam_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetTeam provides a mock function for the type MockTeamStorage
+func (_mock *MockTeamStorage) GetTeam(ctx context.Context, teamName string) (*domain.Team, error) {
+	ret := _mock.Called(ctx, teamName)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTeam")
+	}
+
+	var r0 *domain.Team
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (bool, error)); ok {
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*domain.Team, error)); ok {
 		return returnFunc(ctx, teamName)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) bool); ok {
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *domain.Team); ok {
 		r0 = returnFunc(ctx, teamName)
 	} else {
-		r0 = ret.Get(0).(bool)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Team)
+		}
 	}
 	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
 		r1 = returnFunc(ctx, teamName)
@@ -120,19 +180,342 @@ func (_mock *MockTeamStorage) TeamExists(ctx context.Context, teamName string) (
 	return r0, r1
 }
 
-// MockTeamStorage_TeamExists_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TeamExists'
-type MockTeamStorage_TeamExists_Call struct {
+// MockTeamStorage_GetTeam_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTeam'
+type MockTeamStorage_GetTeam_Call struct {
+	*mock.Call
+}
+
+// GetTeam is a helper method to define mock.On call
+//   - ctx context.Context
+//   - teamName string
+func (_e *MockTeamStorage_Expecter) GetTeam(ctx interface{}, teamName interface{}) *MockTeamStorage_GetTeam_Call {
+	return &MockTeamStorage_GetTeam_Call{Call: _e.mock.On("GetTeam", ctx, teamName)}
+}
+
+func (_c *MockTeamStorage_GetTeam_Call) Run(run func(ctx context.Context, teamName string)) *MockTeamStorage_GetTeam_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTeamStorage_GetTeam_Call) Return(team *domain.Team, err error) *MockTeamStorage_GetTeam_Call {
+	_c.Call.Return(team, err)
+	return _c
+}
+
+func (_c *MockTeamStorage_GetTeam_Call) RunAndReturn(run func(ctx context.Context, teamName string) (*domain.Team, error)) *MockTeamStorage_GetTeam_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RenameTeam provides a mock function for the type MockTeamStorage
+func (_mock *MockTeamStorage) RenameTeam(ctx context.Context, teamName string, newTeamName string) error {
+	ret := _mock.Called(ctx, teamName, newTeamName)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RenameTeam")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = returnFunc(ctx, teamName, newTeamName)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockTeamStorage_RenameTeam_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RenameTeam'
+type MockTeamStorage_RenameTeam_Call struct {
+	*mock.Call
+}
+
+// RenameTeam is a helper method to define mock.On call
+//   - ctx context.Context
+//   - teamName string
+//   - newTeamName string
+func (_e *MockTeamStorage_Expecter) RenameTeam(ctx interface{}, teamName interface{}, newTeamName interface{}) *MockTeamStorage_RenameTeam_Call {
+	return &MockTeamStorage_RenameTeam_Call{Call: _e.mock.On("RenameTeam", ctx, teamName, newTeamName)}
+}
+
+func (_c *MockTeamStorage_RenameTeam_Call) Run(run func(ctx context.Context, teamName string, newTeamName string)) *MockTeamStorage_RenameTeam_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTeamStorage_RenameTeam_Call) Return(err error) *MockTeamStorage_RenameTeam_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockTeamStorage_RenameTeam_Call) RunAndReturn(run func(ctx context.Context, teamName string, newTeamName string) error) *MockTeamStorage_RenameTeam_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetArchived provides a mock function for the type MockTeamStorage
+func (_mock *MockTeamStorage) SetArchived(ctx context.Context, teamName string, archived bool) error {
+	ret := _mock.Called(ctx, teamName, archived)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetArchived")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, bool) error); ok {
+		r0 = returnFunc(ctx, teamName, archived)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockTeamStorage_SetArchived_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetArchived'
+type MockTeamStorage_SetArchived_Call struct {
+	*mock.Call
+}
+
+// SetArchived is a helper method to define mock.On call
+//   - ctx context.Context
+//   - teamName string
+//   - archived bool
+func (_e *MockTeamStorage_Expecter) SetArchived(ctx interface{}, teamName interface{}, archived interface{}) *MockTeamStorage_SetArchived_Call {
+	return &MockTeamStorage_SetArchived_Call{Call: _e.mock.On("SetArchived", ctx, teamName, archived)}
+}
+
+func (_c *MockTeamStorage_SetArchived_Call) Run(run func(ctx context.Context, teamName string, archived bool)) *MockTeamStorage_SetArchived_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 bool
+		if args[2] != nil {
+			arg2 = args[2].(bool)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTeamStorage_SetArchived_Call) Return(err error) *MockTeamStorage_SetArchived_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockTeamStorage_SetArchived_Call) RunAndReturn(run func(ctx context.Context, teamName string, archived bool) error) *MockTeamStorage_SetArchived_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetMaxOpenReviews provides a mock function for the type MockTeamStorage
+func (_mock *MockTeamStorage) SetMaxOpenReviews(ctx context.Context, teamName string, limit *int) error {
+	ret := _mock.Called(ctx, teamName, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetMaxOpenReviews")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *int) error); ok {
+		r0 = returnFunc(ctx, teamName, limit)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockTeamStorage_SetMaxOpenReviews_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetMaxOpenReviews'
+type MockTeamStorage_SetMaxOpenReviews_Call struct {
+	*mock.Call
+}
+
+// SetMaxOpenReviews is a helper method to define mock.On call
+//   - ctx context.Context
+//   - teamName string
+//   - limit *int
+func (_e *MockTeamStorage_Expecter) SetMaxOpenReviews(ctx interface{}, teamName interface{}, limit interface{}) *MockTeamStorage_SetMaxOpenReviews_Call {
+	return &MockTeamStorage_SetMaxOpenReviews_Call{Call: _e.mock.On("SetMaxOpenReviews", ctx, teamName, limit)}
+}
+
+func (_c *MockTeamStorage_SetMaxOpenReviews_Call) Run(run func(ctx context.Context, teamName string, limit *int)) *MockTeamStorage_SetMaxOpenReviews_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 *int
+		if args[2] != nil {
+			arg2 = args[2].(*int)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTeamStorage_SetMaxOpenReviews_Call) Return(err error) *MockTeamStorage_SetMaxOpenReviews_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockTeamStorage_SetMaxOpenReviews_Call) RunAndReturn(run func(ctx context.Context, teamName string, limit *int) error) *MockTeamStorage_SetMaxOpenReviews_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetSeniorityRules provides a mock function for the type MockTeamStorage
+func (_mock *MockTeamStorage) SetSeniorityRules(ctx context.Context, teamName string, rules []domain.SeniorityRule) error {
+	ret := _mock.Called(ctx, teamName, rules)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetSeniorityRules")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, []domain.SeniorityRule) error); ok {
+		r0 = returnFunc(ctx, teamName, rules)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockTeamStorage_SetSeniorityRules_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetSeniorityRules'
+type MockTeamStorage_SetSeniorityRules_Call struct {
+	*mock.Call
+}
+
+// SetSeniorityRules is a helper method to define mock.On call
+//   - ctx context.Context
+//   - teamName string
+//   - rules []domain.SeniorityRule
+func (_e *MockTeamStorage_Expecter) SetSeniorityRules(ctx interface{}, teamName interface{}, rules interface{}) *MockTeamStorage_SetSeniorityRules_Call {
+	return &MockTeamStorage_SetSeniorityRules_Call{Call: _e.mock.On("SetSeniorityRules", ctx, teamName, rules)}
+}
+
+func (_c *MockTeamStorage_SetSeniorityRules_Call) Run(run func(ctx context.Context, teamName string, rules []domain.SeniorityRule)) *MockTeamStorage_SetSeniorityRules_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 []domain.SeniorityRule
+		if args[2] != nil {
+			arg2 = args[2].([]domain.SeniorityRule)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTeamStorage_SetSeniorityRules_Call) Return(err error) *MockTeamStorage_SetSeniorityRules_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockTeamStorage_SetSeniorityRules_Call) RunAndReturn(run func(ctx context.Context, teamName string, rules []domain.SeniorityRule) error) *MockTeamStorage_SetSeniorityRules_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SyncTeam provides a mock function for the type MockTeamStorage
+func (_mock *MockTeamStorage) SyncTeam(ctx context.Context, teamName string, members []*domain.User, detach bool, dryRun bool) (*domain.TeamDiff, error) {
+	ret := _mock.Called(ctx, teamName, members, detach, dryRun)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SyncTeam")
+	}
+
+	var r0 *domain.TeamDiff
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, []*domain.User, bool, bool) (*domain.TeamDiff, error)); ok {
+		return returnFunc(ctx, teamName, members, detach, dryRun)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, []*domain.User, bool, bool) *domain.TeamDiff); ok {
+		r0 = returnFunc(ctx, teamName, members, detach, dryRun)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.TeamDiff)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, []*domain.User, bool, bool) error); ok {
+		r1 = returnFunc(ctx, teamName, members, detach, dryRun)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockTeamStorage_SyncTeam_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SyncTeam'
+type MockTeamStorage_SyncTeam_Call struct {
 	*mock.Call
 }
 
-// TeamExists is a helper method to define mock.On call
+// SyncTeam is a helper method to define mock.On call
 //   - ctx context.Context
 //   - teamName string
-func (_e *MockTeamStorage_Expecter) TeamExists(ctx interface{}, teamName interface{}) *MockTeamStorage_TeamExists_Call {
-	return &MockTeamStorage_TeamExists_Call{Call: _e.mock.On("TeamExists", ctx, teamName)}
+//   - members []*domain.User
+//   - detach bool
+//   - dryRun bool
+func (_e *MockTeamStorage_Expecter) SyncTeam(ctx interface{}, teamName interface{}, members interface{}, detach interface{}, dryRun interface{}) *MockTeamStorage_SyncTeam_Call {
+	return &MockTeamStorage_SyncTeam_Call{Call: _e.mock.On("SyncTeam", ctx, teamName, members, detach, dryRun)}
 }
 
-func (_c *MockTeamStorage_TeamExists_Call) Run(run func(ctx context.Context, teamName string)) *MockTeamStorage_TeamExists_Call {
+func (_c *MockTeamStorage_SyncTeam_Call) Run(run func(ctx context.Context, teamName string, members []*domain.User, detach bool, dryRun bool)) *MockTeamStorage_SyncTeam_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -142,20 +525,35 @@ func (_c *MockTeamStorage_TeamExists_Call) Run(run func(ctx context.Context, tea
 		if args[1] != nil {
 			arg1 = args[1].(string)
 		}
+		var arg2 []*domain.User
+		if args[2] != nil {
+			arg2 = args[2].([]*domain.User)
+		}
+		var arg3 bool
+		if args[3] != nil {
+			arg3 = args[3].(bool)
+		}
+		var arg4 bool
+		if args[4] != nil {
+			arg4 = args[4].(bool)
+		}
 		run(
 			arg0,
 			arg1,
+			arg2,
+			arg3,
+			arg4,
 		)
 	})
 	return _c
 }
 
-func (_c *MockTeamStorage_TeamExists_Call) Return(b bool, err error) *MockTeamStorage_TeamExists_Call {
-	_c.Call.Return(b, err)
+func (_c *MockTeamStorage_SyncTeam_Call) Return(teamDiff *domain.TeamDiff, err error) *MockTeamStorage_SyncTeam_Call {
+	_c.Call.Return(teamDiff, err)
 	return _c
 }
 
-func (_c *MockTeamStorage_TeamExists_Call) RunAndReturn(run func(ctx context.Context, teamName string) (bool, error)) *MockTeamStorage_TeamExists_Call {
+func (_c *MockTeamStorage_SyncTeam_Call) RunAndReturn(run func(ctx context.Context, teamName string, members []*domain.User, detach bool, dryRun bool) (*domain.TeamDiff, error)) *MockTeamStorage_SyncTeam_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -38,6 +38,74 @@ func (_m *MockUserStorage) EXPECT() *MockUserStorage_Expecter {
 	return &MockUserStorage_Expecter{mock: &_m.Mock}
 }
 
+// GetUser provides a mock function for the type MockUserStorage
+func (_mock *MockUserStorage) GetUser(ctx context.Context, userID string) (*domain.User, error) {
+	ret := _mock.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUser")
+	}
+
+	var r0 *domain.User
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*domain.User, error)); ok {
+		return returnFunc(ctx, userID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *domain.User); ok {
+		r0 = returnFunc(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.User)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockUserStorage_GetUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUser'
+type MockUserStorage_GetUser_Call struct {
+	*mock.Call
+}
+
+// GetUser is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+func (_e *MockUserStorage_Expecter) GetUser(ctx interface{}, userID interface{}) *MockUserStorage_GetUser_Call {
+	return &MockUserStorage_GetUser_Call{Call: _e.mock.On("GetUser", ctx, userID)}
+}
+
+func (_c *MockUserStorage_GetUser_Call) Run(run func(ctx context.Context, userID string)) *MockUserStorage_GetUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUserStorage_GetUser_Call) Return(user *domain.User, err error) *MockUserStorage_GetUser_Call {
+	_c.Call.Return(user, err)
+	return _c
+}
+
+func (_c *MockUserStorage_GetUser_Call) RunAndReturn(run func(ctx context.Context, userID string) (*domain.User, error)) *MockUserStorage_GetUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetUsersByTeamName provides a mock function for the type MockUserStorage
 func (_mock *MockUserStorage) GetUsersByTeamName(ctx context.Context, teamName string) ([]*domain.User, error) {
 	ret := _mock.Called(ctx, teamName)
@@ -106,6 +174,138 @@ func (_c *MockUserStorage_GetUsersByTeamName_Call) RunAndReturn(run func(ctx con
 	return _c
 }
 
+// MoveMembership provides a mock function for the type MockUserStorage
+func (_mock *MockUserStorage) MoveMembership(ctx context.Context, userID string, fromTeamName string, toTeamName string) error {
+	ret := _mock.Called(ctx, userID, fromTeamName, toTeamName)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MoveMembership")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string) error); ok {
+		r0 = returnFunc(ctx, userID, fromTeamName, toTeamName)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockUserStorage_MoveMembership_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MoveMembership'
+type MockUserStorage_MoveMembership_Call struct {
+	*mock.Call
+}
+
+// MoveMembership is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+//   - fromTeamName string
+//   - toTeamName string
+func (_e *MockUserStorage_Expecter) MoveMembership(ctx interface{}, userID interface{}, fromTeamName interface{}, toTeamName interface{}) *MockUserStorage_MoveMembership_Call {
+	return &MockUserStorage_MoveMembership_Call{Call: _e.mock.On("MoveMembership", ctx, userID, fromTeamName, toTeamName)}
+}
+
+func (_c *MockUserStorage_MoveMembership_Call) Run(run func(ctx context.Context, userID string, fromTeamName string, toTeamName string)) *MockUserStorage_MoveMembership_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUserStorage_MoveMembership_Call) Return(err error) *MockUserStorage_MoveMembership_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockUserStorage_MoveMembership_Call) RunAndReturn(run func(ctx context.Context, userID string, fromTeamName string, toTeamName string) error) *MockUserStorage_MoveMembership_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RemoveMemberships provides a mock function for the type MockUserStorage
+func (_mock *MockUserStorage) RemoveMemberships(ctx context.Context, teamName string, userIDs []string) error {
+	ret := _mock.Called(ctx, teamName, userIDs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveMemberships")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, []string) error); ok {
+		r0 = returnFunc(ctx, teamName, userIDs)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockUserStorage_RemoveMemberships_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RemoveMemberships'
+type MockUserStorage_RemoveMemberships_Call struct {
+	*mock.Call
+}
+
+// RemoveMemberships is a helper method to define mock.On call
+//   - ctx context.Context
+//   - teamName string
+//   - userIDs []string
+func (_e *MockUserStorage_Expecter) RemoveMemberships(ctx interface{}, teamName interface{}, userIDs interface{}) *MockUserStorage_RemoveMemberships_Call {
+	return &MockUserStorage_RemoveMemberships_Call{Call: _e.mock.On("RemoveMemberships", ctx, teamName, userIDs)}
+}
+
+func (_c *MockUserStorage_RemoveMemberships_Call) Run(run func(ctx context.Context, teamName string, userIDs []string)) *MockUserStorage_RemoveMemberships_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 []string
+		if args[2] != nil {
+			arg2 = args[2].([]string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUserStorage_RemoveMemberships_Call) Return(err error) *MockUserStorage_RemoveMemberships_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockUserStorage_RemoveMemberships_Call) RunAndReturn(run func(ctx context.Context, teamName string, userIDs []string) error) *MockUserStorage_RemoveMemberships_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // UpsertUsers provides a mock function for the type MockUserStorage
 func (_mock *MockUserStorage) UpsertUsers(ctx context.Context, users []*domain.User) error {
 	ret := _mock.Called(ctx, users)
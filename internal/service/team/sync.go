@@ -0,0 +1,91 @@
+package team
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/whitxowl/pr-reviewer-assignment-service.git/internal/domain"
+	serviceErr "github.com/whitxowl/pr-reviewer-assignment-service.git/internal/service/errors"
+	"github.com/whitxowl/pr-reviewer-assignment-service.git/internal/service/validation"
+)
+
+// What SyncTeam does with current members missing from the desired roster.
+const (
+	MissingDeactivate = "deactivate"
+	MissingDetach     = "detach"
+)
+
+// SyncTeam makes the team's roster equal to members, creating the team if needed.
+// The diff is computed from the roster and applied in one storage transaction,
+// unless dryRun is set. Syncing the same roster twice returns an empty diff the
+// second time. Detached members that still review open PRs of the team are
+// reported, and with reassign set those reviews are handed to another member as
+// RemoveMembers does. Deactivated members keep their reviews, like users
+// deactivated one by one.
+func (s *Service) SyncTeam(
+	ctx context.Context,
+	teamName string,
+	members []*domain.User,
+	missing string,
+	reassign bool,
+	dryRun bool,
+) (*domain.TeamDiff, []domain.DepartedReviewer, error) {
+	const op = "service.team.SyncTeam"
+
+	log := s.log.With(
+		slog.String("op", op),
+		slog.String("teamName", teamName),
+		slog.Bool("dryRun", dryRun),
+	)
+
+	if err := s.ids.Users(members); err != nil {
+		log.DebugContext(ctx, "invalid user id in roster", "error", err)
+		return nil, nil, err
+	}
+
+	if err := validation.UserTags(members); err != nil {
+		log.DebugContext(ctx, "invalid tag", "error", err)
+		return nil, nil, err
+	}
+
+	desired := make(map[string]bool, len(members))
+	for _, member := range members {
+		if desired[member.UserID] {
+			log.DebugContext(ctx, "duplicate user in roster", "userID", member.UserID)
+			return nil, nil, serviceErr.ErrDuplicateUser
+		}
+		desired[member.UserID] = true
+	}
+
+	diff, err := s.teamStorage.SyncTeam(ctx, teamName, members, missing == MissingDetach, dryRun)
+	if err != nil {
+		log.ErrorContext(ctx, "error syncing team", "error", err)
+		return nil, nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	detached := make([]string, len(diff.Detached))
+	for i, user := range diff.Detached {
+		detached[i] = user.UserID
+	}
+
+	// A dry run only reports the reviews a detached member would leave.
+	departed, err := s.departedReviewers(ctx, detached, teamName, reassign && !dryRun)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if dryRun || diff.Empty() {
+		return diff, departed, nil
+	}
+
+	log.InfoContext(ctx, "team synced successfully",
+		"added", len(diff.Added),
+		"updated", len(diff.Updated),
+		"deactivated", len(diff.Deactivated),
+		"detached", len(diff.Detached))
+
+	s.wake()
+
+	return diff, departed, nil
+}
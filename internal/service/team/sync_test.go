@@ -0,0 +1,227 @@
+package team
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/whitxowl/pr-reviewer-assignment-service.git/internal/domain"
+	serviceErr "github.com/whitxowl/pr-reviewer-assignment-service.git/internal/service/errors"
+)
+
+func TestService_SyncTeam(t *testing.T) {
+	ctx := context.Background()
+	log := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
+
+	current := []*domain.User{
+		{UserID: "u1", Username: "Alice", TeamName: "backend", IsActive: true},
+		{UserID: "u2", Username: "Bob", TeamName: "backend", IsActive: true},
+		{UserID: "u3", Username: "Carol", TeamName: "backend", IsActive: true},
+		{UserID: "u4", Username: "Dave", TeamName: "backend", IsActive: false},
+	}
+	// syncedFrom diffs the roster in storage against current, a new team when nil.
+	syncedFrom := func(current []*domain.User) func(context.Context, string, []*domain.User, bool, bool) (*domain.TeamDiff, error) {
+		return func(_ context.Context, teamName string, members []*domain.User, detach bool, _ bool) (*domain.TeamDiff, error) {
+			diff := domain.NewTeamDiff(teamName, current, members, detach)
+			diff.TeamCreated = current == nil
+			return diff, nil
+		}
+	}
+	desired := func() []*domain.User {
+		return []*domain.User{
+			{UserID: "u1", Username: "Alice", IsActive: true},
+			{UserID: "u2", Username: "Bob Smith", IsActive: true},
+			{UserID: "u5", Username: "Eve", IsActive: true},
+		}
+	}
+
+	tests := []struct {
+		name             string
+		members          []*domain.User
+		missing          string
+		reassign         bool
+		dryRun           bool
+		setupMocks       func(m membershipMocks)
+		expectedDiff     *domain.TeamDiff
+		expectedDeparted []domain.DepartedReviewer
+		expectedError    error
+	}{
+		{
+			name:    "success - diff applied, missing members deactivated",
+			members: desired(),
+			missing: MissingDeactivate,
+			setupMocks: func(m membershipMocks) {
+				m.team.EXPECT().SyncTeam(ctx, "backend", mock.Anything, false, false).RunAndReturn(syncedFrom(current)).Once()
+			},
+			expectedDiff: &domain.TeamDiff{
+				TeamName:    "backend",
+				Added:       []*domain.User{{UserID: "u5", Username: "Eve", TeamName: "backend", IsActive: true}},
+				Updated:     []*domain.User{{UserID: "u2", Username: "Bob Smith", TeamName: "backend", IsActive: true}},
+				Deactivated: []*domain.User{{UserID: "u3", Username: "Carol", TeamName: "backend", IsActive: false}},
+				Unchanged:   []string{"u1", "u4"},
+			},
+		},
+		{
+			name:     "success - dry run with missing members detached reports their reviews",
+			members:  desired(),
+			missing:  MissingDetach,
+			reassign: true,
+			dryRun:   true,
+			setupMocks: func(m membershipMocks) {
+				m.team.EXPECT().SyncTeam(ctx, "backend", mock.Anything, true, true).RunAndReturn(syncedFrom(current)).Once()
+				m.pr.EXPECT().GetOpenPRsByReviewerAndTeam(ctx, "u3", "backend").Return([]domain.PRRef{{PullRequestID: "pr-1"}}, nil).Once()
+				m.pr.EXPECT().GetOpenPRsByReviewerAndTeam(ctx, "u4", "backend").Return(nil, nil).Once()
+			},
+			expectedDiff: &domain.TeamDiff{
+				TeamName: "backend",
+				Added:    []*domain.User{{UserID: "u5", Username: "Eve", TeamName: "backend", IsActive: true}},
+				Updated:  []*domain.User{{UserID: "u2", Username: "Bob Smith", TeamName: "backend", IsActive: true}},
+				Detached: []*domain.User{
+					{UserID: "u3", Username: "Carol", IsActive: true},
+					{UserID: "u4", Username: "Dave", IsActive: false},
+				},
+				Unchanged: []string{"u1"},
+			},
+			expectedDeparted: []domain.DepartedReviewer{
+				{UserID: "u3", FormerTeamName: "backend", PullRequests: []domain.PRRef{{PullRequestID: "pr-1"}}},
+			},
+		},
+		{
+			name:     "success - reviews of detached members reassigned",
+			members:  desired()[:2],
+			missing:  MissingDetach,
+			reassign: true,
+			setupMocks: func(m membershipMocks) {
+				m.team.EXPECT().SyncTeam(ctx, "backend", mock.Anything, true, false).RunAndReturn(syncedFrom(current[:3])).Once()
+				m.pr.EXPECT().GetOpenPRsByReviewerAndTeam(ctx, "u3", "backend").Return([]domain.PRRef{{PullRequestID: "pr-1"}}, nil).Once()
+				m.reassigner.EXPECT().ReassignReviewer(ctx, "", "pr-1", "u3", false).Return(&domain.PullRequest{}, "u1", nil).Once()
+			},
+			expectedDiff: &domain.TeamDiff{
+				TeamName:  "backend",
+				Updated:   []*domain.User{{UserID: "u2", Username: "Bob Smith", TeamName: "backend", IsActive: true}},
+				Detached:  []*domain.User{{UserID: "u3", Username: "Carol", IsActive: true}},
+				Unchanged: []string{"u1"},
+			},
+			expectedDeparted: []domain.DepartedReviewer{{
+				UserID:         "u3",
+				FormerTeamName: "backend",
+				PullRequests:   []domain.PRRef{{PullRequestID: "pr-1"}},
+				ReplacedBy:     map[domain.PRRef]string{{PullRequestID: "pr-1"}: "u1"},
+			}},
+		},
+		{
+			name: "success - replay changes nothing",
+			members: []*domain.User{
+				{UserID: "u1", Username: "Alice", IsActive: true},
+				{UserID: "u2", Username: "Bob", IsActive: true},
+			},
+			missing: MissingDeactivate,
+			setupMocks: func(m membershipMocks) {
+				m.team.EXPECT().SyncTeam(ctx, "backend", mock.Anything, false, false).RunAndReturn(syncedFrom(current[:2])).Once()
+			},
+			expectedDiff: &domain.TeamDiff{
+				TeamName:  "backend",
+				Unchanged: []string{"u1", "u2"},
+			},
+		},
+		{
+			name: "success - changed tags update a member, omitted tags are kept",
+			members: []*domain.User{
+				{UserID: "u1", Username: "Alice", IsActive: true, Tags: []string{"Postgres", "go"}},
+				{UserID: "u2", Username: "Bob", IsActive: true},
+				{UserID: "u3", Username: "Carol", IsActive: true, Tags: []string{"go"}},
+			},
+			missing: MissingDeactivate,
+			setupMocks: func(m membershipMocks) {
+				tagged := []*domain.User{current[0], current[1], {UserID: "u3", Username: "Carol", IsActive: true, Tags: []string{"go"}}}
+				m.team.EXPECT().SyncTeam(ctx, "backend", mock.Anything, false, false).RunAndReturn(syncedFrom(tagged)).Once()
+			},
+			expectedDiff: &domain.TeamDiff{
+				TeamName: "backend",
+				Updated: []*domain.User{
+					{UserID: "u1", Username: "Alice", TeamName: "backend", IsActive: true, Tags: []string{"go", "postgres"}},
+				},
+				Unchanged: []string{"u2", "u3"},
+			},
+		},
+		{
+			name:    "success - team created",
+			members: desired()[:1],
+			missing: MissingDeactivate,
+			setupMocks: func(m membershipMocks) {
+				m.team.EXPECT().SyncTeam(ctx, "backend", mock.Anything, false, false).RunAndReturn(syncedFrom(nil)).Once()
+			},
+			expectedDiff: &domain.TeamDiff{
+				TeamName:    "backend",
+				TeamCreated: true,
+				Added:       []*domain.User{{UserID: "u1", Username: "Alice", TeamName: "backend", IsActive: true}},
+			},
+		},
+		{
+			name: "error - duplicate user",
+			members: []*domain.User{
+				{UserID: "u1", Username: "Alice", IsActive: true},
+				{UserID: "u1", Username: "Alice", IsActive: false},
+			},
+			missing:       MissingDeactivate,
+			setupMocks:    func(m membershipMocks) {},
+			expectedError: serviceErr.ErrDuplicateUser,
+		},
+		{
+			name: "error - invalid user id",
+			members: []*domain.User{
+				{UserID: "u1", Username: "Alice", IsActive: true},
+				{UserID: "alice", Username: "Alice", IsActive: true},
+			},
+			missing:       MissingDeactivate,
+			setupMocks:    func(m membershipMocks) {},
+			expectedError: serviceErr.ErrInvalidUserID,
+		},
+		{
+			name: "error - invalid tag",
+			members: []*domain.User{
+				{UserID: "u1", Username: "Alice", IsActive: true, Tags: []string{"machine learning"}},
+			},
+			missing:       MissingDeactivate,
+			setupMocks:    func(m membershipMocks) {},
+			expectedError: serviceErr.ErrInvalidTag,
+		},
+		{
+			name:    "error - storage error on sync",
+			members: desired(),
+			missing: MissingDeactivate,
+			setupMocks: func(m membershipMocks) {
+				m.team.EXPECT().SyncTeam(ctx, "backend", mock.Anything, false, false).Return(nil, errors.New("database error")).Once()
+			},
+			expectedError: errors.New("service.team.SyncTeam: database error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			m := newMembershipMocks(t)
+			tt.setupMocks(m)
+
+			// Act
+			diff, departed, err := m.service(log).SyncTeam(ctx, "backend", tt.members, tt.missing, tt.reassign, tt.dryRun)
+
+			// Assert
+			if tt.expectedError != nil {
+				assert.Error(t, err)
+				assert.Nil(t, diff)
+				if !errors.Is(err, tt.expectedError) {
+					assert.Contains(t, err.Error(), tt.expectedError.Error())
+				}
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedDiff, diff)
+				assert.Equal(t, tt.expectedDeparted, departed)
+			}
+		})
+	}
+}
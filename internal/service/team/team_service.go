@@ -5,33 +5,92 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"slices"
+	"time"
 
 	"github.com/whitxowl/pr-reviewer-assignment-service.git/internal/domain"
 	serviceErr "github.com/whitxowl/pr-reviewer-assignment-service.git/internal/service/errors"
+	"github.com/whitxowl/pr-reviewer-assignment-service.git/internal/service/validation"
 	storageErr "github.com/whitxowl/pr-reviewer-assignment-service.git/internal/storage/errors"
 )
 
 type TeamStorage interface {
 	CreateTeam(ctx context.Context, teamName string) error
-	TeamExists(ctx context.Context, teamName string) (bool, error)
+	GetTeam(ctx context.Context, teamName string) (*domain.Team, error)
+	RenameTeam(ctx context.Context, teamName string, newTeamName string) error
+	SetArchived(ctx context.Context, teamName string, archived bool) error
+	SetMaxOpenReviews(ctx context.Context, teamName string, limit *int) error
+	SetSeniorityRules(ctx context.Context, teamName string, rules []domain.SeniorityRule) error
+	DeleteTeam(ctx context.Context, teamName string) error
+	SyncTeam(ctx context.Context, teamName string, members []*domain.User, detach bool, dryRun bool) (*domain.TeamDiff, error)
 }
 
 type UserStorage interface {
 	UpsertUsers(ctx context.Context, users []*domain.User) error
 	GetUsersByTeamName(ctx context.Context, teamName string) ([]*domain.User, error)
+	GetUser(ctx context.Context, userID string) (*domain.User, error)
+	RemoveMemberships(ctx context.Context, teamName string, userIDs []string) error
+	MoveMembership(ctx context.Context, userID string, fromTeamName string, toTeamName string) error
+}
+
+type PRStorage interface {
+	GetOpenPRsReviewedByTeam(ctx context.Context, teamName string) ([]domain.PRRef, error)
+	GetOpenPRsByReviewerAndTeam(ctx context.Context, reviewerID string, teamName string) ([]domain.PRRef, error)
+	GetPairings(ctx context.Context, authorIDs []string, window time.Duration) ([]domain.Pairing, error)
+}
+
+// Reassigner replaces a reviewer of an open PR; it is implemented by the PR service.
+type Reassigner interface {
+	ReassignReviewer(
+		ctx context.Context,
+		repository string,
+		prID string,
+		oldReviewerID string,
+		dryRun bool,
+	) (*domain.PullRequest, string, error)
+}
+
+// Waker is told when a reviewer may have become available for queued slots.
+type Waker interface {
+	Wake()
 }
 
 type Service struct {
 	log         *slog.Logger
 	teamStorage TeamStorage
 	userStorage UserStorage
+	prStorage   PRStorage
+	reassigner  Reassigner
+	ids         *validation.IDs
+	waker       Waker
 }
 
-func New(log *slog.Logger, teamStorage TeamStorage, userStorage UserStorage) *Service {
+func New(
+	log *slog.Logger,
+	teamStorage TeamStorage,
+	userStorage UserStorage,
+	prStorage PRStorage,
+	reassigner Reassigner,
+	ids *validation.IDs,
+) *Service {
 	return &Service{
 		log:         log,
 		teamStorage: teamStorage,
 		userStorage: userStorage,
+		prStorage:   prStorage,
+		reassigner:  reassigner,
+		ids:         ids,
+	}
+}
+
+// SetWaker sets the worker to wake when members join or come back.
+func (s *Service) SetWaker(waker Waker) {
+	s.waker = waker
+}
+
+func (s *Service) wake() {
+	if s.waker != nil {
+		s.waker.Wake()
 	}
 }
 
@@ -43,6 +102,16 @@ func (s *Service) CreateTeam(ctx context.Context, team domain.Team) error {
 		slog.String("teamName", team.TeamName),
 	)
 
+	if err := s.ids.Users(team.Members); err != nil {
+		log.DebugContext(ctx, "invalid user id", "error", err)
+		return err
+	}
+
+	if err := validation.UserTags(team.Members); err != nil {
+		log.DebugContext(ctx, "invalid tag", "error", err)
+		return err
+	}
+
 	err := s.teamStorage.CreateTeam(ctx, team.TeamName)
 	if errors.Is(err, storageErr.ErrTeamExists) {
 		log.DebugContext(ctx, "team already exists")
@@ -59,6 +128,8 @@ func (s *Service) CreateTeam(ctx context.Context, team domain.Team) error {
 
 	log.InfoContext(ctx, "team created successfully")
 
+	s.wake()
+
 	return nil
 }
 
@@ -70,14 +141,14 @@ func (s *Service) GetTeam(ctx context.Context, teamName string) (*domain.Team, e
 		slog.String("teamName", teamName),
 	)
 
-	exists, err := s.teamStorage.TeamExists(ctx, teamName)
+	team, err := s.teamStorage.GetTeam(ctx, teamName)
+	if errors.Is(err, storageErr.ErrTeamNotFound) {
+		return nil, serviceErr.ErrTeamNotFound
+	}
 	if err != nil {
-		log.ErrorContext(ctx, "error checking if team exists", "error", err)
+		log.ErrorContext(ctx, "error getting team", "error", err)
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
-	if !exists {
-		return nil, serviceErr.ErrTeamNotFound
-	}
 
 	users, err := s.userStorage.GetUsersByTeamName(ctx, teamName)
 	if err != nil {
@@ -85,8 +156,241 @@ func (s *Service) GetTeam(ctx context.Context, teamName string) (*domain.Team, e
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
-	return &domain.Team{
-		TeamName: teamName,
-		Members:  users,
+	team.Members = users
+
+	return team, nil
+}
+
+// GetPairingMatrix counts how often the team's members were assigned to each
+// other's PRs within window, or ever for a zero window. Reviews by or of users
+// outside the team are left out.
+func (s *Service) GetPairingMatrix(ctx context.Context, teamName string, window time.Duration) (*domain.PairingMatrix, error) {
+	const op = "service.team.GetPairingMatrix"
+
+	log := s.log.With(
+		slog.String("op", op),
+		slog.String("teamName", teamName),
+	)
+
+	team, err := s.GetTeam(ctx, teamName)
+	if err != nil {
+		return nil, err
+	}
+
+	members := make([]string, len(team.Members))
+	for i, member := range team.Members {
+		members[i] = member.UserID
+	}
+
+	pairings, err := s.prStorage.GetPairings(ctx, members, window)
+	if err != nil {
+		log.ErrorContext(ctx, "error getting pairings", "error", err)
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &domain.PairingMatrix{
+		TeamName: team.TeamName,
+		Window:   window,
+		Members:  members,
+		Pairings: slices.DeleteFunc(pairings, func(pairing domain.Pairing) bool {
+			return !slices.Contains(members, pairing.ReviewerID)
+		}),
 	}, nil
 }
+
+// RenameTeam renames the team together with its members' team_name.
+// Reviewers are assigned to PRs by user, so open PRs are not affected.
+func (s *Service) RenameTeam(ctx context.Context, teamName string, newTeamName string) (*domain.Team, error) {
+	const op = "service.team.RenameTeam"
+
+	log := s.log.With(
+		slog.String("op", op),
+		slog.String("teamName", teamName),
+		slog.String("newTeamName", newTeamName),
+	)
+
+	err := s.teamStorage.RenameTeam(ctx, teamName, newTeamName)
+	if errors.Is(err, storageErr.ErrTeamNotFound) {
+		log.DebugContext(ctx, "team not found")
+		return nil, serviceErr.ErrTeamNotFound
+	}
+	if errors.Is(err, storageErr.ErrTeamExists) {
+		log.DebugContext(ctx, "team with new name already exists")
+		return nil, serviceErr.ErrTeamExists
+	}
+	if err != nil {
+		log.ErrorContext(ctx, "error renaming team", "error", err)
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.InfoContext(ctx, "team renamed successfully")
+
+	return s.GetTeam(ctx, newTeamName)
+}
+
+// SetMaxOpenReviews sets the default review limit of members whose primary team this is.
+// Members with a limit of their own keep it; a nil limit removes the default.
+func (s *Service) SetMaxOpenReviews(ctx context.Context, teamName string, limit *int) (*domain.Team, error) {
+	const op = "service.team.SetMaxOpenReviews"
+
+	log := s.log.With(
+		slog.String("op", op),
+		slog.String("teamName", teamName),
+	)
+
+	err := s.teamStorage.SetMaxOpenReviews(ctx, teamName, limit)
+	if errors.Is(err, storageErr.ErrTeamNotFound) {
+		log.DebugContext(ctx, "team not found")
+		return nil, serviceErr.ErrTeamNotFound
+	}
+	if err != nil {
+		log.ErrorContext(ctx, "error setting max_open_reviews", "error", err)
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.InfoContext(ctx, "team max_open_reviews set")
+
+	s.wake()
+
+	return s.GetTeam(ctx, teamName)
+}
+
+// SetSeniorityRules replaces the seniority rules the team's PRs are assigned
+// reviewers by. Every pair of author and reviewer level may have one rule.
+func (s *Service) SetSeniorityRules(ctx context.Context, teamName string, rules []domain.SeniorityRule) (*domain.Team, error) {
+	const op = "service.team.SetSeniorityRules"
+
+	log := s.log.With(
+		slog.String("op", op),
+		slog.String("teamName", teamName),
+	)
+
+	if err := validateSeniorityRules(rules); err != nil {
+		log.DebugContext(ctx, "invalid seniority rules", "error", err)
+		return nil, err
+	}
+
+	err := s.teamStorage.SetSeniorityRules(ctx, teamName, rules)
+	if errors.Is(err, storageErr.ErrTeamNotFound) {
+		log.DebugContext(ctx, "team not found")
+		return nil, serviceErr.ErrTeamNotFound
+	}
+	if err != nil {
+		log.ErrorContext(ctx, "error setting seniority rules", "error", err)
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.InfoContext(ctx, "team seniority rules set", "rules", len(rules))
+
+	return s.GetTeam(ctx, teamName)
+}
+
+func validateSeniorityRules(rules []domain.SeniorityRule) error {
+	for i, rule := range rules {
+		if !domain.ValidLevel(rule.AuthorLevel) || !domain.ValidLevel(rule.MinLevel) {
+			return fmt.Errorf("%w: unknown level in %s/%s", serviceErr.ErrInvalidSeniorityRule, rule.AuthorLevel, rule.MinLevel)
+		}
+		if rule.MinReviewers < 1 {
+			return fmt.Errorf("%w: min_reviewers must be positive", serviceErr.ErrInvalidSeniorityRule)
+		}
+		for _, other := range rules[:i] {
+			if other.AuthorLevel == rule.AuthorLevel && other.MinLevel == rule.MinLevel {
+				return fmt.Errorf("%w: %s/%s is listed more than once",
+					serviceErr.ErrInvalidSeniorityRule, rule.AuthorLevel, rule.MinLevel)
+			}
+		}
+	}
+
+	return nil
+}
+
+// ArchiveTeam stops picking the team's members as reviewers for new PRs and reassignments.
+// Open PRs keep the reviewers they already have; they are returned so that
+// they can be reassigned explicitly.
+func (s *Service) ArchiveTeam(ctx context.Context, teamName string) (*domain.Team, []domain.PRRef, error) {
+	const op = "service.team.ArchiveTeam"
+
+	log := s.log.With(
+		slog.String("op", op),
+		slog.String("teamName", teamName),
+	)
+
+	err := s.teamStorage.SetArchived(ctx, teamName, true)
+	if errors.Is(err, storageErr.ErrTeamNotFound) {
+		log.DebugContext(ctx, "team not found")
+		return nil, nil, serviceErr.ErrTeamNotFound
+	}
+	if err != nil {
+		log.ErrorContext(ctx, "error archiving team", "error", err)
+		return nil, nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	openPRs, err := s.prStorage.GetOpenPRsReviewedByTeam(ctx, teamName)
+	if err != nil {
+		log.ErrorContext(ctx, "error getting open prs reviewed by team", "error", err)
+		return nil, nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	team, err := s.GetTeam(ctx, teamName)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	log.InfoContext(ctx, "team archived successfully", "openPRs", len(openPRs))
+
+	return team, openPRs, nil
+}
+
+func (s *Service) UnarchiveTeam(ctx context.Context, teamName string) (*domain.Team, error) {
+	const op = "service.team.UnarchiveTeam"
+
+	log := s.log.With(
+		slog.String("op", op),
+		slog.String("teamName", teamName),
+	)
+
+	err := s.teamStorage.SetArchived(ctx, teamName, false)
+	if errors.Is(err, storageErr.ErrTeamNotFound) {
+		log.DebugContext(ctx, "team not found")
+		return nil, serviceErr.ErrTeamNotFound
+	}
+	if err != nil {
+		log.ErrorContext(ctx, "error unarchiving team", "error", err)
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.InfoContext(ctx, "team unarchived successfully")
+
+	s.wake()
+
+	return s.GetTeam(ctx, teamName)
+}
+
+// DeleteTeam deletes a team without members. A team with members has to be
+// emptied first, so no reviewer of an open PR can lose its team this way.
+func (s *Service) DeleteTeam(ctx context.Context, teamName string) error {
+	const op = "service.team.DeleteTeam"
+
+	log := s.log.With(
+		slog.String("op", op),
+		slog.String("teamName", teamName),
+	)
+
+	err := s.teamStorage.DeleteTeam(ctx, teamName)
+	if errors.Is(err, storageErr.ErrTeamNotFound) {
+		log.DebugContext(ctx, "team not found")
+		return serviceErr.ErrTeamNotFound
+	}
+	if errors.Is(err, storageErr.ErrTeamNotEmpty) {
+		log.DebugContext(ctx, "team has members")
+		return serviceErr.ErrTeamNotEmpty
+	}
+	if err != nil {
+		log.ErrorContext(ctx, "error deleting team", "error", err)
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.InfoContext(ctx, "team deleted successfully")
+
+	return nil
+}
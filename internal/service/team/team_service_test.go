@@ -6,15 +6,26 @@ import (
 	"log/slog"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/whitxowl/pr-reviewer-assignment-service.git/internal/domain"
 	serviceErr "github.com/whitxowl/pr-reviewer-assignment-service.git/internal/service/errors"
 	"github.com/whitxowl/pr-reviewer-assignment-service.git/internal/service/team/mocks"
+	"github.com/whitxowl/pr-reviewer-assignment-service.git/internal/service/validation"
 	storageErr "github.com/whitxowl/pr-reviewer-assignment-service.git/internal/storage/errors"
 )
 
+// testIDs accepts the ID formats of the default configuration.
+var testIDs = func() *validation.IDs {
+	ids, err := validation.NewIDs(`^u[0-9]+$`, `^pr-[0-9]+$`)
+	if err != nil {
+		panic(err)
+	}
+	return ids
+}()
+
 func TestService_CreateTeam(t *testing.T) {
 	ctx := context.Background()
 	log := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
@@ -49,6 +60,29 @@ func TestService_CreateTeam(t *testing.T) {
 			},
 			expectedError: nil,
 		},
+		{
+			name: "error - invalid user id",
+			team: domain.Team{
+				TeamName: "backend",
+				Members: []*domain.User{
+					{UserID: "u1", Username: "user1", TeamName: "backend"},
+					{UserID: "octocat", Username: "user2", TeamName: "backend"},
+				},
+			},
+			setupMocks:    func(*mocks.MockTeamStorage, *mocks.MockUserStorage) {},
+			expectedError: serviceErr.ErrInvalidUserID,
+		},
+		{
+			name: "error - invalid tag",
+			team: domain.Team{
+				TeamName: "backend",
+				Members: []*domain.User{
+					{UserID: "u1", Username: "user1", TeamName: "backend", Tags: []string{"go", "c sharp"}},
+				},
+			},
+			setupMocks:    func(*mocks.MockTeamStorage, *mocks.MockUserStorage) {},
+			expectedError: serviceErr.ErrInvalidTag,
+		},
 		{
 			name: "error - team already exists",
 			team: domain.Team{
@@ -107,7 +141,7 @@ func TestService_CreateTeam(t *testing.T) {
 			userStorage := mocks.NewMockUserStorage(t)
 			tt.setupMocks(teamStorage, userStorage)
 
-			service := New(log, teamStorage, userStorage)
+			service := New(log, teamStorage, userStorage, mocks.NewMockPRStorage(t), mocks.NewMockReassigner(t), testIDs)
 
 			// Act
 			err := service.CreateTeam(ctx, tt.team)
@@ -141,8 +175,8 @@ func TestService_GetTeam(t *testing.T) {
 			teamName: "backend",
 			setupMocks: func(teamStorage *mocks.MockTeamStorage, userStorage *mocks.MockUserStorage) {
 				teamStorage.EXPECT().
-					TeamExists(ctx, "backend").
-					Return(true, nil).
+					GetTeam(ctx, "backend").
+					Return(&domain.Team{TeamName: "backend"}, nil).
 					Once()
 
 				userStorage.EXPECT().
@@ -167,8 +201,8 @@ func TestService_GetTeam(t *testing.T) {
 			teamName: "empty-team",
 			setupMocks: func(teamStorage *mocks.MockTeamStorage, userStorage *mocks.MockUserStorage) {
 				teamStorage.EXPECT().
-					TeamExists(ctx, "empty-team").
-					Return(true, nil).
+					GetTeam(ctx, "empty-team").
+					Return(&domain.Team{TeamName: "empty-team"}, nil).
 					Once()
 
 				userStorage.EXPECT().
@@ -187,20 +221,20 @@ func TestService_GetTeam(t *testing.T) {
 			teamName: "non-existent-team",
 			setupMocks: func(teamStorage *mocks.MockTeamStorage, userStorage *mocks.MockUserStorage) {
 				teamStorage.EXPECT().
-					TeamExists(ctx, "non-existent-team").
-					Return(false, nil).
+					GetTeam(ctx, "non-existent-team").
+					Return(nil, storageErr.ErrTeamNotFound).
 					Once()
 			},
 			expectedTeam:  nil,
 			expectedError: serviceErr.ErrTeamNotFound,
 		},
 		{
-			name:     "error - storage error on team exists check",
+			name:     "error - storage error on get team",
 			teamName: "backend",
 			setupMocks: func(teamStorage *mocks.MockTeamStorage, userStorage *mocks.MockUserStorage) {
 				teamStorage.EXPECT().
-					TeamExists(ctx, "backend").
-					Return(false, errors.New("database error")).
+					GetTeam(ctx, "backend").
+					Return(nil, errors.New("database error")).
 					Once()
 			},
 			expectedTeam:  nil,
@@ -211,8 +245,8 @@ func TestService_GetTeam(t *testing.T) {
 			teamName: "backend",
 			setupMocks: func(teamStorage *mocks.MockTeamStorage, userStorage *mocks.MockUserStorage) {
 				teamStorage.EXPECT().
-					TeamExists(ctx, "backend").
-					Return(true, nil).
+					GetTeam(ctx, "backend").
+					Return(&domain.Team{TeamName: "backend"}, nil).
 					Once()
 
 				userStorage.EXPECT().
@@ -232,7 +266,7 @@ func TestService_GetTeam(t *testing.T) {
 			userStorage := mocks.NewMockUserStorage(t)
 			tt.setupMocks(teamStorage, userStorage)
 
-			service := New(log, teamStorage, userStorage)
+			service := New(log, teamStorage, userStorage, mocks.NewMockPRStorage(t), mocks.NewMockReassigner(t), testIDs)
 
 			// Act
 			result, err := service.GetTeam(ctx, tt.teamName)
@@ -258,3 +292,375 @@ func TestService_GetTeam(t *testing.T) {
 		})
 	}
 }
+
+func TestService_GetPairingMatrix(t *testing.T) {
+	ctx := context.Background()
+	log := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
+
+	window := 7 * 24 * time.Hour
+
+	tests := []struct {
+		name           string
+		setupMocks     func(*mocks.MockTeamStorage, *mocks.MockUserStorage, *mocks.MockPRStorage)
+		expectedMatrix *domain.PairingMatrix
+		expectedError  error
+	}{
+		{
+			name: "success - pairings of members only",
+			setupMocks: func(teamStorage *mocks.MockTeamStorage, userStorage *mocks.MockUserStorage, prStorage *mocks.MockPRStorage) {
+				teamStorage.EXPECT().GetTeam(ctx, "backend").Return(&domain.Team{TeamName: "backend"}, nil).Once()
+				userStorage.EXPECT().
+					GetUsersByTeamName(ctx, "backend").
+					Return([]*domain.User{{UserID: "u1"}, {UserID: "u2"}}, nil).
+					Once()
+				prStorage.EXPECT().
+					GetPairings(ctx, []string{"u1", "u2"}, window).
+					Return([]domain.Pairing{
+						{AuthorID: "u1", ReviewerID: "u2", Count: 3},
+						{AuthorID: "u1", ReviewerID: "u7", Count: 1},
+						{AuthorID: "u2", ReviewerID: "u1", Count: 1},
+					}, nil).
+					Once()
+			},
+			expectedMatrix: &domain.PairingMatrix{
+				TeamName: "backend",
+				Window:   window,
+				Members:  []string{"u1", "u2"},
+				Pairings: []domain.Pairing{
+					{AuthorID: "u1", ReviewerID: "u2", Count: 3},
+					{AuthorID: "u2", ReviewerID: "u1", Count: 1},
+				},
+			},
+		},
+		{
+			name: "error - team not found",
+			setupMocks: func(teamStorage *mocks.MockTeamStorage, userStorage *mocks.MockUserStorage, prStorage *mocks.MockPRStorage) {
+				teamStorage.EXPECT().GetTeam(ctx, "backend").Return(nil, storageErr.ErrTeamNotFound).Once()
+			},
+			expectedError: serviceErr.ErrTeamNotFound,
+		},
+		{
+			name: "error - storage error on get pairings",
+			setupMocks: func(teamStorage *mocks.MockTeamStorage, userStorage *mocks.MockUserStorage, prStorage *mocks.MockPRStorage) {
+				teamStorage.EXPECT().GetTeam(ctx, "backend").Return(&domain.Team{TeamName: "backend"}, nil).Once()
+				userStorage.EXPECT().GetUsersByTeamName(ctx, "backend").Return([]*domain.User{{UserID: "u1"}}, nil).Once()
+				prStorage.EXPECT().GetPairings(ctx, []string{"u1"}, window).Return(nil, errors.New("database error")).Once()
+			},
+			expectedError: errors.New("service.team.GetPairingMatrix: database error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			teamStorage := mocks.NewMockTeamStorage(t)
+			userStorage := mocks.NewMockUserStorage(t)
+			prStorage := mocks.NewMockPRStorage(t)
+			tt.setupMocks(teamStorage, userStorage, prStorage)
+
+			service := New(log, teamStorage, userStorage, prStorage, mocks.NewMockReassigner(t), testIDs)
+
+			// Act
+			result, err := service.GetPairingMatrix(ctx, "backend", window)
+
+			// Assert
+			if tt.expectedError != nil {
+				assert.Error(t, err)
+				assert.Nil(t, result)
+				if !errors.Is(err, tt.expectedError) {
+					assert.EqualError(t, err, tt.expectedError.Error())
+				}
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedMatrix, result)
+			}
+		})
+	}
+}
+
+func TestService_RenameTeam(t *testing.T) {
+	ctx := context.Background()
+	log := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
+
+	tests := []struct {
+		name          string
+		setupMocks    func(*mocks.MockTeamStorage, *mocks.MockUserStorage)
+		expectedTeam  *domain.Team
+		expectedError error
+	}{
+		{
+			name: "success - team renamed",
+			setupMocks: func(teamStorage *mocks.MockTeamStorage, userStorage *mocks.MockUserStorage) {
+				teamStorage.EXPECT().RenameTeam(ctx, "backend", "platform").Return(nil).Once()
+				teamStorage.EXPECT().GetTeam(ctx, "platform").Return(&domain.Team{TeamName: "platform"}, nil).Once()
+				userStorage.EXPECT().
+					GetUsersByTeamName(ctx, "platform").
+					Return([]*domain.User{{UserID: "u1", Username: "user1", TeamName: "platform"}}, nil).
+					Once()
+			},
+			expectedTeam: &domain.Team{
+				TeamName: "platform",
+				Members:  []*domain.User{{UserID: "u1", Username: "user1", TeamName: "platform"}},
+			},
+		},
+		{
+			name: "error - team not found",
+			setupMocks: func(teamStorage *mocks.MockTeamStorage, userStorage *mocks.MockUserStorage) {
+				teamStorage.EXPECT().RenameTeam(ctx, "backend", "platform").Return(storageErr.ErrTeamNotFound).Once()
+			},
+			expectedError: serviceErr.ErrTeamNotFound,
+		},
+		{
+			name: "error - new name taken",
+			setupMocks: func(teamStorage *mocks.MockTeamStorage, userStorage *mocks.MockUserStorage) {
+				teamStorage.EXPECT().RenameTeam(ctx, "backend", "platform").Return(storageErr.ErrTeamExists).Once()
+			},
+			expectedError: serviceErr.ErrTeamExists,
+		},
+		{
+			name: "error - storage error on rename",
+			setupMocks: func(teamStorage *mocks.MockTeamStorage, userStorage *mocks.MockUserStorage) {
+				teamStorage.EXPECT().RenameTeam(ctx, "backend", "platform").Return(errors.New("database error")).Once()
+			},
+			expectedError: errors.New("service.team.RenameTeam: database error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			teamStorage := mocks.NewMockTeamStorage(t)
+			userStorage := mocks.NewMockUserStorage(t)
+			tt.setupMocks(teamStorage, userStorage)
+
+			service := New(log, teamStorage, userStorage, mocks.NewMockPRStorage(t), mocks.NewMockReassigner(t), testIDs)
+
+			// Act
+			result, err := service.RenameTeam(ctx, "backend", "platform")
+
+			// Assert
+			if tt.expectedError != nil {
+				assert.Error(t, err)
+				assert.Nil(t, result)
+				if !errors.Is(err, tt.expectedError) {
+					assert.Contains(t, err.Error(), tt.expectedError.Error())
+				}
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedTeam, result)
+			}
+		})
+	}
+}
+
+func TestService_SetSeniorityRules(t *testing.T) {
+	ctx := context.Background()
+	log := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
+
+	juniorNeedsSenior := domain.SeniorityRule{AuthorLevel: domain.LevelJunior, MinLevel: domain.LevelSenior, MinReviewers: 1}
+	juniorNeedsMiddle := domain.SeniorityRule{AuthorLevel: domain.LevelJunior, MinLevel: domain.LevelMiddle, MinReviewers: 2}
+
+	tests := []struct {
+		name          string
+		rules         []domain.SeniorityRule
+		setupMocks    func(*mocks.MockTeamStorage, *mocks.MockUserStorage)
+		expectedTeam  *domain.Team
+		expectedError error
+	}{
+		{
+			name:  "success",
+			rules: []domain.SeniorityRule{juniorNeedsSenior, juniorNeedsMiddle},
+			setupMocks: func(teamStorage *mocks.MockTeamStorage, userStorage *mocks.MockUserStorage) {
+				rules := []domain.SeniorityRule{juniorNeedsSenior, juniorNeedsMiddle}
+				teamStorage.EXPECT().SetSeniorityRules(ctx, "backend", rules).Return(nil).Once()
+				teamStorage.EXPECT().
+					GetTeam(ctx, "backend").
+					Return(&domain.Team{TeamName: "backend", SeniorityRules: []domain.SeniorityRule{juniorNeedsMiddle, juniorNeedsSenior}}, nil).
+					Once()
+				userStorage.EXPECT().GetUsersByTeamName(ctx, "backend").Return(nil, nil).Once()
+			},
+			expectedTeam: &domain.Team{
+				TeamName:       "backend",
+				SeniorityRules: []domain.SeniorityRule{juniorNeedsMiddle, juniorNeedsSenior},
+			},
+		},
+		{
+			name:          "error - unknown level",
+			rules:         []domain.SeniorityRule{{AuthorLevel: "intern", MinLevel: domain.LevelSenior, MinReviewers: 1}},
+			setupMocks:    func(*mocks.MockTeamStorage, *mocks.MockUserStorage) {},
+			expectedError: serviceErr.ErrInvalidSeniorityRule,
+		},
+		{
+			name:          "error - no reviewers required",
+			rules:         []domain.SeniorityRule{{AuthorLevel: domain.LevelJunior, MinLevel: domain.LevelSenior}},
+			setupMocks:    func(*mocks.MockTeamStorage, *mocks.MockUserStorage) {},
+			expectedError: serviceErr.ErrInvalidSeniorityRule,
+		},
+		{
+			name:          "error - duplicate rule",
+			rules:         []domain.SeniorityRule{juniorNeedsSenior, juniorNeedsSenior},
+			setupMocks:    func(*mocks.MockTeamStorage, *mocks.MockUserStorage) {},
+			expectedError: serviceErr.ErrInvalidSeniorityRule,
+		},
+		{
+			name:  "error - team not found",
+			rules: []domain.SeniorityRule{juniorNeedsSenior},
+			setupMocks: func(teamStorage *mocks.MockTeamStorage, _ *mocks.MockUserStorage) {
+				teamStorage.EXPECT().
+					SetSeniorityRules(ctx, "backend", []domain.SeniorityRule{juniorNeedsSenior}).
+					Return(storageErr.ErrTeamNotFound).
+					Once()
+			},
+			expectedError: serviceErr.ErrTeamNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			teamStorage := mocks.NewMockTeamStorage(t)
+			userStorage := mocks.NewMockUserStorage(t)
+			tt.setupMocks(teamStorage, userStorage)
+
+			service := New(log, teamStorage, userStorage, mocks.NewMockPRStorage(t), mocks.NewMockReassigner(t), testIDs)
+
+			// Act
+			team, err := service.SetSeniorityRules(ctx, "backend", tt.rules)
+
+			// Assert
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				assert.Nil(t, team)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedTeam, team)
+			}
+		})
+	}
+}
+
+func TestService_ArchiveTeam(t *testing.T) {
+	ctx := context.Background()
+	log := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
+	archivedAt := time.Date(2025, 11, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name          string
+		setupMocks    func(*mocks.MockTeamStorage, *mocks.MockUserStorage, *mocks.MockPRStorage)
+		expectedPRs   []domain.PRRef
+		expectedError error
+	}{
+		{
+			name: "success - open PRs reported",
+			setupMocks: func(teamStorage *mocks.MockTeamStorage, userStorage *mocks.MockUserStorage, prStorage *mocks.MockPRStorage) {
+				teamStorage.EXPECT().SetArchived(ctx, "backend", true).Return(nil).Once()
+				prStorage.EXPECT().
+					GetOpenPRsReviewedByTeam(ctx, "backend").
+					Return([]domain.PRRef{{PullRequestID: "pr-1"}, {Repository: "acme/api", PullRequestID: "pr-1"}}, nil).
+					Once()
+				teamStorage.EXPECT().
+					GetTeam(ctx, "backend").
+					Return(&domain.Team{TeamName: "backend", ArchivedAt: &archivedAt}, nil).
+					Once()
+				userStorage.EXPECT().GetUsersByTeamName(ctx, "backend").Return(nil, nil).Once()
+			},
+			expectedPRs: []domain.PRRef{{PullRequestID: "pr-1"}, {Repository: "acme/api", PullRequestID: "pr-1"}},
+		},
+		{
+			name: "error - team not found",
+			setupMocks: func(teamStorage *mocks.MockTeamStorage, userStorage *mocks.MockUserStorage, prStorage *mocks.MockPRStorage) {
+				teamStorage.EXPECT().SetArchived(ctx, "backend", true).Return(storageErr.ErrTeamNotFound).Once()
+			},
+			expectedError: serviceErr.ErrTeamNotFound,
+		},
+		{
+			name: "error - storage error on open PRs",
+			setupMocks: func(teamStorage *mocks.MockTeamStorage, userStorage *mocks.MockUserStorage, prStorage *mocks.MockPRStorage) {
+				teamStorage.EXPECT().SetArchived(ctx, "backend", true).Return(nil).Once()
+				prStorage.EXPECT().GetOpenPRsReviewedByTeam(ctx, "backend").Return(nil, errors.New("query error")).Once()
+			},
+			expectedError: errors.New("service.team.ArchiveTeam: query error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			teamStorage := mocks.NewMockTeamStorage(t)
+			userStorage := mocks.NewMockUserStorage(t)
+			prStorage := mocks.NewMockPRStorage(t)
+			tt.setupMocks(teamStorage, userStorage, prStorage)
+
+			service := New(log, teamStorage, userStorage, prStorage, mocks.NewMockReassigner(t), testIDs)
+
+			// Act
+			team, prs, err := service.ArchiveTeam(ctx, "backend")
+
+			// Assert
+			if tt.expectedError != nil {
+				assert.Error(t, err)
+				assert.Nil(t, team)
+				if !errors.Is(err, tt.expectedError) {
+					assert.Contains(t, err.Error(), tt.expectedError.Error())
+				}
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, &archivedAt, team.ArchivedAt)
+				assert.Equal(t, tt.expectedPRs, prs)
+			}
+		})
+	}
+}
+
+func TestService_DeleteTeam(t *testing.T) {
+	ctx := context.Background()
+	log := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
+
+	tests := []struct {
+		name          string
+		storageError  error
+		expectedError error
+	}{
+		{
+			name: "success - team deleted",
+		},
+		{
+			name:          "error - team not found",
+			storageError:  storageErr.ErrTeamNotFound,
+			expectedError: serviceErr.ErrTeamNotFound,
+		},
+		{
+			name:          "error - team has members",
+			storageError:  storageErr.ErrTeamNotEmpty,
+			expectedError: serviceErr.ErrTeamNotEmpty,
+		},
+		{
+			name:          "error - storage error",
+			storageError:  errors.New("database error"),
+			expectedError: errors.New("service.team.DeleteTeam: database error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			teamStorage := mocks.NewMockTeamStorage(t)
+			teamStorage.EXPECT().DeleteTeam(ctx, "backend").Return(tt.storageError).Once()
+
+			service := New(log, teamStorage, mocks.NewMockUserStorage(t), mocks.NewMockPRStorage(t), mocks.NewMockReassigner(t), testIDs)
+
+			// Act
+			err := service.DeleteTeam(ctx, "backend")
+
+			// Assert
+			if tt.expectedError != nil {
+				assert.Error(t, err)
+				if !errors.Is(err, tt.expectedError) {
+					assert.Contains(t, err.Error(), tt.expectedError.Error())
+				}
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -38,6 +38,148 @@ func (_m *MockUserStorage) EXPECT() *MockUserStorage_Expecter {
 	return &MockUserStorage_Expecter{mock: &_m.Mock}
 }
 
+// GetUser provides a mock function for the type MockUserStorage
+func (_mock *MockUserStorage) GetUser(ctx context.Context, userID string) (*domain.User, error) {
+	ret := _mock.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUser")
+	}
+
+	var r0 *domain.User
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*domain.User, error)); ok {
+		return returnFunc(ctx, userID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *domain.User); ok {
+		r0 = returnFunc(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.User)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockUserStorage_GetUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUser'
+type MockUserStorage_GetUser_Call struct {
+	*mock.Call
+}
+
+// GetUser is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+func (_e *MockUserStorage_Expecter) GetUser(ctx interface{}, userID interface{}) *MockUserStorage_GetUser_Call {
+	return &MockUserStorage_GetUser_Call{Call: _e.mock.On("GetUser", ctx, userID)}
+}
+
+func (_c *MockUserStorage_GetUser_Call) Run(run func(ctx context.Context, userID string)) *MockUserStorage_GetUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUserStorage_GetUser_Call) Return(user *domain.User, err error) *MockUserStorage_GetUser_Call {
+	_c.Call.Return(user, err)
+	return _c
+}
+
+func (_c *MockUserStorage_GetUser_Call) RunAndReturn(run func(ctx context.Context, userID string) (*domain.User, error)) *MockUserStorage_GetUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SearchUsers provides a mock function for the type MockUserStorage
+func (_mock *MockUserStorage) SearchUsers(ctx context.Context, search domain.UserSearch) ([]*domain.User, int, error) {
+	ret := _mock.Called(ctx, search)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SearchUsers")
+	}
+
+	var r0 []*domain.User
+	var r1 int
+	var r2 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.UserSearch) ([]*domain.User, int, error)); ok {
+		return returnFunc(ctx, search)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.UserSearch) []*domain.User); ok {
+		r0 = returnFunc(ctx, search)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.User)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, domain.UserSearch) int); ok {
+		r1 = returnFunc(ctx, search)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+	if returnFunc, ok := ret.Get(2).(func(context.Context, domain.UserSearch) error); ok {
+		r2 = returnFunc(ctx, search)
+	} else {
+		r2 = ret.Error(2)
+	}
+	return r0, r1, r2
+}
+
+// MockUserStorage_SearchUsers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SearchUsers'
+type MockUserStorage_SearchUsers_Call struct {
+	*mock.Call
+}
+
+// SearchUsers is a helper method to define mock.On call
+//   - ctx context.Context
+//   - search domain.UserSearch
+func (_e *MockUserStorage_Expecter) SearchUsers(ctx interface{}, search interface{}) *MockUserStorage_SearchUsers_Call {
+	return &MockUserStorage_SearchUsers_Call{Call: _e.mock.On("SearchUsers", ctx, search)}
+}
+
+func (_c *MockUserStorage_SearchUsers_Call) Run(run func(ctx context.Context, search domain.UserSearch)) *MockUserStorage_SearchUsers_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 domain.UserSearch
+		if args[1] != nil {
+			arg1 = args[1].(domain.UserSearch)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUserStorage_SearchUsers_Call) Return(users []*domain.User, n int, err error) *MockUserStorage_SearchUsers_Call {
+	_c.Call.Return(users, n, err)
+	return _c
+}
+
+func (_c *MockUserStorage_SearchUsers_Call) RunAndReturn(run func(ctx context.Context, search domain.UserSearch) ([]*domain.User, int, error)) *MockUserStorage_SearchUsers_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // SetIsActive provides a mock function for the type MockUserStorage
 func (_mock *MockUserStorage) SetIsActive(ctx context.Context, userID string, isActive bool) (*domain.User, error) {
 	ret := _mock.Called(ctx, userID, isActive)
@@ -111,3 +253,305 @@ func (_c *MockUserStorage_SetIsActive_Call) RunAndReturn(run func(ctx context.Co
 	_c.Call.Return(run)
 	return _c
 }
+
+// SetLevel provides a mock function for the type MockUserStorage
+func (_mock *MockUserStorage) SetLevel(ctx context.Context, userID string, level string) (*domain.User, error) {
+	ret := _mock.Called(ctx, userID, level)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetLevel")
+	}
+
+	var r0 *domain.User
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) (*domain.User, error)); ok {
+		return returnFunc(ctx, userID, level)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) *domain.User); ok {
+		r0 = returnFunc(ctx, userID, level)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.User)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = returnFunc(ctx, userID, level)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockUserStorage_SetLevel_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetLevel'
+type MockUserStorage_SetLevel_Call struct {
+	*mock.Call
+}
+
+// SetLevel is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+//   - level string
+func (_e *MockUserStorage_Expecter) SetLevel(ctx interface{}, userID interface{}, level interface{}) *MockUserStorage_SetLevel_Call {
+	return &MockUserStorage_SetLevel_Call{Call: _e.mock.On("SetLevel", ctx, userID, level)}
+}
+
+func (_c *MockUserStorage_SetLevel_Call) Run(run func(ctx context.Context, userID string, level string)) *MockUserStorage_SetLevel_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUserStorage_SetLevel_Call) Return(user *domain.User, err error) *MockUserStorage_SetLevel_Call {
+	_c.Call.Return(user, err)
+	return _c
+}
+
+func (_c *MockUserStorage_SetLevel_Call) RunAndReturn(run func(ctx context.Context, userID string, level string) (*domain.User, error)) *MockUserStorage_SetLevel_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetMaxOpenReviews provides a mock function for the type MockUserStorage
+func (_mock *MockUserStorage) SetMaxOpenReviews(ctx context.Context, userID string, limit *int) (*domain.User, error) {
+	ret := _mock.Called(ctx, userID, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetMaxOpenReviews")
+	}
+
+	var r0 *domain.User
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *int) (*domain.User, error)); ok {
+		return returnFunc(ctx, userID, limit)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *int) *domain.User); ok {
+		r0 = returnFunc(ctx, userID, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.User)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, *int) error); ok {
+		r1 = returnFunc(ctx, userID, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockUserStorage_SetMaxOpenReviews_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetMaxOpenReviews'
+type MockUserStorage_SetMaxOpenReviews_Call struct {
+	*mock.Call
+}
+
+// SetMaxOpenReviews is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+//   - limit *int
+func (_e *MockUserStorage_Expecter) SetMaxOpenReviews(ctx interface{}, userID interface{}, limit interface{}) *MockUserStorage_SetMaxOpenReviews_Call {
+	return &MockUserStorage_SetMaxOpenReviews_Call{Call: _e.mock.On("SetMaxOpenReviews", ctx, userID, limit)}
+}
+
+func (_c *MockUserStorage_SetMaxOpenReviews_Call) Run(run func(ctx context.Context, userID string, limit *int)) *MockUserStorage_SetMaxOpenReviews_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 *int
+		if args[2] != nil {
+			arg2 = args[2].(*int)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUserStorage_SetMaxOpenReviews_Call) Return(user *domain.User, err error) *MockUserStorage_SetMaxOpenReviews_Call {
+	_c.Call.Return(user, err)
+	return _c
+}
+
+func (_c *MockUserStorage_SetMaxOpenReviews_Call) RunAndReturn(run func(ctx context.Context, userID string, limit *int) (*domain.User, error)) *MockUserStorage_SetMaxOpenReviews_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetUsername provides a mock function for the type MockUserStorage
+func (_mock *MockUserStorage) SetUsername(ctx context.Context, userID string, username string) (*domain.User, error) {
+	ret := _mock.Called(ctx, userID, username)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetUsername")
+	}
+
+	var r0 *domain.User
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) (*domain.User, error)); ok {
+		return returnFunc(ctx, userID, username)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) *domain.User); ok {
+		r0 = returnFunc(ctx, userID, username)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.User)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = returnFunc(ctx, userID, username)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockUserStorage_SetUsername_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetUsername'
+type MockUserStorage_SetUsername_Call struct {
+	*mock.Call
+}
+
+// SetUsername is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+//   - username string
+func (_e *MockUserStorage_Expecter) SetUsername(ctx interface{}, userID interface{}, username interface{}) *MockUserStorage_SetUsername_Call {
+	return &MockUserStorage_SetUsername_Call{Call: _e.mock.On("SetUsername", ctx, userID, username)}
+}
+
+func (_c *MockUserStorage_SetUsername_Call) Run(run func(ctx context.Context, userID string, username string)) *MockUserStorage_SetUsername_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUserStorage_SetUsername_Call) Return(user *domain.User, err error) *MockUserStorage_SetUsername_Call {
+	_c.Call.Return(user, err)
+	return _c
+}
+
+func (_c *MockUserStorage_SetUsername_Call) RunAndReturn(run func(ctx context.Context, userID string, username string) (*domain.User, error)) *MockUserStorage_SetUsername_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetWorkingHours provides a mock function for the type MockUserStorage
+func (_mock *MockUserStorage) SetWorkingHours(ctx context.Context, userID string, timezone string, hours *domain.WorkingHours) (*domain.User, error) {
+	ret := _mock.Called(ctx, userID, timezone, hours)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetWorkingHours")
+	}
+
+	var r0 *domain.User
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, *domain.WorkingHours) (*domain.User, error)); ok {
+		return returnFunc(ctx, userID, timezone, hours)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, *domain.WorkingHours) *domain.User); ok {
+		r0 = returnFunc(ctx, userID, timezone, hours)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.User)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string, *domain.WorkingHours) error); ok {
+		r1 = returnFunc(ctx, userID, timezone, hours)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockUserStorage_SetWorkingHours_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetWorkingHours'
+type MockUserStorage_SetWorkingHours_Call struct {
+	*mock.Call
+}
+
+// SetWorkingHours is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+//   - timezone string
+//   - hours *domain.WorkingHours
+func (_e *MockUserStorage_Expecter) SetWorkingHours(ctx interface{}, userID interface{}, timezone interface{}, hours interface{}) *MockUserStorage_SetWorkingHours_Call {
+	return &MockUserStorage_SetWorkingHours_Call{Call: _e.mock.On("SetWorkingHours", ctx, userID, timezone, hours)}
+}
+
+func (_c *MockUserStorage_SetWorkingHours_Call) Run(run func(ctx context.Context, userID string, timezone string, hours *domain.WorkingHours)) *MockUserStorage_SetWorkingHours_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 *domain.WorkingHours
+		if args[3] != nil {
+			arg3 = args[3].(*domain.WorkingHours)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUserStorage_SetWorkingHours_Call) Return(user *domain.User, err error) *MockUserStorage_SetWorkingHours_Call {
+	_c.Call.Return(user, err)
+	return _c
+}
+
+func (_c *MockUserStorage_SetWorkingHours_Call) RunAndReturn(run func(ctx context.Context, userID string, timezone string, hours *domain.WorkingHours) (*domain.User, error)) *MockUserStorage_SetWorkingHours_Call {
+	_c.Call.Return(run)
+	return _c
+}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"time"
 
 	"github.com/whitxowl/pr-reviewer-assignment-service.git/internal/domain"
 	serviceErr "github.com/whitxowl/pr-reviewer-assignment-service.git/internal/service/errors"
@@ -13,16 +14,28 @@ import (
 
 type UserStorage interface {
 	SetIsActive(ctx context.Context, userID string, isActive bool) (*domain.User, error)
+	SetUsername(ctx context.Context, userID string, username string) (*domain.User, error)
+	SetMaxOpenReviews(ctx context.Context, userID string, limit *int) (*domain.User, error)
+	SetWorkingHours(ctx context.Context, userID string, timezone string, hours *domain.WorkingHours) (*domain.User, error)
+	SetLevel(ctx context.Context, userID string, level string) (*domain.User, error)
+	GetUser(ctx context.Context, userID string) (*domain.User, error)
+	SearchUsers(ctx context.Context, search domain.UserSearch) ([]*domain.User, int, error)
 }
 
 type PRStorage interface {
 	GetPRsReviewedBy(ctx context.Context, userID string) ([]*domain.PullRequest, error)
 }
 
+// Waker is told when a reviewer may have become available for queued slots.
+type Waker interface {
+	Wake()
+}
+
 type Service struct {
 	log         *slog.Logger
 	userStorage UserStorage
 	prStorage   PRStorage
+	waker       Waker
 }
 
 func New(log *slog.Logger, userStorage UserStorage, prStorage PRStorage) *Service {
@@ -33,6 +46,17 @@ func New(log *slog.Logger, userStorage UserStorage, prStorage PRStorage) *Servic
 	}
 }
 
+// SetWaker sets the worker to wake when a user becomes available for review.
+func (s *Service) SetWaker(waker Waker) {
+	s.waker = waker
+}
+
+func (s *Service) wake() {
+	if s.waker != nil {
+		s.waker.Wake()
+	}
+}
+
 func (s *Service) SetIsActive(ctx context.Context, userID string, isActive bool) (*domain.User, error) {
 	const op = "storage.user.SetIsActive"
 
@@ -53,9 +77,198 @@ func (s *Service) SetIsActive(ctx context.Context, userID string, isActive bool)
 
 	log.InfoContext(ctx, "user set is_active", "user", user)
 
+	if isActive {
+		s.wake()
+	}
+
 	return user, err
 }
 
+func (s *Service) SetUsername(ctx context.Context, userID string, username string) (*domain.User, error) {
+	const op = "service.user.SetUsername"
+
+	log := s.log.With(
+		slog.String("op", op),
+		slog.String("user_id", userID),
+	)
+
+	user, err := s.userStorage.SetUsername(ctx, userID, username)
+	if errors.Is(err, storageErr.ErrUserNotFound) {
+		log.DebugContext(ctx, "user not found", "error", err)
+		return nil, serviceErr.ErrUserNotFound
+	}
+	if err != nil {
+		log.ErrorContext(ctx, "failed to set username", "error", err)
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.InfoContext(ctx, "user set username", "user", user)
+
+	return user, nil
+}
+
+// SetMaxOpenReviews sets how many open PRs the user may review at once.
+// A nil limit falls back to the default of the user's primary team.
+func (s *Service) SetMaxOpenReviews(ctx context.Context, userID string, limit *int) (*domain.User, error) {
+	const op = "service.user.SetMaxOpenReviews"
+
+	log := s.log.With(
+		slog.String("op", op),
+		slog.String("user_id", userID),
+	)
+
+	user, err := s.userStorage.SetMaxOpenReviews(ctx, userID, limit)
+	if errors.Is(err, storageErr.ErrUserNotFound) {
+		log.DebugContext(ctx, "user not found", "error", err)
+		return nil, serviceErr.ErrUserNotFound
+	}
+	if err != nil {
+		log.ErrorContext(ctx, "failed to set max_open_reviews", "error", err)
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.InfoContext(ctx, "user set max_open_reviews", "user", user)
+
+	s.wake()
+
+	return user, nil
+}
+
+// SetWorkingHours sets the user's time zone and working hours, read in that
+// time zone. Hours without days cover domain.DefaultWorkDays; nil hours make
+// the user available at any time.
+func (s *Service) SetWorkingHours(
+	ctx context.Context,
+	userID string,
+	timezone string,
+	hours *domain.WorkingHours,
+) (*domain.User, error) {
+	const op = "service.user.SetWorkingHours"
+
+	log := s.log.With(
+		slog.String("op", op),
+		slog.String("user_id", userID),
+		slog.String("timezone", timezone),
+	)
+
+	if _, err := time.LoadLocation(timezone); err != nil || timezone == "Local" {
+		return nil, fmt.Errorf("%w: %q", serviceErr.ErrInvalidTimezone, timezone)
+	}
+
+	if hours != nil {
+		if err := validateWorkingHours(hours); err != nil {
+			return nil, err
+		}
+		if len(hours.Days) == 0 {
+			hours = &domain.WorkingHours{Start: hours.Start, End: hours.End, Days: domain.DefaultWorkDays}
+		}
+	}
+
+	user, err := s.userStorage.SetWorkingHours(ctx, userID, timezone, hours)
+	if errors.Is(err, storageErr.ErrUserNotFound) {
+		log.DebugContext(ctx, "user not found", "error", err)
+		return nil, serviceErr.ErrUserNotFound
+	}
+	if err != nil {
+		log.ErrorContext(ctx, "failed to set working hours", "error", err)
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.InfoContext(ctx, "user set working hours", "user", user)
+
+	return user, nil
+}
+
+// SetLevel sets the user's seniority, one of the domain.Level* constants; an
+// empty level makes it unknown.
+func (s *Service) SetLevel(ctx context.Context, userID string, level string) (*domain.User, error) {
+	const op = "service.user.SetLevel"
+
+	log := s.log.With(
+		slog.String("op", op),
+		slog.String("user_id", userID),
+		slog.String("level", level),
+	)
+
+	if level != "" && !domain.ValidLevel(level) {
+		return nil, fmt.Errorf("%w: %q", serviceErr.ErrInvalidLevel, level)
+	}
+
+	user, err := s.userStorage.SetLevel(ctx, userID, level)
+	if errors.Is(err, storageErr.ErrUserNotFound) {
+		log.DebugContext(ctx, "user not found", "error", err)
+		return nil, serviceErr.ErrUserNotFound
+	}
+	if err != nil {
+		log.ErrorContext(ctx, "failed to set level", "error", err)
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.InfoContext(ctx, "user set level", "user", user)
+
+	return user, nil
+}
+
+func validateWorkingHours(hours *domain.WorkingHours) error {
+	const day = 24 * 60
+
+	if hours.Start < 0 || hours.Start >= day || hours.End < 0 || hours.End >= day {
+		return fmt.Errorf("%w: start and end must be within a day", serviceErr.ErrInvalidWorkingHours)
+	}
+	if hours.Start == hours.End {
+		return fmt.Errorf("%w: start and end must differ", serviceErr.ErrInvalidWorkingHours)
+	}
+	for _, weekday := range hours.Days {
+		if weekday < time.Sunday || weekday > time.Saturday {
+			return fmt.Errorf("%w: unknown weekday %d", serviceErr.ErrInvalidWorkingHours, weekday)
+		}
+	}
+
+	return nil
+}
+
+func (s *Service) GetUser(ctx context.Context, userID string) (*domain.User, error) {
+	const op = "service.user.GetUser"
+
+	log := s.log.With(
+		slog.String("op", op),
+		slog.String("user_id", userID),
+	)
+
+	user, err := s.userStorage.GetUser(ctx, userID)
+	if errors.Is(err, storageErr.ErrUserNotFound) {
+		log.DebugContext(ctx, "user not found", "error", err)
+		return nil, serviceErr.ErrUserNotFound
+	}
+	if err != nil {
+		log.ErrorContext(ctx, "failed to get user", "error", err)
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return user, nil
+}
+
+// SearchUsers returns one page of the users matching search and the number of all matching users.
+func (s *Service) SearchUsers(ctx context.Context, search domain.UserSearch) ([]*domain.User, int, error) {
+	const op = "service.user.SearchUsers"
+
+	log := s.log.With(
+		slog.String("op", op),
+		slog.String("prefix", search.Prefix),
+		slog.String("team_name", search.TeamName),
+	)
+
+	users, total, err := s.userStorage.SearchUsers(ctx, search)
+	if err != nil {
+		log.ErrorContext(ctx, "failed to search users", "error", err)
+		return nil, 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.DebugContext(ctx, "searched users", "found", len(users), "total", total)
+
+	return users, total, nil
+}
+
 func (s *Service) GetPRsReviewedBy(ctx context.Context, userID string) ([]*domain.PullRequest, error) {
 	const op = "storage.user.GetPRsReviewedBy"
 
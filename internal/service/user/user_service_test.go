@@ -132,6 +132,43 @@ func TestService_SetIsActive(t *testing.T) {
 	}
 }
 
+func TestService_SetIsActive_Wake(t *testing.T) {
+	ctx := context.Background()
+	log := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
+
+	tests := []struct {
+		name         string
+		isActive     bool
+		expectedWake bool
+	}{
+		{name: "activated user wakes the backfill", isActive: true, expectedWake: true},
+		{name: "deactivated user does not", isActive: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			userStorage := mocks.NewMockUserStorage(t)
+			prStorage := mocks.NewMockPRStorage(t)
+			waker := mocks.NewMockWaker(t)
+
+			userStorage.EXPECT().
+				SetIsActive(ctx, "u1", tt.isActive).
+				Return(&domain.User{UserID: "u1", IsActive: tt.isActive}, nil).
+				Once()
+			if tt.expectedWake {
+				waker.EXPECT().Wake().Once()
+			}
+
+			service := New(log, userStorage, prStorage)
+			service.SetWaker(waker)
+
+			_, err := service.SetIsActive(ctx, "u1", tt.isActive)
+
+			assert.NoError(t, err)
+		})
+	}
+}
+
 func TestService_GetPRsReviewedBy(t *testing.T) {
 	ctx := context.Background()
 	log := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
@@ -311,3 +348,485 @@ func TestService_GetPRsReviewedBy(t *testing.T) {
 		})
 	}
 }
+
+func TestService_SetUsername(t *testing.T) {
+	ctx := context.Background()
+	log := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
+
+	tests := []struct {
+		name          string
+		userID        string
+		username      string
+		setupMocks    func(*mocks.MockUserStorage)
+		expectedUser  *domain.User
+		expectedError error
+	}{
+		{
+			name:     "success",
+			userID:   "u1",
+			username: "johnny",
+			setupMocks: func(userStorage *mocks.MockUserStorage) {
+				userStorage.EXPECT().
+					SetUsername(ctx, "u1", "johnny").
+					Return(&domain.User{UserID: "u1", Username: "johnny", TeamName: "backend", IsActive: true}, nil).
+					Once()
+			},
+			expectedUser: &domain.User{UserID: "u1", Username: "johnny", TeamName: "backend", IsActive: true},
+		},
+		{
+			name:     "error - user not found",
+			userID:   "u404",
+			username: "nobody",
+			setupMocks: func(userStorage *mocks.MockUserStorage) {
+				userStorage.EXPECT().
+					SetUsername(ctx, "u404", "nobody").
+					Return(nil, storageErr.ErrUserNotFound).
+					Once()
+			},
+			expectedError: serviceErr.ErrUserNotFound,
+		},
+		{
+			name:     "error - storage error",
+			userID:   "u1",
+			username: "johnny",
+			setupMocks: func(userStorage *mocks.MockUserStorage) {
+				userStorage.EXPECT().
+					SetUsername(ctx, "u1", "johnny").
+					Return(nil, errors.New("database connection error")).
+					Once()
+			},
+			expectedError: errors.New("service.user.SetUsername: database connection error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			userStorage := mocks.NewMockUserStorage(t)
+			prStorage := mocks.NewMockPRStorage(t)
+			tt.setupMocks(userStorage)
+
+			service := New(log, userStorage, prStorage)
+
+			result, err := service.SetUsername(ctx, tt.userID, tt.username)
+
+			if tt.expectedError != nil {
+				assert.Error(t, err)
+				assert.Nil(t, result)
+				if !errors.Is(err, tt.expectedError) {
+					assert.Contains(t, err.Error(), tt.expectedError.Error())
+				}
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedUser, result)
+			}
+		})
+	}
+}
+
+func TestService_SetMaxOpenReviews(t *testing.T) {
+	ctx := context.Background()
+	log := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
+
+	limit := 3
+
+	tests := []struct {
+		name          string
+		userID        string
+		limit         *int
+		setupMocks    func(*mocks.MockUserStorage)
+		expectedUser  *domain.User
+		expectedError error
+	}{
+		{
+			name:   "success",
+			userID: "u1",
+			limit:  &limit,
+			setupMocks: func(userStorage *mocks.MockUserStorage) {
+				userStorage.EXPECT().
+					SetMaxOpenReviews(ctx, "u1", &limit).
+					Return(&domain.User{UserID: "u1", TeamName: "backend", IsActive: true, MaxOpenReviews: &limit}, nil).
+					Once()
+			},
+			expectedUser: &domain.User{UserID: "u1", TeamName: "backend", IsActive: true, MaxOpenReviews: &limit},
+		},
+		{
+			name:   "success - limit cleared",
+			userID: "u1",
+			setupMocks: func(userStorage *mocks.MockUserStorage) {
+				userStorage.EXPECT().
+					SetMaxOpenReviews(ctx, "u1", (*int)(nil)).
+					Return(&domain.User{UserID: "u1", TeamName: "backend", IsActive: true}, nil).
+					Once()
+			},
+			expectedUser: &domain.User{UserID: "u1", TeamName: "backend", IsActive: true},
+		},
+		{
+			name:   "error - user not found",
+			userID: "u404",
+			limit:  &limit,
+			setupMocks: func(userStorage *mocks.MockUserStorage) {
+				userStorage.EXPECT().
+					SetMaxOpenReviews(ctx, "u404", &limit).
+					Return(nil, storageErr.ErrUserNotFound).
+					Once()
+			},
+			expectedError: serviceErr.ErrUserNotFound,
+		},
+		{
+			name:   "error - storage error",
+			userID: "u1",
+			limit:  &limit,
+			setupMocks: func(userStorage *mocks.MockUserStorage) {
+				userStorage.EXPECT().
+					SetMaxOpenReviews(ctx, "u1", &limit).
+					Return(nil, errors.New("database connection error")).
+					Once()
+			},
+			expectedError: errors.New("service.user.SetMaxOpenReviews: database connection error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			userStorage := mocks.NewMockUserStorage(t)
+			prStorage := mocks.NewMockPRStorage(t)
+			tt.setupMocks(userStorage)
+
+			service := New(log, userStorage, prStorage)
+
+			result, err := service.SetMaxOpenReviews(ctx, tt.userID, tt.limit)
+
+			if tt.expectedError != nil {
+				assert.Error(t, err)
+				assert.Nil(t, result)
+				if !errors.Is(err, tt.expectedError) {
+					assert.Contains(t, err.Error(), tt.expectedError.Error())
+				}
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedUser, result)
+			}
+		})
+	}
+}
+
+func TestService_SetWorkingHours(t *testing.T) {
+	ctx := context.Background()
+	log := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
+
+	weekend := []time.Weekday{time.Saturday, time.Sunday}
+	saved := &domain.User{
+		UserID:       "u1",
+		TeamName:     "backend",
+		IsActive:     true,
+		Timezone:     "Europe/Berlin",
+		WorkingHours: &domain.WorkingHours{Start: 9 * 60, End: 17 * 60, Days: domain.DefaultWorkDays},
+	}
+
+	tests := []struct {
+		name          string
+		userID        string
+		timezone      string
+		hours         *domain.WorkingHours
+		setupMocks    func(*mocks.MockUserStorage)
+		expectedUser  *domain.User
+		expectedError error
+	}{
+		{
+			name:     "success - hours without days cover weekdays",
+			userID:   "u1",
+			timezone: "Europe/Berlin",
+			hours:    &domain.WorkingHours{Start: 9 * 60, End: 17 * 60},
+			setupMocks: func(userStorage *mocks.MockUserStorage) {
+				userStorage.EXPECT().
+					SetWorkingHours(ctx, "u1", "Europe/Berlin", saved.WorkingHours).
+					Return(saved, nil).
+					Once()
+			},
+			expectedUser: saved,
+		},
+		{
+			name:   "success - night shift",
+			userID: "u1",
+			hours:  &domain.WorkingHours{Start: 22 * 60, End: 6 * 60, Days: weekend},
+			setupMocks: func(userStorage *mocks.MockUserStorage) {
+				userStorage.EXPECT().
+					SetWorkingHours(ctx, "u1", "", &domain.WorkingHours{Start: 22 * 60, End: 6 * 60, Days: weekend}).
+					Return(&domain.User{UserID: "u1"}, nil).
+					Once()
+			},
+			expectedUser: &domain.User{UserID: "u1"},
+		},
+		{
+			name:   "success - schedule cleared",
+			userID: "u1",
+			setupMocks: func(userStorage *mocks.MockUserStorage) {
+				userStorage.EXPECT().
+					SetWorkingHours(ctx, "u1", "", (*domain.WorkingHours)(nil)).
+					Return(&domain.User{UserID: "u1"}, nil).
+					Once()
+			},
+			expectedUser: &domain.User{UserID: "u1"},
+		},
+		{
+			name:          "error - unknown time zone",
+			userID:        "u1",
+			timezone:      "Mars/Olympus",
+			setupMocks:    func(_ *mocks.MockUserStorage) {},
+			expectedError: serviceErr.ErrInvalidTimezone,
+		},
+		{
+			name:          "error - host time zone",
+			userID:        "u1",
+			timezone:      "Local",
+			setupMocks:    func(_ *mocks.MockUserStorage) {},
+			expectedError: serviceErr.ErrInvalidTimezone,
+		},
+		{
+			name:          "error - empty shift",
+			userID:        "u1",
+			hours:         &domain.WorkingHours{Start: 9 * 60, End: 9 * 60},
+			setupMocks:    func(_ *mocks.MockUserStorage) {},
+			expectedError: serviceErr.ErrInvalidWorkingHours,
+		},
+		{
+			name:          "error - end past midnight",
+			userID:        "u1",
+			hours:         &domain.WorkingHours{Start: 9 * 60, End: 24 * 60},
+			setupMocks:    func(_ *mocks.MockUserStorage) {},
+			expectedError: serviceErr.ErrInvalidWorkingHours,
+		},
+		{
+			name:          "error - unknown weekday",
+			userID:        "u1",
+			hours:         &domain.WorkingHours{Start: 9 * 60, End: 17 * 60, Days: []time.Weekday{7}},
+			setupMocks:    func(_ *mocks.MockUserStorage) {},
+			expectedError: serviceErr.ErrInvalidWorkingHours,
+		},
+		{
+			name:   "error - user not found",
+			userID: "u404",
+			setupMocks: func(userStorage *mocks.MockUserStorage) {
+				userStorage.EXPECT().
+					SetWorkingHours(ctx, "u404", "", (*domain.WorkingHours)(nil)).
+					Return(nil, storageErr.ErrUserNotFound).
+					Once()
+			},
+			expectedError: serviceErr.ErrUserNotFound,
+		},
+		{
+			name:   "error - storage error",
+			userID: "u1",
+			setupMocks: func(userStorage *mocks.MockUserStorage) {
+				userStorage.EXPECT().
+					SetWorkingHours(ctx, "u1", "", (*domain.WorkingHours)(nil)).
+					Return(nil, errors.New("database connection error")).
+					Once()
+			},
+			expectedError: errors.New("service.user.SetWorkingHours: database connection error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			userStorage := mocks.NewMockUserStorage(t)
+			prStorage := mocks.NewMockPRStorage(t)
+			tt.setupMocks(userStorage)
+
+			service := New(log, userStorage, prStorage)
+
+			result, err := service.SetWorkingHours(ctx, tt.userID, tt.timezone, tt.hours)
+
+			if tt.expectedError != nil {
+				assert.Error(t, err)
+				assert.Nil(t, result)
+				if !errors.Is(err, tt.expectedError) {
+					assert.Contains(t, err.Error(), tt.expectedError.Error())
+				}
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedUser, result)
+			}
+		})
+	}
+}
+func TestService_SetLevel(t *testing.T) {
+	ctx := context.Background()
+	log := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
+
+	tests := []struct {
+		name          string
+		userID        string
+		level         string
+		setupMocks    func(*mocks.MockUserStorage)
+		expectedUser  *domain.User
+		expectedError error
+	}{
+		{
+			name:   "success",
+			userID: "u1",
+			level:  domain.LevelSenior,
+			setupMocks: func(userStorage *mocks.MockUserStorage) {
+				userStorage.EXPECT().
+					SetLevel(ctx, "u1", domain.LevelSenior).
+					Return(&domain.User{UserID: "u1", TeamName: "backend", IsActive: true, Level: domain.LevelSenior}, nil).
+					Once()
+			},
+			expectedUser: &domain.User{UserID: "u1", TeamName: "backend", IsActive: true, Level: domain.LevelSenior},
+		},
+		{
+			name:   "success - level cleared",
+			userID: "u1",
+			setupMocks: func(userStorage *mocks.MockUserStorage) {
+				userStorage.EXPECT().
+					SetLevel(ctx, "u1", "").
+					Return(&domain.User{UserID: "u1", TeamName: "backend", IsActive: true}, nil).
+					Once()
+			},
+			expectedUser: &domain.User{UserID: "u1", TeamName: "backend", IsActive: true},
+		},
+		{
+			name:          "error - unknown level",
+			userID:        "u1",
+			level:         "principal",
+			setupMocks:    func(*mocks.MockUserStorage) {},
+			expectedError: serviceErr.ErrInvalidLevel,
+		},
+		{
+			name:   "error - user not found",
+			userID: "u404",
+			level:  domain.LevelJunior,
+			setupMocks: func(userStorage *mocks.MockUserStorage) {
+				userStorage.EXPECT().
+					SetLevel(ctx, "u404", domain.LevelJunior).
+					Return(nil, storageErr.ErrUserNotFound).
+					Once()
+			},
+			expectedError: serviceErr.ErrUserNotFound,
+		},
+		{
+			name:   "error - storage error",
+			userID: "u1",
+			level:  domain.LevelJunior,
+			setupMocks: func(userStorage *mocks.MockUserStorage) {
+				userStorage.EXPECT().
+					SetLevel(ctx, "u1", domain.LevelJunior).
+					Return(nil, errors.New("database connection error")).
+					Once()
+			},
+			expectedError: errors.New("service.user.SetLevel: database connection error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			userStorage := mocks.NewMockUserStorage(t)
+			prStorage := mocks.NewMockPRStorage(t)
+			tt.setupMocks(userStorage)
+
+			service := New(log, userStorage, prStorage)
+
+			result, err := service.SetLevel(ctx, tt.userID, tt.level)
+
+			if tt.expectedError != nil {
+				assert.Error(t, err)
+				assert.Nil(t, result)
+				if !errors.Is(err, tt.expectedError) {
+					assert.Contains(t, err.Error(), tt.expectedError.Error())
+				}
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedUser, result)
+			}
+		})
+	}
+}
+
+func TestService_GetUser(t *testing.T) {
+	ctx := context.Background()
+	log := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
+
+	tests := []struct {
+		name          string
+		userID        string
+		setupMocks    func(*mocks.MockUserStorage)
+		expectedUser  *domain.User
+		expectedError error
+	}{
+		{
+			name:   "success",
+			userID: "u1",
+			setupMocks: func(userStorage *mocks.MockUserStorage) {
+				userStorage.EXPECT().
+					GetUser(ctx, "u1").
+					Return(&domain.User{UserID: "u1", Username: "john", TeamName: "backend", TeamNames: []string{"backend", "infra"}, IsActive: true}, nil).
+					Once()
+			},
+			expectedUser: &domain.User{UserID: "u1", Username: "john", TeamName: "backend", TeamNames: []string{"backend", "infra"}, IsActive: true},
+		},
+		{
+			name:   "error - user not found",
+			userID: "u404",
+			setupMocks: func(userStorage *mocks.MockUserStorage) {
+				userStorage.EXPECT().
+					GetUser(ctx, "u404").
+					Return(nil, storageErr.ErrUserNotFound).
+					Once()
+			},
+			expectedError: serviceErr.ErrUserNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			userStorage := mocks.NewMockUserStorage(t)
+			prStorage := mocks.NewMockPRStorage(t)
+			tt.setupMocks(userStorage)
+
+			service := New(log, userStorage, prStorage)
+
+			result, err := service.GetUser(ctx, tt.userID)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				assert.Nil(t, result)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedUser, result)
+			}
+		})
+	}
+}
+
+func TestService_SearchUsers(t *testing.T) {
+	ctx := context.Background()
+	log := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
+
+	search := domain.UserSearch{Prefix: "jo", TeamName: "backend", Limit: 20}
+
+	t.Run("success", func(t *testing.T) {
+		userStorage := mocks.NewMockUserStorage(t)
+		users := []*domain.User{{UserID: "u1", Username: "john", TeamName: "backend", IsActive: true}}
+		userStorage.EXPECT().SearchUsers(ctx, search).Return(users, 21, nil).Once()
+
+		service := New(log, userStorage, mocks.NewMockPRStorage(t))
+
+		result, total, err := service.SearchUsers(ctx, search)
+
+		assert.NoError(t, err)
+		assert.Equal(t, users, result)
+		assert.Equal(t, 21, total)
+	})
+
+	t.Run("error - storage error", func(t *testing.T) {
+		userStorage := mocks.NewMockUserStorage(t)
+		userStorage.EXPECT().SearchUsers(ctx, search).Return(nil, 0, errors.New("database connection error")).Once()
+
+		service := New(log, userStorage, mocks.NewMockPRStorage(t))
+
+		result, _, err := service.SearchUsers(ctx, search)
+
+		assert.ErrorContains(t, err, "service.user.SearchUsers: database connection error")
+		assert.Nil(t, result)
+	})
+}
@@ -0,0 +1,62 @@
+package validation
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/whitxowl/pr-reviewer-assignment-service.git/internal/domain"
+	serviceErr "github.com/whitxowl/pr-reviewer-assignment-service.git/internal/service/errors"
+)
+
+// IDs checks user and PR IDs against the formats configured for the deployment,
+// e.g. GitHub logins and "org/repo#123" references.
+type IDs struct {
+	user *regexp.Regexp
+	pr   *regexp.Regexp
+}
+
+func NewIDs(userPattern string, prPattern string) (*IDs, error) {
+	user, err := regexp.Compile(userPattern)
+	if err != nil {
+		return nil, fmt.Errorf("user_id pattern: %w", err)
+	}
+
+	pr, err := regexp.Compile(prPattern)
+	if err != nil {
+		return nil, fmt.Errorf("pull_request_id pattern: %w", err)
+	}
+
+	return &IDs{
+		user: user,
+		pr:   pr,
+	}, nil
+}
+
+// UserID reports serviceErr.ErrInvalidUserID unless id matches the user_id format.
+func (v *IDs) UserID(id string) error {
+	if id == "" || !v.user.MatchString(id) {
+		return fmt.Errorf("%w: %q must match %s", serviceErr.ErrInvalidUserID, id, v.user)
+	}
+
+	return nil
+}
+
+// Users checks every user's ID and reports the first one that does not match.
+func (v *IDs) Users(users []*domain.User) error {
+	for _, user := range users {
+		if err := v.UserID(user.UserID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// PRID reports serviceErr.ErrInvalidPRID unless id matches the pull_request_id format.
+func (v *IDs) PRID(id string) error {
+	if id == "" || !v.pr.MatchString(id) {
+		return fmt.Errorf("%w: %q must match %s", serviceErr.ErrInvalidPRID, id, v.pr)
+	}
+
+	return nil
+}
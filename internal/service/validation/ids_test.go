@@ -0,0 +1,54 @@
+package validation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/whitxowl/pr-reviewer-assignment-service.git/internal/domain"
+	serviceErr "github.com/whitxowl/pr-reviewer-assignment-service.git/internal/service/errors"
+)
+
+func TestIDs(t *testing.T) {
+	ids, err := NewIDs(`^[A-Za-z0-9-]+$`, `^[\w.-]+/[\w.-]+#[0-9]+$`)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name          string
+		check         func() error
+		expectedError error
+	}{
+		{name: "github login", check: func() error { return ids.UserID("octo-cat") }},
+		{name: "bad user_id", check: func() error { return ids.UserID("octo cat") }, expectedError: serviceErr.ErrInvalidUserID},
+		{name: "empty user_id", check: func() error { return ids.UserID("") }, expectedError: serviceErr.ErrInvalidUserID},
+		{name: "repo reference", check: func() error { return ids.PRID("acme/api#123") }},
+		{name: "bad pull_request_id", check: func() error { return ids.PRID("pr-1") }, expectedError: serviceErr.ErrInvalidPRID},
+		{
+			name: "first bad user",
+			check: func() error {
+				return ids.Users([]*domain.User{{UserID: "alice"}, {UserID: "bob?"}, {UserID: ""}})
+			},
+			expectedError: serviceErr.ErrInvalidUserID,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.check()
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestNewIDs_InvalidPattern(t *testing.T) {
+	_, err := NewIDs(`^u[0-9+$`, `.*`)
+	assert.ErrorContains(t, err, "user_id pattern")
+
+	_, err = NewIDs(`.*`, `(`)
+	assert.ErrorContains(t, err, "pull_request_id pattern")
+}
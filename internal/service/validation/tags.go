@@ -0,0 +1,51 @@
+package validation
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/whitxowl/pr-reviewer-assignment-service.git/internal/domain"
+	serviceErr "github.com/whitxowl/pr-reviewer-assignment-service.git/internal/service/errors"
+)
+
+const maxTagLength = 50
+
+var tagPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9+#._-]*$`)
+
+// Tags returns the skill tags lowercased, sorted and without duplicates, or
+// serviceErr.ErrInvalidTag for the first malformed one. Nil stays nil.
+func Tags(tags []string) ([]string, error) {
+	if tags == nil {
+		return nil, nil
+	}
+
+	normalized := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if len(tag) > maxTagLength || !tagPattern.MatchString(tag) {
+			return nil, fmt.Errorf("%w: %q must match %s and be at most %d characters",
+				serviceErr.ErrInvalidTag, tag, tagPattern, maxTagLength)
+		}
+		normalized = append(normalized, tag)
+	}
+
+	slices.Sort(normalized)
+
+	return slices.Compact(normalized), nil
+}
+
+// UserTags normalizes the tags of every user as Tags does and reports the
+// first malformed one.
+func UserTags(users []*domain.User) error {
+	for _, user := range users {
+		tags, err := Tags(user.Tags)
+		if err != nil {
+			return err
+		}
+		user.Tags = tags
+	}
+
+	return nil
+}
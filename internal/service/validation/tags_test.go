@@ -0,0 +1,37 @@
+package validation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	serviceErr "github.com/whitxowl/pr-reviewer-assignment-service.git/internal/service/errors"
+)
+
+func TestTags(t *testing.T) {
+	tests := []struct {
+		name          string
+		tags          []string
+		expectedTags  []string
+		expectedError error
+	}{
+		{name: "nil", tags: nil, expectedTags: nil},
+		{name: "empty", tags: []string{}, expectedTags: []string{}},
+		{name: "normalized", tags: []string{"Postgres", " go ", "c++", "go"}, expectedTags: []string{"c++", "go", "postgres"}},
+		{name: "blank", tags: []string{"go", " "}, expectedError: serviceErr.ErrInvalidTag},
+		{name: "space inside", tags: []string{"machine learning"}, expectedError: serviceErr.ErrInvalidTag},
+		{name: "too long", tags: []string{"a123456789a123456789a123456789a123456789a123456789a"}, expectedError: serviceErr.ErrInvalidTag},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tags, err := Tags(tt.tags)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedTags, tags)
+			}
+		})
+	}
+}
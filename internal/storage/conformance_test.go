@@ -0,0 +1,108 @@
+package storage_test
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/stretchr/testify/require"
+	"github.com/whitxowl/pr-reviewer-assignment-service.git/internal/storage/memory"
+	prStorage "github.com/whitxowl/pr-reviewer-assignment-service.git/internal/storage/pr"
+	repositoryStorage "github.com/whitxowl/pr-reviewer-assignment-service.git/internal/storage/repository"
+	sqliteStorage "github.com/whitxowl/pr-reviewer-assignment-service.git/internal/storage/sqlite"
+	"github.com/whitxowl/pr-reviewer-assignment-service.git/internal/storage/storagetest"
+	teamStorage "github.com/whitxowl/pr-reviewer-assignment-service.git/internal/storage/team"
+	userStorage "github.com/whitxowl/pr-reviewer-assignment-service.git/internal/storage/user"
+	"github.com/whitxowl/pr-reviewer-assignment-service.git/migrations"
+	"github.com/whitxowl/pr-reviewer-assignment-service.git/pkg/migrator"
+	"github.com/whitxowl/pr-reviewer-assignment-service.git/pkg/postgres"
+	"github.com/whitxowl/pr-reviewer-assignment-service.git/pkg/sqlite"
+)
+
+// postgresDSNEnv points the suite at a disposable Postgres database.
+// The Postgres run is skipped when it is not set; `task test-postgres` runs it
+// against a Postgres container, and CI runs it on every push.
+const postgresDSNEnv = "TEST_POSTGRES_DSN"
+
+func TestConformance_Memory(t *testing.T) {
+	storagetest.Run(t, func(t *testing.T) storagetest.Storages {
+		db := memory.NewDB()
+
+		return storagetest.Storages{
+			Team: memory.NewTeamStorage(db),
+			User: memory.NewUserStorage(db),
+			PR:   memory.NewPRStorage(db),
+
+			Repository: memory.NewRepositoryStorage(db),
+		}
+	})
+}
+
+func TestConformance_Postgres(t *testing.T) {
+	dsn := os.Getenv(postgresDSNEnv)
+	if dsn == "" {
+		t.Skipf("%s is not set", postgresDSNEnv)
+	}
+
+	ctx := context.Background()
+
+	pool, err := postgres.NewPool(ctx, dsn)
+	require.NoError(t, err)
+	t.Cleanup(pool.Close)
+
+	m, err := migrator.NewPostgres(pool, migrations.FS, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	require.NoError(t, err)
+	t.Cleanup(func() { m.Close() })
+
+	_, err = m.Up(ctx)
+	require.NoError(t, err)
+
+	storagetest.Run(t, func(t *testing.T) storagetest.Storages {
+		truncate(t, pool)
+
+		return storagetest.Storages{
+			Team: teamStorage.New(pool),
+			User: userStorage.New(pool),
+			PR:   prStorage.New(pool),
+
+			Repository: repositoryStorage.New(pool),
+		}
+	})
+}
+
+func truncate(t *testing.T, pool *pgxpool.Pool) {
+	t.Helper()
+
+	const query = "TRUNCATE pull_request_reviewers, pull_requests, repositories, team_memberships, users, teams CASCADE"
+
+	_, err := pool.Exec(context.Background(), query)
+	require.NoError(t, err)
+}
+
+func TestConformance_SQLite(t *testing.T) {
+	ctx := context.Background()
+
+	storagetest.Run(t, func(t *testing.T) storagetest.Storages {
+		db, err := sqlite.Open(ctx, filepath.Join(t.TempDir(), "conformance.db"))
+		require.NoError(t, err)
+		t.Cleanup(func() { db.Close() })
+
+		m, err := migrator.NewSQLite(db, migrations.SQLiteFS, slog.New(slog.NewTextHandler(io.Discard, nil)))
+		require.NoError(t, err)
+
+		_, err = m.Up(ctx)
+		require.NoError(t, err)
+
+		return storagetest.Storages{
+			Team: sqliteStorage.NewTeamStorage(db),
+			User: sqliteStorage.NewUserStorage(db),
+			PR:   sqliteStorage.NewPRStorage(db),
+
+			Repository: sqliteStorage.NewRepositoryStorage(db),
+		}
+	})
+}
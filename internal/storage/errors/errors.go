@@ -3,12 +3,20 @@ package storageErr
 import "errors"
 
 var (
-	ErrTeamExists = errors.New("team already exists")
+	ErrTeamExists   = errors.New("team already exists")
+	ErrTeamNotFound = errors.New("team not found")
+	ErrTeamNotEmpty = errors.New("team has members")
 
 	ErrUserNotFound = errors.New("user not found")
 
+	ErrAbsenceNotFound = errors.New("absence not found")
+
 	ErrPRNotFound       = errors.New("pull request not found")
 	ErrPRExists         = errors.New("pull request already exists")
 	ErrPRMerged         = errors.New("pull request merged")
 	ErrReviewerNotFound = errors.New("reviewer not found")
+	ErrReviewerAssigned = errors.New("reviewer already assigned")
+
+	ErrRepositoryExists   = errors.New("repository already exists")
+	ErrRepositoryNotFound = errors.New("repository not found")
 )
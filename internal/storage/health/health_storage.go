@@ -0,0 +1,45 @@
+package health
+
+import (
+	"context"
+	"fmt"
+
+	pg "github.com/whitxowl/pr-reviewer-assignment-service.git/pkg/postgres"
+)
+
+type Storage struct {
+	Db pg.Pool
+}
+
+func New(db pg.Pool) *Storage {
+	return &Storage{
+		Db: db,
+	}
+}
+
+func (s *Storage) Ping(ctx context.Context) error {
+	const op = "storage.health.Ping"
+
+	if err := s.Db.Ping(ctx); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (s *Storage) MigrationVersion(ctx context.Context) (int64, error) {
+	const op = "storage.health.MigrationVersion"
+
+	const query = "SELECT COALESCE(MAX(version_id), 0) FROM goose_db_version WHERE is_applied"
+
+	var version int64
+	err := s.Db.QueryRow(ctx, query).Scan(&version)
+	if pg.IsUndefinedTableError(err) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return version, nil
+}
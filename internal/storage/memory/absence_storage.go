@@ -0,0 +1,139 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/whitxowl/pr-reviewer-assignment-service.git/internal/domain"
+	storageErr "github.com/whitxowl/pr-reviewer-assignment-service.git/internal/storage/errors"
+)
+
+func (s *UserStorage) SaveAbsences(_ context.Context, userID string, absences []domain.Absence) ([]domain.Absence, error) {
+	const op = "storage.memory.SaveAbsences"
+
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+
+	if _, ok := s.db.users[userID]; !ok {
+		return nil, fmt.Errorf("%s: %w", op, storageErr.ErrUserNotFound)
+	}
+
+	for _, absence := range absences {
+		if !absence.EndsAt.After(absence.StartsAt) {
+			return nil, fmt.Errorf("%s: ends_at %s: %w", op, absence.EndsAt, ErrCheckViolation)
+		}
+	}
+
+	result := make([]domain.Absence, 0, len(absences))
+	for _, absence := range absences {
+		saved := s.db.absenceByUID(userID, absence.UID)
+		if saved == nil {
+			s.db.nextAbsenceID++
+			saved = &domain.Absence{ID: s.db.nextAbsenceID, UserID: userID, UID: absence.UID}
+			s.db.absences[saved.ID] = saved
+		} else if !saved.StartsAt.Equal(absence.StartsAt) {
+			// A moved absence is handed over again when it starts.
+			saved.ReassignedAt = nil
+		}
+
+		saved.StartsAt = absence.StartsAt
+		saved.EndsAt = absence.EndsAt
+		saved.Reason = absence.Reason
+		saved.Reassign = absence.Reassign
+
+		result = append(result, copyAbsence(saved))
+	}
+
+	return result, nil
+}
+
+// absenceByUID returns the absence of the user imported with uid, nil if there
+// is none. Absences without a UID are never matched.
+func (db *DB) absenceByUID(userID string, uid string) *domain.Absence {
+	if uid == "" {
+		return nil
+	}
+	for _, absence := range db.absences {
+		if absence.UserID == userID && absence.UID == uid {
+			return absence
+		}
+	}
+	return nil
+}
+
+func (s *UserStorage) GetAbsences(_ context.Context, userID string) ([]domain.Absence, error) {
+	s.db.mu.RLock()
+	defer s.db.mu.RUnlock()
+
+	var result []domain.Absence
+	for _, absence := range s.db.absences {
+		if absence.UserID == userID {
+			result = append(result, copyAbsence(absence))
+		}
+	}
+
+	sortAbsences(result)
+
+	return result, nil
+}
+
+func (s *UserStorage) DeleteAbsence(_ context.Context, userID string, absenceID int64) error {
+	const op = "storage.memory.DeleteAbsence"
+
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+
+	absence, ok := s.db.absences[absenceID]
+	if !ok || absence.UserID != userID {
+		return fmt.Errorf("%s: %w", op, storageErr.ErrAbsenceNotFound)
+	}
+
+	delete(s.db.absences, absenceID)
+
+	return nil
+}
+
+func (s *UserStorage) GetStartedAbsences(_ context.Context) ([]domain.Absence, error) {
+	s.db.mu.RLock()
+	defer s.db.mu.RUnlock()
+
+	t := time.Now()
+
+	var result []domain.Absence
+	for _, absence := range s.db.absences {
+		if absence.Reassign && absence.ReassignedAt == nil && absence.ActiveAt(t) {
+			result = append(result, copyAbsence(absence))
+		}
+	}
+
+	sortAbsences(result)
+
+	return result, nil
+}
+
+func (s *UserStorage) MarkAbsenceReassigned(_ context.Context, absenceID int64) error {
+	const op = "storage.memory.MarkAbsenceReassigned"
+
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+
+	absence, ok := s.db.absences[absenceID]
+	if !ok {
+		return fmt.Errorf("%s: %w", op, storageErr.ErrAbsenceNotFound)
+	}
+
+	absence.ReassignedAt = now()
+
+	return nil
+}
+
+func sortAbsences(absences []domain.Absence) {
+	sort.Slice(absences, func(i, j int) bool {
+		if !absences[i].StartsAt.Equal(absences[j].StartsAt) {
+			return absences[i].StartsAt.Before(absences[j].StartsAt)
+		}
+		return absences[i].ID < absences[j].ID
+	})
+}
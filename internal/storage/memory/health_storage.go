@@ -0,0 +1,19 @@
+package memory
+
+import "context"
+
+// HealthStorage reports the in-memory backend as always reachable.
+// It has no migrations, so its schema version is always zero.
+type HealthStorage struct{}
+
+func NewHealthStorage() *HealthStorage {
+	return &HealthStorage{}
+}
+
+func (s *HealthStorage) Ping(_ context.Context) error {
+	return nil
+}
+
+func (s *HealthStorage) MigrationVersion(_ context.Context) (int64, error) {
+	return 0, nil
+}
@@ -0,0 +1,92 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/whitxowl/pr-reviewer-assignment-service.git/internal/domain"
+	storageErr "github.com/whitxowl/pr-reviewer-assignment-service.git/internal/storage/errors"
+)
+
+// ImportPRs checks every PR before writing any, so that either all of them are
+// created or none.
+func (s *PRStorage) ImportPRs(_ context.Context, prs []domain.PRImport) error {
+	const op = "storage.memory.ImportPRs"
+
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+
+	refs := make(map[domain.PRRef]bool, len(prs))
+	for _, imp := range prs {
+		pr := imp.PR
+		ref := pr.Ref()
+		if _, ok := s.db.prs[ref]; ok || refs[ref] {
+			return fmt.Errorf("%s: %w", op, storageErr.ErrPRExists)
+		}
+		refs[ref] = true
+
+		if pr.PullRequestID == "" {
+			return fmt.Errorf("%s: pull_request_id %q: %w", op, pr.PullRequestID, ErrCheckViolation)
+		}
+		if _, ok := s.db.users[pr.AuthorID]; !ok {
+			return fmt.Errorf("%s: %w", op, storageErr.ErrUserNotFound)
+		}
+		if _, ok := s.db.teams[pr.TeamName]; pr.TeamName != "" && !ok {
+			return fmt.Errorf("%s: team %q: %w", op, pr.TeamName, ErrForeignKeyViolation)
+		}
+
+		for i, reviewerID := range pr.AssignedReviewers {
+			if _, ok := s.db.users[reviewerID]; !ok {
+				return fmt.Errorf("%s: %w", op, storageErr.ErrUserNotFound)
+			}
+			if slices.Contains(pr.AssignedReviewers[:i], reviewerID) {
+				return fmt.Errorf("%s: reviewer %q: %w", op, reviewerID, ErrUniqueViolation)
+			}
+		}
+		for _, r := range pr.Rationales {
+			if !slices.Contains(pr.AssignedReviewers, r.ReviewerID) {
+				return fmt.Errorf("%s: reviewer %q: %w", op, r.ReviewerID, storageErr.ErrReviewerNotFound)
+			}
+		}
+		if imp.Queued < 0 {
+			return fmt.Errorf("%s: slots %d: %w", op, imp.Queued, ErrCheckViolation)
+		}
+	}
+
+	for _, imp := range prs {
+		pr := imp.PR
+		ref := pr.Ref()
+
+		s.db.prs[ref] = &domain.PullRequest{
+			Repository:        pr.Repository,
+			PullRequestID:     pr.PullRequestID,
+			PullRequestName:   pr.PullRequestName,
+			AuthorID:          pr.AuthorID,
+			TeamName:          pr.TeamName,
+			Status:            statusOpen,
+			AssignedReviewers: slices.Clone(pr.AssignedReviewers),
+			CreatedAt:         now(),
+		}
+		s.db.recordAssignments(&pr, pr.AssignedReviewers)
+
+		if len(pr.Rationales) > 0 {
+			s.db.rationales[ref] = make(map[string]domain.AssignmentRationale)
+		}
+		for _, r := range pr.Rationales {
+			s.db.rationales[ref][r.ReviewerID] = copyRationale(r)
+		}
+
+		s.db.setRequiredTags(ref, imp.RequiredTags)
+
+		if imp.Queued > 0 {
+			s.db.pending[ref] = &domain.PendingSlots{
+				PR:       domain.PullRequest{Repository: pr.Repository, PullRequestID: pr.PullRequestID},
+				Slots:    imp.Queued,
+				QueuedAt: now(),
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,208 @@
+package memory
+
+import (
+	"errors"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/whitxowl/pr-reviewer-assignment-service.git/internal/domain"
+)
+
+// The errors below mirror constraint violations reported by Postgres
+// for the CHECK and FOREIGN KEY rules of the schema.
+var (
+	ErrCheckViolation      = errors.New("check constraint violation")
+	ErrForeignKeyViolation = errors.New("foreign key violation")
+	ErrUniqueViolation     = errors.New("unique violation")
+)
+
+const (
+	statusOpen   = "OPEN"
+	statusMerged = "MERGED"
+)
+
+// DB is the shared in-memory state used by every storage of this package.
+// All storages built on the same DB see each other's writes, like tables of one database.
+type DB struct {
+	mu sync.RWMutex
+
+	teams map[string]*domain.Team
+	users map[string]*domain.User
+	prs   map[domain.PRRef]*domain.PullRequest
+	// rationales holds the assignment rationales of every PR by reviewer ID.
+	rationales map[domain.PRRef]map[string]domain.AssignmentRationale
+	// pending holds the reviewer slots queued for later assignment.
+	pending map[domain.PRRef]*domain.PendingSlots
+	// requiredTags holds the skill tags every PR requires of its reviewers.
+	requiredTags map[domain.PRRef][]string
+	// history holds every assignment of a reviewer to a PR, oldest first.
+	history []assignment
+
+	repositories map[string]*domain.Repository
+	// codeOwners holds the code owner rules by repository name.
+	codeOwners map[string][]domain.CodeOwnerRule
+	// memberships holds the teams of every user by user ID. users[id].TeamName is
+	// the primary team and is always one of them.
+	memberships map[string]map[string]bool
+	// absences holds the absences of every user by absence ID.
+	absences      map[int64]*domain.Absence
+	nextAbsenceID int64
+}
+
+func NewDB() *DB {
+	return &DB{
+		teams:        make(map[string]*domain.Team),
+		users:        make(map[string]*domain.User),
+		prs:          make(map[domain.PRRef]*domain.PullRequest),
+		rationales:   make(map[domain.PRRef]map[string]domain.AssignmentRationale),
+		pending:      make(map[domain.PRRef]*domain.PendingSlots),
+		requiredTags: make(map[domain.PRRef][]string),
+		repositories: make(map[string]*domain.Repository),
+		codeOwners:   make(map[string][]domain.CodeOwnerRule),
+		memberships:  make(map[string]map[string]bool),
+		absences:     make(map[int64]*domain.Absence),
+	}
+}
+
+// assignment is an entry of the assignment history.
+type assignment struct {
+	authorID   string
+	reviewerID string
+	assignedAt time.Time
+}
+
+// recordAssignments adds the assignments of reviewerIDs to the PR to the history.
+func (db *DB) recordAssignments(pr *domain.PullRequest, reviewerIDs []string) {
+	t := time.Now()
+	for _, reviewerID := range reviewerIDs {
+		db.history = append(db.history, assignment{authorID: pr.AuthorID, reviewerID: reviewerID, assignedAt: t})
+	}
+}
+
+func (db *DB) isMember(userID string, teamName string) bool {
+	return db.memberships[userID][teamName]
+}
+
+func (db *DB) addMembership(userID string, teamName string) {
+	if db.memberships[userID] == nil {
+		db.memberships[userID] = make(map[string]bool)
+	}
+	db.memberships[userID][teamName] = true
+	if user := db.users[userID]; user.TeamName == "" {
+		user.TeamName = teamName
+	}
+}
+
+// removeMembership removes the user from the team and, if it was the primary team,
+// makes the first remaining team of the user primary.
+func (db *DB) removeMembership(userID string, teamName string) {
+	delete(db.memberships[userID], teamName)
+	if user := db.users[userID]; user.TeamName == teamName {
+		user.TeamName = ""
+		if teamNames := db.teamNamesOf(userID); len(teamNames) > 0 {
+			user.TeamName = teamNames[0]
+		}
+	}
+}
+
+func (db *DB) teamNamesOf(userID string) []string {
+	teamNames := make([]string, 0, len(db.memberships[userID]))
+	for teamName := range db.memberships[userID] {
+		teamNames = append(teamNames, teamName)
+	}
+	sort.Strings(teamNames)
+	return teamNames
+}
+
+func copyUser(user *domain.User) *domain.User {
+	u := *user
+	u.TeamNames = slices.Clone(user.TeamNames)
+	u.MaxOpenReviews = copyLimit(user.MaxOpenReviews)
+	u.WorkingHours = copyHours(user.WorkingHours)
+	u.Tags = slices.Clone(user.Tags)
+	return &u
+}
+
+// copyHours copies the schedule with its days in the order a day mask yields them.
+func copyHours(hours *domain.WorkingHours) *domain.WorkingHours {
+	if hours == nil {
+		return nil
+	}
+	h := *hours
+	h.Days = domain.WorkDays(hours.DayMask())
+	return &h
+}
+
+// copyTeam copies the team row and its seniority rules without members, which
+// live in DB.users.
+func copyTeam(team *domain.Team) *domain.Team {
+	t := domain.Team{TeamName: team.TeamName}
+	if team.ArchivedAt != nil {
+		archivedAt := *team.ArchivedAt
+		t.ArchivedAt = &archivedAt
+	}
+	t.MaxOpenReviews = copyLimit(team.MaxOpenReviews)
+	t.SeniorityRules = slices.Clone(team.SeniorityRules)
+	return &t
+}
+
+// absent reports whether an absence of the user is in progress.
+func (db *DB) absent(userID string) bool {
+	t := time.Now()
+	for _, absence := range db.absences {
+		if absence.UserID == userID && absence.ActiveAt(t) {
+			return true
+		}
+	}
+	return false
+}
+
+func copyAbsence(absence *domain.Absence) domain.Absence {
+	a := *absence
+	if absence.ReassignedAt != nil {
+		reassignedAt := *absence.ReassignedAt
+		a.ReassignedAt = &reassignedAt
+	}
+	return a
+}
+
+func copyLimit(limit *int) *int {
+	if limit == nil {
+		return nil
+	}
+	l := *limit
+	return &l
+}
+
+func copyRules(rules []domain.CodeOwnerRule) []domain.CodeOwnerRule {
+	result := make([]domain.CodeOwnerRule, len(rules))
+	for i, rule := range rules {
+		result[i] = domain.CodeOwnerRule{
+			Pattern:   rule.Pattern,
+			UserIDs:   append([]string{}, rule.UserIDs...),
+			TeamNames: append([]string{}, rule.TeamNames...),
+		}
+	}
+	return result
+}
+
+func copyPR(pr *domain.PullRequest) *domain.PullRequest {
+	p := *pr
+	p.AssignedReviewers = append([]string(nil), pr.AssignedReviewers...)
+	if pr.CreatedAt != nil {
+		createdAt := *pr.CreatedAt
+		p.CreatedAt = &createdAt
+	}
+	if pr.MergedAt != nil {
+		mergedAt := *pr.MergedAt
+		p.MergedAt = &mergedAt
+	}
+	return &p
+}
+
+func now() *time.Time {
+	t := time.Now()
+	return &t
+}
@@ -0,0 +1,124 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+
+	"github.com/whitxowl/pr-reviewer-assignment-service.git/internal/domain"
+	storageErr "github.com/whitxowl/pr-reviewer-assignment-service.git/internal/storage/errors"
+)
+
+func (s *PRStorage) QueueReviewerSlots(_ context.Context, repository string, prID string, slots int) error {
+	const op = "storage.memory.QueueReviewerSlots"
+
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+
+	if slots <= 0 {
+		return fmt.Errorf("%s: slots %d: %w", op, slots, ErrCheckViolation)
+	}
+
+	ref := domain.PRRef{Repository: repository, PullRequestID: prID}
+	if _, ok := s.db.prs[ref]; !ok {
+		return fmt.Errorf("%s: %w", op, storageErr.ErrPRNotFound)
+	}
+
+	s.db.queueSlots(ref, slots)
+
+	return nil
+}
+
+// queueSlots adds slots to the queued reviewer slots of the PR.
+func (db *DB) queueSlots(ref domain.PRRef, slots int) {
+	if pending, ok := db.pending[ref]; ok {
+		pending.Slots += slots
+		return
+	}
+
+	db.pending[ref] = &domain.PendingSlots{
+		PR:       domain.PullRequest{Repository: ref.Repository, PullRequestID: ref.PullRequestID},
+		Slots:    slots,
+		QueuedAt: now(),
+	}
+}
+
+func (s *PRStorage) GetPendingSlots(_ context.Context) ([]domain.PendingSlots, error) {
+	s.db.mu.RLock()
+	defer s.db.mu.RUnlock()
+
+	var result []domain.PendingSlots
+	for ref, pending := range s.db.pending {
+		pr := s.db.prs[ref]
+		if pr.Status != statusOpen {
+			continue
+		}
+
+		p := copyPR(pr)
+		p.AssignedReviewers = nil
+		p.MergedAt = nil
+
+		queuedAt := *pending.QueuedAt
+		result = append(result, domain.PendingSlots{PR: *p, Slots: pending.Slots, QueuedAt: &queuedAt})
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if !result[i].QueuedAt.Equal(*result[j].QueuedAt) {
+			return result[i].QueuedAt.Before(*result[j].QueuedAt)
+		}
+		return result[i].PR.Ref().Less(result[j].PR.Ref())
+	})
+
+	return result, nil
+}
+
+func (s *PRStorage) FillReviewerSlots(_ context.Context, repository string, prID string, reviewerIDs []string) error {
+	const op = "storage.memory.FillReviewerSlots"
+
+	if len(reviewerIDs) == 0 {
+		return nil
+	}
+
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+
+	ref := domain.PRRef{Repository: repository, PullRequestID: prID}
+	pr, ok := s.db.prs[ref]
+	if !ok {
+		return fmt.Errorf("%s: %w", op, storageErr.ErrPRNotFound)
+	}
+	if pr.Status == statusMerged {
+		return fmt.Errorf("%s: %w", op, storageErr.ErrPRMerged)
+	}
+
+	for i, reviewerID := range reviewerIDs {
+		if _, ok := s.db.users[reviewerID]; !ok {
+			return fmt.Errorf("%s: reviewer %q: %w", op, reviewerID, ErrForeignKeyViolation)
+		}
+		if slices.Contains(pr.AssignedReviewers, reviewerID) || slices.Contains(reviewerIDs[:i], reviewerID) {
+			return fmt.Errorf("%s: reviewer %q: %w", op, reviewerID, storageErr.ErrReviewerAssigned)
+		}
+	}
+
+	pr.AssignedReviewers = append(pr.AssignedReviewers, reviewerIDs...)
+	s.db.recordAssignments(pr, reviewerIDs)
+
+	if pending, ok := s.db.pending[ref]; ok {
+		pending.Slots -= len(reviewerIDs)
+		if pending.Slots <= 0 {
+			delete(s.db.pending, ref)
+		}
+	}
+
+	return nil
+}
+
+func (s *PRStorage) DropReviewerSlots(_ context.Context, repository string, prID string) error {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+
+	delete(s.db.pending, domain.PRRef{Repository: repository, PullRequestID: prID})
+
+	return nil
+}
@@ -0,0 +1,292 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/whitxowl/pr-reviewer-assignment-service.git/internal/domain"
+	storageErr "github.com/whitxowl/pr-reviewer-assignment-service.git/internal/storage/errors"
+)
+
+type PRStorage struct {
+	db *DB
+}
+
+func NewPRStorage(db *DB) *PRStorage {
+	return &PRStorage{
+		db: db,
+	}
+}
+
+func (s *PRStorage) GetPRsReviewedBy(_ context.Context, userID string) ([]*domain.PullRequest, error) {
+	s.db.mu.RLock()
+	defer s.db.mu.RUnlock()
+
+	var prs []*domain.PullRequest
+	for _, pr := range s.db.prs {
+		if slices.Contains(pr.AssignedReviewers, userID) {
+			prs = append(prs, &domain.PullRequest{
+				Repository:      pr.Repository,
+				PullRequestID:   pr.PullRequestID,
+				PullRequestName: pr.PullRequestName,
+				AuthorID:        pr.AuthorID,
+				Status:          pr.Status,
+			})
+		}
+	}
+
+	sort.Slice(prs, func(i, j int) bool { return prs[i].Ref().Less(prs[j].Ref()) })
+
+	return prs, nil
+}
+
+func (s *PRStorage) CreatePR(
+	_ context.Context,
+	repository string,
+	prID string,
+	prName string,
+	authorID string,
+	teamName string,
+) error {
+	const op = "storage.memory.CreatePR"
+
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+
+	ref := domain.PRRef{Repository: repository, PullRequestID: prID}
+	if _, ok := s.db.prs[ref]; ok {
+		return fmt.Errorf("%s: %w", op, storageErr.ErrPRExists)
+	}
+	if prID == "" {
+		return fmt.Errorf("%s: pull_request_id %q: %w", op, prID, ErrCheckViolation)
+	}
+	if _, ok := s.db.users[authorID]; !ok {
+		return fmt.Errorf("%s: author %q: %w", op, authorID, ErrForeignKeyViolation)
+	}
+	if _, ok := s.db.teams[teamName]; teamName != "" && !ok {
+		return fmt.Errorf("%s: team %q: %w", op, teamName, ErrForeignKeyViolation)
+	}
+
+	s.db.prs[ref] = &domain.PullRequest{
+		Repository:      repository,
+		PullRequestID:   prID,
+		PullRequestName: prName,
+		AuthorID:        authorID,
+		TeamName:        teamName,
+		Status:          statusOpen,
+		CreatedAt:       now(),
+	}
+
+	return nil
+}
+
+func (s *PRStorage) AssignReviewers(_ context.Context, repository string, prID string, reviewersIDs []string) error {
+	const op = "storage.memory.AssignReviewers"
+
+	if len(reviewersIDs) == 0 {
+		return nil
+	}
+
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+
+	pr, ok := s.db.prs[domain.PRRef{Repository: repository, PullRequestID: prID}]
+	if !ok {
+		return fmt.Errorf("%s: pull request %q: %w", op, prID, ErrForeignKeyViolation)
+	}
+
+	for i, reviewerID := range reviewersIDs {
+		if _, ok := s.db.users[reviewerID]; !ok {
+			return fmt.Errorf("%s: reviewer %q: %w", op, reviewerID, ErrForeignKeyViolation)
+		}
+		if slices.Contains(pr.AssignedReviewers, reviewerID) || slices.Contains(reviewersIDs[:i], reviewerID) {
+			return fmt.Errorf("%s: reviewer %q: %w", op, reviewerID, ErrUniqueViolation)
+		}
+	}
+
+	pr.AssignedReviewers = append(pr.AssignedReviewers, reviewersIDs...)
+	s.db.recordAssignments(pr, reviewersIDs)
+
+	return nil
+}
+
+func (s *PRStorage) SetStatusMerged(_ context.Context, repository string, prID string) (*domain.PullRequest, error) {
+	const op = "storage.memory.SetStatusMerged"
+
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+
+	pr, ok := s.db.prs[domain.PRRef{Repository: repository, PullRequestID: prID}]
+	if !ok {
+		return nil, fmt.Errorf("%s: %w", op, storageErr.ErrPRNotFound)
+	}
+
+	pr.Status = statusMerged
+	if pr.MergedAt == nil {
+		pr.MergedAt = now()
+	}
+	delete(s.db.pending, domain.PRRef{Repository: repository, PullRequestID: prID})
+
+	result := copyPR(pr)
+	result.CreatedAt = nil
+
+	return result, nil
+}
+
+func (s *PRStorage) GetPR(_ context.Context, repository string, prID string) (*domain.PullRequest, error) {
+	const op = "storage.memory.GetPR"
+
+	s.db.mu.RLock()
+	defer s.db.mu.RUnlock()
+
+	pr, ok := s.db.prs[domain.PRRef{Repository: repository, PullRequestID: prID}]
+	if !ok {
+		return nil, fmt.Errorf("%s: %w", op, storageErr.ErrPRNotFound)
+	}
+
+	return copyPR(pr), nil
+}
+
+func (s *PRStorage) ReassignReviewer(
+	_ context.Context,
+	repository string,
+	prID string,
+	reassignment domain.Reassignment,
+	dryRun bool,
+) (*domain.PullRequest, error) {
+	const op = "storage.memory.ReassignReviewer"
+
+	oldReviewerID := reassignment.OldReviewerID
+	var newReviewerID string
+	if reassignment.Replacement != nil {
+		newReviewerID = reassignment.Replacement.ReviewerID
+	}
+
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+
+	pr, ok := s.db.prs[domain.PRRef{Repository: repository, PullRequestID: prID}]
+	if !ok {
+		return nil, fmt.Errorf("%s: %w", op, storageErr.ErrPRNotFound)
+	}
+	if pr.Status == statusMerged {
+		return nil, fmt.Errorf("%s: %w", op, storageErr.ErrPRMerged)
+	}
+
+	idx := slices.Index(pr.AssignedReviewers, oldReviewerID)
+	if idx < 0 {
+		return nil, fmt.Errorf("%s: %w", op, storageErr.ErrReviewerNotFound)
+	}
+
+	reviewers := slices.Delete(slices.Clone(pr.AssignedReviewers), idx, idx+1)
+
+	if newReviewerID != "" {
+		if _, ok := s.db.users[newReviewerID]; !ok {
+			return nil, fmt.Errorf("%s: reviewer %q: %w", op, newReviewerID, ErrForeignKeyViolation)
+		}
+		if slices.Contains(reviewers, newReviewerID) {
+			return nil, fmt.Errorf("%s: reviewer %q: %w", op, newReviewerID, storageErr.ErrReviewerAssigned)
+		}
+		reviewers = append(reviewers, newReviewerID)
+	}
+
+	result := copyPR(pr)
+	result.AssignedReviewers = append([]string(nil), reviewers...)
+	result.CreatedAt = nil
+	result.MergedAt = nil
+
+	if !dryRun {
+		ref := pr.Ref()
+		pr.AssignedReviewers = reviewers
+		delete(s.db.rationales[ref], oldReviewerID)
+		if newReviewerID != "" {
+			s.db.recordAssignments(pr, []string{newReviewerID})
+			if s.db.rationales[ref] == nil {
+				s.db.rationales[ref] = make(map[string]domain.AssignmentRationale)
+			}
+			s.db.rationales[ref][newReviewerID] = copyRationale(*reassignment.Replacement)
+		}
+		if reassignment.QueueSlot {
+			s.db.queueSlots(ref, 1)
+		}
+	}
+
+	return result, nil
+}
+
+func (s *PRStorage) GetOpenPRsReviewedByTeam(_ context.Context, teamName string) ([]domain.PRRef, error) {
+	s.db.mu.RLock()
+	defer s.db.mu.RUnlock()
+
+	var refs []domain.PRRef
+	for ref, pr := range s.db.prs {
+		if pr.Status != statusOpen {
+			continue
+		}
+		for _, reviewerID := range pr.AssignedReviewers {
+			if s.db.isMember(reviewerID, teamName) {
+				refs = append(refs, ref)
+				break
+			}
+		}
+	}
+
+	sortPRRefs(refs)
+
+	return refs, nil
+}
+
+func (s *PRStorage) GetOpenPRsByReviewerAndTeam(_ context.Context, reviewerID string, teamName string) ([]domain.PRRef, error) {
+	s.db.mu.RLock()
+	defer s.db.mu.RUnlock()
+
+	var refs []domain.PRRef
+	for ref, pr := range s.db.prs {
+		if pr.Status != statusOpen || !slices.Contains(pr.AssignedReviewers, reviewerID) {
+			continue
+		}
+		if pr.TeamName == teamName {
+			refs = append(refs, ref)
+		}
+	}
+
+	sortPRRefs(refs)
+
+	return refs, nil
+}
+
+func (s *PRStorage) GetPairings(_ context.Context, authorIDs []string, window time.Duration) ([]domain.Pairing, error) {
+	s.db.mu.RLock()
+	defer s.db.mu.RUnlock()
+
+	since := time.Now().Add(-window)
+	counts := make(map[domain.Pairing]int)
+	for _, a := range s.db.history {
+		if !slices.Contains(authorIDs, a.authorID) || window > 0 && a.assignedAt.Before(since) {
+			continue
+		}
+		counts[domain.Pairing{AuthorID: a.authorID, ReviewerID: a.reviewerID}]++
+	}
+
+	var pairings []domain.Pairing
+	for pairing, count := range counts {
+		pairing.Count = count
+		pairings = append(pairings, pairing)
+	}
+
+	sort.Slice(pairings, func(i, j int) bool {
+		if pairings[i].AuthorID != pairings[j].AuthorID {
+			return pairings[i].AuthorID < pairings[j].AuthorID
+		}
+		return pairings[i].ReviewerID < pairings[j].ReviewerID
+	})
+
+	return pairings, nil
+}
+
+func sortPRRefs(refs []domain.PRRef) {
+	sort.Slice(refs, func(i, j int) bool { return refs[i].Less(refs[j]) })
+}
@@ -0,0 +1,65 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+
+	"github.com/whitxowl/pr-reviewer-assignment-service.git/internal/domain"
+	storageErr "github.com/whitxowl/pr-reviewer-assignment-service.git/internal/storage/errors"
+)
+
+func (s *PRStorage) SaveAssignmentRationales(
+	_ context.Context,
+	repository string,
+	prID string,
+	rationales []domain.AssignmentRationale,
+) error {
+	const op = "storage.memory.SaveAssignmentRationales"
+
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+
+	ref := domain.PRRef{Repository: repository, PullRequestID: prID}
+	pr, ok := s.db.prs[ref]
+	for _, r := range rationales {
+		if !ok || !slices.Contains(pr.AssignedReviewers, r.ReviewerID) {
+			return fmt.Errorf("%s: reviewer %q: %w", op, r.ReviewerID, storageErr.ErrReviewerNotFound)
+		}
+	}
+
+	if len(rationales) > 0 && s.db.rationales[ref] == nil {
+		s.db.rationales[ref] = make(map[string]domain.AssignmentRationale)
+	}
+	for _, r := range rationales {
+		s.db.rationales[ref][r.ReviewerID] = copyRationale(r)
+	}
+
+	return nil
+}
+
+func (s *PRStorage) GetAssignmentRationales(
+	_ context.Context,
+	repository string,
+	prID string,
+) ([]domain.AssignmentRationale, error) {
+	s.db.mu.RLock()
+	defer s.db.mu.RUnlock()
+
+	var rationales []domain.AssignmentRationale
+	for _, r := range s.db.rationales[domain.PRRef{Repository: repository, PullRequestID: prID}] {
+		rationales = append(rationales, copyRationale(r))
+	}
+
+	sort.Slice(rationales, func(i, j int) bool { return rationales[i].ReviewerID < rationales[j].ReviewerID })
+
+	return rationales, nil
+}
+
+// copyRationale returns a copy of r with exclusions ordered by user, as the SQL backends return them.
+func copyRationale(r domain.AssignmentRationale) domain.AssignmentRationale {
+	r.Excluded = slices.Clone(r.Excluded)
+	sort.Slice(r.Excluded, func(i, j int) bool { return r.Excluded[i].UserID < r.Excluded[j].UserID })
+	return r
+}
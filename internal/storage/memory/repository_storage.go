@@ -0,0 +1,142 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+
+	"github.com/whitxowl/pr-reviewer-assignment-service.git/internal/domain"
+	storageErr "github.com/whitxowl/pr-reviewer-assignment-service.git/internal/storage/errors"
+)
+
+type RepositoryStorage struct {
+	db *DB
+}
+
+func NewRepositoryStorage(db *DB) *RepositoryStorage {
+	return &RepositoryStorage{
+		db: db,
+	}
+}
+
+func (s *RepositoryStorage) CreateRepository(_ context.Context, repo *domain.Repository) error {
+	const op = "storage.memory.CreateRepository"
+
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+
+	if _, ok := s.db.repositories[repo.Name]; ok {
+		return fmt.Errorf("%s: %w", op, storageErr.ErrRepositoryExists)
+	}
+	if err := s.check(repo); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	r := *repo
+	s.db.repositories[repo.Name] = &r
+
+	return nil
+}
+
+func (s *RepositoryStorage) GetRepository(_ context.Context, name string) (*domain.Repository, error) {
+	const op = "storage.memory.GetRepository"
+
+	s.db.mu.RLock()
+	defer s.db.mu.RUnlock()
+
+	repo, ok := s.db.repositories[name]
+	if !ok {
+		return nil, fmt.Errorf("%s: %w", op, storageErr.ErrRepositoryNotFound)
+	}
+
+	r := *repo
+	return &r, nil
+}
+
+func (s *RepositoryStorage) UpdateRepository(_ context.Context, repo *domain.Repository) error {
+	const op = "storage.memory.UpdateRepository"
+
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+
+	if err := s.check(repo); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if _, ok := s.db.repositories[repo.Name]; !ok {
+		return fmt.Errorf("%s: %w", op, storageErr.ErrRepositoryNotFound)
+	}
+
+	r := *repo
+	s.db.repositories[repo.Name] = &r
+
+	return nil
+}
+
+// check mirrors the constraints of the repositories table.
+func (s *RepositoryStorage) check(repo *domain.Repository) error {
+	if repo.Name == "" {
+		return fmt.Errorf("name %q: %w", repo.Name, ErrCheckViolation)
+	}
+	if repo.ReviewersCount <= 0 {
+		return fmt.Errorf("reviewers_count %d: %w", repo.ReviewersCount, ErrCheckViolation)
+	}
+	if _, ok := s.db.teams[repo.TeamName]; repo.TeamName != "" && !ok {
+		return storageErr.ErrTeamNotFound
+	}
+	return nil
+}
+
+func (s *RepositoryStorage) SetCodeOwners(_ context.Context, repository string, rules []domain.CodeOwnerRule) error {
+	const op = "storage.memory.SetCodeOwners"
+
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+
+	if _, ok := s.db.repositories[repository]; !ok {
+		return fmt.Errorf("%s: %w", op, storageErr.ErrRepositoryNotFound)
+	}
+
+	for _, rule := range rules {
+		if rule.Pattern == "" {
+			return fmt.Errorf("%s: pattern %q: %w", op, rule.Pattern, ErrCheckViolation)
+		}
+		for i, userID := range rule.UserIDs {
+			if _, ok := s.db.users[userID]; !ok {
+				return fmt.Errorf("%s: user %q: %w", op, userID, storageErr.ErrUserNotFound)
+			}
+			if slices.Contains(rule.UserIDs[:i], userID) {
+				return fmt.Errorf("%s: user %q: %w", op, userID, ErrUniqueViolation)
+			}
+		}
+		for i, teamName := range rule.TeamNames {
+			if _, ok := s.db.teams[teamName]; !ok {
+				return fmt.Errorf("%s: team %q: %w", op, teamName, storageErr.ErrTeamNotFound)
+			}
+			if slices.Contains(rule.TeamNames[:i], teamName) {
+				return fmt.Errorf("%s: team %q: %w", op, teamName, ErrUniqueViolation)
+			}
+		}
+	}
+
+	stored := copyRules(rules)
+	for i := range stored {
+		sort.Strings(stored[i].UserIDs)
+		sort.Strings(stored[i].TeamNames)
+	}
+	s.db.codeOwners[repository] = stored
+
+	return nil
+}
+
+func (s *RepositoryStorage) GetCodeOwners(_ context.Context, repository string) ([]domain.CodeOwnerRule, error) {
+	s.db.mu.RLock()
+	defer s.db.mu.RUnlock()
+
+	rules := s.db.codeOwners[repository]
+	if len(rules) == 0 {
+		return nil, nil
+	}
+
+	return copyRules(rules), nil
+}
@@ -0,0 +1,45 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/whitxowl/pr-reviewer-assignment-service.git/internal/domain"
+	storageErr "github.com/whitxowl/pr-reviewer-assignment-service.git/internal/storage/errors"
+)
+
+func (s *PRStorage) SetRequiredTags(_ context.Context, repository string, prID string, tags []string) error {
+	const op = "storage.memory.SetRequiredTags"
+
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+
+	ref := domain.PRRef{Repository: repository, PullRequestID: prID}
+	if _, ok := s.db.prs[ref]; !ok && len(tags) > 0 {
+		return fmt.Errorf("%s: %w", op, storageErr.ErrPRNotFound)
+	}
+
+	s.db.setRequiredTags(ref, tags)
+
+	return nil
+}
+
+func (s *PRStorage) GetRequiredTags(_ context.Context, repository string, prID string) ([]string, error) {
+	s.db.mu.RLock()
+	defer s.db.mu.RUnlock()
+
+	return slices.Clone(s.db.requiredTags[domain.PRRef{Repository: repository, PullRequestID: prID}]), nil
+}
+
+// setRequiredTags stores the tags of the PR sorted and without duplicates.
+func (db *DB) setRequiredTags(ref domain.PRRef, tags []string) {
+	if len(tags) == 0 {
+		delete(db.requiredTags, ref)
+		return
+	}
+
+	sorted := slices.Clone(tags)
+	slices.Sort(sorted)
+	db.requiredTags[ref] = slices.Compact(sorted)
+}
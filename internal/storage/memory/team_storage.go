@@ -0,0 +1,322 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+
+	"github.com/whitxowl/pr-reviewer-assignment-service.git/internal/domain"
+	storageErr "github.com/whitxowl/pr-reviewer-assignment-service.git/internal/storage/errors"
+)
+
+type TeamStorage struct {
+	db *DB
+}
+
+func NewTeamStorage(db *DB) *TeamStorage {
+	return &TeamStorage{
+		db: db,
+	}
+}
+
+func (s *TeamStorage) CreateTeam(_ context.Context, teamName string) error {
+	const op = "storage.memory.CreateTeam"
+
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+
+	if _, ok := s.db.teams[teamName]; ok {
+		return fmt.Errorf("%s: %w", op, storageErr.ErrTeamExists)
+	}
+
+	s.db.teams[teamName] = &domain.Team{TeamName: teamName}
+
+	return nil
+}
+
+func (s *TeamStorage) TeamExists(_ context.Context, teamName string) (bool, error) {
+	s.db.mu.RLock()
+	defer s.db.mu.RUnlock()
+
+	_, ok := s.db.teams[teamName]
+
+	return ok, nil
+}
+
+func (s *TeamStorage) GetTeam(_ context.Context, teamName string) (*domain.Team, error) {
+	const op = "storage.memory.GetTeam"
+
+	s.db.mu.RLock()
+	defer s.db.mu.RUnlock()
+
+	team, ok := s.db.teams[teamName]
+	if !ok {
+		return nil, fmt.Errorf("%s: %w", op, storageErr.ErrTeamNotFound)
+	}
+
+	return copyTeam(team), nil
+}
+
+func (s *TeamStorage) RenameTeam(_ context.Context, teamName string, newTeamName string) error {
+	const op = "storage.memory.RenameTeam"
+
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+
+	team, ok := s.db.teams[teamName]
+	if !ok {
+		return fmt.Errorf("%s: %w", op, storageErr.ErrTeamNotFound)
+	}
+	if _, ok := s.db.teams[newTeamName]; ok {
+		return fmt.Errorf("%s: %w", op, storageErr.ErrTeamExists)
+	}
+
+	delete(s.db.teams, teamName)
+	team.TeamName = newTeamName
+	s.db.teams[newTeamName] = team
+
+	for _, user := range s.db.users {
+		if user.TeamName == teamName {
+			user.TeamName = newTeamName
+		}
+	}
+	for _, teamNames := range s.db.memberships {
+		if teamNames[teamName] {
+			delete(teamNames, teamName)
+			teamNames[newTeamName] = true
+		}
+	}
+	for _, pr := range s.db.prs {
+		if pr.TeamName == teamName {
+			pr.TeamName = newTeamName
+		}
+	}
+	for _, repo := range s.db.repositories {
+		if repo.TeamName == teamName {
+			repo.TeamName = newTeamName
+		}
+	}
+	for _, rules := range s.db.codeOwners {
+		for i := range rules {
+			for j, owner := range rules[i].TeamNames {
+				if owner == teamName {
+					rules[i].TeamNames[j] = newTeamName
+				}
+			}
+			sort.Strings(rules[i].TeamNames)
+		}
+	}
+
+	return nil
+}
+
+func (s *TeamStorage) SetArchived(_ context.Context, teamName string, archived bool) error {
+	const op = "storage.memory.SetArchived"
+
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+
+	team, ok := s.db.teams[teamName]
+	if !ok {
+		return fmt.Errorf("%s: %w", op, storageErr.ErrTeamNotFound)
+	}
+
+	switch {
+	case !archived:
+		team.ArchivedAt = nil
+	case team.ArchivedAt == nil:
+		team.ArchivedAt = now()
+	}
+
+	return nil
+}
+
+func (s *TeamStorage) SetMaxOpenReviews(_ context.Context, teamName string, limit *int) error {
+	const op = "storage.memory.SetMaxOpenReviews"
+
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+
+	if limit != nil && *limit < 0 {
+		return fmt.Errorf("%s: max_open_reviews %d: %w", op, *limit, ErrCheckViolation)
+	}
+
+	team, ok := s.db.teams[teamName]
+	if !ok {
+		return fmt.Errorf("%s: %w", op, storageErr.ErrTeamNotFound)
+	}
+
+	team.MaxOpenReviews = copyLimit(limit)
+
+	return nil
+}
+
+func (s *TeamStorage) GetSeniorityRules(_ context.Context, teamName string) ([]domain.SeniorityRule, error) {
+	s.db.mu.RLock()
+	defer s.db.mu.RUnlock()
+
+	if team, ok := s.db.teams[teamName]; ok {
+		return slices.Clone(team.SeniorityRules), nil
+	}
+
+	return nil, nil
+}
+
+func (s *TeamStorage) SetSeniorityRules(_ context.Context, teamName string, rules []domain.SeniorityRule) error {
+	const op = "storage.memory.SetSeniorityRules"
+
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+
+	team, ok := s.db.teams[teamName]
+	if !ok {
+		return fmt.Errorf("%s: %w", op, storageErr.ErrTeamNotFound)
+	}
+
+	for i, rule := range rules {
+		if rule.MinReviewers <= 0 {
+			return fmt.Errorf("%s: min_reviewers %d: %w", op, rule.MinReviewers, ErrCheckViolation)
+		}
+		if !domain.ValidLevel(rule.AuthorLevel) || !domain.ValidLevel(rule.MinLevel) {
+			return fmt.Errorf("%s: rule %s/%s: %w", op, rule.AuthorLevel, rule.MinLevel, ErrCheckViolation)
+		}
+		for _, other := range rules[:i] {
+			if other.AuthorLevel == rule.AuthorLevel && other.MinLevel == rule.MinLevel {
+				return fmt.Errorf("%s: rule %s/%s: %w", op, rule.AuthorLevel, rule.MinLevel, ErrUniqueViolation)
+			}
+		}
+	}
+
+	sorted := slices.Clone(rules)
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].AuthorLevel != sorted[j].AuthorLevel {
+			return levelRank(sorted[i].AuthorLevel) < levelRank(sorted[j].AuthorLevel)
+		}
+		return levelRank(sorted[i].MinLevel) < levelRank(sorted[j].MinLevel)
+	})
+
+	team.SeniorityRules = sorted
+
+	return nil
+}
+
+// levelRank orders seniority levels from the lowest.
+func levelRank(level string) int {
+	return slices.Index([]string{domain.LevelJunior, domain.LevelMiddle, domain.LevelSenior}, level)
+}
+
+func (s *TeamStorage) DeleteTeam(_ context.Context, teamName string) error {
+	const op = "storage.memory.DeleteTeam"
+
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+
+	if _, ok := s.db.teams[teamName]; !ok {
+		return fmt.Errorf("%s: %w", op, storageErr.ErrTeamNotFound)
+	}
+
+	for _, teamNames := range s.db.memberships {
+		if teamNames[teamName] {
+			return fmt.Errorf("%s: %w", op, storageErr.ErrTeamNotEmpty)
+		}
+	}
+
+	delete(s.db.teams, teamName)
+	for _, pr := range s.db.prs {
+		if pr.TeamName == teamName {
+			pr.TeamName = ""
+		}
+	}
+	for _, repo := range s.db.repositories {
+		if repo.TeamName == teamName {
+			repo.TeamName = ""
+		}
+	}
+	for _, rules := range s.db.codeOwners {
+		for i := range rules {
+			rules[i].TeamNames = slices.DeleteFunc(rules[i].TeamNames, func(owner string) bool { return owner == teamName })
+		}
+	}
+
+	return nil
+}
+
+func (s *TeamStorage) ApplyTeamDiff(_ context.Context, diff *domain.TeamDiff) error {
+	const op = "storage.memory.ApplyTeamDiff"
+
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+
+	if err := s.db.applyTeamDiff(diff); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// SyncTeam makes the team's roster equal to members, creating the team if needed,
+// and returns the diff it applied; see domain.NewTeamDiff. With dryRun nothing is
+// written and the diff is only returned.
+func (s *TeamStorage) SyncTeam(
+	_ context.Context,
+	teamName string,
+	members []*domain.User,
+	detach bool,
+	dryRun bool,
+) (*domain.TeamDiff, error) {
+	const op = "storage.memory.SyncTeam"
+
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+
+	_, exists := s.db.teams[teamName]
+
+	diff := domain.NewTeamDiff(teamName, s.db.teamRoster(teamName), members, detach)
+	diff.TeamCreated = !exists
+
+	if dryRun || diff.Empty() {
+		return diff, nil
+	}
+
+	if err := s.db.applyTeamDiff(diff); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return diff, nil
+}
+
+// applyTeamDiff checks diff against the stored rows and applies it.
+func (db *DB) applyTeamDiff(diff *domain.TeamDiff) error {
+	if _, ok := db.teams[diff.TeamName]; !ok && !diff.TeamCreated {
+		return fmt.Errorf("team %q: %w", diff.TeamName, ErrForeignKeyViolation)
+	}
+	for _, users := range [][]*domain.User{diff.Added, diff.Updated} {
+		for _, user := range users {
+			if user.UserID == "" {
+				return fmt.Errorf("user_id %q: %w", user.UserID, ErrCheckViolation)
+			}
+		}
+	}
+
+	if _, ok := db.teams[diff.TeamName]; !ok {
+		db.teams[diff.TeamName] = &domain.Team{TeamName: diff.TeamName}
+	}
+	for _, users := range [][]*domain.User{diff.Added, diff.Updated} {
+		for _, user := range users {
+			upsertUser(db, user, diff.TeamName)
+		}
+	}
+	for _, user := range diff.Deactivated {
+		if db.isMember(user.UserID, diff.TeamName) {
+			db.users[user.UserID].IsActive = false
+		}
+	}
+	for _, user := range diff.Detached {
+		if db.isMember(user.UserID, diff.TeamName) {
+			db.removeMembership(user.UserID, diff.TeamName)
+		}
+	}
+
+	return nil
+}
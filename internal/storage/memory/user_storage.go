@@ -0,0 +1,377 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/whitxowl/pr-reviewer-assignment-service.git/internal/domain"
+	storageErr "github.com/whitxowl/pr-reviewer-assignment-service.git/internal/storage/errors"
+)
+
+type UserStorage struct {
+	db *DB
+}
+
+func NewUserStorage(db *DB) *UserStorage {
+	return &UserStorage{
+		db: db,
+	}
+}
+
+func (s *UserStorage) UpsertUsers(_ context.Context, users []*domain.User) error {
+	const op = "storage.memory.UpsertUsers"
+
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+
+	for _, user := range users {
+		if user.UserID == "" {
+			return fmt.Errorf("%s: user_id %q: %w", op, user.UserID, ErrCheckViolation)
+		}
+		if _, ok := s.db.teams[user.TeamName]; user.TeamName != "" && !ok {
+			return fmt.Errorf("%s: team %q: %w", op, user.TeamName, ErrForeignKeyViolation)
+		}
+	}
+
+	for _, user := range users {
+		upsertUser(s.db, user, user.TeamName)
+	}
+
+	return nil
+}
+
+// upsertUser stores the user as a member of teamName. An existing user keeps its
+// primary team, and its tags unless user has some.
+func upsertUser(db *DB, user *domain.User, teamName string) {
+	u, ok := db.users[user.UserID]
+	if !ok {
+		u = &domain.User{UserID: user.UserID}
+		db.users[user.UserID] = u
+	}
+	u.Username = user.Username
+	u.IsActive = user.IsActive
+	if user.Tags != nil {
+		u.Tags = slices.Compact(slices.Sorted(slices.Values(user.Tags)))
+	}
+	if teamName != "" {
+		db.addMembership(user.UserID, teamName)
+	}
+}
+
+func (s *UserStorage) GetUsersByTeamName(_ context.Context, teamName string) ([]*domain.User, error) {
+	s.db.mu.RLock()
+	defer s.db.mu.RUnlock()
+
+	return s.db.teamRoster(teamName), nil
+}
+
+// teamRoster returns copies of the members of the team.
+func (db *DB) teamRoster(teamName string) []*domain.User {
+	var users []*domain.User
+	for _, user := range db.users {
+		if db.isMember(user.UserID, teamName) {
+			u := copyUser(user)
+			u.TeamName = teamName
+			users = append(users, u)
+		}
+	}
+
+	sort.Slice(users, func(i, j int) bool { return users[i].UserID < users[j].UserID })
+
+	return users
+}
+
+func (s *UserStorage) SetIsActive(_ context.Context, userID string, isActive bool) (*domain.User, error) {
+	const op = "storage.memory.SetIsActive"
+
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+
+	user, ok := s.db.users[userID]
+	if !ok {
+		return nil, fmt.Errorf("%s: %w", op, storageErr.ErrUserNotFound)
+	}
+
+	user.IsActive = isActive
+
+	return copyUser(user), nil
+}
+
+func (s *UserStorage) SetUsername(_ context.Context, userID string, username string) (*domain.User, error) {
+	const op = "storage.memory.SetUsername"
+
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+
+	user, ok := s.db.users[userID]
+	if !ok {
+		return nil, fmt.Errorf("%s: %w", op, storageErr.ErrUserNotFound)
+	}
+
+	user.Username = username
+
+	return copyUser(user), nil
+}
+
+func (s *UserStorage) SetMaxOpenReviews(_ context.Context, userID string, limit *int) (*domain.User, error) {
+	const op = "storage.memory.SetMaxOpenReviews"
+
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+
+	if limit != nil && *limit < 0 {
+		return nil, fmt.Errorf("%s: max_open_reviews %d: %w", op, *limit, ErrCheckViolation)
+	}
+
+	user, ok := s.db.users[userID]
+	if !ok {
+		return nil, fmt.Errorf("%s: %w", op, storageErr.ErrUserNotFound)
+	}
+
+	user.MaxOpenReviews = copyLimit(limit)
+
+	return copyUser(user), nil
+}
+
+func (s *UserStorage) SetLevel(_ context.Context, userID string, level string) (*domain.User, error) {
+	const op = "storage.memory.SetLevel"
+
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+
+	if level != "" && !domain.ValidLevel(level) {
+		return nil, fmt.Errorf("%s: level %q: %w", op, level, ErrCheckViolation)
+	}
+
+	user, ok := s.db.users[userID]
+	if !ok {
+		return nil, fmt.Errorf("%s: %w", op, storageErr.ErrUserNotFound)
+	}
+
+	user.Level = level
+
+	return copyUser(user), nil
+}
+
+func (s *UserStorage) SetWorkingHours(
+	_ context.Context,
+	userID string,
+	timezone string,
+	hours *domain.WorkingHours,
+) (*domain.User, error) {
+	const op = "storage.memory.SetWorkingHours"
+
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+
+	if hours != nil {
+		mask := hours.DayMask()
+		if hours.Start < 0 || hours.Start > 1439 || hours.End < 0 || hours.End > 1439 || mask < 1 || mask > 127 {
+			return nil, fmt.Errorf("%s: working hours: %w", op, ErrCheckViolation)
+		}
+	}
+
+	user, ok := s.db.users[userID]
+	if !ok {
+		return nil, fmt.Errorf("%s: %w", op, storageErr.ErrUserNotFound)
+	}
+
+	user.Timezone = timezone
+	user.WorkingHours = copyHours(hours)
+
+	return copyUser(user), nil
+}
+
+func (s *UserStorage) GetReviewerPool(_ context.Context, teamName string) ([]*domain.User, error) {
+	s.db.mu.RLock()
+	defer s.db.mu.RUnlock()
+
+	if team := s.db.teams[teamName]; team == nil || team.ArchivedAt != nil {
+		return nil, nil
+	}
+
+	var users []*domain.User
+	for _, user := range s.db.users {
+		if s.db.isMember(user.UserID, teamName) {
+			users = append(users, s.db.poolUser(user))
+		}
+	}
+
+	sort.Slice(users, func(i, j int) bool { return users[i].UserID < users[j].UserID })
+
+	return users, nil
+}
+
+func (s *UserStorage) GetCodeOwnerPool(_ context.Context, userIDs []string, teamNames []string) ([]*domain.User, error) {
+	s.db.mu.RLock()
+	defer s.db.mu.RUnlock()
+
+	var users []*domain.User
+	for _, user := range s.db.users {
+		if slices.Contains(userIDs, user.UserID) || s.inUnarchivedTeam(user.UserID, teamNames) {
+			users = append(users, s.db.poolUser(user))
+		}
+	}
+
+	sort.Slice(users, func(i, j int) bool { return users[i].UserID < users[j].UserID })
+
+	return users, nil
+}
+
+// poolUser returns the fields of user that the pool methods report.
+func (db *DB) poolUser(user *domain.User) *domain.User {
+	u := &domain.User{
+		UserID:       user.UserID,
+		Username:     user.Username,
+		IsActive:     user.IsActive,
+		ReviewLimit:  copyLimit(user.MaxOpenReviews),
+		Absent:       db.absent(user.UserID),
+		Timezone:     user.Timezone,
+		WorkingHours: copyHours(user.WorkingHours),
+		Tags:         slices.Clone(user.Tags),
+		Level:        user.Level,
+	}
+	if u.ReviewLimit == nil && user.TeamName != "" {
+		u.ReviewLimit = copyLimit(db.teams[user.TeamName].MaxOpenReviews)
+	}
+
+	for _, pr := range db.prs {
+		if pr.Status != statusMerged && slices.Contains(pr.AssignedReviewers, user.UserID) {
+			u.OpenReviews++
+		}
+	}
+
+	return u
+}
+
+func (s *UserStorage) inUnarchivedTeam(userID string, teamNames []string) bool {
+	for _, teamName := range teamNames {
+		if team := s.db.teams[teamName]; team != nil && team.ArchivedAt == nil && s.db.isMember(userID, teamName) {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *UserStorage) GetUser(_ context.Context, userID string) (*domain.User, error) {
+	const op = "storage.memory.GetUser"
+
+	s.db.mu.RLock()
+	defer s.db.mu.RUnlock()
+
+	user, ok := s.db.users[userID]
+	if !ok {
+		return nil, fmt.Errorf("%s: %w", op, storageErr.ErrUserNotFound)
+	}
+
+	u := copyUser(user)
+	u.TeamNames = s.db.teamNamesOf(userID)
+
+	return u, nil
+}
+
+func (s *UserStorage) GetUsersByIDs(_ context.Context, userIDs []string) ([]*domain.User, error) {
+	s.db.mu.RLock()
+	defer s.db.mu.RUnlock()
+
+	seen := make(map[string]bool, len(userIDs))
+
+	var users []*domain.User
+	for _, userID := range userIDs {
+		user, ok := s.db.users[userID]
+		if !ok || seen[userID] {
+			continue
+		}
+		seen[userID] = true
+		users = append(users, copyUser(user))
+	}
+
+	sort.Slice(users, func(i, j int) bool { return users[i].UserID < users[j].UserID })
+
+	return users, nil
+}
+
+func (s *UserStorage) SearchUsers(_ context.Context, search domain.UserSearch) ([]*domain.User, int, error) {
+	s.db.mu.RLock()
+	defer s.db.mu.RUnlock()
+
+	prefix := strings.ToLower(search.Prefix)
+
+	var matched []*domain.User
+	for _, user := range s.db.users {
+		if prefix != "" &&
+			!strings.HasPrefix(strings.ToLower(user.Username), prefix) &&
+			!strings.HasPrefix(strings.ToLower(user.UserID), prefix) {
+			continue
+		}
+		if search.TeamName != "" && !s.db.isMember(user.UserID, search.TeamName) {
+			continue
+		}
+		if search.IsActive != nil && user.IsActive != *search.IsActive {
+			continue
+		}
+		matched = append(matched, user)
+	}
+
+	sort.Slice(matched, func(i, j int) bool { return matched[i].UserID < matched[j].UserID })
+
+	users := []*domain.User{}
+	for i := search.Offset; i < len(matched) && len(users) < search.Limit; i++ {
+		u := copyUser(matched[i])
+		u.TeamNames = s.db.teamNamesOf(u.UserID)
+		users = append(users, u)
+	}
+
+	return users, len(matched), nil
+}
+
+func (s *UserStorage) RemoveMemberships(_ context.Context, teamName string, userIDs []string) error {
+	const op = "storage.memory.RemoveMemberships"
+
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+
+	for _, userID := range userIDs {
+		if !s.db.isMember(userID, teamName) {
+			return fmt.Errorf("%s: %w", op, storageErr.ErrUserNotFound)
+		}
+	}
+
+	for _, userID := range userIDs {
+		s.db.removeMembership(userID, teamName)
+	}
+
+	return nil
+}
+
+func (s *UserStorage) MoveMembership(_ context.Context, userID string, fromTeamName string, toTeamName string) error {
+	const op = "storage.memory.MoveMembership"
+
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+
+	if _, ok := s.db.teams[toTeamName]; !ok {
+		return fmt.Errorf("%s: team %q: %w", op, toTeamName, ErrForeignKeyViolation)
+	}
+	user, ok := s.db.users[userID]
+	if !ok {
+		return fmt.Errorf("%s: %w", op, storageErr.ErrUserNotFound)
+	}
+	if fromTeamName != "" && !s.db.isMember(userID, fromTeamName) {
+		return fmt.Errorf("%s: %w", op, storageErr.ErrUserNotFound)
+	}
+
+	wasPrimary := fromTeamName != "" && user.TeamName == fromTeamName
+	if fromTeamName != "" {
+		delete(s.db.memberships[userID], fromTeamName)
+	}
+	s.db.addMembership(userID, toTeamName)
+	if wasPrimary {
+		user.TeamName = toTeamName
+	}
+
+	return nil
+}
@@ -0,0 +1,173 @@
+package storage_test
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/whitxowl/pr-reviewer-assignment-service.git/internal/domain"
+	sqliteStorage "github.com/whitxowl/pr-reviewer-assignment-service.git/internal/storage/sqlite"
+	"github.com/whitxowl/pr-reviewer-assignment-service.git/migrations"
+	"github.com/whitxowl/pr-reviewer-assignment-service.git/pkg/migrator"
+	"github.com/whitxowl/pr-reviewer-assignment-service.git/pkg/sqlite"
+)
+
+// TestMigration_TeamMemberships checks that users and PRs created before
+// team_memberships existed keep their team after migrating.
+func TestMigration_TeamMemberships(t *testing.T) {
+	ctx := context.Background()
+
+	db, err := sqlite.Open(ctx, filepath.Join(t.TempDir(), "migration.db"))
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+
+	m, err := migrator.NewSQLite(db, migrations.SQLiteFS, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	require.NoError(t, err)
+
+	_, err = m.To(ctx, 2)
+	require.NoError(t, err)
+
+	seed := []string{
+		"INSERT INTO teams (team_name) VALUES ('backend')",
+		"INSERT INTO users (user_id, username, team_name, is_active) VALUES ('u1', 'Alice', 'backend', TRUE)",
+		"INSERT INTO users (user_id, username, team_name, is_active) VALUES ('u2', 'Bob', 'backend', TRUE)",
+		"INSERT INTO users (user_id, username, team_name, is_active) VALUES ('u3', 'Carol', NULL, TRUE)",
+		"INSERT INTO pull_requests (pull_request_id, pull_request_name, author_id) VALUES ('pr-1', 'Add search', 'u1')",
+	}
+	for _, query := range seed {
+		_, err = db.ExecContext(ctx, query)
+		require.NoError(t, err)
+	}
+
+	_, err = m.Up(ctx)
+	require.NoError(t, err)
+
+	users := sqliteStorage.NewUserStorage(db)
+
+	user, err := users.GetUser(ctx, "u1")
+	require.NoError(t, err)
+	assert.Equal(t, "backend", user.TeamName)
+	assert.Equal(t, []string{"backend"}, user.TeamNames)
+
+	user, err = users.GetUser(ctx, "u3")
+	require.NoError(t, err)
+	assert.Empty(t, user.TeamNames)
+
+	members, err := users.GetUsersByTeamName(ctx, "backend")
+	require.NoError(t, err)
+	assert.Len(t, members, 2)
+
+	pr, err := sqliteStorage.NewPRStorage(db).GetPR(ctx, "", "pr-1")
+	require.NoError(t, err)
+	assert.Equal(t, "backend", pr.TeamName, "PRs get the team of their author")
+}
+
+// TestMigration_RelaxIDChecks checks that rebuilding users and pull_requests
+// without the ID format CHECKs keeps every row and every reference to them.
+func TestMigration_RelaxIDChecks(t *testing.T) {
+	ctx := context.Background()
+
+	db, err := sqlite.Open(ctx, filepath.Join(t.TempDir(), "migration.db"))
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+
+	m, err := migrator.NewSQLite(db, migrations.SQLiteFS, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	require.NoError(t, err)
+
+	_, err = m.To(ctx, 3)
+	require.NoError(t, err)
+
+	seed := []string{
+		"INSERT INTO teams (team_name) VALUES ('backend')",
+		"INSERT INTO users (user_id, username, team_name, is_active) VALUES ('u1', 'Alice', 'backend', TRUE)",
+		"INSERT INTO users (user_id, username, team_name, is_active) VALUES ('u2', 'Bob', 'backend', TRUE)",
+		"INSERT INTO team_memberships (user_id, team_name) VALUES ('u1', 'backend'), ('u2', 'backend')",
+		"INSERT INTO pull_requests (pull_request_id, pull_request_name, author_id, team_name) VALUES ('pr-1', 'Add search', 'u1', 'backend')",
+		"INSERT INTO pull_request_reviewers (pull_request_id, user_id) VALUES ('pr-1', 'u2')",
+	}
+	for _, query := range seed {
+		_, err = db.ExecContext(ctx, query)
+		require.NoError(t, err)
+	}
+
+	_, err = m.Up(ctx)
+	require.NoError(t, err)
+
+	users := sqliteStorage.NewUserStorage(db)
+	prs := sqliteStorage.NewPRStorage(db)
+
+	members, err := users.GetUsersByTeamName(ctx, "backend")
+	require.NoError(t, err)
+	assert.Len(t, members, 2, "memberships survive the rebuild")
+
+	pr, err := prs.GetPR(ctx, "", "pr-1")
+	require.NoError(t, err)
+	assert.Equal(t, "backend", pr.TeamName)
+	assert.Equal(t, []string{"u2"}, pr.AssignedReviewers, "reviewers survive the rebuild")
+
+	var violations int
+	require.NoError(t, db.QueryRowContext(ctx, "SELECT COUNT(*) FROM pragma_foreign_key_check").Scan(&violations))
+	assert.Zero(t, violations)
+
+	var foreignKeys bool
+	require.NoError(t, db.QueryRowContext(ctx, "PRAGMA foreign_keys").Scan(&foreignKeys))
+	assert.True(t, foreignKeys, "foreign keys are enforced again")
+
+	require.NoError(t, users.UpsertUsers(ctx, []*domain.User{
+		{UserID: "octocat", Username: "Octocat", TeamName: "backend", IsActive: true},
+	}))
+	require.NoError(t, prs.CreatePR(ctx, "", "acme/api#7", "Fix typo", "octocat", "backend"))
+
+	_, err = db.ExecContext(ctx, "INSERT INTO users (user_id, username) VALUES ('', 'Nobody')")
+	assert.Error(t, err, "empty IDs are still rejected")
+}
+
+// TestMigration_Repositories checks that PRs created before repositories
+// existed end up with an empty repository and keep their reviewers.
+func TestMigration_Repositories(t *testing.T) {
+	ctx := context.Background()
+
+	db, err := sqlite.Open(ctx, filepath.Join(t.TempDir(), "migration.db"))
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+
+	m, err := migrator.NewSQLite(db, migrations.SQLiteFS, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	require.NoError(t, err)
+
+	_, err = m.To(ctx, 4)
+	require.NoError(t, err)
+
+	seed := []string{
+		"INSERT INTO teams (team_name) VALUES ('backend')",
+		"INSERT INTO users (user_id, username, team_name, is_active) VALUES ('u1', 'Alice', 'backend', TRUE)",
+		"INSERT INTO users (user_id, username, team_name, is_active) VALUES ('u2', 'Bob', 'backend', TRUE)",
+		"INSERT INTO team_memberships (user_id, team_name) VALUES ('u1', 'backend'), ('u2', 'backend')",
+		"INSERT INTO pull_requests (pull_request_id, pull_request_name, author_id, team_name) VALUES ('pr-1', 'Add search', 'u1', 'backend')",
+		"INSERT INTO pull_request_reviewers (pull_request_id, user_id) VALUES ('pr-1', 'u2')",
+	}
+	for _, query := range seed {
+		_, err = db.ExecContext(ctx, query)
+		require.NoError(t, err)
+	}
+
+	_, err = m.Up(ctx)
+	require.NoError(t, err)
+
+	prs := sqliteStorage.NewPRStorage(db)
+
+	pr, err := prs.GetPR(ctx, "", "pr-1")
+	require.NoError(t, err)
+	assert.Empty(t, pr.Repository)
+	assert.Equal(t, []string{"u2"}, pr.AssignedReviewers, "reviewers survive the rebuild")
+
+	var violations int
+	require.NoError(t, db.QueryRowContext(ctx, "SELECT COUNT(*) FROM pragma_foreign_key_check").Scan(&violations))
+	assert.Zero(t, violations)
+
+	require.NoError(t, prs.CreatePR(ctx, "acme/api", "pr-1", "Add search", "u1", "backend"),
+		"the same ID is free in a repository")
+}
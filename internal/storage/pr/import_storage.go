@@ -0,0 +1,140 @@
+package pr
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/whitxowl/pr-reviewer-assignment-service.git/internal/domain"
+	storageErr "github.com/whitxowl/pr-reviewer-assignment-service.git/internal/storage/errors"
+	pg "github.com/whitxowl/pr-reviewer-assignment-service.git/pkg/postgres"
+)
+
+// ImportPRs creates the open PRs with their reviewers, assignment history,
+// rationales, required tags and queued reviewer slots in one transaction: either
+// all of them are created or none. The PRs are inserted in a batch and the rest
+// is copied in.
+func (s *Storage) ImportPRs(ctx context.Context, prs []domain.PRImport) error {
+	const op = "storage.pr.ImportPRs"
+
+	if len(prs) == 0 {
+		return nil
+	}
+
+	tx, err := s.Db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback(ctx)
+
+	const query = `
+		INSERT INTO pull_requests (repository, pull_request_id, pull_request_name, author_id, team_name)
+		VALUES ($1, $2, $3, $4, NULLIF($5, ''))
+	`
+
+	batch := &pg.Batch{}
+	for _, imp := range prs {
+		pr := imp.PR
+		batch.Queue(query, pr.Repository, pr.PullRequestID, pr.PullRequestName, pr.AuthorID, pr.TeamName)
+	}
+
+	batchResults := tx.SendBatch(ctx, batch)
+	defer batchResults.Close()
+
+	for range prs {
+		_, err = batchResults.Exec()
+		if pg.IsUniqueViolationError(err) {
+			e := batchResults.Close()
+
+			return errors.Join(e, fmt.Errorf("%s: %w", op, storageErr.ErrPRExists))
+		}
+		if pg.IsForeignKeyErr(err) {
+			e := batchResults.Close()
+
+			return errors.Join(e, fmt.Errorf("%s: %w", op, storageErr.ErrUserNotFound))
+		}
+		if err != nil {
+			e := batchResults.Close()
+
+			return errors.Join(e, fmt.Errorf("%s: %w", op, err))
+		}
+	}
+
+	if err = batchResults.Close(); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	var reviewers, history, rationales, exclusions, tags, slots [][]any
+	for _, imp := range prs {
+		pr := imp.PR
+		for _, reviewerID := range pr.AssignedReviewers {
+			reviewers = append(reviewers, []any{pr.Repository, pr.PullRequestID, reviewerID})
+			history = append(history, []any{pr.Repository, pr.PullRequestID, pr.AuthorID, reviewerID})
+		}
+		for _, r := range pr.Rationales {
+			rationales = append(rationales, []any{pr.Repository, pr.PullRequestID, r.ReviewerID, r.Strategy, r.Rule, r.PoolSize})
+			for _, excluded := range r.Excluded {
+				exclusions = append(exclusions, []any{pr.Repository, pr.PullRequestID, r.ReviewerID, excluded.UserID, excluded.Reason})
+			}
+		}
+		for _, tag := range imp.RequiredTags {
+			tags = append(tags, []any{pr.Repository, pr.PullRequestID, tag})
+		}
+		if imp.Queued > 0 {
+			slots = append(slots, []any{pr.Repository, pr.PullRequestID, imp.Queued})
+		}
+	}
+
+	// fkErr is what a missing row referenced by the copied ones means.
+	copies := []struct {
+		table   string
+		columns []string
+		rows    [][]any
+		fkErr   error
+	}{
+		{
+			"pull_request_reviewers", []string{"repository", "pull_request_id", "user_id"},
+			reviewers, storageErr.ErrUserNotFound,
+		},
+		{
+			"assignment_history", []string{"repository", "pull_request_id", "author_id", "reviewer_id"},
+			history, storageErr.ErrPRNotFound,
+		},
+		{
+			"assignment_rationales", []string{"repository", "pull_request_id", "reviewer_id", "strategy", "rule", "pool_size"},
+			rationales, storageErr.ErrReviewerNotFound,
+		},
+		{
+			"assignment_exclusions", []string{"repository", "pull_request_id", "reviewer_id", "user_id", "reason"},
+			exclusions, storageErr.ErrReviewerNotFound,
+		},
+		{
+			"pull_request_required_tags", []string{"repository", "pull_request_id", "tag"},
+			tags, storageErr.ErrPRNotFound,
+		},
+		{
+			"pending_reviewer_slots", []string{"repository", "pull_request_id", "slots"},
+			slots, storageErr.ErrPRNotFound,
+		},
+	}
+
+	for _, c := range copies {
+		if len(c.rows) == 0 {
+			continue
+		}
+
+		_, err = tx.CopyFrom(ctx, pg.Identifier{c.table}, c.columns, pg.CopyFromRows(c.rows))
+		if pg.IsForeignKeyErr(err) {
+			return fmt.Errorf("%s: %s: %w", op, c.table, c.fkErr)
+		}
+		if err != nil {
+			return fmt.Errorf("%s: %s: %w", op, c.table, err)
+		}
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
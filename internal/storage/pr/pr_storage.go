@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/whitxowl/pr-reviewer-assignment-service.git/internal/domain"
 	storageErr "github.com/whitxowl/pr-reviewer-assignment-service.git/internal/storage/errors"
@@ -28,10 +29,10 @@ func (s *Storage) GetPRsReviewedBy(ctx context.Context, userID string) ([]*domai
 	const op = "storage.pr.GetPRsReviewedBy"
 
 	const query = `
-		SELECT pr.pull_request_id, pr.pull_request_name, pr.author_id, pr.status
+		SELECT pr.repository, pr.pull_request_id, pr.pull_request_name, pr.author_id, pr.status
 		FROM pull_requests pr
 		JOIN pull_request_reviewers prr
-			ON pr.pull_request_id = prr.pull_request_id
+			ON pr.repository = prr.repository AND pr.pull_request_id = prr.pull_request_id
 		WHERE prr.user_id = $1
 	`
 
@@ -44,7 +45,7 @@ func (s *Storage) GetPRsReviewedBy(ctx context.Context, userID string) ([]*domai
 	var prs []*domain.PullRequest
 	for rows.Next() {
 		var pr domain.PullRequest
-		if err := rows.Scan(&pr.PullRequestID, &pr.PullRequestName, &pr.AuthorID, &pr.Status); err != nil {
+		if err := rows.Scan(&pr.Repository, &pr.PullRequestID, &pr.PullRequestName, &pr.AuthorID, &pr.Status); err != nil {
 			return nil, fmt.Errorf("%s: %w", op, err)
 		}
 		prs = append(prs, &pr)
@@ -57,15 +58,23 @@ func (s *Storage) GetPRsReviewedBy(ctx context.Context, userID string) ([]*domai
 	return prs, nil
 }
 
-func (s *Storage) CreatePR(ctx context.Context, prID string, prName string, authorID string) error {
+// CreatePR creates an open PR in the repository for the team its reviewers are picked from.
+func (s *Storage) CreatePR(
+	ctx context.Context,
+	repository string,
+	prID string,
+	prName string,
+	authorID string,
+	teamName string,
+) error {
 	const op = "storage.pr.CreatePR"
 
 	const query = `
-		INSERT INTO pull_requests (pull_request_id, pull_request_name, author_id)
-		VALUES ($1, $2, $3)
+		INSERT INTO pull_requests (repository, pull_request_id, pull_request_name, author_id, team_name)
+		VALUES ($1, $2, $3, $4, NULLIF($5, ''))
 	`
 
-	_, err := s.Db.Exec(ctx, query, prID, prName, authorID)
+	_, err := s.Db.Exec(ctx, query, repository, prID, prName, authorID, teamName)
 	if pg.IsUniqueViolationError(err) {
 		return fmt.Errorf("%s: %w", op, storageErr.ErrPRExists)
 	}
@@ -76,7 +85,7 @@ func (s *Storage) CreatePR(ctx context.Context, prID string, prName string, auth
 	return nil
 }
 
-func (s *Storage) AssignReviewers(ctx context.Context, prID string, reviewersIDs []string) error {
+func (s *Storage) AssignReviewers(ctx context.Context, repository string, prID string, reviewersIDs []string) error {
 	const op = "storage.pr.AssignReviewers"
 
 	if len(reviewersIDs) == 0 {
@@ -89,16 +98,17 @@ func (s *Storage) AssignReviewers(ctx context.Context, prID string, reviewersIDs
 	}
 	defer tx.Rollback(ctx)
 
-	const query = "INSERT INTO pull_request_reviewers VALUES ($1, $2)"
+	const query = "INSERT INTO pull_request_reviewers (repository, pull_request_id, user_id) VALUES ($1, $2, $3)"
 
 	batch := &pg.Batch{}
 	for _, reviewerID := range reviewersIDs {
-		batch.Queue(query, prID, reviewerID)
+		batch.Queue(query, repository, prID, reviewerID)
+		batch.Queue(recordAssignmentQuery, repository, prID, reviewerID)
 	}
 	batchResults := tx.SendBatch(ctx, batch)
 	defer batchResults.Close()
 
-	for range reviewersIDs {
+	for range batch.Len() {
 		_, err = batchResults.Exec()
 		if err != nil {
 			e := batchResults.Close()
@@ -118,15 +128,16 @@ func (s *Storage) AssignReviewers(ctx context.Context, prID string, reviewersIDs
 	return nil
 }
 
-func (s *Storage) SetStatusMerged(ctx context.Context, prID string) (*domain.PullRequest, error) {
+func (s *Storage) SetStatusMerged(ctx context.Context, repository string, prID string) (*domain.PullRequest, error) {
 	const op = "storage.pr.SetStatusMerged"
 
 	const query = `
         UPDATE pull_requests 
         SET status = 'MERGED', 
             merged_at = COALESCE(merged_at, NOW())
-        WHERE pull_request_id = $1
-        RETURNING pull_request_id, 
+        WHERE repository = $1 AND pull_request_id = $2
+        RETURNING repository,
+                  pull_request_id, 
                   pull_request_name, 
                   author_id, 
                   status,
@@ -134,7 +145,8 @@ func (s *Storage) SetStatusMerged(ctx context.Context, prID string) (*domain.Pul
     `
 
 	var pr domain.PullRequest
-	err := s.Db.QueryRow(ctx, query, prID).Scan(
+	err := s.Db.QueryRow(ctx, query, repository, prID).Scan(
+		&pr.Repository,
 		&pr.PullRequestID,
 		&pr.PullRequestName,
 		&pr.AuthorID,
@@ -148,7 +160,14 @@ func (s *Storage) SetStatusMerged(ctx context.Context, prID string) (*domain.Pul
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
-	reviewers, err := s.getReviewersByPRID(ctx, prID)
+	// Slots still pending on a merged PR will never be filled.
+	const dropPendingQuery = "DELETE FROM pending_reviewer_slots WHERE repository = $1 AND pull_request_id = $2"
+
+	if _, err = s.Db.Exec(ctx, dropPendingQuery, repository, prID); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	reviewers, err := s.getReviewersByPRID(ctx, repository, prID)
 	if err != nil {
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
@@ -158,28 +177,54 @@ func (s *Storage) SetStatusMerged(ctx context.Context, prID string) (*domain.Pul
 	return &pr, nil
 }
 
-func (s *Storage) GetPRAuthorID(ctx context.Context, prID string) (string, error) {
-	const op = "storage.pr.GetPRAuthorID"
+// GetPR returns the PR with its reviewers. A PR whose team was deleted has an empty TeamName.
+func (s *Storage) GetPR(ctx context.Context, repository string, prID string) (*domain.PullRequest, error) {
+	const op = "storage.pr.GetPR"
 
-	const query = "SELECT author_id FROM pull_requests WHERE pull_request_id = $1"
+	const query = `
+		SELECT repository, pull_request_id, pull_request_name, author_id, COALESCE(team_name, ''), status, created_at, merged_at
+		FROM pull_requests
+		WHERE repository = $1 AND pull_request_id = $2
+	`
 
-	var authorID string
-	err := s.Db.QueryRow(ctx, query, prID).Scan(&authorID)
+	var pr domain.PullRequest
+	err := s.Db.QueryRow(ctx, query, repository, prID).Scan(
+		&pr.Repository,
+		&pr.PullRequestID,
+		&pr.PullRequestName,
+		&pr.AuthorID,
+		&pr.TeamName,
+		&pr.Status,
+		&pr.CreatedAt,
+		&pr.MergedAt,
+	)
 	if pg.IsNoRowsError(err) {
-		return "", fmt.Errorf("%s: %w", op, storageErr.ErrPRNotFound)
+		return nil, fmt.Errorf("%s: %w", op, storageErr.ErrPRNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
 	}
+
+	reviewers, err := s.getReviewersByPRID(ctx, repository, prID)
 	if err != nil {
-		return "", fmt.Errorf("%s: %w", op, err)
+		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
-	return authorID, nil
+	pr.AssignedReviewers = reviewers
+
+	return &pr, nil
 }
 
+// ReassignReviewer removes the old reviewer and in the same transaction assigns
+// the replacement with its rationale, if any, and queues the empty slot if
+// asked to. With dryRun the transaction is rolled back and the PR is returned as
+// it would have been.
 func (s *Storage) ReassignReviewer(
 	ctx context.Context,
+	repository string,
 	prID string,
-	oldReviewerID string,
-	newReviewerID string,
+	reassignment domain.Reassignment,
+	dryRun bool,
 ) (*domain.PullRequest, error) {
 	const op = "storage.pr.ReassignReviewer"
 
@@ -189,25 +234,38 @@ func (s *Storage) ReassignReviewer(
 	}
 	defer tx.Rollback(ctx)
 
-	if err := s.checkPRStatus(ctx, tx, prID); err != nil {
+	if err := s.checkPRStatus(ctx, tx, repository, prID); err != nil {
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
-	if err := s.removeReviewerTx(ctx, tx, prID, oldReviewerID); err != nil {
+	if err := s.removeReviewerTx(ctx, tx, repository, prID, reassignment.OldReviewerID); err != nil {
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
-	if newReviewerID != "" {
-		if err := s.addReviewerTx(ctx, tx, prID, newReviewerID); err != nil {
+	if r := reassignment.Replacement; r != nil {
+		if err := s.addReviewerTx(ctx, tx, repository, prID, r.ReviewerID); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		if err := s.saveRationalesTx(ctx, tx, repository, prID, []domain.AssignmentRationale{*r}); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	if reassignment.QueueSlot {
+		if _, err := tx.Exec(ctx, queueSlotsQuery, repository, prID, 1); err != nil {
 			return nil, fmt.Errorf("%s: %w", op, err)
 		}
 	}
 
-	pr, err := s.getPRWithReviewersTx(ctx, tx, prID)
+	pr, err := s.getPRWithReviewersTx(ctx, tx, repository, prID)
 	if err != nil {
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
+	if dryRun {
+		return pr, nil
+	}
+
 	if err = tx.Commit(ctx); err != nil {
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
@@ -215,13 +273,13 @@ func (s *Storage) ReassignReviewer(
 	return pr, nil
 }
 
-func (s *Storage) checkPRStatus(ctx context.Context, tx pg.Tx, prID string) error {
+func (s *Storage) checkPRStatus(ctx context.Context, tx pg.Tx, repository string, prID string) error {
 	const op = "storage.pr.checkPRStatus"
 
-	const query = "SELECT status FROM pull_requests WHERE pull_request_id = $1"
+	const query = "SELECT status FROM pull_requests WHERE repository = $1 AND pull_request_id = $2"
 
 	var status string
-	err := tx.QueryRow(ctx, query, prID).Scan(&status)
+	err := tx.QueryRow(ctx, query, repository, prID).Scan(&status)
 	if pg.IsNoRowsError(err) {
 		return fmt.Errorf("%s: %w", op, storageErr.ErrPRNotFound)
 	}
@@ -236,15 +294,15 @@ func (s *Storage) checkPRStatus(ctx context.Context, tx pg.Tx, prID string) erro
 	return nil
 }
 
-func (s *Storage) removeReviewerTx(ctx context.Context, tx pg.Tx, prID string, reviewerID string) error {
+func (s *Storage) removeReviewerTx(ctx context.Context, tx pg.Tx, repository string, prID string, reviewerID string) error {
 	const op = "storage.pr.removeReviewerTx"
 
 	const query = `
         DELETE FROM pull_request_reviewers
-        WHERE pull_request_id = $1 AND user_id = $2
+        WHERE repository = $1 AND pull_request_id = $2 AND user_id = $3
     `
 
-	result, err := tx.Exec(ctx, query, prID, reviewerID)
+	result, err := tx.Exec(ctx, query, repository, prID, reviewerID)
 	if err != nil {
 		return fmt.Errorf("%s: %w", op, err)
 	}
@@ -256,33 +314,49 @@ func (s *Storage) removeReviewerTx(ctx context.Context, tx pg.Tx, prID string, r
 	return nil
 }
 
-func (s *Storage) addReviewerTx(ctx context.Context, tx pg.Tx, prID string, reviewerID string) error {
+// recordAssignmentQuery adds an assignment of the PR to the assignment history.
+const recordAssignmentQuery = `
+	INSERT INTO assignment_history (repository, pull_request_id, author_id, reviewer_id)
+	SELECT repository, pull_request_id, author_id, $3
+	FROM pull_requests
+	WHERE repository = $1 AND pull_request_id = $2
+`
+
+func (s *Storage) addReviewerTx(ctx context.Context, tx pg.Tx, repository string, prID string, reviewerID string) error {
 	const op = "storage.pr.addReviewerTx"
 
 	const query = `
-        INSERT INTO pull_request_reviewers (pull_request_id, user_id)
-        VALUES ($1, $2)
+        INSERT INTO pull_request_reviewers (repository, pull_request_id, user_id)
+        VALUES ($1, $2, $3)
     `
 
-	_, err := tx.Exec(ctx, query, prID, reviewerID)
+	_, err := tx.Exec(ctx, query, repository, prID, reviewerID)
+	if pg.IsUniqueViolationError(err) {
+		return fmt.Errorf("%s: %w", op, storageErr.ErrReviewerAssigned)
+	}
 	if err != nil {
 		return fmt.Errorf("%s: %w", op, err)
 	}
 
+	if _, err = tx.Exec(ctx, recordAssignmentQuery, repository, prID, reviewerID); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
 	return nil
 }
 
-func (s *Storage) getPRWithReviewersTx(ctx context.Context, tx pg.Tx, prID string) (*domain.PullRequest, error) {
+func (s *Storage) getPRWithReviewersTx(ctx context.Context, tx pg.Tx, repository string, prID string) (*domain.PullRequest, error) {
 	const op = "storage.pr.getPRWithReviewersTx"
 
 	const query = `
-        SELECT pull_request_id, pull_request_name, author_id, status
+        SELECT repository, pull_request_id, pull_request_name, author_id, status
         FROM pull_requests
-        WHERE pull_request_id = $1
+        WHERE repository = $1 AND pull_request_id = $2
     `
 
 	var pr domain.PullRequest
-	err := tx.QueryRow(ctx, query, prID).Scan(
+	err := tx.QueryRow(ctx, query, repository, prID).Scan(
+		&pr.Repository,
 		&pr.PullRequestID,
 		&pr.PullRequestName,
 		&pr.AuthorID,
@@ -295,10 +369,10 @@ func (s *Storage) getPRWithReviewersTx(ctx context.Context, tx pg.Tx, prID strin
 	const getReviewersQuery = `
         SELECT user_id
         FROM pull_request_reviewers
-        WHERE pull_request_id = $1
+        WHERE repository = $1 AND pull_request_id = $2
     `
 
-	rows, err := tx.Query(ctx, getReviewersQuery, prID)
+	rows, err := tx.Query(ctx, getReviewersQuery, repository, prID)
 	if err != nil {
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
@@ -322,16 +396,16 @@ func (s *Storage) getPRWithReviewersTx(ctx context.Context, tx pg.Tx, prID strin
 	return &pr, nil
 }
 
-func (s *Storage) getReviewersByPRID(ctx context.Context, prID string) ([]string, error) {
+func (s *Storage) getReviewersByPRID(ctx context.Context, repository string, prID string) ([]string, error) {
 	const op = "storage.pr.GetReviewersByPRID"
 
 	const query = `
         SELECT user_id 
         FROM pull_request_reviewers 
-        WHERE pull_request_id = $1
+        WHERE repository = $1 AND pull_request_id = $2
     `
 
-	rows, err := s.Db.Query(ctx, query, prID)
+	rows, err := s.Db.Query(ctx, query, repository, prID)
 	if err != nil {
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
@@ -352,3 +426,105 @@ func (s *Storage) getReviewersByPRID(ctx context.Context, prID string) ([]string
 
 	return reviewers, nil
 }
+
+// GetOpenPRsReviewedByTeam returns open PRs that have at least one reviewer who is a member of the team.
+func (s *Storage) GetOpenPRsReviewedByTeam(ctx context.Context, teamName string) ([]domain.PRRef, error) {
+	const op = "storage.pr.GetOpenPRsReviewedByTeam"
+
+	const query = `
+		SELECT DISTINCT pr.repository, pr.pull_request_id
+		FROM pull_requests pr
+		JOIN pull_request_reviewers prr
+			ON pr.repository = prr.repository AND pr.pull_request_id = prr.pull_request_id
+		JOIN team_memberships m ON m.user_id = prr.user_id
+		WHERE m.team_name = $1
+		  AND pr.status = 'OPEN'
+		ORDER BY pr.repository, pr.pull_request_id
+	`
+
+	refs, err := s.queryPRRefs(ctx, query, teamName)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return refs, nil
+}
+
+// GetOpenPRsByReviewerAndTeam returns open PRs of the team reviewed by the user.
+func (s *Storage) GetOpenPRsByReviewerAndTeam(ctx context.Context, reviewerID string, teamName string) ([]domain.PRRef, error) {
+	const op = "storage.pr.GetOpenPRsByReviewerAndTeam"
+
+	const query = `
+		SELECT pr.repository, pr.pull_request_id
+		FROM pull_requests pr
+		JOIN pull_request_reviewers prr
+			ON pr.repository = prr.repository AND pr.pull_request_id = prr.pull_request_id
+		WHERE prr.user_id = $1
+		  AND pr.team_name = $2
+		  AND pr.status = 'OPEN'
+		ORDER BY pr.repository, pr.pull_request_id
+	`
+
+	refs, err := s.queryPRRefs(ctx, query, reviewerID, teamName)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return refs, nil
+}
+
+// GetPairings counts the assignments of reviewers to the PRs by the authors
+// within window, all assignments for a zero window, including those of reviewers
+// since replaced or removed. Pairings are ordered by author and reviewer.
+func (s *Storage) GetPairings(ctx context.Context, authorIDs []string, window time.Duration) ([]domain.Pairing, error) {
+	const op = "storage.pr.GetPairings"
+
+	const query = `
+		SELECT author_id, reviewer_id, COUNT(*)
+		FROM assignment_history
+		WHERE author_id = ANY($1)
+		  AND ($2 = 0 OR assigned_at >= NOW() - $2 * INTERVAL '1 second')
+		GROUP BY author_id, reviewer_id
+		ORDER BY author_id, reviewer_id
+	`
+
+	rows, err := s.Db.Query(ctx, query, authorIDs, int64(window.Seconds()))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var pairings []domain.Pairing
+	for rows.Next() {
+		var pairing domain.Pairing
+		if err := rows.Scan(&pairing.AuthorID, &pairing.ReviewerID, &pairing.Count); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		pairings = append(pairings, pairing)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return pairings, nil
+}
+
+func (s *Storage) queryPRRefs(ctx context.Context, query string, args ...any) ([]domain.PRRef, error) {
+	rows, err := s.Db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var refs []domain.PRRef
+	for rows.Next() {
+		var ref domain.PRRef
+		if err := rows.Scan(&ref.Repository, &ref.PullRequestID); err != nil {
+			return nil, err
+		}
+		refs = append(refs, ref)
+	}
+
+	return refs, rows.Err()
+}
@@ -0,0 +1,167 @@
+package pr
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/whitxowl/pr-reviewer-assignment-service.git/internal/domain"
+	storageErr "github.com/whitxowl/pr-reviewer-assignment-service.git/internal/storage/errors"
+	pg "github.com/whitxowl/pr-reviewer-assignment-service.git/pkg/postgres"
+)
+
+// SaveAssignmentRationales stores why the reviewers were assigned to the PR,
+// replacing earlier rationales of the same reviewers. Every reviewer must be
+// assigned to the PR.
+func (s *Storage) SaveAssignmentRationales(
+	ctx context.Context,
+	repository string,
+	prID string,
+	rationales []domain.AssignmentRationale,
+) error {
+	const op = "storage.pr.SaveAssignmentRationales"
+
+	if len(rationales) == 0 {
+		return nil
+	}
+
+	tx, err := s.Db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback(ctx)
+
+	if err = s.saveRationalesTx(ctx, tx, repository, prID, rationales); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (s *Storage) saveRationalesTx(
+	ctx context.Context,
+	tx pg.Tx,
+	repository string,
+	prID string,
+	rationales []domain.AssignmentRationale,
+) error {
+	const op = "storage.pr.saveRationalesTx"
+
+	const deleteQuery = `
+		DELETE FROM assignment_rationales
+		WHERE repository = $1 AND pull_request_id = $2 AND reviewer_id = $3
+	`
+
+	const insertQuery = `
+		INSERT INTO assignment_rationales (repository, pull_request_id, reviewer_id, strategy, rule, pool_size)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`
+
+	const insertExclusionQuery = `
+		INSERT INTO assignment_exclusions (repository, pull_request_id, reviewer_id, user_id, reason)
+		VALUES ($1, $2, $3, $4, $5)
+	`
+
+	batch := &pg.Batch{}
+	for _, r := range rationales {
+		batch.Queue(deleteQuery, repository, prID, r.ReviewerID)
+		batch.Queue(insertQuery, repository, prID, r.ReviewerID, r.Strategy, r.Rule, r.PoolSize)
+		for _, excluded := range r.Excluded {
+			batch.Queue(insertExclusionQuery, repository, prID, r.ReviewerID, excluded.UserID, excluded.Reason)
+		}
+	}
+
+	batchResults := tx.SendBatch(ctx, batch)
+	defer batchResults.Close()
+
+	for range batch.Len() {
+		_, err := batchResults.Exec()
+		if pg.IsForeignKeyErr(err) {
+			e := batchResults.Close()
+
+			return errors.Join(e, fmt.Errorf("%s: %w", op, storageErr.ErrReviewerNotFound))
+		}
+		if err != nil {
+			e := batchResults.Close()
+
+			return errors.Join(e, fmt.Errorf("%s: %w", op, err))
+		}
+	}
+
+	if err := batchResults.Close(); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// GetAssignmentRationales returns the rationales of the current reviewers of the
+// PR ordered by reviewer, with exclusions ordered by user.
+func (s *Storage) GetAssignmentRationales(
+	ctx context.Context,
+	repository string,
+	prID string,
+) ([]domain.AssignmentRationale, error) {
+	const op = "storage.pr.GetAssignmentRationales"
+
+	const query = `
+		SELECT reviewer_id, strategy, rule, pool_size
+		FROM assignment_rationales
+		WHERE repository = $1 AND pull_request_id = $2
+		ORDER BY reviewer_id
+	`
+
+	rows, err := s.Db.Query(ctx, query, repository, prID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var rationales []domain.AssignmentRationale
+	index := make(map[string]int)
+	for rows.Next() {
+		var r domain.AssignmentRationale
+		if err := rows.Scan(&r.ReviewerID, &r.Strategy, &r.Rule, &r.PoolSize); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		index[r.ReviewerID] = len(rationales)
+		rationales = append(rationales, r)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	const exclusionsQuery = `
+		SELECT reviewer_id, user_id, reason
+		FROM assignment_exclusions
+		WHERE repository = $1 AND pull_request_id = $2
+		ORDER BY reviewer_id, user_id
+	`
+
+	rows, err = s.Db.Query(ctx, exclusionsQuery, repository, prID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var reviewerID string
+		var excluded domain.ExcludedCandidate
+		if err := rows.Scan(&reviewerID, &excluded.UserID, &excluded.Reason); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		r := &rationales[index[reviewerID]]
+		r.Excluded = append(r.Excluded, excluded)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return rationales, nil
+}
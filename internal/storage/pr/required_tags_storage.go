@@ -0,0 +1,79 @@
+package pr
+
+import (
+	"context"
+	"fmt"
+
+	storageErr "github.com/whitxowl/pr-reviewer-assignment-service.git/internal/storage/errors"
+	pg "github.com/whitxowl/pr-reviewer-assignment-service.git/pkg/postgres"
+)
+
+// SetRequiredTags replaces the skill tags the PR requires of its reviewers.
+func (s *Storage) SetRequiredTags(ctx context.Context, repository string, prID string, tags []string) error {
+	const op = "storage.pr.SetRequiredTags"
+
+	tx, err := s.Db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback(ctx)
+
+	const clearQuery = "DELETE FROM pull_request_required_tags WHERE repository = $1 AND pull_request_id = $2"
+
+	const insertQuery = `
+		INSERT INTO pull_request_required_tags (repository, pull_request_id, tag)
+		SELECT $1, $2, UNNEST($3::TEXT[])
+		ON CONFLICT DO NOTHING
+	`
+
+	if _, err = tx.Exec(ctx, clearQuery, repository, prID); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	_, err = tx.Exec(ctx, insertQuery, repository, prID, tags)
+	if pg.IsForeignKeyErr(err) {
+		return fmt.Errorf("%s: %w", op, storageErr.ErrPRNotFound)
+	}
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// GetRequiredTags returns the sorted skill tags the PR requires of its reviewers.
+func (s *Storage) GetRequiredTags(ctx context.Context, repository string, prID string) ([]string, error) {
+	const op = "storage.pr.GetRequiredTags"
+
+	const query = `
+		SELECT tag
+		FROM pull_request_required_tags
+		WHERE repository = $1 AND pull_request_id = $2
+		ORDER BY tag
+	`
+
+	rows, err := s.Db.Query(ctx, query, repository, prID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var tags []string
+	for rows.Next() {
+		var tag string
+		if err := rows.Scan(&tag); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		tags = append(tags, tag)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return tags, nil
+}
@@ -0,0 +1,224 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/whitxowl/pr-reviewer-assignment-service.git/internal/domain"
+	storageErr "github.com/whitxowl/pr-reviewer-assignment-service.git/internal/storage/errors"
+	pg "github.com/whitxowl/pr-reviewer-assignment-service.git/pkg/postgres"
+)
+
+type Storage struct {
+	Db pg.DB
+}
+
+func New(db pg.DB) *Storage {
+	return &Storage{
+		Db: db,
+	}
+}
+
+func (s *Storage) CreateRepository(ctx context.Context, repo *domain.Repository) error {
+	const op = "storage.repository.CreateRepository"
+
+	const query = `
+		INSERT INTO repositories (name, team_name, reviewers_count)
+		VALUES ($1, NULLIF($2, ''), $3)
+	`
+
+	_, err := s.Db.Exec(ctx, query, repo.Name, repo.TeamName, repo.ReviewersCount)
+	if pg.IsUniqueViolationError(err) {
+		return fmt.Errorf("%s: %w", op, storageErr.ErrRepositoryExists)
+	}
+	if pg.IsForeignKeyErr(err) {
+		return fmt.Errorf("%s: %w", op, storageErr.ErrTeamNotFound)
+	}
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// GetRepository returns the repository. A repository whose team was deleted has an empty TeamName.
+func (s *Storage) GetRepository(ctx context.Context, name string) (*domain.Repository, error) {
+	const op = "storage.repository.GetRepository"
+
+	const query = "SELECT name, COALESCE(team_name, ''), reviewers_count FROM repositories WHERE name = $1"
+
+	var repo domain.Repository
+	err := s.Db.QueryRow(ctx, query, name).Scan(&repo.Name, &repo.TeamName, &repo.ReviewersCount)
+	if pg.IsNoRowsError(err) {
+		return nil, fmt.Errorf("%s: %w", op, storageErr.ErrRepositoryNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &repo, nil
+}
+
+// UpdateRepository replaces the owning team and the reviewer policy of the repository.
+func (s *Storage) UpdateRepository(ctx context.Context, repo *domain.Repository) error {
+	const op = "storage.repository.UpdateRepository"
+
+	const query = `
+		UPDATE repositories
+		SET team_name = NULLIF($2, ''),
+		    reviewers_count = $3
+		WHERE name = $1
+	`
+
+	result, err := s.Db.Exec(ctx, query, repo.Name, repo.TeamName, repo.ReviewersCount)
+	if pg.IsForeignKeyErr(err) {
+		return fmt.Errorf("%s: %w", op, storageErr.ErrTeamNotFound)
+	}
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("%s: %w", op, storageErr.ErrRepositoryNotFound)
+	}
+
+	return nil
+}
+
+// SetCodeOwners replaces the code owner rules of the repository.
+func (s *Storage) SetCodeOwners(ctx context.Context, repository string, rules []domain.CodeOwnerRule) error {
+	const op = "storage.repository.SetCodeOwners"
+
+	tx, err := s.Db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback(ctx)
+
+	const lockQuery = "SELECT 1 FROM repositories WHERE name = $1 FOR UPDATE"
+
+	var one int
+	err = tx.QueryRow(ctx, lockQuery, repository).Scan(&one)
+	if pg.IsNoRowsError(err) {
+		return fmt.Errorf("%s: %w", op, storageErr.ErrRepositoryNotFound)
+	}
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	var userIDs, teamNames []string
+	for _, rule := range rules {
+		userIDs = append(userIDs, rule.UserIDs...)
+		teamNames = append(teamNames, rule.TeamNames...)
+	}
+
+	const missingUserQuery = `
+		SELECT id FROM unnest($1::text[]) AS id
+		WHERE NOT EXISTS (SELECT 1 FROM users u WHERE u.user_id = id)
+		LIMIT 1
+	`
+
+	const missingTeamQuery = `
+		SELECT name FROM unnest($1::text[]) AS name
+		WHERE NOT EXISTS (SELECT 1 FROM teams t WHERE t.team_name = name)
+		LIMIT 1
+	`
+
+	var missing string
+	err = tx.QueryRow(ctx, missingUserQuery, userIDs).Scan(&missing)
+	if err == nil {
+		return fmt.Errorf("%s: user %q: %w", op, missing, storageErr.ErrUserNotFound)
+	}
+	if !pg.IsNoRowsError(err) {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	err = tx.QueryRow(ctx, missingTeamQuery, teamNames).Scan(&missing)
+	if err == nil {
+		return fmt.Errorf("%s: team %q: %w", op, missing, storageErr.ErrTeamNotFound)
+	}
+	if !pg.IsNoRowsError(err) {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	const deleteQuery = "DELETE FROM code_owner_rules WHERE repository = $1"
+
+	const insertRuleQuery = "INSERT INTO code_owner_rules (repository, position, pattern) VALUES ($1, $2, $3)"
+
+	const insertUserQuery = "INSERT INTO code_owners (repository, position, user_id) VALUES ($1, $2, $3)"
+
+	const insertTeamQuery = "INSERT INTO code_owners (repository, position, team_name) VALUES ($1, $2, $3)"
+
+	batch := &pg.Batch{}
+	batch.Queue(deleteQuery, repository)
+	for position, rule := range rules {
+		batch.Queue(insertRuleQuery, repository, position, rule.Pattern)
+		for _, userID := range rule.UserIDs {
+			batch.Queue(insertUserQuery, repository, position, userID)
+		}
+		for _, teamName := range rule.TeamNames {
+			batch.Queue(insertTeamQuery, repository, position, teamName)
+		}
+	}
+
+	batchResults := tx.SendBatch(ctx, batch)
+	defer batchResults.Close()
+
+	for range batch.Len() {
+		if _, err = batchResults.Exec(); err != nil {
+			e := batchResults.Close()
+
+			return errors.Join(e, fmt.Errorf("%s: %w", op, err))
+		}
+	}
+
+	if err = batchResults.Close(); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// GetCodeOwners returns the code owner rules of the repository in order.
+// Owners of a rule are sorted.
+func (s *Storage) GetCodeOwners(ctx context.Context, repository string) ([]domain.CodeOwnerRule, error) {
+	const op = "storage.repository.GetCodeOwners"
+
+	const query = `
+		SELECT r.pattern,
+		       ARRAY(SELECT o.user_id FROM code_owners o
+		             WHERE o.repository = r.repository AND o.position = r.position AND o.user_id IS NOT NULL
+		             ORDER BY o.user_id),
+		       ARRAY(SELECT o.team_name FROM code_owners o
+		             WHERE o.repository = r.repository AND o.position = r.position AND o.team_name IS NOT NULL
+		             ORDER BY o.team_name)
+		FROM code_owner_rules r
+		WHERE r.repository = $1
+		ORDER BY r.position
+	`
+
+	rows, err := s.Db.Query(ctx, query, repository)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var rules []domain.CodeOwnerRule
+	for rows.Next() {
+		var rule domain.CodeOwnerRule
+		if err := rows.Scan(&rule.Pattern, &rule.UserIDs, &rule.TeamNames); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		rules = append(rules, rule)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return rules, nil
+}
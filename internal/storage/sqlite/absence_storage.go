@@ -0,0 +1,188 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/whitxowl/pr-reviewer-assignment-service.git/internal/domain"
+	storageErr "github.com/whitxowl/pr-reviewer-assignment-service.git/internal/storage/errors"
+	"github.com/whitxowl/pr-reviewer-assignment-service.git/pkg/sqlite"
+)
+
+const absenceColumns = `
+	absence_id, user_id, starts_at, ends_at, reason, reassign, reassigned_at, COALESCE(uid, '')
+`
+
+// SaveAbsences adds the absences of the user. An absence with a UID replaces the
+// absence of the user saved with the same UID; if it moved, it is handed over
+// again when it starts.
+func (s *UserStorage) SaveAbsences(ctx context.Context, userID string, absences []domain.Absence) ([]domain.Absence, error) {
+	const op = "storage.sqlite.SaveAbsences"
+
+	const query = `
+		INSERT INTO user_absences (user_id, starts_at, ends_at, reason, reassign, uid)
+		VALUES (?, ?, ?, ?, ?, NULLIF(?, ''))
+		ON CONFLICT (user_id, uid) DO UPDATE
+		SET starts_at     = excluded.starts_at,
+		    ends_at       = excluded.ends_at,
+		    reason        = excluded.reason,
+		    reassign      = excluded.reassign,
+		    reassigned_at = CASE
+		        WHEN julianday(user_absences.starts_at) = julianday(excluded.starts_at)
+		        THEN user_absences.reassigned_at
+		    END
+		RETURNING ` + absenceColumns
+
+	tx, err := s.Db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback()
+
+	result := make([]domain.Absence, 0, len(absences))
+	for _, absence := range absences {
+		row := tx.QueryRowContext(ctx, query,
+			userID,
+			absence.StartsAt,
+			absence.EndsAt,
+			absence.Reason,
+			absence.Reassign,
+			absence.UID,
+		)
+
+		saved, err := scanAbsence(row)
+		if sqlite.IsForeignKeyErr(err) {
+			return nil, fmt.Errorf("%s: %w", op, storageErr.ErrUserNotFound)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		result = append(result, saved)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return result, nil
+}
+
+// GetAbsences returns the absences of the user, earliest first.
+func (s *UserStorage) GetAbsences(ctx context.Context, userID string) ([]domain.Absence, error) {
+	const op = "storage.sqlite.GetAbsences"
+
+	const query = `
+		SELECT ` + absenceColumns + `
+		FROM user_absences
+		WHERE user_id = ?
+		ORDER BY julianday(starts_at), absence_id
+	`
+
+	absences, err := s.queryAbsences(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return absences, nil
+}
+
+func (s *UserStorage) DeleteAbsence(ctx context.Context, userID string, absenceID int64) error {
+	const op = "storage.sqlite.DeleteAbsence"
+
+	const query = `DELETE FROM user_absences WHERE absence_id = ? AND user_id = ?`
+
+	result, err := s.Db.ExecContext(ctx, query, absenceID, userID)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if affected == 0 {
+		return fmt.Errorf("%s: %w", op, storageErr.ErrAbsenceNotFound)
+	}
+
+	return nil
+}
+
+// GetStartedAbsences returns the absences in progress whose open reviews are to
+// be handed over and have not been yet, earliest first.
+func (s *UserStorage) GetStartedAbsences(ctx context.Context) ([]domain.Absence, error) {
+	const op = "storage.sqlite.GetStartedAbsences"
+
+	// Timestamps are compared through julianday, which reads the time zone
+	// suffix the driver stores with them.
+	const query = `
+		SELECT ` + absenceColumns + `
+		FROM user_absences
+		WHERE reassign
+		  AND reassigned_at IS NULL
+		  AND julianday(starts_at) <= julianday('now')
+		  AND julianday(ends_at) > julianday('now')
+		ORDER BY julianday(starts_at), absence_id
+	`
+
+	absences, err := s.queryAbsences(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return absences, nil
+}
+
+func (s *UserStorage) MarkAbsenceReassigned(ctx context.Context, absenceID int64) error {
+	const op = "storage.sqlite.MarkAbsenceReassigned"
+
+	const query = `UPDATE user_absences SET reassigned_at = CURRENT_TIMESTAMP WHERE absence_id = ?`
+
+	result, err := s.Db.ExecContext(ctx, query, absenceID)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if affected == 0 {
+		return fmt.Errorf("%s: %w", op, storageErr.ErrAbsenceNotFound)
+	}
+
+	return nil
+}
+
+func (s *UserStorage) queryAbsences(ctx context.Context, query string, args ...any) ([]domain.Absence, error) {
+	rows, err := s.Db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var absences []domain.Absence
+	for rows.Next() {
+		absence, err := scanAbsence(rows)
+		if err != nil {
+			return nil, err
+		}
+		absences = append(absences, absence)
+	}
+
+	return absences, rows.Err()
+}
+
+func scanAbsence(row interface{ Scan(dest ...any) error }) (domain.Absence, error) {
+	var absence domain.Absence
+	err := row.Scan(
+		&absence.ID,
+		&absence.UserID,
+		&absence.StartsAt,
+		&absence.EndsAt,
+		&absence.Reason,
+		&absence.Reassign,
+		&absence.ReassignedAt,
+		&absence.UID,
+	)
+	return absence, err
+}
@@ -0,0 +1,50 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+type HealthStorage struct {
+	Db *sql.DB
+}
+
+func NewHealthStorage(db *sql.DB) *HealthStorage {
+	return &HealthStorage{
+		Db: db,
+	}
+}
+
+func (s *HealthStorage) Ping(ctx context.Context) error {
+	const op = "storage.sqlite.Ping"
+
+	if err := s.Db.PingContext(ctx); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (s *HealthStorage) MigrationVersion(ctx context.Context) (int64, error) {
+	const op = "storage.sqlite.MigrationVersion"
+
+	const tableQuery = "SELECT EXISTS(SELECT 1 FROM sqlite_master WHERE type = 'table' AND name = 'goose_db_version')"
+
+	var exists bool
+	if err := s.Db.QueryRowContext(ctx, tableQuery).Scan(&exists); err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+	if !exists {
+		return 0, nil
+	}
+
+	const query = "SELECT COALESCE(MAX(version_id), 0) FROM goose_db_version WHERE is_applied"
+
+	var version int64
+	if err := s.Db.QueryRowContext(ctx, query).Scan(&version); err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return version, nil
+}
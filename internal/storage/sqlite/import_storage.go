@@ -0,0 +1,107 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/whitxowl/pr-reviewer-assignment-service.git/internal/domain"
+	storageErr "github.com/whitxowl/pr-reviewer-assignment-service.git/internal/storage/errors"
+	"github.com/whitxowl/pr-reviewer-assignment-service.git/pkg/sqlite"
+)
+
+// ImportPRs creates the open PRs with their reviewers, assignment history,
+// rationales, required tags and queued reviewer slots in one transaction: either
+// all of them are created or none.
+func (s *PRStorage) ImportPRs(ctx context.Context, prs []domain.PRImport) error {
+	const op = "storage.sqlite.ImportPRs"
+
+	if len(prs) == 0 {
+		return nil
+	}
+
+	tx, err := s.Db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback()
+
+	const insertQuery = `
+		INSERT INTO pull_requests (repository, pull_request_id, pull_request_name, author_id, team_name)
+		VALUES (?, ?, ?, ?, NULLIF(?, ''))
+	`
+
+	const insertRationaleQuery = `
+		INSERT INTO assignment_rationales (repository, pull_request_id, reviewer_id, strategy, rule, pool_size)
+		VALUES (?, ?, ?, ?, ?, ?)
+	`
+
+	const insertExclusionQuery = `
+		INSERT INTO assignment_exclusions (repository, pull_request_id, reviewer_id, user_id, reason)
+		VALUES (?, ?, ?, ?, ?)
+	`
+
+	const queueQuery = `
+		INSERT INTO pending_reviewer_slots (repository, pull_request_id, slots)
+		VALUES (?, ?, ?)
+	`
+
+	for _, imp := range prs {
+		pr := imp.PR
+
+		_, err = tx.ExecContext(ctx, insertQuery, pr.Repository, pr.PullRequestID, pr.PullRequestName, pr.AuthorID, pr.TeamName)
+		if sqlite.IsUniqueViolationError(err) {
+			return fmt.Errorf("%s: %w", op, storageErr.ErrPRExists)
+		}
+		if sqlite.IsForeignKeyErr(err) {
+			return fmt.Errorf("%s: %w", op, storageErr.ErrUserNotFound)
+		}
+		if err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+
+		for _, reviewerID := range pr.AssignedReviewers {
+			err = addReviewer(ctx, tx, pr.Repository, pr.PullRequestID, reviewerID)
+			if sqlite.IsForeignKeyErr(err) {
+				return fmt.Errorf("%s: %w", op, storageErr.ErrUserNotFound)
+			}
+			if err != nil {
+				return fmt.Errorf("%s: %w", op, err)
+			}
+		}
+
+		for _, r := range pr.Rationales {
+			_, err = tx.ExecContext(ctx, insertRationaleQuery, pr.Repository, pr.PullRequestID, r.ReviewerID, r.Strategy, r.Rule, r.PoolSize)
+			if sqlite.IsForeignKeyErr(err) {
+				return fmt.Errorf("%s: %w", op, storageErr.ErrReviewerNotFound)
+			}
+			if err != nil {
+				return fmt.Errorf("%s: %w", op, err)
+			}
+
+			for _, excluded := range r.Excluded {
+				_, err = tx.ExecContext(ctx, insertExclusionQuery, pr.Repository, pr.PullRequestID, r.ReviewerID, excluded.UserID, excluded.Reason)
+				if err != nil {
+					return fmt.Errorf("%s: %w", op, err)
+				}
+			}
+		}
+
+		for _, tag := range imp.RequiredTags {
+			if err = addRequiredTag(ctx, tx, pr.Repository, pr.PullRequestID, tag); err != nil {
+				return fmt.Errorf("%s: %w", op, err)
+			}
+		}
+
+		if imp.Queued > 0 {
+			if _, err = tx.ExecContext(ctx, queueQuery, pr.Repository, pr.PullRequestID, imp.Queued); err != nil {
+				return fmt.Errorf("%s: %w", op, err)
+			}
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
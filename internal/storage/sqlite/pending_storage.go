@@ -0,0 +1,142 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/whitxowl/pr-reviewer-assignment-service.git/internal/domain"
+	storageErr "github.com/whitxowl/pr-reviewer-assignment-service.git/internal/storage/errors"
+	"github.com/whitxowl/pr-reviewer-assignment-service.git/pkg/sqlite"
+)
+
+// queueSlotsQuery adds slots to the queued reviewer slots of the PR.
+const queueSlotsQuery = `
+	INSERT INTO pending_reviewer_slots (repository, pull_request_id, slots)
+	VALUES (?, ?, ?)
+	ON CONFLICT (repository, pull_request_id)
+	DO UPDATE SET slots = pending_reviewer_slots.slots + excluded.slots
+`
+
+// QueueReviewerSlots adds slots to the pending reviewer slots of the PR.
+func (s *PRStorage) QueueReviewerSlots(ctx context.Context, repository string, prID string, slots int) error {
+	const op = "storage.sqlite.QueueReviewerSlots"
+
+	_, err := s.Db.ExecContext(ctx, queueSlotsQuery, repository, prID, slots)
+	if sqlite.IsForeignKeyErr(err) {
+		return fmt.Errorf("%s: %w", op, storageErr.ErrPRNotFound)
+	}
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// GetPendingSlots returns the open PRs with pending reviewer slots, longest waiting first.
+func (s *PRStorage) GetPendingSlots(ctx context.Context) ([]domain.PendingSlots, error) {
+	const op = "storage.sqlite.GetPendingSlots"
+
+	const query = `
+		SELECT pr.repository, pr.pull_request_id, pr.pull_request_name, pr.author_id,
+		       COALESCE(pr.team_name, ''), pr.status, pr.created_at, prs.slots, prs.queued_at
+		FROM pending_reviewer_slots prs
+		JOIN pull_requests pr
+			ON pr.repository = prs.repository AND pr.pull_request_id = prs.pull_request_id
+		WHERE pr.status = 'OPEN'
+		ORDER BY prs.queued_at, pr.repository, pr.pull_request_id
+	`
+
+	rows, err := s.Db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var result []domain.PendingSlots
+	for rows.Next() {
+		var pending domain.PendingSlots
+		if err := rows.Scan(
+			&pending.PR.Repository,
+			&pending.PR.PullRequestID,
+			&pending.PR.PullRequestName,
+			&pending.PR.AuthorID,
+			&pending.PR.TeamName,
+			&pending.PR.Status,
+			&pending.PR.CreatedAt,
+			&pending.Slots,
+			&pending.QueuedAt,
+		); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		result = append(result, pending)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return result, nil
+}
+
+// FillReviewerSlots assigns reviewerIDs to the PR and takes as many slots off its
+// pending reviewer slots.
+func (s *PRStorage) FillReviewerSlots(ctx context.Context, repository string, prID string, reviewerIDs []string) error {
+	const op = "storage.sqlite.FillReviewerSlots"
+
+	if len(reviewerIDs) == 0 {
+		return nil
+	}
+
+	tx, err := s.Db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback()
+
+	if err = checkPRStatus(ctx, tx, repository, prID); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	for _, reviewerID := range reviewerIDs {
+		if err = addReviewer(ctx, tx, repository, prID, reviewerID); err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	const deleteQuery = `
+		DELETE FROM pending_reviewer_slots
+		WHERE repository = ? AND pull_request_id = ? AND slots <= ?
+	`
+
+	const updateQuery = `
+		UPDATE pending_reviewer_slots
+		SET slots = slots - ?
+		WHERE repository = ? AND pull_request_id = ?
+	`
+
+	if _, err = tx.ExecContext(ctx, deleteQuery, repository, prID, len(reviewerIDs)); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if _, err = tx.ExecContext(ctx, updateQuery, len(reviewerIDs), repository, prID); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// DropReviewerSlots removes the pending reviewer slots of the PR, if any.
+func (s *PRStorage) DropReviewerSlots(ctx context.Context, repository string, prID string) error {
+	const op = "storage.sqlite.DropReviewerSlots"
+
+	const query = "DELETE FROM pending_reviewer_slots WHERE repository = ? AND pull_request_id = ?"
+
+	if _, err := s.Db.ExecContext(ctx, query, repository, prID); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
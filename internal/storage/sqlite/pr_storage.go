@@ -0,0 +1,494 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/whitxowl/pr-reviewer-assignment-service.git/internal/domain"
+	storageErr "github.com/whitxowl/pr-reviewer-assignment-service.git/internal/storage/errors"
+	"github.com/whitxowl/pr-reviewer-assignment-service.git/pkg/sqlite"
+)
+
+type PRStorage struct {
+	Db *sql.DB
+}
+
+func NewPRStorage(db *sql.DB) *PRStorage {
+	return &PRStorage{
+		Db: db,
+	}
+}
+
+const (
+	statusMerged = "MERGED"
+)
+
+// querier is the part of *sql.DB and *sql.Tx used by the helpers below.
+type querier interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+func (s *PRStorage) GetPRsReviewedBy(ctx context.Context, userID string) ([]*domain.PullRequest, error) {
+	const op = "storage.sqlite.GetPRsReviewedBy"
+
+	const query = `
+		SELECT pr.repository, pr.pull_request_id, pr.pull_request_name, pr.author_id, pr.status
+		FROM pull_requests pr
+		JOIN pull_request_reviewers prr
+			ON pr.repository = prr.repository AND pr.pull_request_id = prr.pull_request_id
+		WHERE prr.user_id = ?
+	`
+
+	rows, err := s.Db.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var prs []*domain.PullRequest
+	for rows.Next() {
+		var pr domain.PullRequest
+		if err := rows.Scan(&pr.Repository, &pr.PullRequestID, &pr.PullRequestName, &pr.AuthorID, &pr.Status); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		prs = append(prs, &pr)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return prs, nil
+}
+
+func (s *PRStorage) CreatePR(
+	ctx context.Context,
+	repository string,
+	prID string,
+	prName string,
+	authorID string,
+	teamName string,
+) error {
+	const op = "storage.sqlite.CreatePR"
+
+	const query = `
+		INSERT INTO pull_requests (repository, pull_request_id, pull_request_name, author_id, team_name)
+		VALUES (?, ?, ?, ?, NULLIF(?, ''))
+	`
+
+	_, err := s.Db.ExecContext(ctx, query, repository, prID, prName, authorID, teamName)
+	if sqlite.IsUniqueViolationError(err) {
+		return fmt.Errorf("%s: %w", op, storageErr.ErrPRExists)
+	}
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (s *PRStorage) AssignReviewers(ctx context.Context, repository string, prID string, reviewersIDs []string) error {
+	const op = "storage.sqlite.AssignReviewers"
+
+	if len(reviewersIDs) == 0 {
+		return nil
+	}
+
+	tx, err := s.Db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback()
+
+	for _, reviewerID := range reviewersIDs {
+		if err = addReviewer(ctx, tx, repository, prID, reviewerID); err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (s *PRStorage) SetStatusMerged(ctx context.Context, repository string, prID string) (*domain.PullRequest, error) {
+	const op = "storage.sqlite.SetStatusMerged"
+
+	const query = `
+		UPDATE pull_requests
+		SET status = 'MERGED',
+			merged_at = COALESCE(merged_at, CURRENT_TIMESTAMP)
+		WHERE repository = ? AND pull_request_id = ?
+		RETURNING repository,
+				  pull_request_id,
+				  pull_request_name,
+				  author_id,
+				  status,
+				  merged_at
+	`
+
+	var pr domain.PullRequest
+	err := s.Db.QueryRowContext(ctx, query, repository, prID).Scan(
+		&pr.Repository,
+		&pr.PullRequestID,
+		&pr.PullRequestName,
+		&pr.AuthorID,
+		&pr.Status,
+		&pr.MergedAt,
+	)
+	if sqlite.IsNoRowsError(err) {
+		return nil, fmt.Errorf("%s: %w", op, storageErr.ErrPRNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	// Slots still pending on a merged PR will never be filled.
+	const dropPendingQuery = "DELETE FROM pending_reviewer_slots WHERE repository = ? AND pull_request_id = ?"
+
+	if _, err = s.Db.ExecContext(ctx, dropPendingQuery, repository, prID); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	reviewers, err := getReviewers(ctx, s.Db, repository, prID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	pr.AssignedReviewers = reviewers
+
+	return &pr, nil
+}
+
+func (s *PRStorage) GetPR(ctx context.Context, repository string, prID string) (*domain.PullRequest, error) {
+	const op = "storage.sqlite.GetPR"
+
+	const query = `
+		SELECT repository, pull_request_id, pull_request_name, author_id, COALESCE(team_name, ''), status, created_at, merged_at
+		FROM pull_requests
+		WHERE repository = ? AND pull_request_id = ?
+	`
+
+	var pr domain.PullRequest
+	err := s.Db.QueryRowContext(ctx, query, repository, prID).Scan(
+		&pr.Repository,
+		&pr.PullRequestID,
+		&pr.PullRequestName,
+		&pr.AuthorID,
+		&pr.TeamName,
+		&pr.Status,
+		&pr.CreatedAt,
+		&pr.MergedAt,
+	)
+	if sqlite.IsNoRowsError(err) {
+		return nil, fmt.Errorf("%s: %w", op, storageErr.ErrPRNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	reviewers, err := getReviewers(ctx, s.Db, repository, prID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	pr.AssignedReviewers = reviewers
+
+	return &pr, nil
+}
+
+// ReassignReviewer removes the old reviewer and in the same transaction assigns
+// the replacement with its rationale, if any, and queues the empty slot if
+// asked to. With dryRun the transaction is rolled back and the PR is returned as
+// it would have been.
+func (s *PRStorage) ReassignReviewer(
+	ctx context.Context,
+	repository string,
+	prID string,
+	reassignment domain.Reassignment,
+	dryRun bool,
+) (*domain.PullRequest, error) {
+	const op = "storage.sqlite.ReassignReviewer"
+
+	tx, err := s.Db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback()
+
+	if err := checkPRStatus(ctx, tx, repository, prID); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := removeReviewer(ctx, tx, repository, prID, reassignment.OldReviewerID); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if r := reassignment.Replacement; r != nil {
+		if err := addReviewer(ctx, tx, repository, prID, r.ReviewerID); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		if err := saveRationales(ctx, tx, repository, prID, []domain.AssignmentRationale{*r}); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	if reassignment.QueueSlot {
+		if _, err := tx.ExecContext(ctx, queueSlotsQuery, repository, prID, 1); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	pr, err := getPRWithReviewers(ctx, tx, repository, prID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if dryRun {
+		return pr, nil
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return pr, nil
+}
+
+func checkPRStatus(ctx context.Context, q querier, repository string, prID string) error {
+	const op = "storage.sqlite.checkPRStatus"
+
+	const query = "SELECT status FROM pull_requests WHERE repository = ? AND pull_request_id = ?"
+
+	var status string
+	err := q.QueryRowContext(ctx, query, repository, prID).Scan(&status)
+	if sqlite.IsNoRowsError(err) {
+		return fmt.Errorf("%s: %w", op, storageErr.ErrPRNotFound)
+	}
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if status == statusMerged {
+		return fmt.Errorf("%s: %w", op, storageErr.ErrPRMerged)
+	}
+
+	return nil
+}
+
+func removeReviewer(ctx context.Context, q querier, repository string, prID string, reviewerID string) error {
+	const op = "storage.sqlite.removeReviewer"
+
+	const query = "DELETE FROM pull_request_reviewers WHERE repository = ? AND pull_request_id = ? AND user_id = ?"
+
+	result, err := q.ExecContext(ctx, query, repository, prID, reviewerID)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if affected == 0 {
+		return fmt.Errorf("%s: %w", op, storageErr.ErrReviewerNotFound)
+	}
+
+	return nil
+}
+
+func addReviewer(ctx context.Context, q querier, repository string, prID string, reviewerID string) error {
+	const op = "storage.sqlite.addReviewer"
+
+	const query = "INSERT INTO pull_request_reviewers (repository, pull_request_id, user_id) VALUES (?, ?, ?)"
+
+	const historyQuery = `
+		INSERT INTO assignment_history (repository, pull_request_id, author_id, reviewer_id)
+		SELECT repository, pull_request_id, author_id, ?
+		FROM pull_requests
+		WHERE repository = ? AND pull_request_id = ?
+	`
+
+	_, err := q.ExecContext(ctx, query, repository, prID, reviewerID)
+	if sqlite.IsUniqueViolationError(err) {
+		return fmt.Errorf("%s: %w", op, storageErr.ErrReviewerAssigned)
+	}
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if _, err = q.ExecContext(ctx, historyQuery, reviewerID, repository, prID); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func getPRWithReviewers(ctx context.Context, q querier, repository string, prID string) (*domain.PullRequest, error) {
+	const op = "storage.sqlite.getPRWithReviewers"
+
+	const query = `
+		SELECT repository, pull_request_id, pull_request_name, author_id, status
+		FROM pull_requests
+		WHERE repository = ? AND pull_request_id = ?
+	`
+
+	var pr domain.PullRequest
+	err := q.QueryRowContext(ctx, query, repository, prID).Scan(
+		&pr.Repository,
+		&pr.PullRequestID,
+		&pr.PullRequestName,
+		&pr.AuthorID,
+		&pr.Status,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	reviewers, err := getReviewers(ctx, q, repository, prID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	pr.AssignedReviewers = reviewers
+
+	return &pr, nil
+}
+
+func getReviewers(ctx context.Context, q querier, repository string, prID string) ([]string, error) {
+	const op = "storage.sqlite.getReviewers"
+
+	const query = "SELECT user_id FROM pull_request_reviewers WHERE repository = ? AND pull_request_id = ?"
+
+	rows, err := q.QueryContext(ctx, query, repository, prID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var reviewers []string
+	for rows.Next() {
+		var userID string
+		if err := rows.Scan(&userID); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		reviewers = append(reviewers, userID)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return reviewers, nil
+}
+
+func (s *PRStorage) GetOpenPRsReviewedByTeam(ctx context.Context, teamName string) ([]domain.PRRef, error) {
+	const op = "storage.sqlite.GetOpenPRsReviewedByTeam"
+
+	const query = `
+		SELECT DISTINCT pr.repository, pr.pull_request_id
+		FROM pull_requests pr
+		JOIN pull_request_reviewers prr
+			ON pr.repository = prr.repository AND pr.pull_request_id = prr.pull_request_id
+		JOIN team_memberships m ON m.user_id = prr.user_id
+		WHERE m.team_name = ?
+		  AND pr.status = 'OPEN'
+		ORDER BY pr.repository, pr.pull_request_id
+	`
+
+	refs, err := queryPRRefs(ctx, s.Db, query, teamName)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return refs, nil
+}
+
+func (s *PRStorage) GetOpenPRsByReviewerAndTeam(ctx context.Context, reviewerID string, teamName string) ([]domain.PRRef, error) {
+	const op = "storage.sqlite.GetOpenPRsByReviewerAndTeam"
+
+	const query = `
+		SELECT pr.repository, pr.pull_request_id
+		FROM pull_requests pr
+		JOIN pull_request_reviewers prr
+			ON pr.repository = prr.repository AND pr.pull_request_id = prr.pull_request_id
+		WHERE prr.user_id = ?
+		  AND pr.team_name = ?
+		  AND pr.status = 'OPEN'
+		ORDER BY pr.repository, pr.pull_request_id
+	`
+
+	refs, err := queryPRRefs(ctx, s.Db, query, reviewerID, teamName)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return refs, nil
+}
+
+// GetPairings counts the assignments of reviewers to the PRs by the authors
+// within window, all assignments for a zero window, including those of reviewers
+// since replaced or removed. Pairings are ordered by author and reviewer.
+func (s *PRStorage) GetPairings(ctx context.Context, authorIDs []string, window time.Duration) ([]domain.Pairing, error) {
+	const op = "storage.sqlite.GetPairings"
+
+	if len(authorIDs) == 0 {
+		return nil, nil
+	}
+
+	query := `
+		SELECT author_id, reviewer_id, COUNT(*)
+		FROM assignment_history
+		WHERE author_id IN (` + placeholders(len(authorIDs)) + `)
+		  AND (? = 0 OR julianday(assigned_at) >= julianday('now') - ? / 86400.0)
+		GROUP BY author_id, reviewer_id
+		ORDER BY author_id, reviewer_id
+	`
+
+	seconds := int64(window.Seconds())
+	rows, err := s.Db.QueryContext(ctx, query, append(anySlice(authorIDs), seconds, seconds)...)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var pairings []domain.Pairing
+	for rows.Next() {
+		var pairing domain.Pairing
+		if err := rows.Scan(&pairing.AuthorID, &pairing.ReviewerID, &pairing.Count); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		pairings = append(pairings, pairing)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return pairings, nil
+}
+
+func queryPRRefs(ctx context.Context, q querier, query string, args ...any) ([]domain.PRRef, error) {
+	rows, err := q.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var refs []domain.PRRef
+	for rows.Next() {
+		var ref domain.PRRef
+		if err := rows.Scan(&ref.Repository, &ref.PullRequestID); err != nil {
+			return nil, err
+		}
+		refs = append(refs, ref)
+	}
+
+	return refs, rows.Err()
+}
@@ -0,0 +1,157 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/whitxowl/pr-reviewer-assignment-service.git/internal/domain"
+	storageErr "github.com/whitxowl/pr-reviewer-assignment-service.git/internal/storage/errors"
+	"github.com/whitxowl/pr-reviewer-assignment-service.git/pkg/sqlite"
+)
+
+// SaveAssignmentRationales stores why the reviewers were assigned to the PR,
+// replacing earlier rationales of the same reviewers. Every reviewer must be
+// assigned to the PR.
+func (s *PRStorage) SaveAssignmentRationales(
+	ctx context.Context,
+	repository string,
+	prID string,
+	rationales []domain.AssignmentRationale,
+) error {
+	const op = "storage.sqlite.SaveAssignmentRationales"
+
+	if len(rationales) == 0 {
+		return nil
+	}
+
+	tx, err := s.Db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback()
+
+	if err = saveRationales(ctx, tx, repository, prID, rationales); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func saveRationales(
+	ctx context.Context,
+	q querier,
+	repository string,
+	prID string,
+	rationales []domain.AssignmentRationale,
+) error {
+	const op = "storage.sqlite.saveRationales"
+
+	const deleteQuery = `
+		DELETE FROM assignment_rationales
+		WHERE repository = ? AND pull_request_id = ? AND reviewer_id = ?
+	`
+
+	const insertQuery = `
+		INSERT INTO assignment_rationales (repository, pull_request_id, reviewer_id, strategy, rule, pool_size)
+		VALUES (?, ?, ?, ?, ?, ?)
+	`
+
+	const insertExclusionQuery = `
+		INSERT INTO assignment_exclusions (repository, pull_request_id, reviewer_id, user_id, reason)
+		VALUES (?, ?, ?, ?, ?)
+	`
+
+	for _, r := range rationales {
+		if _, err := q.ExecContext(ctx, deleteQuery, repository, prID, r.ReviewerID); err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+
+		_, err := q.ExecContext(ctx, insertQuery, repository, prID, r.ReviewerID, r.Strategy, r.Rule, r.PoolSize)
+		if sqlite.IsForeignKeyErr(err) {
+			return fmt.Errorf("%s: %w", op, storageErr.ErrReviewerNotFound)
+		}
+		if err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+
+		for _, excluded := range r.Excluded {
+			_, err = q.ExecContext(ctx, insertExclusionQuery, repository, prID, r.ReviewerID, excluded.UserID, excluded.Reason)
+			if err != nil {
+				return fmt.Errorf("%s: %w", op, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// GetAssignmentRationales returns the rationales of the current reviewers of the
+// PR ordered by reviewer, with exclusions ordered by user.
+func (s *PRStorage) GetAssignmentRationales(
+	ctx context.Context,
+	repository string,
+	prID string,
+) ([]domain.AssignmentRationale, error) {
+	const op = "storage.sqlite.GetAssignmentRationales"
+
+	const query = `
+		SELECT reviewer_id, strategy, rule, pool_size
+		FROM assignment_rationales
+		WHERE repository = ? AND pull_request_id = ?
+		ORDER BY reviewer_id
+	`
+
+	rows, err := s.Db.QueryContext(ctx, query, repository, prID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var rationales []domain.AssignmentRationale
+	index := make(map[string]int)
+	for rows.Next() {
+		var r domain.AssignmentRationale
+		if err := rows.Scan(&r.ReviewerID, &r.Strategy, &r.Rule, &r.PoolSize); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		index[r.ReviewerID] = len(rationales)
+		rationales = append(rationales, r)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	const exclusionsQuery = `
+		SELECT reviewer_id, user_id, reason
+		FROM assignment_exclusions
+		WHERE repository = ? AND pull_request_id = ?
+		ORDER BY reviewer_id, user_id
+	`
+
+	exclusionRows, err := s.Db.QueryContext(ctx, exclusionsQuery, repository, prID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer exclusionRows.Close()
+
+	for exclusionRows.Next() {
+		var reviewerID string
+		var excluded domain.ExcludedCandidate
+		if err := exclusionRows.Scan(&reviewerID, &excluded.UserID, &excluded.Reason); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		r := &rationales[index[reviewerID]]
+		r.Excluded = append(r.Excluded, excluded)
+	}
+
+	if err := exclusionRows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return rationales, nil
+}
@@ -0,0 +1,230 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/whitxowl/pr-reviewer-assignment-service.git/internal/domain"
+	storageErr "github.com/whitxowl/pr-reviewer-assignment-service.git/internal/storage/errors"
+	"github.com/whitxowl/pr-reviewer-assignment-service.git/pkg/sqlite"
+)
+
+type RepositoryStorage struct {
+	Db *sql.DB
+}
+
+func NewRepositoryStorage(db *sql.DB) *RepositoryStorage {
+	return &RepositoryStorage{
+		Db: db,
+	}
+}
+
+func (s *RepositoryStorage) CreateRepository(ctx context.Context, repo *domain.Repository) error {
+	const op = "storage.sqlite.CreateRepository"
+
+	const query = `
+		INSERT INTO repositories (name, team_name, reviewers_count)
+		VALUES (?, NULLIF(?, ''), ?)
+	`
+
+	_, err := s.Db.ExecContext(ctx, query, repo.Name, repo.TeamName, repo.ReviewersCount)
+	if sqlite.IsUniqueViolationError(err) {
+		return fmt.Errorf("%s: %w", op, storageErr.ErrRepositoryExists)
+	}
+	if sqlite.IsForeignKeyErr(err) {
+		return fmt.Errorf("%s: %w", op, storageErr.ErrTeamNotFound)
+	}
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (s *RepositoryStorage) GetRepository(ctx context.Context, name string) (*domain.Repository, error) {
+	const op = "storage.sqlite.GetRepository"
+
+	const query = "SELECT name, COALESCE(team_name, ''), reviewers_count FROM repositories WHERE name = ?"
+
+	var repo domain.Repository
+	err := s.Db.QueryRowContext(ctx, query, name).Scan(&repo.Name, &repo.TeamName, &repo.ReviewersCount)
+	if sqlite.IsNoRowsError(err) {
+		return nil, fmt.Errorf("%s: %w", op, storageErr.ErrRepositoryNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &repo, nil
+}
+
+func (s *RepositoryStorage) UpdateRepository(ctx context.Context, repo *domain.Repository) error {
+	const op = "storage.sqlite.UpdateRepository"
+
+	const query = `
+		UPDATE repositories
+		SET team_name = NULLIF(?2, ''),
+		    reviewers_count = ?3
+		WHERE name = ?1
+	`
+
+	result, err := s.Db.ExecContext(ctx, query, repo.Name, repo.TeamName, repo.ReviewersCount)
+	if sqlite.IsForeignKeyErr(err) {
+		return fmt.Errorf("%s: %w", op, storageErr.ErrTeamNotFound)
+	}
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if affected == 0 {
+		return fmt.Errorf("%s: %w", op, storageErr.ErrRepositoryNotFound)
+	}
+
+	return nil
+}
+
+// SetCodeOwners replaces the code owner rules of the repository.
+func (s *RepositoryStorage) SetCodeOwners(ctx context.Context, repository string, rules []domain.CodeOwnerRule) error {
+	const op = "storage.sqlite.SetCodeOwners"
+
+	tx, err := s.Db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback()
+
+	const existsQuery = "SELECT EXISTS (SELECT 1 FROM repositories WHERE name = ?)"
+
+	var exists bool
+	if err = tx.QueryRowContext(ctx, existsQuery, repository).Scan(&exists); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if !exists {
+		return fmt.Errorf("%s: %w", op, storageErr.ErrRepositoryNotFound)
+	}
+
+	const userExistsQuery = "SELECT EXISTS (SELECT 1 FROM users WHERE user_id = ?)"
+
+	const teamExistsQuery = "SELECT EXISTS (SELECT 1 FROM teams WHERE team_name = ?)"
+
+	for _, rule := range rules {
+		for _, userID := range rule.UserIDs {
+			if err = tx.QueryRowContext(ctx, userExistsQuery, userID).Scan(&exists); err != nil {
+				return fmt.Errorf("%s: %w", op, err)
+			}
+			if !exists {
+				return fmt.Errorf("%s: user %q: %w", op, userID, storageErr.ErrUserNotFound)
+			}
+		}
+		for _, teamName := range rule.TeamNames {
+			if err = tx.QueryRowContext(ctx, teamExistsQuery, teamName).Scan(&exists); err != nil {
+				return fmt.Errorf("%s: %w", op, err)
+			}
+			if !exists {
+				return fmt.Errorf("%s: team %q: %w", op, teamName, storageErr.ErrTeamNotFound)
+			}
+		}
+	}
+
+	const deleteQuery = "DELETE FROM code_owner_rules WHERE repository = ?"
+
+	const insertRuleQuery = "INSERT INTO code_owner_rules (repository, position, pattern) VALUES (?, ?, ?)"
+
+	const insertUserQuery = "INSERT INTO code_owners (repository, position, user_id) VALUES (?, ?, ?)"
+
+	const insertTeamQuery = "INSERT INTO code_owners (repository, position, team_name) VALUES (?, ?, ?)"
+
+	if _, err = tx.ExecContext(ctx, deleteQuery, repository); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	for position, rule := range rules {
+		if _, err = tx.ExecContext(ctx, insertRuleQuery, repository, position, rule.Pattern); err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+		for _, userID := range rule.UserIDs {
+			if _, err = tx.ExecContext(ctx, insertUserQuery, repository, position, userID); err != nil {
+				return fmt.Errorf("%s: %w", op, err)
+			}
+		}
+		for _, teamName := range rule.TeamNames {
+			if _, err = tx.ExecContext(ctx, insertTeamQuery, repository, position, teamName); err != nil {
+				return fmt.Errorf("%s: %w", op, err)
+			}
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// GetCodeOwners returns the code owner rules of the repository in order.
+// Owners of a rule are sorted.
+func (s *RepositoryStorage) GetCodeOwners(ctx context.Context, repository string) ([]domain.CodeOwnerRule, error) {
+	const op = "storage.sqlite.GetCodeOwners"
+
+	const rulesQuery = "SELECT position, pattern FROM code_owner_rules WHERE repository = ? ORDER BY position"
+
+	const ownersQuery = `
+		SELECT position, COALESCE(user_id, ''), COALESCE(team_name, '')
+		FROM code_owners
+		WHERE repository = ?
+		ORDER BY position, user_id, team_name
+	`
+
+	rows, err := s.Db.QueryContext(ctx, rulesQuery, repository)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var rules []domain.CodeOwnerRule
+	index := make(map[int]int)
+	for rows.Next() {
+		var position int
+		rule := domain.CodeOwnerRule{UserIDs: []string{}, TeamNames: []string{}}
+		if err := rows.Scan(&position, &rule.Pattern); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		index[position] = len(rules)
+		rules = append(rules, rule)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	ownerRows, err := s.Db.QueryContext(ctx, ownersQuery, repository)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer ownerRows.Close()
+
+	for ownerRows.Next() {
+		var position int
+		var userID, teamName string
+		if err := ownerRows.Scan(&position, &userID, &teamName); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		rule := &rules[index[position]]
+		if userID != "" {
+			rule.UserIDs = append(rule.UserIDs, userID)
+		} else {
+			rule.TeamNames = append(rule.TeamNames, teamName)
+		}
+	}
+
+	if err := ownerRows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return rules, nil
+}
@@ -0,0 +1,91 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+
+	storageErr "github.com/whitxowl/pr-reviewer-assignment-service.git/internal/storage/errors"
+	"github.com/whitxowl/pr-reviewer-assignment-service.git/pkg/sqlite"
+)
+
+// SetRequiredTags replaces the skill tags the PR requires of its reviewers.
+func (s *PRStorage) SetRequiredTags(ctx context.Context, repository string, prID string, tags []string) error {
+	const op = "storage.sqlite.SetRequiredTags"
+
+	tx, err := s.Db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback()
+
+	const clearQuery = "DELETE FROM pull_request_required_tags WHERE repository = ? AND pull_request_id = ?"
+
+	if _, err = tx.ExecContext(ctx, clearQuery, repository, prID); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	for _, tag := range tags {
+		err = addRequiredTag(ctx, tx, repository, prID, tag)
+		if sqlite.IsForeignKeyErr(err) {
+			return fmt.Errorf("%s: %w", op, storageErr.ErrPRNotFound)
+		}
+		if err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// GetRequiredTags returns the sorted skill tags the PR requires of its reviewers.
+func (s *PRStorage) GetRequiredTags(ctx context.Context, repository string, prID string) ([]string, error) {
+	const op = "storage.sqlite.GetRequiredTags"
+
+	const query = `
+		SELECT tag
+		FROM pull_request_required_tags
+		WHERE repository = ? AND pull_request_id = ?
+		ORDER BY tag
+	`
+
+	rows, err := s.Db.QueryContext(ctx, query, repository, prID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var tags []string
+	for rows.Next() {
+		var tag string
+		if err := rows.Scan(&tag); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		tags = append(tags, tag)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return tags, nil
+}
+
+func addRequiredTag(ctx context.Context, q querier, repository string, prID string, tag string) error {
+	const op = "storage.sqlite.addRequiredTag"
+
+	const query = `
+		INSERT INTO pull_request_required_tags (repository, pull_request_id, tag)
+		VALUES (?, ?, ?)
+		ON CONFLICT DO NOTHING
+	`
+
+	if _, err := q.ExecContext(ctx, query, repository, prID, tag); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
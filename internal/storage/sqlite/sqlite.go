@@ -0,0 +1,24 @@
+package sqlite
+
+import "strings"
+
+// placeholders returns "?, ?, ..." with n placeholders for an IN list.
+func placeholders(n int) string {
+	return strings.TrimSuffix(strings.Repeat("?, ", n), ", ")
+}
+
+func anySlice(values []string) []any {
+	args := make([]any, len(values))
+	for i, v := range values {
+		args[i] = v
+	}
+	return args
+}
+
+// likePrefix returns a lower-cased LIKE pattern matching strings that start with prefix.
+// Queries using it must declare ESCAPE '\'.
+func likePrefix(prefix string) string {
+	return likeEscaper.Replace(strings.ToLower(prefix)) + "%"
+}
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
@@ -0,0 +1,51 @@
+package sqlite
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// tagsColumn selects the sorted tags of users u as a JSON array, NULL for a
+// user without tags, scanned by tagList.
+const tagsColumn = "(SELECT json_group_array(t.tag ORDER BY t.tag) FROM user_tags t WHERE t.user_id = u.user_id HAVING COUNT(*) > 0)"
+
+// tagList scans a JSON array of tags; NULL leaves it nil.
+type tagList []string
+
+func (l *tagList) Scan(src any) error {
+	var raw []byte
+	switch v := src.(type) {
+	case nil:
+		*l = nil
+		return nil
+	case string:
+		raw = []byte(v)
+	case []byte:
+		raw = v
+	default:
+		return fmt.Errorf("tags: unsupported type %T", src)
+	}
+
+	return json.Unmarshal(raw, (*[]string)(l))
+}
+
+// setTags replaces the tags of the user.
+func setTags(ctx context.Context, q querier, userID string, tags []string) error {
+	const op = "storage.sqlite.setTags"
+
+	const clearQuery = "DELETE FROM user_tags WHERE user_id = ?"
+
+	const insertQuery = "INSERT INTO user_tags (user_id, tag) VALUES (?, ?) ON CONFLICT DO NOTHING"
+
+	if _, err := q.ExecContext(ctx, clearQuery, userID); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	for _, tag := range tags {
+		if _, err := q.ExecContext(ctx, insertQuery, userID, tag); err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	return nil
+}
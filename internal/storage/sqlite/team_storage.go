@@ -0,0 +1,413 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/whitxowl/pr-reviewer-assignment-service.git/internal/domain"
+	storageErr "github.com/whitxowl/pr-reviewer-assignment-service.git/internal/storage/errors"
+	"github.com/whitxowl/pr-reviewer-assignment-service.git/pkg/sqlite"
+)
+
+type TeamStorage struct {
+	Db *sql.DB
+}
+
+func NewTeamStorage(db *sql.DB) *TeamStorage {
+	return &TeamStorage{
+		Db: db,
+	}
+}
+
+func (s *TeamStorage) CreateTeam(ctx context.Context, teamName string) error {
+	const op = "storage.sqlite.CreateTeam"
+
+	const query = "INSERT INTO teams(team_name) VALUES (?)"
+
+	_, err := s.Db.ExecContext(ctx, query, teamName)
+	if sqlite.IsUniqueViolationError(err) {
+		return fmt.Errorf("%s: %w", op, storageErr.ErrTeamExists)
+	}
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (s *TeamStorage) TeamExists(ctx context.Context, teamName string) (bool, error) {
+	const op = "storage.sqlite.TeamExists"
+
+	const query = "SELECT EXISTS(SELECT 1 FROM teams WHERE team_name = ?)"
+
+	var exists bool
+	err := s.Db.QueryRowContext(ctx, query, teamName).Scan(&exists)
+	if err != nil {
+		return false, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return exists, nil
+}
+
+// GetTeam returns the team with its seniority rules, without members.
+func (s *TeamStorage) GetTeam(ctx context.Context, teamName string) (*domain.Team, error) {
+	const op = "storage.sqlite.GetTeam"
+
+	const query = "SELECT team_name, archived_at, max_open_reviews FROM teams WHERE team_name = ?"
+
+	var team domain.Team
+	err := s.Db.QueryRowContext(ctx, query, teamName).Scan(&team.TeamName, &team.ArchivedAt, &team.MaxOpenReviews)
+	if sqlite.IsNoRowsError(err) {
+		return nil, fmt.Errorf("%s: %w", op, storageErr.ErrTeamNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	team.SeniorityRules, err = s.GetSeniorityRules(ctx, teamName)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &team, nil
+}
+
+// GetSeniorityRules returns the seniority rules of the team, none for a team
+// that does not exist.
+func (s *TeamStorage) GetSeniorityRules(ctx context.Context, teamName string) ([]domain.SeniorityRule, error) {
+	const op = "storage.sqlite.GetSeniorityRules"
+
+	const query = `
+		SELECT author_level, min_level, min_reviewers
+		FROM team_seniority_rules
+		WHERE team_name = ?
+		ORDER BY ` + levelOrder + `
+	`
+
+	rows, err := s.Db.QueryContext(ctx, query, teamName)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var rules []domain.SeniorityRule
+	for rows.Next() {
+		var rule domain.SeniorityRule
+		if err := rows.Scan(&rule.AuthorLevel, &rule.MinLevel, &rule.MinReviewers); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		rules = append(rules, rule)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return rules, nil
+}
+
+// SetSeniorityRules replaces the seniority rules of the team.
+func (s *TeamStorage) SetSeniorityRules(ctx context.Context, teamName string, rules []domain.SeniorityRule) error {
+	const op = "storage.sqlite.SetSeniorityRules"
+
+	tx, err := s.Db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback()
+
+	const existsQuery = "SELECT EXISTS (SELECT 1 FROM teams WHERE team_name = ?)"
+
+	var exists bool
+	if err = tx.QueryRowContext(ctx, existsQuery, teamName).Scan(&exists); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if !exists {
+		return fmt.Errorf("%s: %w", op, storageErr.ErrTeamNotFound)
+	}
+
+	const deleteQuery = "DELETE FROM team_seniority_rules WHERE team_name = ?"
+
+	const insertQuery = `
+		INSERT INTO team_seniority_rules (team_name, author_level, min_level, min_reviewers)
+		VALUES (?, ?, ?, ?)
+	`
+
+	if _, err = tx.ExecContext(ctx, deleteQuery, teamName); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	for _, rule := range rules {
+		if _, err = tx.ExecContext(ctx, insertQuery, teamName, rule.AuthorLevel, rule.MinLevel, rule.MinReviewers); err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// levelOrder orders seniority rules by author level, then by reviewer level.
+const levelOrder = `
+	CASE author_level WHEN 'junior' THEN 0 WHEN 'middle' THEN 1 ELSE 2 END,
+	CASE min_level WHEN 'junior' THEN 0 WHEN 'middle' THEN 1 ELSE 2 END
+`
+
+func (s *TeamStorage) RenameTeam(ctx context.Context, teamName string, newTeamName string) error {
+	const op = "storage.sqlite.RenameTeam"
+
+	tx, err := s.Db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback()
+
+	const insertQuery = `
+		INSERT INTO teams (team_name, archived_at, max_open_reviews)
+		SELECT ?2, archived_at, max_open_reviews FROM teams WHERE team_name = ?1
+	`
+
+	result, err := tx.ExecContext(ctx, insertQuery, teamName, newTeamName)
+	if sqlite.IsUniqueViolationError(err) {
+		return fmt.Errorf("%s: %w", op, storageErr.ErrTeamExists)
+	}
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if n, err := result.RowsAffected(); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	} else if n == 0 {
+		return fmt.Errorf("%s: %w", op, storageErr.ErrTeamNotFound)
+	}
+
+	moveQueries := []string{
+		"UPDATE users SET team_name = ?2 WHERE team_name = ?1",
+		"UPDATE team_memberships SET team_name = ?2 WHERE team_name = ?1",
+		"UPDATE pull_requests SET team_name = ?2 WHERE team_name = ?1",
+		"UPDATE repositories SET team_name = ?2 WHERE team_name = ?1",
+		"UPDATE code_owners SET team_name = ?2 WHERE team_name = ?1",
+		"UPDATE team_seniority_rules SET team_name = ?2 WHERE team_name = ?1",
+	}
+
+	for _, query := range moveQueries {
+		if _, err = tx.ExecContext(ctx, query, teamName, newTeamName); err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	const deleteQuery = "DELETE FROM teams WHERE team_name = ?"
+
+	if _, err = tx.ExecContext(ctx, deleteQuery, teamName); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (s *TeamStorage) SetArchived(ctx context.Context, teamName string, archived bool) error {
+	const op = "storage.sqlite.SetArchived"
+
+	const query = `
+		UPDATE teams
+		SET archived_at = CASE WHEN ?2 THEN COALESCE(archived_at, CURRENT_TIMESTAMP) END
+		WHERE team_name = ?1
+	`
+
+	result, err := s.Db.ExecContext(ctx, query, teamName, archived)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if n, err := result.RowsAffected(); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	} else if n == 0 {
+		return fmt.Errorf("%s: %w", op, storageErr.ErrTeamNotFound)
+	}
+
+	return nil
+}
+
+// SetMaxOpenReviews sets the default review limit of the team's members; nil removes it.
+func (s *TeamStorage) SetMaxOpenReviews(ctx context.Context, teamName string, limit *int) error {
+	const op = "storage.sqlite.SetMaxOpenReviews"
+
+	const query = "UPDATE teams SET max_open_reviews = ? WHERE team_name = ?"
+
+	result, err := s.Db.ExecContext(ctx, query, limit, teamName)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if n, err := result.RowsAffected(); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	} else if n == 0 {
+		return fmt.Errorf("%s: %w", op, storageErr.ErrTeamNotFound)
+	}
+
+	return nil
+}
+
+func (s *TeamStorage) DeleteTeam(ctx context.Context, teamName string) error {
+	const op = "storage.sqlite.DeleteTeam"
+
+	const query = `
+		DELETE FROM teams
+		WHERE team_name = ?1
+		  AND NOT EXISTS (SELECT 1 FROM team_memberships WHERE team_name = ?1)
+	`
+
+	result, err := s.Db.ExecContext(ctx, query, teamName)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if n, err := result.RowsAffected(); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	} else if n > 0 {
+		return nil
+	}
+
+	exists, err := s.TeamExists(ctx, teamName)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if !exists {
+		return fmt.Errorf("%s: %w", op, storageErr.ErrTeamNotFound)
+	}
+
+	return fmt.Errorf("%s: %w", op, storageErr.ErrTeamNotEmpty)
+}
+
+func (s *TeamStorage) ApplyTeamDiff(ctx context.Context, diff *domain.TeamDiff) error {
+	const op = "storage.sqlite.ApplyTeamDiff"
+
+	tx, err := s.Db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback()
+
+	if err = applyTeamDiff(ctx, tx, diff); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// SyncTeam makes the team's roster equal to members, creating the team if needed,
+// and returns the diff it applied; see domain.NewTeamDiff. The roster is read and
+// the diff applied in one transaction. With dryRun nothing is written and the
+// diff is only returned.
+func (s *TeamStorage) SyncTeam(
+	ctx context.Context,
+	teamName string,
+	members []*domain.User,
+	detach bool,
+	dryRun bool,
+) (*domain.TeamDiff, error) {
+	const op = "storage.sqlite.SyncTeam"
+
+	tx, err := s.Db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback()
+
+	const existsQuery = "SELECT EXISTS(SELECT 1 FROM teams WHERE team_name = ?)"
+
+	var exists bool
+	if err = tx.QueryRowContext(ctx, existsQuery, teamName).Scan(&exists); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	var current []*domain.User
+	if exists {
+		current, err = teamRoster(ctx, tx, teamName)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	diff := domain.NewTeamDiff(teamName, current, members, detach)
+	diff.TeamCreated = !exists
+
+	if dryRun || diff.Empty() {
+		return diff, nil
+	}
+
+	if err = applyTeamDiff(ctx, tx, diff); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return diff, nil
+}
+
+// applyTeamDiff applies diff with q.
+func applyTeamDiff(ctx context.Context, q querier, diff *domain.TeamDiff) error {
+	const createTeamQuery = "INSERT INTO teams(team_name) VALUES (?) ON CONFLICT (team_name) DO NOTHING"
+
+	const upsertUserQuery = `
+		INSERT INTO users (user_id, username, team_name, is_active)
+			VALUES (?, ?, ?, ?)
+			ON CONFLICT (user_id)
+			DO UPDATE SET
+				username = excluded.username,
+				team_name = COALESCE(users.team_name, excluded.team_name),
+				is_active = excluded.is_active
+	`
+
+	const deactivateQuery = `
+		UPDATE users SET is_active = FALSE
+		WHERE user_id = ?1
+		  AND EXISTS (SELECT 1 FROM team_memberships WHERE user_id = ?1 AND team_name = ?2)
+	`
+
+	if diff.TeamCreated {
+		if _, err := q.ExecContext(ctx, createTeamQuery, diff.TeamName); err != nil {
+			return err
+		}
+	}
+	for _, users := range [][]*domain.User{diff.Added, diff.Updated} {
+		for _, user := range users {
+			_, err := q.ExecContext(ctx, upsertUserQuery, user.UserID, user.Username, diff.TeamName, user.IsActive)
+			if err != nil {
+				return err
+			}
+			if err = addMembership(ctx, q, user.UserID, diff.TeamName); err != nil {
+				return err
+			}
+			if user.Tags != nil {
+				if err = setTags(ctx, q, user.UserID, user.Tags); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	for _, user := range diff.Deactivated {
+		if _, err := q.ExecContext(ctx, deactivateQuery, user.UserID, diff.TeamName); err != nil {
+			return err
+		}
+	}
+	for _, user := range diff.Detached {
+		err := removeMembership(ctx, q, user.UserID, diff.TeamName)
+		if err != nil && !errors.Is(err, storageErr.ErrUserNotFound) {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,618 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/whitxowl/pr-reviewer-assignment-service.git/internal/domain"
+	storageErr "github.com/whitxowl/pr-reviewer-assignment-service.git/internal/storage/errors"
+	"github.com/whitxowl/pr-reviewer-assignment-service.git/pkg/sqlite"
+)
+
+type UserStorage struct {
+	Db *sql.DB
+}
+
+func NewUserStorage(db *sql.DB) *UserStorage {
+	return &UserStorage{
+		Db: db,
+	}
+}
+
+// UpsertUsers creates or updates the users and makes each a member of its TeamName.
+// An existing user keeps its primary team; a user without one gets TeamName as primary.
+// Tags replace the user's tags unless nil.
+func (s *UserStorage) UpsertUsers(ctx context.Context, users []*domain.User) error {
+	const op = "storage.sqlite.UpsertUsers"
+
+	tx, err := s.Db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback()
+
+	const query = `
+		INSERT INTO users (user_id, username, team_name, is_active)
+			VALUES (?, ?, ?, ?)
+			ON CONFLICT (user_id)
+			DO UPDATE SET
+				username = excluded.username,
+				team_name = COALESCE(users.team_name, excluded.team_name),
+				is_active = excluded.is_active
+	`
+
+	for _, member := range users {
+		_, err = tx.ExecContext(ctx, query, member.UserID, member.Username, member.TeamName, member.IsActive)
+		if err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+		if err = addMembership(ctx, tx, member.UserID, member.TeamName); err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+		if member.Tags != nil {
+			if err = setTags(ctx, tx, member.UserID, member.Tags); err != nil {
+				return fmt.Errorf("%s: %w", op, err)
+			}
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (s *UserStorage) GetUsersByTeamName(ctx context.Context, teamName string) ([]*domain.User, error) {
+	const op = "storage.sqlite.GetUsersByTeamName"
+
+	users, err := teamRoster(ctx, s.Db, teamName)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return users, nil
+}
+
+// teamRoster returns the members of the team.
+func teamRoster(ctx context.Context, q querier, teamName string) ([]*domain.User, error) {
+	const query = `
+		SELECT u.user_id, u.username, m.team_name, u.is_active, ` + tagsColumn + `, COALESCE(u.level, '')
+		FROM team_memberships m
+		JOIN users u ON u.user_id = m.user_id
+		WHERE m.team_name = ?
+		ORDER BY u.user_id
+	`
+
+	rows, err := q.QueryContext(ctx, query, teamName)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var users []*domain.User
+	for rows.Next() {
+		var user domain.User
+		err := rows.Scan(
+			&user.UserID,
+			&user.Username,
+			&user.TeamName,
+			&user.IsActive,
+			(*tagList)(&user.Tags),
+			&user.Level,
+		)
+		if err != nil {
+			return nil, err
+		}
+		users = append(users, &user)
+	}
+
+	return users, rows.Err()
+}
+
+func (s *UserStorage) SetIsActive(ctx context.Context, userID string, isActive bool) (*domain.User, error) {
+	const op = "storage.sqlite.SetIsActive"
+
+	const query = "UPDATE users SET is_active = ? WHERE user_id = ? RETURNING user_id, username, COALESCE(team_name, ''), is_active"
+
+	var user domain.User
+
+	err := s.Db.QueryRowContext(ctx, query, isActive, userID).Scan(
+		&user.UserID,
+		&user.Username,
+		&user.TeamName,
+		&user.IsActive,
+	)
+	if sqlite.IsNoRowsError(err) {
+		return nil, fmt.Errorf("%s: %w", op, storageErr.ErrUserNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &user, nil
+}
+
+func (s *UserStorage) SetUsername(ctx context.Context, userID string, username string) (*domain.User, error) {
+	const op = "storage.sqlite.SetUsername"
+
+	const query = "UPDATE users SET username = ? WHERE user_id = ? RETURNING user_id, username, COALESCE(team_name, ''), is_active"
+
+	var user domain.User
+
+	err := s.Db.QueryRowContext(ctx, query, username, userID).Scan(
+		&user.UserID,
+		&user.Username,
+		&user.TeamName,
+		&user.IsActive,
+	)
+	if sqlite.IsNoRowsError(err) {
+		return nil, fmt.Errorf("%s: %w", op, storageErr.ErrUserNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &user, nil
+}
+
+// SetMaxOpenReviews sets the user's own review limit; nil restores the team default.
+func (s *UserStorage) SetMaxOpenReviews(ctx context.Context, userID string, limit *int) (*domain.User, error) {
+	const op = "storage.sqlite.SetMaxOpenReviews"
+
+	const query = `
+		UPDATE users SET max_open_reviews = ? WHERE user_id = ?
+		RETURNING user_id, username, COALESCE(team_name, ''), is_active, max_open_reviews
+	`
+
+	var user domain.User
+
+	err := s.Db.QueryRowContext(ctx, query, limit, userID).Scan(
+		&user.UserID,
+		&user.Username,
+		&user.TeamName,
+		&user.IsActive,
+		&user.MaxOpenReviews,
+	)
+	if sqlite.IsNoRowsError(err) {
+		return nil, fmt.Errorf("%s: %w", op, storageErr.ErrUserNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &user, nil
+}
+
+// SetLevel sets the user's seniority; an empty level clears it.
+func (s *UserStorage) SetLevel(ctx context.Context, userID string, level string) (*domain.User, error) {
+	const op = "storage.sqlite.SetLevel"
+
+	const query = `
+		UPDATE users SET level = NULLIF(?, '') WHERE user_id = ?
+		RETURNING user_id, username, COALESCE(team_name, ''), is_active, COALESCE(level, '')
+	`
+
+	var user domain.User
+
+	err := s.Db.QueryRowContext(ctx, query, level, userID).Scan(
+		&user.UserID,
+		&user.Username,
+		&user.TeamName,
+		&user.IsActive,
+		&user.Level,
+	)
+	if sqlite.IsNoRowsError(err) {
+		return nil, fmt.Errorf("%s: %w", op, storageErr.ErrUserNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &user, nil
+}
+
+// GetReviewerPool returns all members of the team ordered by user ID, inactive
+// ones included, so that the caller can tell why a member is not eligible. An
+// archived team has no pool.
+func (s *UserStorage) GetReviewerPool(ctx context.Context, teamName string) ([]*domain.User, error) {
+	const op = "storage.sqlite.GetReviewerPool"
+
+	const query = `
+		SELECT ` + poolColumns + `
+		FROM team_memberships m
+		JOIN users u ON u.user_id = m.user_id
+		JOIN teams t ON t.team_name = m.team_name
+		WHERE m.team_name = ?
+		  AND t.archived_at IS NULL
+		ORDER BY u.user_id
+	`
+
+	users, err := s.queryPool(ctx, query, teamName)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return users, nil
+}
+
+// GetCodeOwnerPool returns, ordered by user ID, the users owning code through
+// userIDs directly or as members of an unarchived team of teamNames, inactive ones
+// included.
+func (s *UserStorage) GetCodeOwnerPool(ctx context.Context, userIDs []string, teamNames []string) ([]*domain.User, error) {
+	const op = "storage.sqlite.GetCodeOwnerPool"
+
+	query := `
+		SELECT ` + poolColumns + `
+		FROM users u
+		WHERE u.user_id IN (` + placeholders(len(userIDs)) + `) OR EXISTS (
+			SELECT 1
+			FROM team_memberships m
+			JOIN teams t ON t.team_name = m.team_name
+			WHERE m.user_id = u.user_id
+			  AND m.team_name IN (` + placeholders(len(teamNames)) + `)
+			  AND t.archived_at IS NULL
+		)
+		ORDER BY u.user_id
+	`
+
+	users, err := s.queryPool(ctx, query, append(anySlice(userIDs), anySlice(teamNames)...)...)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return users, nil
+}
+
+// poolColumns are the pool columns of users u scanned by queryPool: the open
+// reviews, the effective review limit, whether the user is absent, the tags and
+// the level follow the user's columns, then the user's schedule.
+const poolColumns = `
+	u.user_id, u.username, u.is_active,
+	(SELECT COUNT(*)
+	 FROM pull_request_reviewers r
+	 JOIN pull_requests p ON p.repository = r.repository AND p.pull_request_id = r.pull_request_id
+	 WHERE r.user_id = u.user_id AND p.status = 'OPEN'),
+	COALESCE(u.max_open_reviews, (SELECT t.max_open_reviews FROM teams t WHERE t.team_name = u.team_name)),
+	EXISTS (SELECT 1 FROM user_absences a WHERE a.user_id = u.user_id AND julianday(a.starts_at) <= julianday('now') AND julianday(a.ends_at) > julianday('now')),
+	` + tagsColumn + `,
+	COALESCE(u.level, ''),
+	u.timezone, u.work_start, u.work_end, u.work_days
+`
+
+func (s *UserStorage) queryPool(ctx context.Context, query string, args ...any) ([]*domain.User, error) {
+	rows, err := s.Db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var users []*domain.User
+	for rows.Next() {
+		var user domain.User
+		var sched schedule
+		err := rows.Scan(append([]any{
+			&user.UserID,
+			&user.Username,
+			&user.IsActive,
+			&user.OpenReviews,
+			&user.ReviewLimit,
+			&user.Absent,
+			(*tagList)(&user.Tags),
+			&user.Level,
+		}, sched.dest()...)...)
+		if err != nil {
+			return nil, err
+		}
+		sched.apply(&user)
+		users = append(users, &user)
+	}
+
+	return users, rows.Err()
+}
+
+func (s *UserStorage) GetUser(ctx context.Context, userID string) (*domain.User, error) {
+	const op = "storage.sqlite.GetUser"
+
+	const query = `
+		SELECT u.user_id, u.username, COALESCE(u.team_name, ''), u.is_active, u.max_open_reviews,
+		       ` + tagsColumn + `,
+		       COALESCE(u.level, ''),
+		       u.timezone, u.work_start, u.work_end, u.work_days
+		FROM users u
+		WHERE u.user_id = ?
+	`
+
+	var user domain.User
+	var sched schedule
+	err := s.Db.QueryRowContext(ctx, query, userID).Scan(append([]any{
+		&user.UserID,
+		&user.Username,
+		&user.TeamName,
+		&user.IsActive,
+		&user.MaxOpenReviews,
+		(*tagList)(&user.Tags),
+		&user.Level,
+	}, sched.dest()...)...)
+	if sqlite.IsNoRowsError(err) {
+		return nil, fmt.Errorf("%s: %w", op, storageErr.ErrUserNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	sched.apply(&user)
+
+	teamNames, err := teamNamesOf(ctx, s.Db, []string{userID})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	user.TeamNames = teamNames[userID]
+
+	return &user, nil
+}
+
+func (s *UserStorage) GetUsersByIDs(ctx context.Context, userIDs []string) ([]*domain.User, error) {
+	const op = "storage.sqlite.GetUsersByIDs"
+
+	if len(userIDs) == 0 {
+		return nil, nil
+	}
+
+	query := `
+		SELECT user_id, username, COALESCE(team_name, ''), is_active
+		FROM users
+		WHERE user_id IN (` + placeholders(len(userIDs)) + `)
+		ORDER BY user_id
+	`
+
+	rows, err := s.Db.QueryContext(ctx, query, anySlice(userIDs)...)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var users []*domain.User
+	for rows.Next() {
+		var user domain.User
+		err := rows.Scan(&user.UserID, &user.Username, &user.TeamName, &user.IsActive)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		users = append(users, &user)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return users, nil
+}
+
+// SearchUsers returns one page of the users matching search, ordered by user_id,
+// with all of their teams as TeamNames, and the number of matching users.
+func (s *UserStorage) SearchUsers(ctx context.Context, search domain.UserSearch) ([]*domain.User, int, error) {
+	const op = "storage.sqlite.SearchUsers"
+
+	const where = `
+		WHERE (?1 = '' OR lower(u.username) LIKE ?1 ESCAPE '\' OR lower(u.user_id) LIKE ?1 ESCAPE '\')
+		  AND (?2 = '' OR EXISTS (
+			SELECT 1 FROM team_memberships m WHERE m.user_id = u.user_id AND m.team_name = ?2
+		  ))
+		  AND (?3 IS NULL OR u.is_active = ?3)
+	`
+
+	const countQuery = "SELECT COUNT(*) FROM users u" + where
+
+	const query = `
+		SELECT u.user_id, u.username, COALESCE(u.team_name, ''), u.is_active
+		FROM users u
+	` + where + `
+		ORDER BY u.user_id
+		LIMIT ?4 OFFSET ?5
+	`
+
+	pattern := ""
+	if search.Prefix != "" {
+		pattern = likePrefix(search.Prefix)
+	}
+
+	var isActive any
+	if search.IsActive != nil {
+		isActive = *search.IsActive
+	}
+
+	var total int
+	err := s.Db.QueryRowContext(ctx, countQuery, pattern, search.TeamName, isActive).Scan(&total)
+	if err != nil {
+		return nil, 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	rows, err := s.Db.QueryContext(ctx, query, pattern, search.TeamName, isActive, search.Limit, search.Offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	users := []*domain.User{}
+	userIDs := []string{}
+	for rows.Next() {
+		var user domain.User
+		err := rows.Scan(&user.UserID, &user.Username, &user.TeamName, &user.IsActive)
+		if err != nil {
+			return nil, 0, fmt.Errorf("%s: %w", op, err)
+		}
+		users = append(users, &user)
+		userIDs = append(userIDs, user.UserID)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	teamNames, err := teamNamesOf(ctx, s.Db, userIDs)
+	if err != nil {
+		return nil, 0, fmt.Errorf("%s: %w", op, err)
+	}
+	for _, user := range users {
+		user.TeamNames = teamNames[user.UserID]
+	}
+
+	return users, total, nil
+}
+
+func (s *UserStorage) RemoveMemberships(ctx context.Context, teamName string, userIDs []string) error {
+	const op = "storage.sqlite.RemoveMemberships"
+
+	if len(userIDs) == 0 {
+		return nil
+	}
+
+	tx, err := s.Db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback()
+
+	for _, userID := range userIDs {
+		if err = removeMembership(ctx, tx, userID, teamName); err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (s *UserStorage) MoveMembership(ctx context.Context, userID string, fromTeamName string, toTeamName string) error {
+	const op = "storage.sqlite.MoveMembership"
+
+	tx, err := s.Db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback()
+
+	const primaryQuery = `
+		UPDATE users
+		SET team_name = CASE WHEN team_name IS NULL OR team_name = ?2 THEN ?3 ELSE team_name END
+		WHERE user_id = ?1
+	`
+
+	result, err := tx.ExecContext(ctx, primaryQuery, userID, fromTeamName, toTeamName)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if n, err := result.RowsAffected(); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	} else if n == 0 {
+		return fmt.Errorf("%s: %w", op, storageErr.ErrUserNotFound)
+	}
+
+	if fromTeamName != "" {
+		const deleteQuery = "DELETE FROM team_memberships WHERE user_id = ? AND team_name = ?"
+
+		result, err = tx.ExecContext(ctx, deleteQuery, userID, fromTeamName)
+		if err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+		if n, err := result.RowsAffected(); err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		} else if n == 0 {
+			return fmt.Errorf("%s: %w", op, storageErr.ErrUserNotFound)
+		}
+	}
+
+	if err = addMembership(ctx, tx, userID, toTeamName); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func addMembership(ctx context.Context, q querier, userID string, teamName string) error {
+	const op = "storage.sqlite.addMembership"
+
+	const query = "INSERT INTO team_memberships (user_id, team_name) VALUES (?, ?) ON CONFLICT DO NOTHING"
+
+	if _, err := q.ExecContext(ctx, query, userID, teamName); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// removeMembership removes the user from the team and, if it was the primary team,
+// makes another of the user's teams primary. It fails if the user is not a member.
+func removeMembership(ctx context.Context, q querier, userID string, teamName string) error {
+	const op = "storage.sqlite.removeMembership"
+
+	const deleteQuery = "DELETE FROM team_memberships WHERE user_id = ? AND team_name = ?"
+
+	result, err := q.ExecContext(ctx, deleteQuery, userID, teamName)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if n, err := result.RowsAffected(); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	} else if n == 0 {
+		return fmt.Errorf("%s: %w", op, storageErr.ErrUserNotFound)
+	}
+
+	const primaryQuery = `
+		UPDATE users
+		SET team_name = (SELECT MIN(m.team_name) FROM team_memberships m WHERE m.user_id = users.user_id)
+		WHERE user_id = ?1
+		  AND team_name = ?2
+	`
+
+	if _, err = q.ExecContext(ctx, primaryQuery, userID, teamName); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// teamNamesOf returns the sorted teams of each of the users; every user gets a non-nil slice.
+func teamNamesOf(ctx context.Context, q querier, userIDs []string) (map[string][]string, error) {
+	const op = "storage.sqlite.teamNamesOf"
+
+	teamNames := make(map[string][]string, len(userIDs))
+	for _, id := range userIDs {
+		teamNames[id] = []string{}
+	}
+	if len(userIDs) == 0 {
+		return teamNames, nil
+	}
+
+	query := "SELECT user_id, team_name FROM team_memberships WHERE user_id IN (" +
+		placeholders(len(userIDs)) + ") ORDER BY team_name"
+
+	rows, err := q.QueryContext(ctx, query, anySlice(userIDs)...)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var userID, teamName string
+		if err := rows.Scan(&userID, &teamName); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		teamNames[userID] = append(teamNames[userID], teamName)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return teamNames, nil
+}
@@ -0,0 +1,1666 @@
+// Package storagetest is a conformance suite shared by all storage backends.
+// Every backend must pass it to be selectable by config.
+package storagetest
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/whitxowl/pr-reviewer-assignment-service.git/internal/domain"
+	storageErr "github.com/whitxowl/pr-reviewer-assignment-service.git/internal/storage/errors"
+)
+
+type TeamStorage interface {
+	CreateTeam(ctx context.Context, teamName string) error
+	TeamExists(ctx context.Context, teamName string) (bool, error)
+	GetTeam(ctx context.Context, teamName string) (*domain.Team, error)
+	RenameTeam(ctx context.Context, teamName string, newTeamName string) error
+	SetArchived(ctx context.Context, teamName string, archived bool) error
+	SetMaxOpenReviews(ctx context.Context, teamName string, limit *int) error
+	GetSeniorityRules(ctx context.Context, teamName string) ([]domain.SeniorityRule, error)
+	SetSeniorityRules(ctx context.Context, teamName string, rules []domain.SeniorityRule) error
+	DeleteTeam(ctx context.Context, teamName string) error
+	ApplyTeamDiff(ctx context.Context, diff *domain.TeamDiff) error
+	SyncTeam(ctx context.Context, teamName string, members []*domain.User, detach bool, dryRun bool) (*domain.TeamDiff, error)
+}
+
+type UserStorage interface {
+	UpsertUsers(ctx context.Context, users []*domain.User) error
+	GetUsersByTeamName(ctx context.Context, teamName string) ([]*domain.User, error)
+	SetIsActive(ctx context.Context, userID string, isActive bool) (*domain.User, error)
+	SetUsername(ctx context.Context, userID string, username string) (*domain.User, error)
+	SetMaxOpenReviews(ctx context.Context, userID string, limit *int) (*domain.User, error)
+	SetWorkingHours(ctx context.Context, userID string, timezone string, hours *domain.WorkingHours) (*domain.User, error)
+	SetLevel(ctx context.Context, userID string, level string) (*domain.User, error)
+	GetReviewerPool(ctx context.Context, teamName string) ([]*domain.User, error)
+	GetCodeOwnerPool(ctx context.Context, userIDs []string, teamNames []string) ([]*domain.User, error)
+	GetUser(ctx context.Context, userID string) (*domain.User, error)
+	GetUsersByIDs(ctx context.Context, userIDs []string) ([]*domain.User, error)
+	SearchUsers(ctx context.Context, search domain.UserSearch) ([]*domain.User, int, error)
+	RemoveMemberships(ctx context.Context, teamName string, userIDs []string) error
+	MoveMembership(ctx context.Context, userID string, fromTeamName string, toTeamName string) error
+	SaveAbsences(ctx context.Context, userID string, absences []domain.Absence) ([]domain.Absence, error)
+	GetAbsences(ctx context.Context, userID string) ([]domain.Absence, error)
+	DeleteAbsence(ctx context.Context, userID string, absenceID int64) error
+	GetStartedAbsences(ctx context.Context) ([]domain.Absence, error)
+	MarkAbsenceReassigned(ctx context.Context, absenceID int64) error
+}
+
+type PRStorage interface {
+	GetPRsReviewedBy(ctx context.Context, userID string) ([]*domain.PullRequest, error)
+	CreatePR(ctx context.Context, repository string, prID string, prName string, authorID string, teamName string) error
+	AssignReviewers(ctx context.Context, repository string, prID string, reviewersIDs []string) error
+	SetStatusMerged(ctx context.Context, repository string, prID string) (*domain.PullRequest, error)
+	GetPR(ctx context.Context, repository string, prID string) (*domain.PullRequest, error)
+	ReassignReviewer(
+		ctx context.Context,
+		repository string,
+		prID string,
+		reassignment domain.Reassignment,
+		dryRun bool,
+	) (*domain.PullRequest, error)
+	GetOpenPRsReviewedByTeam(ctx context.Context, teamName string) ([]domain.PRRef, error)
+	GetOpenPRsByReviewerAndTeam(ctx context.Context, reviewerID string, teamName string) ([]domain.PRRef, error)
+	SaveAssignmentRationales(ctx context.Context, repository string, prID string, rationales []domain.AssignmentRationale) error
+	GetAssignmentRationales(ctx context.Context, repository string, prID string) ([]domain.AssignmentRationale, error)
+	QueueReviewerSlots(ctx context.Context, repository string, prID string, slots int) error
+	GetPendingSlots(ctx context.Context) ([]domain.PendingSlots, error)
+	FillReviewerSlots(ctx context.Context, repository string, prID string, reviewerIDs []string) error
+	DropReviewerSlots(ctx context.Context, repository string, prID string) error
+	SetRequiredTags(ctx context.Context, repository string, prID string, tags []string) error
+	GetRequiredTags(ctx context.Context, repository string, prID string) ([]string, error)
+	GetPairings(ctx context.Context, authorIDs []string, window time.Duration) ([]domain.Pairing, error)
+	ImportPRs(ctx context.Context, prs []domain.PRImport) error
+}
+
+type RepositoryStorage interface {
+	CreateRepository(ctx context.Context, repo *domain.Repository) error
+	GetRepository(ctx context.Context, name string) (*domain.Repository, error)
+	UpdateRepository(ctx context.Context, repo *domain.Repository) error
+	SetCodeOwners(ctx context.Context, repository string, rules []domain.CodeOwnerRule) error
+	GetCodeOwners(ctx context.Context, repository string) ([]domain.CodeOwnerRule, error)
+}
+
+type Storages struct {
+	Team TeamStorage
+	User UserStorage
+	PR   PRStorage
+
+	Repository RepositoryStorage
+}
+
+// Factory returns storages backed by an empty database.
+type Factory func(t *testing.T) Storages
+
+func Run(t *testing.T, newStorages Factory) {
+	t.Run("Team", func(t *testing.T) { testTeam(t, newStorages(t)) })
+	t.Run("TeamLifecycle", func(t *testing.T) { testTeamLifecycle(t, newStorages(t)) })
+	t.Run("User", func(t *testing.T) { testUser(t, newStorages(t)) })
+	t.Run("SearchUsers", func(t *testing.T) { testSearchUsers(t, newStorages(t)) })
+	t.Run("Membership", func(t *testing.T) { testMembership(t, newStorages(t)) })
+	t.Run("TeamDiff", func(t *testing.T) { testTeamDiff(t, newStorages(t)) })
+	t.Run("SyncTeam", func(t *testing.T) { testSyncTeam(t, newStorages(t)) })
+	t.Run("ReviewerPool", func(t *testing.T) { testReviewerPool(t, newStorages(t)) })
+	t.Run("PR", func(t *testing.T) { testPR(t, newStorages(t)) })
+	t.Run("Reassign", func(t *testing.T) { testReassign(t, newStorages(t)) })
+	t.Run("Repository", func(t *testing.T) { testRepository(t, newStorages(t)) })
+	t.Run("CodeOwners", func(t *testing.T) { testCodeOwners(t, newStorages(t)) })
+	t.Run("CodeOwnerPool", func(t *testing.T) { testCodeOwnerPool(t, newStorages(t)) })
+	t.Run("AssignmentRationales", func(t *testing.T) { testAssignmentRationales(t, newStorages(t)) })
+	t.Run("ReviewCapacity", func(t *testing.T) { testReviewCapacity(t, newStorages(t)) })
+	t.Run("PendingSlots", func(t *testing.T) { testPendingSlots(t, newStorages(t)) })
+	t.Run("Absences", func(t *testing.T) { testAbsences(t, newStorages(t)) })
+	t.Run("WorkingHours", func(t *testing.T) { testWorkingHours(t, newStorages(t)) })
+	t.Run("Tags", func(t *testing.T) { testTags(t, newStorages(t)) })
+	t.Run("Seniority", func(t *testing.T) { testSeniority(t, newStorages(t)) })
+	t.Run("Pairings", func(t *testing.T) { testPairings(t, newStorages(t)) })
+	t.Run("ImportPRs", func(t *testing.T) { testImportPRs(t, newStorages(t)) })
+	t.Run("RequiredTags", func(t *testing.T) { testRequiredTags(t, newStorages(t)) })
+}
+
+// seed creates a team with the given members, all active unless listed in inactive.
+// replace replaces oldReviewerID with newReviewerID, named by hand, or just
+// removes it if newReviewerID is empty.
+func replace(oldReviewerID string, newReviewerID string) domain.Reassignment {
+	reassignment := domain.Reassignment{OldReviewerID: oldReviewerID}
+	if newReviewerID != "" {
+		reassignment.Replacement = &domain.AssignmentRationale{ReviewerID: newReviewerID, Strategy: domain.StrategyManual, PoolSize: 1}
+	}
+	return reassignment
+}
+
+func seed(t *testing.T, s Storages, teamName string, userIDs []string, inactive ...string) {
+	t.Helper()
+
+	ctx := context.Background()
+
+	require.NoError(t, s.Team.CreateTeam(ctx, teamName))
+
+	users := make([]*domain.User, len(userIDs))
+	for i, id := range userIDs {
+		users[i] = &domain.User{
+			UserID:   id,
+			Username: "name-" + id,
+			TeamName: teamName,
+			IsActive: !slices.Contains(inactive, id),
+		}
+	}
+
+	require.NoError(t, s.User.UpsertUsers(ctx, users))
+}
+
+func testTeam(t *testing.T, s Storages) {
+	ctx := context.Background()
+
+	exists, err := s.Team.TeamExists(ctx, "backend")
+	require.NoError(t, err)
+	assert.False(t, exists)
+
+	require.NoError(t, s.Team.CreateTeam(ctx, "backend"))
+
+	exists, err = s.Team.TeamExists(ctx, "backend")
+	require.NoError(t, err)
+	assert.True(t, exists)
+
+	err = s.Team.CreateTeam(ctx, "backend")
+	assert.ErrorIs(t, err, storageErr.ErrTeamExists)
+}
+
+func testTeamLifecycle(t *testing.T, s Storages) {
+	ctx := context.Background()
+
+	seed(t, s, "backend", []string{"u1", "u2", "u3"})
+	seed(t, s, "frontend", []string{"u10", "u11"})
+	require.NoError(t, s.Team.CreateTeam(ctx, "empty"))
+
+	team, err := s.Team.GetTeam(ctx, "backend")
+	require.NoError(t, err)
+	assert.Equal(t, "backend", team.TeamName)
+	assert.Nil(t, team.ArchivedAt)
+
+	_, err = s.Team.GetTeam(ctx, "missing")
+	assert.ErrorIs(t, err, storageErr.ErrTeamNotFound)
+
+	require.NoError(t, s.PR.CreatePR(ctx, "", "pr-1", "Add search", "u10", "frontend"))
+	require.NoError(t, s.PR.AssignReviewers(ctx, "", "pr-1", []string{"u11"}))
+	require.NoError(t, s.PR.CreatePR(ctx, "", "pr-2", "Fix login", "u1", "backend"))
+	require.NoError(t, s.PR.AssignReviewers(ctx, "", "pr-2", []string{"u2"}))
+	require.NoError(t, s.PR.CreatePR(ctx, "", "pr-3", "Old change", "u1", "backend"))
+	require.NoError(t, s.PR.AssignReviewers(ctx, "", "pr-3", []string{"u3"}))
+	_, err = s.PR.SetStatusMerged(ctx, "", "pr-3")
+	require.NoError(t, err)
+
+	ids, err := s.PR.GetOpenPRsReviewedByTeam(ctx, "backend")
+	require.NoError(t, err)
+	assert.Equal(t, []domain.PRRef{{PullRequestID: "pr-2"}}, ids, "merged PRs are not reported")
+
+	// Rename
+	require.NoError(t, s.Team.RenameTeam(ctx, "backend", "platform"))
+
+	exists, err := s.Team.TeamExists(ctx, "backend")
+	require.NoError(t, err)
+	assert.False(t, exists)
+
+	users, err := s.User.GetUsersByTeamName(ctx, "platform")
+	require.NoError(t, err)
+	assert.Len(t, users, 3, "members follow the renamed team")
+	for _, user := range users {
+		assert.Equal(t, "platform", user.TeamName)
+	}
+
+	ids, err = s.PR.GetOpenPRsReviewedByTeam(ctx, "platform")
+	require.NoError(t, err)
+	assert.Equal(t, []domain.PRRef{{PullRequestID: "pr-2"}}, ids, "assignments survive a rename")
+
+	pr, err := s.PR.GetPR(ctx, "", "pr-2")
+	require.NoError(t, err)
+	assert.Equal(t, "platform", pr.TeamName, "PRs follow the renamed team")
+
+	err = s.Team.RenameTeam(ctx, "platform", "frontend")
+	assert.ErrorIs(t, err, storageErr.ErrTeamExists)
+
+	err = s.Team.RenameTeam(ctx, "missing", "other")
+	assert.ErrorIs(t, err, storageErr.ErrTeamNotFound)
+
+	// Archive
+	require.NoError(t, s.Team.SetArchived(ctx, "frontend", true))
+
+	team, err = s.Team.GetTeam(ctx, "frontend")
+	require.NoError(t, err)
+	require.NotNil(t, team.ArchivedAt)
+	archivedAt := *team.ArchivedAt
+
+	require.NoError(t, s.Team.SetArchived(ctx, "frontend", true))
+	team, err = s.Team.GetTeam(ctx, "frontend")
+	require.NoError(t, err)
+	require.NotNil(t, team.ArchivedAt)
+	assert.True(t, archivedAt.Equal(*team.ArchivedAt), "archive is idempotent")
+
+	pool, err := s.User.GetReviewerPool(ctx, "frontend")
+	require.NoError(t, err)
+	assert.Empty(t, pool, "members of an archived team are not picked")
+
+	ids, err = s.PR.GetOpenPRsReviewedByTeam(ctx, "frontend")
+	require.NoError(t, err)
+	assert.Equal(t, []domain.PRRef{{PullRequestID: "pr-1"}}, ids, "archiving keeps existing assignments")
+
+	require.NoError(t, s.Team.SetArchived(ctx, "frontend", false))
+	team, err = s.Team.GetTeam(ctx, "frontend")
+	require.NoError(t, err)
+	assert.Nil(t, team.ArchivedAt)
+
+	pool, err = s.User.GetReviewerPool(ctx, "frontend")
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"u10", "u11"}, userIDs(pool))
+
+	err = s.Team.SetArchived(ctx, "missing", true)
+	assert.ErrorIs(t, err, storageErr.ErrTeamNotFound)
+
+	// Delete
+	err = s.Team.DeleteTeam(ctx, "platform")
+	assert.ErrorIs(t, err, storageErr.ErrTeamNotEmpty)
+
+	require.NoError(t, s.Team.DeleteTeam(ctx, "empty"))
+
+	exists, err = s.Team.TeamExists(ctx, "empty")
+	require.NoError(t, err)
+	assert.False(t, exists)
+
+	err = s.Team.DeleteTeam(ctx, "empty")
+	assert.ErrorIs(t, err, storageErr.ErrTeamNotFound)
+}
+
+func testUser(t *testing.T, s Storages) {
+	ctx := context.Background()
+
+	seed(t, s, "backend", []string{"u1", "u2"})
+
+	users, err := s.User.GetUsersByTeamName(ctx, "backend")
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []*domain.User{
+		{UserID: "u1", Username: "name-u1", TeamName: "backend", IsActive: true},
+		{UserID: "u2", Username: "name-u2", TeamName: "backend", IsActive: true},
+	}, users)
+
+	err = s.User.UpsertUsers(ctx, []*domain.User{
+		{UserID: "u2", Username: "Bob", TeamName: "backend", IsActive: false},
+	})
+	require.NoError(t, err)
+
+	users, err = s.User.GetUsersByTeamName(ctx, "backend")
+	require.NoError(t, err)
+	assert.Contains(t, users, &domain.User{UserID: "u2", Username: "Bob", TeamName: "backend", IsActive: false})
+
+	err = s.User.UpsertUsers(ctx, []*domain.User{
+		{UserID: "u3", Username: "Carol", TeamName: "missing", IsActive: true},
+	})
+	assert.Error(t, err, "users must reference an existing team")
+
+	err = s.User.UpsertUsers(ctx, []*domain.User{
+		{UserID: "", Username: "Nobody", TeamName: "backend", IsActive: true},
+	})
+	assert.Error(t, err, "user_id must not be empty")
+
+	err = s.User.UpsertUsers(ctx, []*domain.User{
+		{UserID: "octo-cat", Username: "Octocat", TeamName: "backend", IsActive: true},
+	})
+	assert.NoError(t, err, "the ID format is checked by the service")
+
+	user, err := s.User.SetIsActive(ctx, "u1", false)
+	require.NoError(t, err)
+	assert.Equal(t, &domain.User{UserID: "u1", Username: "name-u1", TeamName: "backend", IsActive: false}, user)
+
+	_, err = s.User.SetIsActive(ctx, "u404", true)
+	assert.ErrorIs(t, err, storageErr.ErrUserNotFound)
+
+	user, err = s.User.GetUser(ctx, "u1")
+	require.NoError(t, err)
+	assert.Equal(t, &domain.User{
+		UserID:    "u1",
+		Username:  "name-u1",
+		TeamName:  "backend",
+		TeamNames: []string{"backend"},
+		IsActive:  false,
+	}, user)
+
+	_, err = s.User.GetUser(ctx, "u404")
+	assert.ErrorIs(t, err, storageErr.ErrUserNotFound)
+
+	user, err = s.User.SetUsername(ctx, "u2", "Robert")
+	require.NoError(t, err)
+	assert.Equal(t, &domain.User{UserID: "u2", Username: "Robert", TeamName: "backend", IsActive: false}, user)
+
+	_, err = s.User.SetUsername(ctx, "u404", "Nobody")
+	assert.ErrorIs(t, err, storageErr.ErrUserNotFound)
+}
+
+func testSearchUsers(t *testing.T, s Storages) {
+	ctx := context.Background()
+
+	require.NoError(t, s.Team.CreateTeam(ctx, "backend"))
+	require.NoError(t, s.Team.CreateTeam(ctx, "frontend"))
+	require.NoError(t, s.User.UpsertUsers(ctx, []*domain.User{
+		{UserID: "u1", Username: "Alice", TeamName: "backend", IsActive: true},
+		{UserID: "u2", Username: "alan_b", TeamName: "backend", IsActive: false},
+		{UserID: "u3", Username: "Bob", TeamName: "frontend", IsActive: true},
+		{UserID: "u10", Username: "al%", TeamName: "frontend", IsActive: true},
+	}))
+	require.NoError(t, s.User.UpsertUsers(ctx, []*domain.User{
+		{UserID: "u3", Username: "Bob", TeamName: "backend", IsActive: true},
+	}))
+
+	ids := func(users []*domain.User) []string {
+		result := make([]string, len(users))
+		for i, user := range users {
+			result[i] = user.UserID
+		}
+		return result
+	}
+	active, inactive := true, false
+
+	tests := []struct {
+		name          string
+		search        domain.UserSearch
+		expectedIDs   []string
+		expectedTotal int
+	}{
+		{name: "all", search: domain.UserSearch{Limit: 10}, expectedIDs: []string{"u1", "u10", "u2", "u3"}, expectedTotal: 4},
+		{name: "username prefix ignores case", search: domain.UserSearch{Prefix: "AL", Limit: 10}, expectedIDs: []string{"u1", "u10", "u2"}, expectedTotal: 3},
+		{name: "wildcards are literal", search: domain.UserSearch{Prefix: "al%", Limit: 10}, expectedIDs: []string{"u10"}, expectedTotal: 1},
+		{name: "underscore is literal", search: domain.UserSearch{Prefix: "ala", Limit: 10}, expectedIDs: []string{"u2"}, expectedTotal: 1},
+		{name: "user_id prefix", search: domain.UserSearch{Prefix: "u1", Limit: 10}, expectedIDs: []string{"u1", "u10"}, expectedTotal: 2},
+		{name: "any team of the user", search: domain.UserSearch{TeamName: "backend", Limit: 10}, expectedIDs: []string{"u1", "u2", "u3"}, expectedTotal: 3},
+		{name: "active", search: domain.UserSearch{IsActive: &active, Limit: 10}, expectedIDs: []string{"u1", "u10", "u3"}, expectedTotal: 3},
+		{name: "inactive", search: domain.UserSearch{IsActive: &inactive, Limit: 10}, expectedIDs: []string{"u2"}, expectedTotal: 1},
+		{name: "page", search: domain.UserSearch{Limit: 2, Offset: 1}, expectedIDs: []string{"u10", "u2"}, expectedTotal: 4},
+		{name: "past the end", search: domain.UserSearch{Limit: 2, Offset: 10}, expectedIDs: []string{}, expectedTotal: 4},
+		{name: "no match", search: domain.UserSearch{Prefix: "zed", Limit: 10}, expectedIDs: []string{}, expectedTotal: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			users, total, err := s.User.SearchUsers(ctx, tt.search)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedIDs, ids(users))
+			assert.Equal(t, tt.expectedTotal, total)
+		})
+	}
+
+	users, _, err := s.User.SearchUsers(ctx, domain.UserSearch{Prefix: "bob", Limit: 10})
+	require.NoError(t, err)
+	assert.Equal(t, []*domain.User{{
+		UserID:    "u3",
+		Username:  "Bob",
+		TeamName:  "frontend",
+		TeamNames: []string{"backend", "frontend"},
+		IsActive:  true,
+	}}, users)
+}
+
+func testMembership(t *testing.T, s Storages) {
+	ctx := context.Background()
+
+	seed(t, s, "backend", []string{"u1", "u2", "u3"})
+	seed(t, s, "frontend", []string{"u10"})
+	require.NoError(t, s.Team.CreateTeam(ctx, "platform"))
+
+	require.NoError(t, s.PR.CreatePR(ctx, "", "pr-1", "Add search", "u1", "backend"))
+	require.NoError(t, s.PR.AssignReviewers(ctx, "", "pr-1", []string{"u2", "u3"}))
+	require.NoError(t, s.PR.CreatePR(ctx, "", "pr-2", "Fix login", "u10", "frontend"))
+	require.NoError(t, s.PR.AssignReviewers(ctx, "", "pr-2", []string{"u2"}))
+
+	users, err := s.User.GetUsersByIDs(ctx, []string{"u2", "u10", "u404", "u2"})
+	require.NoError(t, err)
+	assert.Equal(t, []*domain.User{
+		{UserID: "u10", Username: "name-u10", TeamName: "frontend", IsActive: true},
+		{UserID: "u2", Username: "name-u2", TeamName: "backend", IsActive: true},
+	}, users, "unknown users are skipped")
+
+	// Joining another team keeps the primary one.
+	err = s.User.UpsertUsers(ctx, []*domain.User{
+		{UserID: "u2", Username: "name-u2", TeamName: "frontend", IsActive: true},
+	})
+	require.NoError(t, err)
+
+	user, err := s.User.GetUser(ctx, "u2")
+	require.NoError(t, err)
+	assert.Equal(t, &domain.User{
+		UserID:    "u2",
+		Username:  "name-u2",
+		TeamName:  "backend",
+		TeamNames: []string{"backend", "frontend"},
+		IsActive:  true,
+	}, user)
+
+	members, err := s.User.GetUsersByTeamName(ctx, "frontend")
+	require.NoError(t, err)
+	require.Len(t, members, 2)
+	assert.Equal(t, "frontend", members[0].TeamName, "members are listed with the requested team")
+	assert.Equal(t, "frontend", members[1].TeamName)
+
+	ids, err := s.PR.GetOpenPRsByReviewerAndTeam(ctx, "u2", "backend")
+	require.NoError(t, err)
+	assert.Equal(t, []domain.PRRef{{PullRequestID: "pr-1"}}, ids, "only PRs opened for the team")
+
+	ids, err = s.PR.GetOpenPRsReviewedByTeam(ctx, "frontend")
+	require.NoError(t, err)
+	assert.Equal(t, []domain.PRRef{{PullRequestID: "pr-1"}, {PullRequestID: "pr-2"}}, ids, "reviewers count for every team they are in")
+
+	// Remove
+	require.NoError(t, s.User.RemoveMemberships(ctx, "backend", []string{"u2", "u3"}))
+
+	user, err = s.User.GetUser(ctx, "u2")
+	require.NoError(t, err)
+	assert.Equal(t, "frontend", user.TeamName, "another team becomes primary")
+	assert.Equal(t, []string{"frontend"}, user.TeamNames)
+
+	user, err = s.User.GetUser(ctx, "u3")
+	require.NoError(t, err)
+	assert.Empty(t, user.TeamName, "users without teams have no primary team")
+	assert.Empty(t, user.TeamNames)
+
+	user, err = s.User.SetIsActive(ctx, "u3", false)
+	require.NoError(t, err)
+	assert.Empty(t, user.TeamName)
+
+	members, err = s.User.GetUsersByTeamName(ctx, "backend")
+	require.NoError(t, err)
+	assert.Len(t, members, 1, "other members are untouched")
+
+	err = s.User.RemoveMemberships(ctx, "backend", []string{"u1", "u2"})
+	assert.ErrorIs(t, err, storageErr.ErrUserNotFound)
+
+	members, err = s.User.GetUsersByTeamName(ctx, "backend")
+	require.NoError(t, err)
+	assert.Len(t, members, 1, "a failed removal changes nothing")
+
+	// Move
+	require.NoError(t, s.User.MoveMembership(ctx, "u1", "backend", "frontend"))
+
+	user, err = s.User.GetUser(ctx, "u1")
+	require.NoError(t, err)
+	assert.Equal(t, "frontend", user.TeamName, "moving from the primary team moves it")
+	assert.Equal(t, []string{"frontend"}, user.TeamNames)
+
+	require.NoError(t, s.User.MoveMembership(ctx, "u3", "", "backend"))
+
+	user, err = s.User.GetUser(ctx, "u3")
+	require.NoError(t, err)
+	assert.Equal(t, "backend", user.TeamName)
+	assert.Equal(t, []string{"backend"}, user.TeamNames)
+
+	err = s.User.UpsertUsers(ctx, []*domain.User{
+		{UserID: "u10", Username: "name-u10", TeamName: "backend", IsActive: true},
+	})
+	require.NoError(t, err)
+	require.NoError(t, s.User.MoveMembership(ctx, "u10", "backend", "platform"))
+
+	user, err = s.User.GetUser(ctx, "u10")
+	require.NoError(t, err)
+	assert.Equal(t, "frontend", user.TeamName, "moving from another team keeps the primary one")
+	assert.Equal(t, []string{"frontend", "platform"}, user.TeamNames)
+
+	err = s.User.MoveMembership(ctx, "u404", "", "backend")
+	assert.ErrorIs(t, err, storageErr.ErrUserNotFound)
+
+	err = s.User.MoveMembership(ctx, "u1", "backend", "platform")
+	assert.ErrorIs(t, err, storageErr.ErrUserNotFound, "the user must be a member of the former team")
+
+	err = s.User.MoveMembership(ctx, "u1", "frontend", "missing")
+	assert.Error(t, err, "the team must exist")
+
+	user, err = s.User.GetUser(ctx, "u1")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"frontend"}, user.TeamNames, "a failed move changes nothing")
+}
+
+func testTeamDiff(t *testing.T, s Storages) {
+	ctx := context.Background()
+
+	seed(t, s, "frontend", []string{"u10"})
+
+	err := s.Team.ApplyTeamDiff(ctx, &domain.TeamDiff{
+		TeamName:    "backend",
+		TeamCreated: true,
+		Added: []*domain.User{
+			{UserID: "u1", Username: "Alice", IsActive: true},
+			{UserID: "u2", Username: "Bob", IsActive: true},
+			{UserID: "u3", Username: "Carol", IsActive: true},
+		},
+	})
+	require.NoError(t, err)
+
+	users, err := s.User.GetUsersByTeamName(ctx, "backend")
+	require.NoError(t, err)
+	assert.Len(t, users, 3)
+
+	err = s.Team.ApplyTeamDiff(ctx, &domain.TeamDiff{
+		TeamName:    "backend",
+		Added:       []*domain.User{{UserID: "u10", Username: "Joined", IsActive: true}},
+		Updated:     []*domain.User{{UserID: "u1", Username: "Alice Smith", IsActive: true}},
+		Deactivated: []*domain.User{{UserID: "u2"}},
+		Detached:    []*domain.User{{UserID: "u3"}},
+	})
+	require.NoError(t, err)
+
+	users, err = s.User.GetUsersByTeamName(ctx, "backend")
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []*domain.User{
+		{UserID: "u1", Username: "Alice Smith", TeamName: "backend", IsActive: true},
+		{UserID: "u2", Username: "Bob", TeamName: "backend", IsActive: false},
+		{UserID: "u10", Username: "Joined", TeamName: "backend", IsActive: true},
+	}, users)
+
+	user, err := s.User.GetUser(ctx, "u10")
+	require.NoError(t, err)
+	assert.Equal(t, "frontend", user.TeamName, "added users keep their other teams")
+	assert.Equal(t, []string{"backend", "frontend"}, user.TeamNames)
+
+	user, err = s.User.GetUser(ctx, "u3")
+	require.NoError(t, err)
+	assert.Empty(t, user.TeamName)
+	assert.Empty(t, user.TeamNames)
+
+	err = s.Team.ApplyTeamDiff(ctx, &domain.TeamDiff{
+		TeamName:    "backend",
+		Updated:     []*domain.User{{UserID: "u1", Username: "Renamed", IsActive: true}},
+		Added:       []*domain.User{{UserID: "", Username: "Bad id", IsActive: true}},
+		Deactivated: []*domain.User{{UserID: "u10"}},
+	})
+	assert.Error(t, err)
+
+	users, err = s.User.GetUsersByIDs(ctx, []string{"u1", "u10"})
+	require.NoError(t, err)
+	assert.Equal(t, "Alice Smith", users[0].Username, "a failed diff changes nothing")
+	assert.True(t, users[1].IsActive)
+}
+
+func testSyncTeam(t *testing.T, s Storages) {
+	ctx := context.Background()
+
+	roster := func() []*domain.User {
+		return []*domain.User{
+			{UserID: "u1", Username: "Alice", IsActive: true},
+			{UserID: "u2", Username: "Bob", IsActive: true},
+		}
+	}
+
+	diff, err := s.Team.SyncTeam(ctx, "backend", roster(), false, true)
+	require.NoError(t, err)
+	assert.True(t, diff.TeamCreated)
+	assert.Equal(t, []string{"u1", "u2"}, userIDs(diff.Added))
+
+	exists, err := s.Team.TeamExists(ctx, "backend")
+	require.NoError(t, err)
+	assert.False(t, exists, "a dry run writes nothing")
+
+	diff, err = s.Team.SyncTeam(ctx, "backend", roster(), false, false)
+	require.NoError(t, err)
+	assert.True(t, diff.TeamCreated)
+	assert.Len(t, diff.Added, 2)
+
+	diff, err = s.Team.SyncTeam(ctx, "backend", roster(), false, false)
+	require.NoError(t, err)
+	assert.True(t, diff.Empty(), "the roster is read in the sync")
+	assert.Equal(t, []string{"u1", "u2"}, diff.Unchanged)
+
+	diff, err = s.Team.SyncTeam(ctx, "backend", roster()[:1], true, false)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"u2"}, userIDs(diff.Detached))
+
+	users, err := s.User.GetUsersByTeamName(ctx, "backend")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"u1"}, userIDs(users))
+}
+
+// userIDs returns the IDs of users.
+func userIDs(users []*domain.User) []string {
+	ids := make([]string, len(users))
+	for i, user := range users {
+		ids[i] = user.UserID
+	}
+	return ids
+}
+
+func testReviewerPool(t *testing.T, s Storages) {
+	ctx := context.Background()
+
+	seed(t, s, "backend", []string{"u1", "u2", "u3"}, "u3")
+	seed(t, s, "frontend", []string{"u10"})
+
+	pool, err := s.User.GetReviewerPool(ctx, "backend")
+	require.NoError(t, err)
+	assert.Equal(t, []*domain.User{
+		{UserID: "u1", Username: "name-u1", IsActive: true},
+		{UserID: "u2", Username: "name-u2", IsActive: true},
+		{UserID: "u3", Username: "name-u3", IsActive: false},
+	}, pool, "every member by user ID, inactive ones included")
+
+	pool, err = s.User.GetReviewerPool(ctx, "missing")
+	require.NoError(t, err)
+	assert.Empty(t, pool)
+
+	err = s.User.UpsertUsers(ctx, []*domain.User{
+		{UserID: "u2", Username: "name-u2", TeamName: "frontend", IsActive: true},
+	})
+	require.NoError(t, err)
+
+	pool, err = s.User.GetReviewerPool(ctx, "frontend")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"u10", "u2"}, userIDs(pool), "members of several teams are in each pool")
+
+	pool, err = s.User.GetReviewerPool(ctx, "backend")
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"u1", "u2", "u3"}, userIDs(pool))
+}
+
+func testPR(t *testing.T, s Storages) {
+	ctx := context.Background()
+
+	seed(t, s, "backend", []string{"u1", "u2", "u3"})
+
+	require.NoError(t, s.PR.CreatePR(ctx, "", "pr-1", "Add search", "u1", "backend"))
+
+	err := s.PR.CreatePR(ctx, "", "pr-1", "Add search", "u1", "backend")
+	assert.ErrorIs(t, err, storageErr.ErrPRExists)
+
+	err = s.PR.CreatePR(ctx, "", "pr-2", "Unknown author", "u404", "backend")
+	assert.Error(t, err, "author must exist")
+
+	err = s.PR.CreatePR(ctx, "", "pr-3", "Unknown team", "u1", "missing")
+	assert.Error(t, err, "team must exist")
+
+	err = s.PR.CreatePR(ctx, "", "", "Bad id", "u1", "backend")
+	assert.Error(t, err, "pull_request_id must not be empty")
+
+	require.NoError(t, s.PR.CreatePR(ctx, "", "acme/api#12", "Repo reference", "u1", "backend"),
+		"the ID format is checked by the service")
+
+	require.NoError(t, s.PR.AssignReviewers(ctx, "", "pr-1", nil))
+	require.NoError(t, s.PR.AssignReviewers(ctx, "", "pr-1", []string{"u2", "u3"}))
+
+	pr, err := s.PR.GetPR(ctx, "", "pr-1")
+	require.NoError(t, err)
+	assert.Equal(t, "pr-1", pr.PullRequestID)
+	assert.Equal(t, "Add search", pr.PullRequestName)
+	assert.Equal(t, "u1", pr.AuthorID)
+	assert.Equal(t, "backend", pr.TeamName)
+	assert.Equal(t, "OPEN", pr.Status)
+	assert.ElementsMatch(t, []string{"u2", "u3"}, pr.AssignedReviewers)
+	assert.NotNil(t, pr.CreatedAt)
+	assert.Nil(t, pr.MergedAt)
+
+	_, err = s.PR.GetPR(ctx, "", "pr-404")
+	assert.ErrorIs(t, err, storageErr.ErrPRNotFound)
+
+	prs, err := s.PR.GetPRsReviewedBy(ctx, "u2")
+	require.NoError(t, err)
+	require.Len(t, prs, 1)
+	assert.Equal(t, "pr-1", prs[0].PullRequestID)
+	assert.Equal(t, "Add search", prs[0].PullRequestName)
+	assert.Equal(t, "u1", prs[0].AuthorID)
+	assert.Equal(t, "OPEN", prs[0].Status)
+
+	prs, err = s.PR.GetPRsReviewedBy(ctx, "u1")
+	require.NoError(t, err)
+	assert.Empty(t, prs)
+
+	merged, err := s.PR.SetStatusMerged(ctx, "", "pr-1")
+	require.NoError(t, err)
+	assert.Equal(t, "MERGED", merged.Status)
+	assert.ElementsMatch(t, []string{"u2", "u3"}, merged.AssignedReviewers)
+	require.NotNil(t, merged.MergedAt)
+
+	again, err := s.PR.SetStatusMerged(ctx, "", "pr-1")
+	require.NoError(t, err)
+	require.NotNil(t, again.MergedAt)
+	assert.True(t, merged.MergedAt.Equal(*again.MergedAt), "merge is idempotent")
+
+	_, err = s.PR.SetStatusMerged(ctx, "", "pr-404")
+	assert.ErrorIs(t, err, storageErr.ErrPRNotFound)
+}
+
+func testReassign(t *testing.T, s Storages) {
+	ctx := context.Background()
+
+	seed(t, s, "backend", []string{"u1", "u2", "u3", "u4"})
+
+	require.NoError(t, s.PR.CreatePR(ctx, "", "pr-1", "Add search", "u1", "backend"))
+	require.NoError(t, s.PR.AssignReviewers(ctx, "", "pr-1", []string{"u2", "u3"}))
+
+	preview, err := s.PR.ReassignReviewer(ctx, "", "pr-1", replace("u2", "u4"), true)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"u3", "u4"}, preview.AssignedReviewers)
+
+	pr, err := s.PR.GetPR(ctx, "", "pr-1")
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"u2", "u3"}, pr.AssignedReviewers, "dry run writes nothing")
+
+	_, err = s.PR.ReassignReviewer(ctx, "", "pr-1", replace("u4", "u2"), true)
+	assert.ErrorIs(t, err, storageErr.ErrReviewerNotFound, "dry run checks like a real reassignment")
+
+	pr, err = s.PR.ReassignReviewer(ctx, "", "pr-1", replace("u2", "u4"), false)
+	require.NoError(t, err)
+	assert.Equal(t, "pr-1", pr.PullRequestID)
+	assert.Equal(t, "OPEN", pr.Status)
+	assert.ElementsMatch(t, []string{"u3", "u4"}, pr.AssignedReviewers)
+
+	pr, err = s.PR.ReassignReviewer(ctx, "", "pr-1", replace("u4", ""), false)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"u3"}, pr.AssignedReviewers, "empty replacement only removes")
+
+	_, err = s.PR.ReassignReviewer(ctx, "", "pr-1", replace("u2", "u4"), false)
+	assert.ErrorIs(t, err, storageErr.ErrReviewerNotFound)
+
+	require.NoError(t, s.PR.AssignReviewers(ctx, "", "pr-1", []string{"u4"}))
+	_, err = s.PR.ReassignReviewer(ctx, "", "pr-1", replace("u3", "u4"), false)
+	assert.ErrorIs(t, err, storageErr.ErrReviewerAssigned)
+
+	_, err = s.PR.ReassignReviewer(ctx, "", "pr-404", replace("u2", "u4"), false)
+	assert.ErrorIs(t, err, storageErr.ErrPRNotFound)
+
+	_, err = s.PR.SetStatusMerged(ctx, "", "pr-1")
+	require.NoError(t, err)
+
+	_, err = s.PR.ReassignReviewer(ctx, "", "pr-1", replace("u3", "u4"), false)
+	assert.ErrorIs(t, err, storageErr.ErrPRMerged)
+}
+
+func testRepository(t *testing.T, s Storages) {
+	ctx := context.Background()
+
+	seed(t, s, "backend", []string{"u1", "u2", "u3"})
+	require.NoError(t, s.Team.CreateTeam(ctx, "legacy"))
+
+	_, err := s.Repository.GetRepository(ctx, "acme/api")
+	assert.ErrorIs(t, err, storageErr.ErrRepositoryNotFound)
+
+	require.NoError(t, s.Repository.CreateRepository(ctx, &domain.Repository{
+		Name:           "acme/api",
+		TeamName:       "backend",
+		ReviewersCount: 1,
+	}))
+	require.NoError(t, s.Repository.CreateRepository(ctx, &domain.Repository{
+		Name:           "acme/web",
+		ReviewersCount: 2,
+	}))
+
+	err = s.Repository.CreateRepository(ctx, &domain.Repository{Name: "acme/api", ReviewersCount: 2})
+	assert.ErrorIs(t, err, storageErr.ErrRepositoryExists)
+
+	err = s.Repository.CreateRepository(ctx, &domain.Repository{Name: "acme/ops", TeamName: "missing", ReviewersCount: 2})
+	assert.ErrorIs(t, err, storageErr.ErrTeamNotFound)
+
+	err = s.Repository.CreateRepository(ctx, &domain.Repository{Name: "acme/ops", ReviewersCount: 0})
+	assert.Error(t, err, "reviewers_count must be positive")
+
+	repo, err := s.Repository.GetRepository(ctx, "acme/api")
+	require.NoError(t, err)
+	assert.Equal(t, &domain.Repository{Name: "acme/api", TeamName: "backend", ReviewersCount: 1}, repo)
+
+	repo, err = s.Repository.GetRepository(ctx, "acme/web")
+	require.NoError(t, err)
+	assert.Empty(t, repo.TeamName)
+
+	require.NoError(t, s.Repository.UpdateRepository(ctx, &domain.Repository{
+		Name:           "acme/web",
+		TeamName:       "legacy",
+		ReviewersCount: 3,
+	}))
+
+	repo, err = s.Repository.GetRepository(ctx, "acme/web")
+	require.NoError(t, err)
+	assert.Equal(t, &domain.Repository{Name: "acme/web", TeamName: "legacy", ReviewersCount: 3}, repo)
+
+	err = s.Repository.UpdateRepository(ctx, &domain.Repository{Name: "acme/404", ReviewersCount: 2})
+	assert.ErrorIs(t, err, storageErr.ErrRepositoryNotFound)
+
+	err = s.Repository.UpdateRepository(ctx, &domain.Repository{Name: "acme/web", TeamName: "missing", ReviewersCount: 2})
+	assert.ErrorIs(t, err, storageErr.ErrTeamNotFound)
+
+	require.NoError(t, s.Team.RenameTeam(ctx, "backend", "platform"))
+
+	repo, err = s.Repository.GetRepository(ctx, "acme/api")
+	require.NoError(t, err)
+	assert.Equal(t, "platform", repo.TeamName, "the owning team follows a rename")
+
+	require.NoError(t, s.Team.DeleteTeam(ctx, "legacy"))
+
+	repo, err = s.Repository.GetRepository(ctx, "acme/web")
+	require.NoError(t, err)
+	assert.Empty(t, repo.TeamName, "deleting the owning team unassigns the repository")
+
+	// PR IDs are unique per repository.
+	require.NoError(t, s.PR.CreatePR(ctx, "acme/api", "pr-1", "API change", "u1", "platform"))
+	require.NoError(t, s.PR.CreatePR(ctx, "acme/web", "pr-1", "Web change", "u1", "platform"))
+	require.NoError(t, s.PR.CreatePR(ctx, "", "pr-1", "Unscoped change", "u1", "platform"))
+
+	err = s.PR.CreatePR(ctx, "acme/api", "pr-1", "API change", "u1", "platform")
+	assert.ErrorIs(t, err, storageErr.ErrPRExists)
+
+	require.NoError(t, s.PR.AssignReviewers(ctx, "acme/api", "pr-1", []string{"u2"}))
+	require.NoError(t, s.PR.AssignReviewers(ctx, "acme/web", "pr-1", []string{"u3"}))
+
+	pr, err := s.PR.GetPR(ctx, "acme/api", "pr-1")
+	require.NoError(t, err)
+	assert.Equal(t, "acme/api", pr.Repository)
+	assert.Equal(t, "API change", pr.PullRequestName)
+	assert.Equal(t, []string{"u2"}, pr.AssignedReviewers)
+
+	_, err = s.PR.GetPR(ctx, "acme/404", "pr-1")
+	assert.ErrorIs(t, err, storageErr.ErrPRNotFound)
+
+	pr, err = s.PR.ReassignReviewer(ctx, "acme/web", "pr-1", replace("u3", "u2"), false)
+	require.NoError(t, err)
+	assert.Equal(t, "acme/web", pr.Repository)
+	assert.Equal(t, []string{"u2"}, pr.AssignedReviewers)
+
+	_, err = s.PR.ReassignReviewer(ctx, "", "pr-1", replace("u2", "u3"), false)
+	assert.ErrorIs(t, err, storageErr.ErrReviewerNotFound, "assignments are per repository")
+
+	merged, err := s.PR.SetStatusMerged(ctx, "acme/api", "pr-1")
+	require.NoError(t, err)
+	assert.Equal(t, "acme/api", merged.Repository)
+
+	pr, err = s.PR.GetPR(ctx, "acme/web", "pr-1")
+	require.NoError(t, err)
+	assert.Equal(t, "OPEN", pr.Status, "merging one repository's PR leaves the others open")
+
+	prs, err := s.PR.GetPRsReviewedBy(ctx, "u2")
+	require.NoError(t, err)
+	require.Len(t, prs, 2)
+	repositories := []string{prs[0].Repository, prs[1].Repository}
+	assert.ElementsMatch(t, []string{"acme/api", "acme/web"}, repositories)
+
+	refs, err := s.PR.GetOpenPRsReviewedByTeam(ctx, "platform")
+	require.NoError(t, err)
+	assert.Equal(t, []domain.PRRef{{Repository: "acme/web", PullRequestID: "pr-1"}}, refs)
+
+	refs, err = s.PR.GetOpenPRsByReviewerAndTeam(ctx, "u2", "platform")
+	require.NoError(t, err)
+	assert.Equal(t, []domain.PRRef{{Repository: "acme/web", PullRequestID: "pr-1"}}, refs)
+}
+
+func testCodeOwners(t *testing.T, s Storages) {
+	ctx := context.Background()
+
+	seed(t, s, "backend", []string{"u1", "u2"})
+	seed(t, s, "frontend", []string{"u3"})
+	require.NoError(t, s.Team.CreateTeam(ctx, "legacy"))
+	require.NoError(t, s.Repository.CreateRepository(ctx, &domain.Repository{Name: "acme/api", ReviewersCount: 2}))
+
+	rules, err := s.Repository.GetCodeOwners(ctx, "acme/api")
+	require.NoError(t, err)
+	assert.Empty(t, rules)
+
+	err = s.Repository.SetCodeOwners(ctx, "acme/404", nil)
+	assert.ErrorIs(t, err, storageErr.ErrRepositoryNotFound)
+
+	err = s.Repository.SetCodeOwners(ctx, "acme/api", []domain.CodeOwnerRule{
+		{Pattern: "*", UserIDs: []string{"u404"}},
+	})
+	assert.ErrorIs(t, err, storageErr.ErrUserNotFound)
+
+	err = s.Repository.SetCodeOwners(ctx, "acme/api", []domain.CodeOwnerRule{
+		{Pattern: "*", TeamNames: []string{"missing"}},
+	})
+	assert.ErrorIs(t, err, storageErr.ErrTeamNotFound)
+
+	expected := []domain.CodeOwnerRule{
+		{Pattern: "*", UserIDs: []string{}, TeamNames: []string{"backend", "legacy"}},
+		{Pattern: "/web/", UserIDs: []string{"u1", "u3"}, TeamNames: []string{"frontend"}},
+		{Pattern: "docs/", UserIDs: []string{}, TeamNames: []string{}},
+	}
+	require.NoError(t, s.Repository.SetCodeOwners(ctx, "acme/api", expected))
+
+	rules, err = s.Repository.GetCodeOwners(ctx, "acme/api")
+	require.NoError(t, err)
+	assert.Equal(t, expected, rules, "rules keep their order")
+
+	require.NoError(t, s.Team.RenameTeam(ctx, "frontend", "web"))
+	require.NoError(t, s.Team.DeleteTeam(ctx, "legacy"))
+
+	rules, err = s.Repository.GetCodeOwners(ctx, "acme/api")
+	require.NoError(t, err)
+	assert.Equal(t, []domain.CodeOwnerRule{
+		{Pattern: "*", UserIDs: []string{}, TeamNames: []string{"backend"}},
+		{Pattern: "/web/", UserIDs: []string{"u1", "u3"}, TeamNames: []string{"web"}},
+		{Pattern: "docs/", UserIDs: []string{}, TeamNames: []string{}},
+	}, rules, "owning teams follow renames and deletions")
+
+	require.NoError(t, s.Repository.SetCodeOwners(ctx, "acme/api", []domain.CodeOwnerRule{
+		{Pattern: "*.go", UserIDs: []string{"u2"}, TeamNames: []string{}},
+	}))
+
+	rules, err = s.Repository.GetCodeOwners(ctx, "acme/api")
+	require.NoError(t, err)
+	assert.Equal(t, []domain.CodeOwnerRule{
+		{Pattern: "*.go", UserIDs: []string{"u2"}, TeamNames: []string{}},
+	}, rules, "setting rules replaces the previous ones")
+
+	require.NoError(t, s.Repository.SetCodeOwners(ctx, "acme/api", nil))
+
+	rules, err = s.Repository.GetCodeOwners(ctx, "acme/api")
+	require.NoError(t, err)
+	assert.Empty(t, rules)
+}
+
+func testCodeOwnerPool(t *testing.T, s Storages) {
+	ctx := context.Background()
+
+	seed(t, s, "backend", []string{"u1", "u2", "u3"}, "u3")
+	seed(t, s, "frontend", []string{"u10", "u11"})
+	seed(t, s, "legacy", []string{"u20"})
+	require.NoError(t, s.Team.SetArchived(ctx, "legacy", true))
+
+	pool, err := s.User.GetCodeOwnerPool(ctx, []string{"u10", "u3"}, []string{"backend"})
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []*domain.User{
+		{UserID: "u1", Username: "name-u1", IsActive: true},
+		{UserID: "u2", Username: "name-u2", IsActive: true},
+		{UserID: "u3", Username: "name-u3", IsActive: false},
+		{UserID: "u10", Username: "name-u10", IsActive: true},
+	}, pool)
+
+	pool, err = s.User.GetCodeOwnerPool(ctx, []string{"u2"}, []string{"backend", "frontend"})
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"u1", "u2", "u3", "u10", "u11"}, userIDs(pool), "each owner is listed once")
+
+	pool, err = s.User.GetCodeOwnerPool(ctx, nil, []string{"legacy"})
+	require.NoError(t, err)
+	assert.Empty(t, pool, "archived teams own nothing")
+
+	pool, err = s.User.GetCodeOwnerPool(ctx, []string{"u20"}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"u20"}, userIDs(pool), "users listed by ID are owners whatever their team")
+
+	pool, err = s.User.GetCodeOwnerPool(ctx, nil, nil)
+	require.NoError(t, err)
+	assert.Empty(t, pool)
+}
+
+func testAssignmentRationales(t *testing.T, s Storages) {
+	ctx := context.Background()
+
+	seed(t, s, "backend", []string{"u1", "u2", "u3", "u4"})
+	require.NoError(t, s.PR.CreatePR(ctx, "", "pr-1", "Feature", "u1", "backend"))
+	require.NoError(t, s.PR.AssignReviewers(ctx, "", "pr-1", []string{"u3", "u2"}))
+
+	rationales, err := s.PR.GetAssignmentRationales(ctx, "", "pr-1")
+	require.NoError(t, err)
+	assert.Empty(t, rationales)
+
+	require.NoError(t, s.PR.SaveAssignmentRationales(ctx, "", "pr-1", []domain.AssignmentRationale{
+		{
+			ReviewerID: "u3",
+			Strategy:   domain.StrategyRandom,
+			PoolSize:   4,
+			Excluded: []domain.ExcludedCandidate{
+				{UserID: "u2", Reason: domain.ExclusionAssigned},
+				{UserID: "u1", Reason: domain.ExclusionAuthor},
+			},
+		},
+		{ReviewerID: "u2", Strategy: domain.StrategyCodeOwners, Rule: "*.go", PoolSize: 1},
+	}))
+
+	rationales, err = s.PR.GetAssignmentRationales(ctx, "", "pr-1")
+	require.NoError(t, err)
+	assert.Equal(t, []domain.AssignmentRationale{
+		{ReviewerID: "u2", Strategy: domain.StrategyCodeOwners, Rule: "*.go", PoolSize: 1},
+		{
+			ReviewerID: "u3",
+			Strategy:   domain.StrategyRandom,
+			PoolSize:   4,
+			Excluded: []domain.ExcludedCandidate{
+				{UserID: "u1", Reason: domain.ExclusionAuthor},
+				{UserID: "u2", Reason: domain.ExclusionAssigned},
+			},
+		},
+	}, rationales, "ordered by reviewer, exclusions by user")
+
+	err = s.PR.SaveAssignmentRationales(ctx, "", "pr-1", []domain.AssignmentRationale{
+		{ReviewerID: "u4", Strategy: domain.StrategyRandom, PoolSize: 4},
+	})
+	assert.ErrorIs(t, err, storageErr.ErrReviewerNotFound, "only assigned reviewers have a rationale")
+
+	_, err = s.PR.ReassignReviewer(ctx, "", "pr-1", replace("u3", "u4"), true)
+	require.NoError(t, err)
+	rationales, err = s.PR.GetAssignmentRationales(ctx, "", "pr-1")
+	require.NoError(t, err)
+	require.Len(t, rationales, 2)
+	assert.Equal(t, "u3", rationales[1].ReviewerID, "a dry run saves no rationale")
+
+	_, err = s.PR.ReassignReviewer(ctx, "", "pr-1", replace("u3", "u4"), false)
+	require.NoError(t, err)
+	rationales, err = s.PR.GetAssignmentRationales(ctx, "", "pr-1")
+	require.NoError(t, err)
+	assert.Equal(t, domain.AssignmentRationale{ReviewerID: "u4", Strategy: domain.StrategyManual, PoolSize: 1}, rationales[1],
+		"the replacement is saved with its rationale")
+
+	require.NoError(t, s.PR.SaveAssignmentRationales(ctx, "", "pr-1", []domain.AssignmentRationale{
+		{ReviewerID: "u4", Strategy: domain.StrategyRandom, PoolSize: 4},
+		{ReviewerID: "u2", Strategy: domain.StrategyRandom, PoolSize: 2},
+	}))
+
+	rationales, err = s.PR.GetAssignmentRationales(ctx, "", "pr-1")
+	require.NoError(t, err)
+	assert.Equal(t, []domain.AssignmentRationale{
+		{ReviewerID: "u2", Strategy: domain.StrategyRandom, PoolSize: 2},
+		{ReviewerID: "u4", Strategy: domain.StrategyRandom, PoolSize: 4},
+	}, rationales, "a replaced reviewer's rationale goes away, saving again replaces it")
+}
+
+func testReviewCapacity(t *testing.T, s Storages) {
+	ctx := context.Background()
+
+	seed(t, s, "backend", []string{"u1", "u2", "u3", "u4"})
+	seed(t, s, "frontend", []string{"u10"})
+
+	limit := func(n int) *int { return &n }
+
+	require.NoError(t, s.Team.SetMaxOpenReviews(ctx, "backend", limit(2)))
+	assert.ErrorIs(t, s.Team.SetMaxOpenReviews(ctx, "ghost", limit(2)), storageErr.ErrTeamNotFound)
+
+	team, err := s.Team.GetTeam(ctx, "backend")
+	require.NoError(t, err)
+	assert.Equal(t, limit(2), team.MaxOpenReviews)
+
+	user, err := s.User.SetMaxOpenReviews(ctx, "u2", limit(1))
+	require.NoError(t, err)
+	assert.Equal(t, limit(1), user.MaxOpenReviews)
+
+	_, err = s.User.SetMaxOpenReviews(ctx, "u404", limit(1))
+	assert.ErrorIs(t, err, storageErr.ErrUserNotFound)
+
+	user, err = s.User.GetUser(ctx, "u2")
+	require.NoError(t, err)
+	assert.Equal(t, limit(1), user.MaxOpenReviews)
+
+	require.NoError(t, s.PR.CreatePR(ctx, "", "pr-1", "Open", "u1", "backend"))
+	require.NoError(t, s.PR.AssignReviewers(ctx, "", "pr-1", []string{"u2", "u3"}))
+	require.NoError(t, s.PR.CreatePR(ctx, "", "pr-2", "Merged", "u1", "backend"))
+	require.NoError(t, s.PR.AssignReviewers(ctx, "", "pr-2", []string{"u3", "u4"}))
+	_, err = s.PR.SetStatusMerged(ctx, "", "pr-2")
+	require.NoError(t, err)
+
+	byID := func(users []*domain.User) map[string]*domain.User {
+		result := make(map[string]*domain.User, len(users))
+		for _, user := range users {
+			result[user.UserID] = user
+		}
+		return result
+	}
+
+	pool, err := s.User.GetReviewerPool(ctx, "backend")
+	require.NoError(t, err)
+	members := byID(pool)
+	require.Len(t, members, 4)
+
+	assert.Equal(t, 1, members["u2"].OpenReviews)
+	assert.Equal(t, limit(1), members["u2"].ReviewLimit, "own limit overrides the team default")
+	assert.True(t, members["u2"].AtCapacity())
+
+	assert.Equal(t, 1, members["u3"].OpenReviews, "merged PRs do not count")
+	assert.Equal(t, limit(2), members["u3"].ReviewLimit)
+	assert.False(t, members["u3"].AtCapacity())
+
+	assert.Equal(t, 0, members["u4"].OpenReviews)
+
+	pool, err = s.User.GetCodeOwnerPool(ctx, []string{"u2", "u10"}, nil)
+	require.NoError(t, err)
+	owners := byID(pool)
+	assert.Equal(t, limit(1), owners["u2"].ReviewLimit)
+	assert.Nil(t, owners["u10"].ReviewLimit, "frontend has no default")
+
+	require.NoError(t, s.Team.SetMaxOpenReviews(ctx, "backend", nil))
+	_, err = s.User.SetMaxOpenReviews(ctx, "u2", nil)
+	require.NoError(t, err)
+
+	pool, err = s.User.GetReviewerPool(ctx, "backend")
+	require.NoError(t, err)
+	for _, user := range pool {
+		assert.Nil(t, user.ReviewLimit, user.UserID)
+		assert.False(t, user.AtCapacity(), user.UserID)
+	}
+
+	require.NoError(t, s.Team.SetMaxOpenReviews(ctx, "frontend", limit(3)))
+	require.NoError(t, s.Team.RenameTeam(ctx, "frontend", "web"))
+	team, err = s.Team.GetTeam(ctx, "web")
+	require.NoError(t, err)
+	assert.Equal(t, limit(3), team.MaxOpenReviews, "renaming keeps the default")
+
+	require.NoError(t, s.PR.QueueReviewerSlots(ctx, "", "pr-1", 1))
+	require.NoError(t, s.PR.QueueReviewerSlots(ctx, "", "pr-1", 2))
+	assert.ErrorIs(t, s.PR.QueueReviewerSlots(ctx, "", "pr-404", 1), storageErr.ErrPRNotFound)
+}
+
+func testPendingSlots(t *testing.T, s Storages) {
+	ctx := context.Background()
+
+	seed(t, s, "backend", []string{"u1", "u2", "u3", "u4"})
+
+	for _, id := range []string{"pr-1", "pr-2", "pr-3"} {
+		require.NoError(t, s.PR.CreatePR(ctx, "", id, "PR "+id, "u1", "backend"))
+	}
+
+	pending, err := s.PR.GetPendingSlots(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, pending)
+
+	require.NoError(t, s.PR.QueueReviewerSlots(ctx, "", "pr-2", 2))
+	require.NoError(t, s.PR.QueueReviewerSlots(ctx, "", "pr-1", 1))
+	require.NoError(t, s.PR.QueueReviewerSlots(ctx, "", "pr-3", 1))
+
+	_, err = s.PR.SetStatusMerged(ctx, "", "pr-3")
+	require.NoError(t, err)
+
+	pending, err = s.PR.GetPendingSlots(ctx)
+	require.NoError(t, err)
+	require.Len(t, pending, 2, "merged PRs are not pending")
+
+	slots := make(map[string]int, len(pending))
+	for _, p := range pending {
+		assert.Equal(t, "u1", p.PR.AuthorID)
+		assert.Equal(t, "backend", p.PR.TeamName)
+		assert.Equal(t, "OPEN", p.PR.Status)
+		assert.Equal(t, "PR "+p.PR.PullRequestID, p.PR.PullRequestName)
+		assert.NotNil(t, p.QueuedAt)
+		slots[p.PR.PullRequestID] = p.Slots
+	}
+	assert.Equal(t, map[string]int{"pr-1": 1, "pr-2": 2}, slots)
+
+	require.NoError(t, s.PR.FillReviewerSlots(ctx, "", "pr-2", []string{"u2"}))
+
+	pr, err := s.PR.GetPR(ctx, "", "pr-2")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"u2"}, pr.AssignedReviewers)
+
+	pending, err = s.PR.GetPendingSlots(ctx)
+	require.NoError(t, err)
+	slots = make(map[string]int, len(pending))
+	for _, p := range pending {
+		slots[p.PR.PullRequestID] = p.Slots
+	}
+	assert.Equal(t, map[string]int{"pr-1": 1, "pr-2": 1}, slots)
+
+	require.NoError(t, s.PR.FillReviewerSlots(ctx, "", "pr-2", []string{"u3", "u4"}))
+	require.NoError(t, s.PR.FillReviewerSlots(ctx, "", "pr-1", nil))
+
+	pending, err = s.PR.GetPendingSlots(ctx)
+	require.NoError(t, err)
+	require.Len(t, pending, 1, "filling every slot removes the PR")
+	assert.Equal(t, "pr-1", pending[0].PR.PullRequestID)
+
+	pr, err = s.PR.GetPR(ctx, "", "pr-2")
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"u2", "u3", "u4"}, pr.AssignedReviewers)
+
+	assert.ErrorIs(t, s.PR.FillReviewerSlots(ctx, "", "pr-2", []string{"u2"}), storageErr.ErrReviewerAssigned)
+	assert.ErrorIs(t, s.PR.FillReviewerSlots(ctx, "", "pr-3", []string{"u2"}), storageErr.ErrPRMerged)
+	assert.ErrorIs(t, s.PR.FillReviewerSlots(ctx, "", "pr-404", []string{"u2"}), storageErr.ErrPRNotFound)
+
+	require.NoError(t, s.PR.DropReviewerSlots(ctx, "", "pr-1"))
+	require.NoError(t, s.PR.DropReviewerSlots(ctx, "", "pr-404"))
+
+	pending, err = s.PR.GetPendingSlots(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, pending)
+
+	queued := domain.Reassignment{OldReviewerID: "u4", QueueSlot: true}
+	_, err = s.PR.ReassignReviewer(ctx, "", "pr-2", queued, true)
+	require.NoError(t, err)
+	pending, err = s.PR.GetPendingSlots(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, pending, "a dry run queues nothing")
+
+	_, err = s.PR.ReassignReviewer(ctx, "", "pr-2", queued, false)
+	require.NoError(t, err)
+	pending, err = s.PR.GetPendingSlots(ctx)
+	require.NoError(t, err)
+	require.Len(t, pending, 1, "the slot left empty by a reassignment is queued")
+	assert.Equal(t, 1, pending[0].Slots)
+}
+
+func testAbsences(t *testing.T, s Storages) {
+	ctx := context.Background()
+
+	seed(t, s, "backend", []string{"u1", "u2", "u3"})
+
+	now := time.Now().UTC().Truncate(time.Second)
+	hour := time.Hour
+
+	absences, err := s.User.GetAbsences(ctx, "u1")
+	require.NoError(t, err)
+	assert.Empty(t, absences)
+
+	saved, err := s.User.SaveAbsences(ctx, "u1", []domain.Absence{
+		{StartsAt: now.Add(24 * hour), EndsAt: now.Add(48 * hour), Reason: "conference", UID: "event-1"},
+		{StartsAt: now.Add(-hour), EndsAt: now.Add(hour), Reason: "vacation", Reassign: true},
+	})
+	require.NoError(t, err)
+	require.Len(t, saved, 2)
+	assert.NotZero(t, saved[0].ID)
+	assert.NotEqual(t, saved[0].ID, saved[1].ID)
+	assert.Equal(t, "u1", saved[0].UserID)
+	assert.Equal(t, "event-1", saved[0].UID)
+	assert.Empty(t, saved[1].UID)
+	assert.Nil(t, saved[1].ReassignedAt)
+
+	_, err = s.User.SaveAbsences(ctx, "u2", []domain.Absence{
+		{StartsAt: now.Add(-2 * hour), EndsAt: now.Add(-hour), Reassign: true},
+	})
+	require.NoError(t, err)
+
+	absences, err = s.User.GetAbsences(ctx, "u1")
+	require.NoError(t, err)
+	require.Len(t, absences, 2)
+	assert.Equal(t, "vacation", absences[0].Reason, "earliest first")
+	assert.True(t, absences[0].StartsAt.Equal(now.Add(-hour)))
+	assert.True(t, absences[0].EndsAt.Equal(now.Add(hour)))
+	assert.True(t, absences[0].Reassign)
+	assert.Equal(t, "conference", absences[1].Reason)
+
+	pool, err := s.User.GetReviewerPool(ctx, "backend")
+	require.NoError(t, err)
+	absent := make(map[string]bool, len(pool))
+	for _, user := range pool {
+		absent[user.UserID] = user.Absent
+	}
+	assert.Equal(t, map[string]bool{"u1": true, "u2": false, "u3": false}, absent, "only absences in progress count")
+
+	started, err := s.User.GetStartedAbsences(ctx)
+	require.NoError(t, err)
+	require.Len(t, started, 1, "past absences and absences without reassign are not handed over")
+	assert.Equal(t, saved[1].ID, started[0].ID)
+
+	require.NoError(t, s.User.MarkAbsenceReassigned(ctx, started[0].ID))
+
+	started, err = s.User.GetStartedAbsences(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, started, "an absence is handed over once")
+
+	absences, err = s.User.GetAbsences(ctx, "u1")
+	require.NoError(t, err)
+	assert.NotNil(t, absences[0].ReassignedAt)
+
+	// Importing the same event again updates it in place.
+	saved, err = s.User.SaveAbsences(ctx, "u1", []domain.Absence{
+		{StartsAt: now.Add(72 * hour), EndsAt: now.Add(96 * hour), Reason: "conference (moved)", UID: "event-1"},
+	})
+	require.NoError(t, err)
+	require.Len(t, saved, 1)
+
+	absences, err = s.User.GetAbsences(ctx, "u1")
+	require.NoError(t, err)
+	require.Len(t, absences, 2)
+	assert.Equal(t, saved[0].ID, absences[1].ID)
+	assert.Equal(t, "conference (moved)", absences[1].Reason)
+	assert.True(t, absences[1].StartsAt.Equal(now.Add(72*hour)))
+
+	require.NoError(t, s.User.DeleteAbsence(ctx, "u1", saved[0].ID))
+	assert.ErrorIs(t, s.User.DeleteAbsence(ctx, "u1", saved[0].ID), storageErr.ErrAbsenceNotFound)
+	assert.ErrorIs(t, s.User.DeleteAbsence(ctx, "u2", absences[0].ID), storageErr.ErrAbsenceNotFound,
+		"absences of other users are not deleted")
+	assert.ErrorIs(t, s.User.MarkAbsenceReassigned(ctx, saved[0].ID), storageErr.ErrAbsenceNotFound)
+
+	_, err = s.User.SaveAbsences(ctx, "u404", []domain.Absence{{StartsAt: now, EndsAt: now.Add(hour)}})
+	assert.ErrorIs(t, err, storageErr.ErrUserNotFound)
+}
+
+func testWorkingHours(t *testing.T, s Storages) {
+	ctx := context.Background()
+
+	seed(t, s, "backend", []string{"u1", "u2"})
+
+	hours := &domain.WorkingHours{
+		Start: 22 * 60,
+		End:   6 * 60,
+		Days:  []time.Weekday{time.Friday, time.Monday},
+	}
+
+	user, err := s.User.SetWorkingHours(ctx, "u1", "Europe/Berlin", hours)
+	require.NoError(t, err)
+	assert.Equal(t, "Europe/Berlin", user.Timezone)
+	assert.Equal(t, &domain.WorkingHours{
+		Start: 22 * 60,
+		End:   6 * 60,
+		Days:  []time.Weekday{time.Monday, time.Friday},
+	}, user.WorkingHours, "days come back in weekday order")
+
+	got, err := s.User.GetUser(ctx, "u1")
+	require.NoError(t, err)
+	assert.Equal(t, user.Timezone, got.Timezone)
+	assert.Equal(t, user.WorkingHours, got.WorkingHours)
+
+	pool, err := s.User.GetReviewerPool(ctx, "backend")
+	require.NoError(t, err)
+	for _, u := range pool {
+		switch u.UserID {
+		case "u1":
+			assert.Equal(t, "Europe/Berlin", u.Timezone)
+			assert.Equal(t, user.WorkingHours, u.WorkingHours)
+		case "u2":
+			assert.Empty(t, u.Timezone)
+			assert.Nil(t, u.WorkingHours)
+		}
+	}
+
+	require.NoError(t, s.User.UpsertUsers(ctx, []*domain.User{{UserID: "u1", Username: "renamed", TeamName: "backend", IsActive: true}}))
+	got, err = s.User.GetUser(ctx, "u1")
+	require.NoError(t, err)
+	assert.Equal(t, user.WorkingHours, got.WorkingHours, "an upsert keeps the schedule")
+
+	user, err = s.User.SetWorkingHours(ctx, "u1", "", nil)
+	require.NoError(t, err)
+	assert.Empty(t, user.Timezone)
+	assert.Nil(t, user.WorkingHours)
+
+	_, err = s.User.SetWorkingHours(ctx, "u404", "UTC", hours)
+	assert.ErrorIs(t, err, storageErr.ErrUserNotFound)
+}
+
+func testTags(t *testing.T, s Storages) {
+	ctx := context.Background()
+
+	seed(t, s, "backend", []string{"u1", "u2"})
+
+	tagsOf := func(users []*domain.User) map[string][]string {
+		tags := make(map[string][]string, len(users))
+		for _, user := range users {
+			if len(user.Tags) > 0 {
+				tags[user.UserID] = user.Tags
+			}
+		}
+		return tags
+	}
+
+	require.NoError(t, s.User.UpsertUsers(ctx, []*domain.User{
+		{UserID: "u1", Username: "name-u1", TeamName: "backend", IsActive: true, Tags: []string{"postgres", "go"}},
+		{UserID: "u2", Username: "name-u2", TeamName: "backend", IsActive: true, Tags: []string{}},
+	}))
+
+	user, err := s.User.GetUser(ctx, "u1")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"go", "postgres"}, user.Tags)
+
+	members, err := s.User.GetUsersByTeamName(ctx, "backend")
+	require.NoError(t, err)
+	assert.Equal(t, map[string][]string{"u1": {"go", "postgres"}}, tagsOf(members))
+
+	pool, err := s.User.GetReviewerPool(ctx, "backend")
+	require.NoError(t, err)
+	assert.Equal(t, map[string][]string{"u1": {"go", "postgres"}}, tagsOf(pool))
+
+	pool, err = s.User.GetCodeOwnerPool(ctx, []string{"u1"}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, map[string][]string{"u1": {"go", "postgres"}}, tagsOf(pool))
+
+	require.NoError(t, s.User.UpsertUsers(ctx, []*domain.User{
+		{UserID: "u1", Username: "renamed", TeamName: "backend", IsActive: true},
+	}))
+	user, err = s.User.GetUser(ctx, "u1")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"go", "postgres"}, user.Tags, "nil tags keep the stored ones")
+
+	err = s.Team.ApplyTeamDiff(ctx, &domain.TeamDiff{
+		TeamName: "backend",
+		Updated: []*domain.User{
+			{UserID: "u1", Username: "renamed", IsActive: true, Tags: []string{"security"}},
+			{UserID: "u2", Username: "name-u2", IsActive: false},
+		},
+	})
+	require.NoError(t, err)
+
+	members, err = s.User.GetUsersByTeamName(ctx, "backend")
+	require.NoError(t, err)
+	assert.Equal(t, map[string][]string{"u1": {"security"}}, tagsOf(members))
+}
+
+func testSeniority(t *testing.T, s Storages) {
+	ctx := context.Background()
+
+	seed(t, s, "backend", []string{"u1", "u2"})
+
+	user, err := s.User.SetLevel(ctx, "u1", domain.LevelSenior)
+	require.NoError(t, err)
+	assert.Equal(t, domain.LevelSenior, user.Level)
+
+	_, err = s.User.SetLevel(ctx, "u404", domain.LevelSenior)
+	assert.ErrorIs(t, err, storageErr.ErrUserNotFound)
+
+	user, err = s.User.GetUser(ctx, "u1")
+	require.NoError(t, err)
+	assert.Equal(t, domain.LevelSenior, user.Level)
+
+	members, err := s.User.GetUsersByTeamName(ctx, "backend")
+	require.NoError(t, err)
+	require.Len(t, members, 2)
+	assert.Equal(t, domain.LevelSenior, members[0].Level)
+	assert.Empty(t, members[1].Level)
+
+	pool, err := s.User.GetReviewerPool(ctx, "backend")
+	require.NoError(t, err)
+	for _, user := range pool {
+		if user.UserID == "u1" {
+			assert.Equal(t, domain.LevelSenior, user.Level)
+		}
+	}
+
+	require.NoError(t, s.User.UpsertUsers(ctx, []*domain.User{
+		{UserID: "u1", Username: "renamed", TeamName: "backend", IsActive: true},
+	}))
+	user, err = s.User.GetUser(ctx, "u1")
+	require.NoError(t, err)
+	assert.Equal(t, domain.LevelSenior, user.Level, "upserting keeps the level")
+
+	user, err = s.User.SetLevel(ctx, "u1", "")
+	require.NoError(t, err)
+	assert.Empty(t, user.Level)
+
+	rules := []domain.SeniorityRule{
+		{AuthorLevel: domain.LevelMiddle, MinLevel: domain.LevelSenior, MinReviewers: 1},
+		{AuthorLevel: domain.LevelJunior, MinLevel: domain.LevelSenior, MinReviewers: 1},
+		{AuthorLevel: domain.LevelJunior, MinLevel: domain.LevelMiddle, MinReviewers: 2},
+	}
+	require.NoError(t, s.Team.SetSeniorityRules(ctx, "backend", rules))
+	assert.ErrorIs(t, s.Team.SetSeniorityRules(ctx, "ghost", rules), storageErr.ErrTeamNotFound)
+	assert.Error(t, s.Team.SetSeniorityRules(ctx, "backend", []domain.SeniorityRule{
+		{AuthorLevel: "staff", MinLevel: domain.LevelSenior, MinReviewers: 1},
+	}), "levels are checked")
+
+	sorted := []domain.SeniorityRule{rules[2], rules[1], rules[0]}
+
+	got, err := s.Team.GetSeniorityRules(ctx, "backend")
+	require.NoError(t, err)
+	assert.Equal(t, sorted, got)
+
+	got, err = s.Team.GetSeniorityRules(ctx, "ghost")
+	require.NoError(t, err)
+	assert.Empty(t, got)
+
+	team, err := s.Team.GetTeam(ctx, "backend")
+	require.NoError(t, err)
+	assert.Equal(t, sorted, team.SeniorityRules)
+
+	require.NoError(t, s.Team.RenameTeam(ctx, "backend", "platform"))
+	got, err = s.Team.GetSeniorityRules(ctx, "platform")
+	require.NoError(t, err)
+	assert.Equal(t, sorted, got, "renaming keeps the rules")
+
+	require.NoError(t, s.Team.SetSeniorityRules(ctx, "platform", nil))
+	got, err = s.Team.GetSeniorityRules(ctx, "platform")
+	require.NoError(t, err)
+	assert.Empty(t, got)
+}
+
+func testPairings(t *testing.T, s Storages) {
+	ctx := context.Background()
+
+	seed(t, s, "backend", []string{"u1", "u2", "u3", "u4"})
+
+	require.NoError(t, s.PR.CreatePR(ctx, "", "pr-1", "First", "u1", "backend"))
+	require.NoError(t, s.PR.AssignReviewers(ctx, "", "pr-1", []string{"u2", "u3"}))
+	require.NoError(t, s.PR.CreatePR(ctx, "acme/api", "pr-1", "Second", "u1", "backend"))
+	require.NoError(t, s.PR.AssignReviewers(ctx, "acme/api", "pr-1", []string{"u2"}))
+	require.NoError(t, s.PR.CreatePR(ctx, "", "pr-2", "Third", "u2", "backend"))
+	require.NoError(t, s.PR.AssignReviewers(ctx, "", "pr-2", []string{"u1"}))
+	require.NoError(t, s.PR.CreatePR(ctx, "", "pr-3", "Unreviewed", "u4", "backend"))
+
+	_, err := s.PR.SetStatusMerged(ctx, "", "pr-1")
+	require.NoError(t, err)
+
+	want := []domain.Pairing{
+		{AuthorID: "u1", ReviewerID: "u2", Count: 2},
+		{AuthorID: "u1", ReviewerID: "u3", Count: 1},
+		{AuthorID: "u2", ReviewerID: "u1", Count: 1},
+	}
+
+	pairings, err := s.PR.GetPairings(ctx, []string{"u1", "u2", "u4"}, 0)
+	require.NoError(t, err)
+	assert.Equal(t, want, pairings, "merged PRs count too")
+
+	pairings, err = s.PR.GetPairings(ctx, []string{"u1", "u2", "u4"}, time.Hour)
+	require.NoError(t, err)
+	assert.Equal(t, want, pairings, "assignments within the window")
+
+	pairings, err = s.PR.GetPairings(ctx, []string{"u2"}, 0)
+	require.NoError(t, err)
+	assert.Equal(t, want[2:], pairings)
+
+	_, err = s.PR.ReassignReviewer(ctx, "", "pr-2", replace("u1", "u3"), true)
+	require.NoError(t, err)
+	_, err = s.PR.ReassignReviewer(ctx, "", "pr-2", replace("u1", "u3"), false)
+	require.NoError(t, err)
+	_, err = s.PR.ReassignReviewer(ctx, "", "pr-2", replace("u3", ""), false)
+	require.NoError(t, err)
+
+	pairings, err = s.PR.GetPairings(ctx, []string{"u2"}, 0)
+	require.NoError(t, err)
+	assert.Equal(t, []domain.Pairing{
+		{AuthorID: "u2", ReviewerID: "u1", Count: 1},
+		{AuthorID: "u2", ReviewerID: "u3", Count: 1},
+	}, pairings, "replaced and removed reviewers still count, dry runs do not")
+
+	require.NoError(t, s.PR.FillReviewerSlots(ctx, "", "pr-2", []string{"u1"}))
+	require.NoError(t, s.PR.ImportPRs(ctx, []domain.PRImport{{PR: domain.PullRequest{
+		PullRequestID:     "pr-4",
+		PullRequestName:   "Imported",
+		AuthorID:          "u2",
+		TeamName:          "backend",
+		AssignedReviewers: []string{"u3"},
+	}}}))
+
+	pairings, err = s.PR.GetPairings(ctx, []string{"u2"}, 0)
+	require.NoError(t, err)
+	assert.Equal(t, []domain.Pairing{
+		{AuthorID: "u2", ReviewerID: "u1", Count: 2},
+		{AuthorID: "u2", ReviewerID: "u3", Count: 2},
+	}, pairings, "every assignment counts")
+
+	pairings, err = s.PR.GetPairings(ctx, nil, 0)
+	require.NoError(t, err)
+	assert.Empty(t, pairings)
+}
+
+func testImportPRs(t *testing.T, s Storages) {
+	ctx := context.Background()
+
+	seed(t, s, "backend", []string{"u1", "u2", "u3"})
+	require.NoError(t, s.PR.CreatePR(ctx, "", "pr-1", "Existing", "u1", "backend"))
+
+	imported := func(prID string, reviewers ...string) domain.PRImport {
+		var rationales []domain.AssignmentRationale
+		for _, reviewerID := range reviewers {
+			rationales = append(rationales, domain.AssignmentRationale{
+				ReviewerID: reviewerID,
+				Strategy:   domain.StrategyRandom,
+				PoolSize:   3,
+				Excluded:   []domain.ExcludedCandidate{{UserID: "u1", Reason: domain.ExclusionAuthor}},
+			})
+		}
+		return domain.PRImport{PR: domain.PullRequest{
+			PullRequestID:     prID,
+			PullRequestName:   "PR " + prID,
+			AuthorID:          "u1",
+			TeamName:          "backend",
+			AssignedReviewers: reviewers,
+			Rationales:        rationales,
+		}}
+	}
+
+	err := s.PR.ImportPRs(ctx, []domain.PRImport{imported("pr-2", "u2"), imported("pr-1", "u3")})
+	assert.ErrorIs(t, err, storageErr.ErrPRExists)
+
+	missing := imported("pr-3", "u404")
+	missing.PR.Rationales = nil
+	err = s.PR.ImportPRs(ctx, []domain.PRImport{imported("pr-2", "u2"), missing})
+	assert.ErrorIs(t, err, storageErr.ErrUserNotFound)
+
+	_, err = s.PR.GetPR(ctx, "", "pr-2")
+	assert.ErrorIs(t, err, storageErr.ErrPRNotFound, "a failed import creates nothing")
+
+	queued := imported("pr-3", "u2")
+	queued.Queued = 1
+	queued.RequiredTags = []string{"go"}
+	require.NoError(t, s.PR.ImportPRs(ctx, []domain.PRImport{imported("pr-2", "u2", "u3"), queued}))
+	require.NoError(t, s.PR.ImportPRs(ctx, nil))
+
+	pr, err := s.PR.GetPR(ctx, "", "pr-2")
+	require.NoError(t, err)
+	assert.Equal(t, "PR pr-2", pr.PullRequestName)
+	assert.Equal(t, "OPEN", pr.Status)
+	assert.ElementsMatch(t, []string{"u2", "u3"}, pr.AssignedReviewers)
+
+	rationales, err := s.PR.GetAssignmentRationales(ctx, "", "pr-2")
+	require.NoError(t, err)
+	assert.Equal(t, imported("pr-2", "u2", "u3").PR.Rationales, rationales)
+
+	pending, err := s.PR.GetPendingSlots(ctx)
+	require.NoError(t, err)
+	require.Len(t, pending, 1)
+	assert.Equal(t, "pr-3", pending[0].PR.PullRequestID)
+	assert.Equal(t, 1, pending[0].Slots)
+
+	tags, err := s.PR.GetRequiredTags(ctx, "", "pr-3")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"go"}, tags)
+}
+
+func testRequiredTags(t *testing.T, s Storages) {
+	ctx := context.Background()
+
+	seed(t, s, "backend", []string{"u1"})
+	require.NoError(t, s.PR.CreatePR(ctx, "", "pr-1", "Add search", "u1", "backend"))
+	require.NoError(t, s.PR.CreatePR(ctx, "acme/api", "pr-1", "Add search", "u1", "backend"))
+
+	tags, err := s.PR.GetRequiredTags(ctx, "", "pr-1")
+	require.NoError(t, err)
+	assert.Empty(t, tags)
+
+	require.NoError(t, s.PR.SetRequiredTags(ctx, "", "pr-1", []string{"postgres", "go"}))
+	require.NoError(t, s.PR.SetRequiredTags(ctx, "acme/api", "pr-1", []string{"rust"}))
+
+	tags, err = s.PR.GetRequiredTags(ctx, "", "pr-1")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"go", "postgres"}, tags)
+
+	require.NoError(t, s.PR.SetRequiredTags(ctx, "", "pr-1", []string{"go"}))
+	tags, err = s.PR.GetRequiredTags(ctx, "", "pr-1")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"go"}, tags, "setting replaces the tags")
+
+	tags, err = s.PR.GetRequiredTags(ctx, "acme/api", "pr-1")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"rust"}, tags, "tags are kept per repository")
+
+	require.NoError(t, s.PR.SetRequiredTags(ctx, "", "pr-1", nil))
+	tags, err = s.PR.GetRequiredTags(ctx, "", "pr-1")
+	require.NoError(t, err)
+	assert.Empty(t, tags)
+
+	assert.ErrorIs(t, s.PR.SetRequiredTags(ctx, "", "pr-404", []string{"go"}), storageErr.ErrPRNotFound)
+}
@@ -2,8 +2,10 @@ package team
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
+	"github.com/whitxowl/pr-reviewer-assignment-service.git/internal/domain"
 	storageErr "github.com/whitxowl/pr-reviewer-assignment-service.git/internal/storage/errors"
 	pg "github.com/whitxowl/pr-reviewer-assignment-service.git/pkg/postgres"
 )
@@ -47,3 +49,421 @@ func (s *Storage) TeamExists(ctx context.Context, teamName string) (bool, error)
 
 	return exists, nil
 }
+
+// GetTeam returns the team with its seniority rules, without members.
+func (s *Storage) GetTeam(ctx context.Context, teamName string) (*domain.Team, error) {
+	const op = "storage.team.GetTeam"
+
+	const query = "SELECT team_name, archived_at, max_open_reviews FROM teams WHERE team_name = $1"
+
+	var team domain.Team
+	err := s.Db.QueryRow(ctx, query, teamName).Scan(&team.TeamName, &team.ArchivedAt, &team.MaxOpenReviews)
+	if pg.IsNoRowsError(err) {
+		return nil, fmt.Errorf("%s: %w", op, storageErr.ErrTeamNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	team.SeniorityRules, err = s.GetSeniorityRules(ctx, teamName)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &team, nil
+}
+
+// GetSeniorityRules returns the seniority rules of the team, none for a team
+// that does not exist.
+func (s *Storage) GetSeniorityRules(ctx context.Context, teamName string) ([]domain.SeniorityRule, error) {
+	const op = "storage.team.GetSeniorityRules"
+
+	const query = `
+		SELECT author_level, min_level, min_reviewers
+		FROM team_seniority_rules
+		WHERE team_name = $1
+		ORDER BY ` + levelOrder + `
+	`
+
+	rows, err := s.Db.Query(ctx, query, teamName)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var rules []domain.SeniorityRule
+	for rows.Next() {
+		var rule domain.SeniorityRule
+		if err := rows.Scan(&rule.AuthorLevel, &rule.MinLevel, &rule.MinReviewers); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		rules = append(rules, rule)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return rules, nil
+}
+
+// SetSeniorityRules replaces the seniority rules of the team.
+func (s *Storage) SetSeniorityRules(ctx context.Context, teamName string, rules []domain.SeniorityRule) error {
+	const op = "storage.team.SetSeniorityRules"
+
+	tx, err := s.Db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback(ctx)
+
+	const lockQuery = "SELECT 1 FROM teams WHERE team_name = $1 FOR UPDATE"
+
+	var one int
+	err = tx.QueryRow(ctx, lockQuery, teamName).Scan(&one)
+	if pg.IsNoRowsError(err) {
+		return fmt.Errorf("%s: %w", op, storageErr.ErrTeamNotFound)
+	}
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	const deleteQuery = "DELETE FROM team_seniority_rules WHERE team_name = $1"
+
+	const insertQuery = `
+		INSERT INTO team_seniority_rules (team_name, author_level, min_level, min_reviewers)
+		VALUES ($1, $2, $3, $4)
+	`
+
+	batch := &pg.Batch{}
+	batch.Queue(deleteQuery, teamName)
+	for _, rule := range rules {
+		batch.Queue(insertQuery, teamName, rule.AuthorLevel, rule.MinLevel, rule.MinReviewers)
+	}
+
+	batchResults := tx.SendBatch(ctx, batch)
+	defer batchResults.Close()
+
+	for range batch.Len() {
+		if _, err = batchResults.Exec(); err != nil {
+			e := batchResults.Close()
+
+			return errors.Join(e, fmt.Errorf("%s: %w", op, err))
+		}
+	}
+
+	if err = batchResults.Close(); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// levelOrder orders seniority rules by author level, then by reviewer level.
+const levelOrder = `
+	CASE author_level WHEN 'junior' THEN 0 WHEN 'middle' THEN 1 ELSE 2 END,
+	CASE min_level WHEN 'junior' THEN 0 WHEN 'middle' THEN 1 ELSE 2 END
+`
+
+// RenameTeam moves the team, its memberships, PRs, repositories, code ownership and
+// seniority rules to newTeamName in one transaction.
+func (s *Storage) RenameTeam(ctx context.Context, teamName string, newTeamName string) error {
+	const op = "storage.team.RenameTeam"
+
+	tx, err := s.Db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback(ctx)
+
+	const insertQuery = `
+		INSERT INTO teams (team_name, archived_at, max_open_reviews)
+		SELECT $2, archived_at, max_open_reviews FROM teams WHERE team_name = $1
+	`
+
+	result, err := tx.Exec(ctx, insertQuery, teamName, newTeamName)
+	if pg.IsUniqueViolationError(err) {
+		return fmt.Errorf("%s: %w", op, storageErr.ErrTeamExists)
+	}
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("%s: %w", op, storageErr.ErrTeamNotFound)
+	}
+
+	moveQueries := []string{
+		"UPDATE users SET team_name = $2 WHERE team_name = $1",
+		"UPDATE team_memberships SET team_name = $2 WHERE team_name = $1",
+		"UPDATE pull_requests SET team_name = $2 WHERE team_name = $1",
+		"UPDATE repositories SET team_name = $2 WHERE team_name = $1",
+		"UPDATE code_owners SET team_name = $2 WHERE team_name = $1",
+		"UPDATE team_seniority_rules SET team_name = $2 WHERE team_name = $1",
+	}
+
+	for _, query := range moveQueries {
+		if _, err = tx.Exec(ctx, query, teamName, newTeamName); err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	const deleteQuery = "DELETE FROM teams WHERE team_name = $1"
+
+	if _, err = tx.Exec(ctx, deleteQuery, teamName); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// SetArchived archives or unarchives the team. Archiving an archived team keeps its archived_at.
+func (s *Storage) SetArchived(ctx context.Context, teamName string, archived bool) error {
+	const op = "storage.team.SetArchived"
+
+	const query = `
+		UPDATE teams
+		SET archived_at = CASE WHEN $2 THEN COALESCE(archived_at, NOW()) END
+		WHERE team_name = $1
+	`
+
+	result, err := s.Db.Exec(ctx, query, teamName, archived)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("%s: %w", op, storageErr.ErrTeamNotFound)
+	}
+
+	return nil
+}
+
+// SetMaxOpenReviews sets the default review limit of the team's members; nil removes it.
+func (s *Storage) SetMaxOpenReviews(ctx context.Context, teamName string, limit *int) error {
+	const op = "storage.team.SetMaxOpenReviews"
+
+	const query = "UPDATE teams SET max_open_reviews = $2 WHERE team_name = $1"
+
+	result, err := s.Db.Exec(ctx, query, teamName, limit)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("%s: %w", op, storageErr.ErrTeamNotFound)
+	}
+
+	return nil
+}
+
+// DeleteTeam deletes the team only if it has no members.
+func (s *Storage) DeleteTeam(ctx context.Context, teamName string) error {
+	const op = "storage.team.DeleteTeam"
+
+	const query = `
+		DELETE FROM teams
+		WHERE team_name = $1
+		  AND NOT EXISTS (SELECT 1 FROM team_memberships WHERE team_name = $1)
+	`
+
+	result, err := s.Db.Exec(ctx, query, teamName)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if result.RowsAffected() > 0 {
+		return nil
+	}
+
+	exists, err := s.TeamExists(ctx, teamName)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if !exists {
+		return fmt.Errorf("%s: %w", op, storageErr.ErrTeamNotFound)
+	}
+
+	return fmt.Errorf("%s: %w", op, storageErr.ErrTeamNotEmpty)
+}
+
+// ApplyTeamDiff applies the whole diff in one transaction.
+func (s *Storage) ApplyTeamDiff(ctx context.Context, diff *domain.TeamDiff) error {
+	const op = "storage.team.ApplyTeamDiff"
+
+	tx, err := s.Db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback(ctx)
+
+	if err = applyTeamDiffTx(ctx, tx, diff); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// SyncTeam makes the team's roster equal to members, creating the team if needed,
+// and returns the diff it applied; see domain.NewTeamDiff. The team row is
+// created or locked before the roster is read, so the diff is computed and
+// applied in one transaction and concurrent syncs of the team run one after the
+// other. With dryRun nothing is written and the diff is only returned.
+func (s *Storage) SyncTeam(
+	ctx context.Context,
+	teamName string,
+	members []*domain.User,
+	detach bool,
+	dryRun bool,
+) (*domain.TeamDiff, error) {
+	const op = "storage.team.SyncTeam"
+
+	tx, err := s.Db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback(ctx)
+
+	// A team created by a concurrent sync is waited for and then locked.
+	const createTeamQuery = "INSERT INTO teams(team_name) VALUES ($1) ON CONFLICT (team_name) DO NOTHING"
+
+	const lockTeamQuery = "SELECT 1 FROM teams WHERE team_name = $1 FOR UPDATE"
+
+	const rosterQuery = `
+		SELECT u.user_id, u.username, m.team_name, u.is_active,
+		       (SELECT array_agg(t.tag ORDER BY t.tag) FROM user_tags t WHERE t.user_id = u.user_id),
+		       COALESCE(u.level, '')
+		FROM team_memberships m
+		JOIN users u ON u.user_id = m.user_id
+		WHERE m.team_name = $1
+		ORDER BY u.user_id
+	`
+
+	tag, err := tx.Exec(ctx, createTeamQuery, teamName)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	created := tag.RowsAffected() == 1
+
+	var current []*domain.User
+	if !created {
+		if _, err = tx.Exec(ctx, lockTeamQuery, teamName); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		rows, err := tx.Query(ctx, rosterQuery, teamName)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			var user domain.User
+			err := rows.Scan(&user.UserID, &user.Username, &user.TeamName, &user.IsActive, &user.Tags, &user.Level)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", op, err)
+			}
+			current = append(current, &user)
+		}
+
+		if err := rows.Err(); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	diff := domain.NewTeamDiff(teamName, current, members, detach)
+	diff.TeamCreated = created
+
+	if dryRun || diff.Empty() {
+		return diff, nil
+	}
+
+	if err = applyTeamDiffTx(ctx, tx, diff); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return diff, nil
+}
+
+// applyTeamDiffTx applies diff in tx.
+func applyTeamDiffTx(ctx context.Context, tx pg.Tx, diff *domain.TeamDiff) error {
+	const createTeamQuery = "INSERT INTO teams(team_name) VALUES ($1) ON CONFLICT (team_name) DO NOTHING"
+
+	const upsertUserQuery = `
+		INSERT INTO users (user_id, username, team_name, is_active)
+			VALUES ($1, $2, $3, $4)
+			ON CONFLICT (user_id)
+			DO UPDATE SET
+				username = EXCLUDED.username,
+				team_name = COALESCE(users.team_name, EXCLUDED.team_name),
+				is_active = EXCLUDED.is_active
+	`
+
+	const addMembershipQuery = "INSERT INTO team_memberships (user_id, team_name) VALUES ($1, $2) ON CONFLICT DO NOTHING"
+
+	const clearTagsQuery = "DELETE FROM user_tags WHERE user_id = $1"
+
+	const tagsQuery = "INSERT INTO user_tags (user_id, tag) SELECT $1, UNNEST($2::TEXT[]) ON CONFLICT DO NOTHING"
+
+	const deactivateQuery = `
+		UPDATE users SET is_active = false
+		WHERE user_id = $1
+		  AND EXISTS (SELECT 1 FROM team_memberships WHERE user_id = $1 AND team_name = $2)
+	`
+
+	const detachQuery = "DELETE FROM team_memberships WHERE user_id = $1 AND team_name = $2"
+
+	const detachPrimaryQuery = `
+		UPDATE users u
+		SET team_name = (SELECT MIN(m.team_name) FROM team_memberships m WHERE m.user_id = u.user_id)
+		WHERE u.user_id = $1
+		  AND u.team_name = $2
+	`
+
+	batch := &pg.Batch{}
+	if diff.TeamCreated {
+		batch.Queue(createTeamQuery, diff.TeamName)
+	}
+	for _, users := range [][]*domain.User{diff.Added, diff.Updated} {
+		for _, user := range users {
+			batch.Queue(upsertUserQuery, user.UserID, user.Username, diff.TeamName, user.IsActive)
+			batch.Queue(addMembershipQuery, user.UserID, diff.TeamName)
+			if user.Tags != nil {
+				batch.Queue(clearTagsQuery, user.UserID)
+				batch.Queue(tagsQuery, user.UserID, user.Tags)
+			}
+		}
+	}
+	for _, user := range diff.Deactivated {
+		batch.Queue(deactivateQuery, user.UserID, diff.TeamName)
+	}
+	for _, user := range diff.Detached {
+		batch.Queue(detachQuery, user.UserID, diff.TeamName)
+		batch.Queue(detachPrimaryQuery, user.UserID, diff.TeamName)
+	}
+
+	batchResults := tx.SendBatch(ctx, batch)
+	defer batchResults.Close()
+
+	for range batch.Len() {
+		if _, err := batchResults.Exec(); err != nil {
+			e := batchResults.Close()
+
+			return errors.Join(e, err)
+		}
+	}
+
+	return batchResults.Close()
+}
@@ -0,0 +1,175 @@
+package user
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/whitxowl/pr-reviewer-assignment-service.git/internal/domain"
+	storageErr "github.com/whitxowl/pr-reviewer-assignment-service.git/internal/storage/errors"
+	pg "github.com/whitxowl/pr-reviewer-assignment-service.git/pkg/postgres"
+)
+
+const absenceColumns = `
+	absence_id, user_id, starts_at, ends_at, reason, reassign, reassigned_at, COALESCE(uid, '')
+`
+
+// SaveAbsences adds the absences of the user. An absence with a UID replaces the
+// absence of the user saved with the same UID; if it moved, it is handed over
+// again when it starts.
+func (s *Storage) SaveAbsences(ctx context.Context, userID string, absences []domain.Absence) ([]domain.Absence, error) {
+	const op = "storage.user.SaveAbsences"
+
+	const query = `
+		INSERT INTO user_absences (user_id, starts_at, ends_at, reason, reassign, uid)
+		VALUES ($1, $2, $3, $4, $5, NULLIF($6, ''))
+		ON CONFLICT (user_id, uid) DO UPDATE
+		SET starts_at     = excluded.starts_at,
+		    ends_at       = excluded.ends_at,
+		    reason        = excluded.reason,
+		    reassign      = excluded.reassign,
+		    reassigned_at = CASE
+		        WHEN user_absences.starts_at = excluded.starts_at THEN user_absences.reassigned_at
+		    END
+		RETURNING ` + absenceColumns
+
+	tx, err := s.Db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback(ctx)
+
+	result := make([]domain.Absence, 0, len(absences))
+	for _, absence := range absences {
+		row := tx.QueryRow(ctx, query,
+			userID,
+			absence.StartsAt,
+			absence.EndsAt,
+			absence.Reason,
+			absence.Reassign,
+			absence.UID,
+		)
+
+		saved, err := scanAbsence(row)
+		if pg.IsForeignKeyErr(err) {
+			return nil, fmt.Errorf("%s: %w", op, storageErr.ErrUserNotFound)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		result = append(result, saved)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return result, nil
+}
+
+// GetAbsences returns the absences of the user, earliest first.
+func (s *Storage) GetAbsences(ctx context.Context, userID string) ([]domain.Absence, error) {
+	const op = "storage.user.GetAbsences"
+
+	const query = `
+		SELECT ` + absenceColumns + `
+		FROM user_absences
+		WHERE user_id = $1
+		ORDER BY starts_at, absence_id
+	`
+
+	absences, err := s.queryAbsences(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return absences, nil
+}
+
+func (s *Storage) DeleteAbsence(ctx context.Context, userID string, absenceID int64) error {
+	const op = "storage.user.DeleteAbsence"
+
+	const query = `DELETE FROM user_absences WHERE absence_id = $1 AND user_id = $2`
+
+	tag, err := s.Db.Exec(ctx, query, absenceID, userID)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("%s: %w", op, storageErr.ErrAbsenceNotFound)
+	}
+
+	return nil
+}
+
+// GetStartedAbsences returns the absences in progress whose open reviews are to
+// be handed over and have not been yet, earliest first.
+func (s *Storage) GetStartedAbsences(ctx context.Context) ([]domain.Absence, error) {
+	const op = "storage.user.GetStartedAbsences"
+
+	const query = `
+		SELECT ` + absenceColumns + `
+		FROM user_absences
+		WHERE reassign
+		  AND reassigned_at IS NULL
+		  AND starts_at <= NOW()
+		  AND ends_at > NOW()
+		ORDER BY starts_at, absence_id
+	`
+
+	absences, err := s.queryAbsences(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return absences, nil
+}
+
+func (s *Storage) MarkAbsenceReassigned(ctx context.Context, absenceID int64) error {
+	const op = "storage.user.MarkAbsenceReassigned"
+
+	const query = `UPDATE user_absences SET reassigned_at = NOW() WHERE absence_id = $1`
+
+	tag, err := s.Db.Exec(ctx, query, absenceID)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("%s: %w", op, storageErr.ErrAbsenceNotFound)
+	}
+
+	return nil
+}
+
+func (s *Storage) queryAbsences(ctx context.Context, query string, args ...any) ([]domain.Absence, error) {
+	rows, err := s.Db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var absences []domain.Absence
+	for rows.Next() {
+		absence, err := scanAbsence(rows)
+		if err != nil {
+			return nil, err
+		}
+		absences = append(absences, absence)
+	}
+
+	return absences, rows.Err()
+}
+
+func scanAbsence(row interface{ Scan(dest ...any) error }) (domain.Absence, error) {
+	var absence domain.Absence
+	err := row.Scan(
+		&absence.ID,
+		&absence.UserID,
+		&absence.StartsAt,
+		&absence.EndsAt,
+		&absence.Reason,
+		&absence.Reassign,
+		&absence.ReassignedAt,
+		&absence.UID,
+	)
+	return absence, err
+}
@@ -0,0 +1,77 @@
+package user
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/whitxowl/pr-reviewer-assignment-service.git/internal/domain"
+	storageErr "github.com/whitxowl/pr-reviewer-assignment-service.git/internal/storage/errors"
+	pg "github.com/whitxowl/pr-reviewer-assignment-service.git/pkg/postgres"
+)
+
+// SetWorkingHours sets the user's time zone and schedule; nil hours clear the schedule.
+func (s *Storage) SetWorkingHours(
+	ctx context.Context,
+	userID string,
+	timezone string,
+	hours *domain.WorkingHours,
+) (*domain.User, error) {
+	const op = "storage.user.SetWorkingHours"
+
+	const query = `
+		UPDATE users
+		SET timezone = NULLIF($2, ''), work_start = $3, work_end = $4, work_days = $5
+		WHERE user_id = $1
+		RETURNING user_id, username, COALESCE(team_name, ''), is_active, max_open_reviews,
+		          timezone, work_start, work_end, work_days
+	`
+
+	var start, end, days *int
+	if hours != nil {
+		mask := hours.DayMask()
+		start, end, days = &hours.Start, &hours.End, &mask
+	}
+
+	var user domain.User
+	var sched schedule
+
+	err := s.Db.QueryRow(ctx, query, userID, timezone, start, end, days).Scan(append([]any{
+		&user.UserID,
+		&user.Username,
+		&user.TeamName,
+		&user.IsActive,
+		&user.MaxOpenReviews,
+	}, sched.dest()...)...)
+	if pg.IsNoRowsError(err) {
+		return nil, fmt.Errorf("%s: %w", op, storageErr.ErrUserNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	sched.apply(&user)
+
+	return &user, nil
+}
+
+// schedule receives the time zone and working hours columns of a user, in the
+// order of the migration: timezone, work_start, work_end, work_days.
+type schedule struct {
+	timezone *string
+	start    *int
+	end      *int
+	days     *int
+}
+
+func (sc *schedule) dest() []any {
+	return []any{&sc.timezone, &sc.start, &sc.end, &sc.days}
+}
+
+func (sc *schedule) apply(user *domain.User) {
+	if sc.timezone != nil {
+		user.Timezone = *sc.timezone
+	}
+	if sc.start != nil && sc.end != nil && sc.days != nil {
+		user.WorkingHours = &domain.WorkingHours{Start: *sc.start, End: *sc.end, Days: domain.WorkDays(*sc.days)}
+	}
+}
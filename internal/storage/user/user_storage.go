@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/whitxowl/pr-reviewer-assignment-service.git/internal/domain"
 	storageErr "github.com/whitxowl/pr-reviewer-assignment-service.git/internal/storage/errors"
@@ -20,6 +21,9 @@ func New(db pg.DB) *Storage {
 	}
 }
 
+// UpsertUsers creates or updates the users and makes each a member of its TeamName.
+// An existing user keeps its primary team; a user without one gets TeamName as primary.
+// Tags replace the user's tags unless nil.
 func (s *Storage) UpsertUsers(ctx context.Context, users []*domain.User) error {
 	const op = "storage.user.UpsertUsers"
 
@@ -35,18 +39,33 @@ func (s *Storage) UpsertUsers(ctx context.Context, users []*domain.User) error {
             ON CONFLICT (user_id)
             DO UPDATE SET
                 username = EXCLUDED.username,
-                team_name = EXCLUDED.team_name,
+                team_name = COALESCE(users.team_name, EXCLUDED.team_name),
                 is_active = EXCLUDED.is_active
 	`
 
+	const membershipQuery = `
+		INSERT INTO team_memberships (user_id, team_name)
+			VALUES ($1, $2)
+			ON CONFLICT DO NOTHING
+	`
+
+	const clearTagsQuery = "DELETE FROM user_tags WHERE user_id = $1"
+
+	const tagsQuery = "INSERT INTO user_tags (user_id, tag) SELECT $1, UNNEST($2::TEXT[]) ON CONFLICT DO NOTHING"
+
 	batch := &pg.Batch{}
 	for _, member := range users {
 		batch.Queue(query, member.UserID, member.Username, member.TeamName, member.IsActive)
+		batch.Queue(membershipQuery, member.UserID, member.TeamName)
+		if member.Tags != nil {
+			batch.Queue(clearTagsQuery, member.UserID)
+			batch.Queue(tagsQuery, member.UserID, member.Tags)
+		}
 	}
 	batchResults := tx.SendBatch(ctx, batch)
 	defer batchResults.Close()
 
-	for range users {
+	for range batch.Len() {
 		_, err = batchResults.Exec()
 		if err != nil {
 			e := batchResults.Close()
@@ -69,7 +88,15 @@ func (s *Storage) UpsertUsers(ctx context.Context, users []*domain.User) error {
 func (s *Storage) GetUsersByTeamName(ctx context.Context, teamName string) ([]*domain.User, error) {
 	const op = "storage.user.GetUsersByTeamName"
 
-	const query = "SELECT user_id, username, is_active FROM users WHERE team_name = $1"
+	const query = `
+		SELECT u.user_id, u.username, m.team_name, u.is_active,
+		       (SELECT array_agg(t.tag ORDER BY t.tag) FROM user_tags t WHERE t.user_id = u.user_id),
+		       COALESCE(u.level, '')
+		FROM team_memberships m
+		JOIN users u ON u.user_id = m.user_id
+		WHERE m.team_name = $1
+		ORDER BY u.user_id
+	`
 
 	rows, err := s.Db.Query(ctx, query, teamName)
 	if err != nil {
@@ -80,7 +107,7 @@ func (s *Storage) GetUsersByTeamName(ctx context.Context, teamName string) ([]*d
 	var users []*domain.User
 	for rows.Next() {
 		var user domain.User
-		err := rows.Scan(&user.UserID, &user.Username, &user.IsActive)
+		err := rows.Scan(&user.UserID, &user.Username, &user.TeamName, &user.IsActive, &user.Tags, &user.Level)
 		if err != nil {
 			return nil, fmt.Errorf("%s: %w", op, err)
 		}
@@ -97,7 +124,7 @@ func (s *Storage) GetUsersByTeamName(ctx context.Context, teamName string) ([]*d
 func (s *Storage) SetIsActive(ctx context.Context, userID string, isActive bool) (*domain.User, error) {
 	const op = "storage.user.SetIsActive"
 
-	const query = "UPDATE users SET is_active = $1 WHERE user_id = $2 RETURNING user_id, username, team_name, is_active"
+	const query = "UPDATE users SET is_active = $1 WHERE user_id = $2 RETURNING user_id, username, COALESCE(team_name, ''), is_active"
 
 	var user domain.User
 
@@ -117,60 +144,421 @@ func (s *Storage) SetIsActive(ctx context.Context, userID string, isActive bool)
 	return &user, nil
 }
 
-func (s *Storage) UserExistsAndHasTeam(ctx context.Context, userID string) (bool, error) {
-	const op = "storage.user.UserExistsAndHasTeam"
+func (s *Storage) SetUsername(ctx context.Context, userID string, username string) (*domain.User, error) {
+	const op = "storage.user.SetUsername"
 
-	const query = "SELECT EXISTS(SELECT 1 FROM users WHERE user_id = $1 AND team_name IS NOT NULL)"
+	const query = "UPDATE users SET username = $1 WHERE user_id = $2 RETURNING user_id, username, COALESCE(team_name, ''), is_active"
 
-	var exists bool
-	err := s.Db.QueryRow(ctx, query, userID).Scan(&exists)
+	var user domain.User
+
+	err := s.Db.QueryRow(ctx, query, username, userID).Scan(
+		&user.UserID,
+		&user.Username,
+		&user.TeamName,
+		&user.IsActive,
+	)
+	if pg.IsNoRowsError(err) {
+		return nil, fmt.Errorf("%s: %w", op, storageErr.ErrUserNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &user, nil
+}
+
+// SetMaxOpenReviews sets the user's own review limit; nil restores the team default.
+func (s *Storage) SetMaxOpenReviews(ctx context.Context, userID string, limit *int) (*domain.User, error) {
+	const op = "storage.user.SetMaxOpenReviews"
+
+	const query = `
+		UPDATE users SET max_open_reviews = $1 WHERE user_id = $2
+		RETURNING user_id, username, COALESCE(team_name, ''), is_active, max_open_reviews
+	`
+
+	var user domain.User
+
+	err := s.Db.QueryRow(ctx, query, limit, userID).Scan(
+		&user.UserID,
+		&user.Username,
+		&user.TeamName,
+		&user.IsActive,
+		&user.MaxOpenReviews,
+	)
+	if pg.IsNoRowsError(err) {
+		return nil, fmt.Errorf("%s: %w", op, storageErr.ErrUserNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &user, nil
+}
+
+// SetLevel sets the user's seniority; an empty level clears it.
+func (s *Storage) SetLevel(ctx context.Context, userID string, level string) (*domain.User, error) {
+	const op = "storage.user.SetLevel"
+
+	const query = `
+		UPDATE users SET level = NULLIF($1, '') WHERE user_id = $2
+		RETURNING user_id, username, COALESCE(team_name, ''), is_active, COALESCE(level, '')
+	`
+
+	var user domain.User
+
+	err := s.Db.QueryRow(ctx, query, level, userID).Scan(
+		&user.UserID,
+		&user.Username,
+		&user.TeamName,
+		&user.IsActive,
+		&user.Level,
+	)
+	if pg.IsNoRowsError(err) {
+		return nil, fmt.Errorf("%s: %w", op, storageErr.ErrUserNotFound)
+	}
 	if err != nil {
-		return false, fmt.Errorf("%s: %w", op, err)
+		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
-	return exists, nil
+	return &user, nil
 }
 
-func (s *Storage) GetPotentialReviewersIDs(
-	ctx context.Context,
-	authorID string,
-	userID string, // in case of reassignment
-	limit int,
-) ([]string, error) {
-	const op = "storage.user.GetPotentialReviewersIDs"
+// GetReviewerPool returns all members of the team ordered by user ID, inactive
+// ones included, so that the caller can tell why a member is not eligible. An
+// archived team has no pool.
+func (s *Storage) GetReviewerPool(ctx context.Context, teamName string) ([]*domain.User, error) {
+	const op = "storage.user.GetReviewerPool"
 
 	const query = `
-        SELECT u2.user_id
-        FROM users u1
-        JOIN users u2 ON u1.team_name = u2.team_name
-        WHERE u1.user_id = $1
-          AND u2.user_id != $1
-          AND u2.user_id != $2
-          AND u2.is_active = true
-          AND u1.team_name IS NOT NULL
-        ORDER BY RANDOM()
-        LIMIT $3
+        SELECT ` + poolColumns + `
+        FROM team_memberships m
+        JOIN users u ON u.user_id = m.user_id
+        JOIN teams t ON t.team_name = m.team_name
+        WHERE m.team_name = $1
+          AND t.archived_at IS NULL
+        ORDER BY u.user_id
     `
 
-	rows, err := s.Db.Query(ctx, query, authorID, userID, limit)
+	users, err := s.queryPool(ctx, query, teamName)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return users, nil
+}
+
+// GetCodeOwnerPool returns, ordered by user ID, the users owning code through
+// userIDs directly or as members of an unarchived team of teamNames, inactive ones
+// included.
+func (s *Storage) GetCodeOwnerPool(ctx context.Context, userIDs []string, teamNames []string) ([]*domain.User, error) {
+	const op = "storage.user.GetCodeOwnerPool"
+
+	const query = `
+		SELECT ` + poolColumns + `
+		FROM users u
+		WHERE u.user_id = ANY($1) OR EXISTS (
+			SELECT 1
+			FROM team_memberships m
+			JOIN teams t ON t.team_name = m.team_name
+			WHERE m.user_id = u.user_id
+			  AND m.team_name = ANY($2)
+			  AND t.archived_at IS NULL
+		)
+		ORDER BY u.user_id
+	`
+
+	users, err := s.queryPool(ctx, query, userIDs, teamNames)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return users, nil
+}
+
+// poolColumns are the pool columns of users u scanned by queryPool: the open
+// reviews, the effective review limit, whether the user is absent, the tags and
+// the level follow the user's columns, then the user's schedule.
+const poolColumns = `
+	u.user_id, u.username, u.is_active,
+	(SELECT COUNT(*)
+	 FROM pull_request_reviewers r
+	 JOIN pull_requests p ON p.repository = r.repository AND p.pull_request_id = r.pull_request_id
+	 WHERE r.user_id = u.user_id AND p.status = 'OPEN'),
+	COALESCE(u.max_open_reviews, (SELECT t.max_open_reviews FROM teams t WHERE t.team_name = u.team_name)),
+	EXISTS (SELECT 1 FROM user_absences a WHERE a.user_id = u.user_id AND a.starts_at <= NOW() AND a.ends_at > NOW()),
+	(SELECT array_agg(t.tag ORDER BY t.tag) FROM user_tags t WHERE t.user_id = u.user_id),
+	COALESCE(u.level, ''),
+	u.timezone, u.work_start, u.work_end, u.work_days
+`
+
+func (s *Storage) queryPool(ctx context.Context, query string, args ...any) ([]*domain.User, error) {
+	rows, err := s.Db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var users []*domain.User
+	for rows.Next() {
+		var user domain.User
+		var sched schedule
+		err := rows.Scan(append([]any{
+			&user.UserID,
+			&user.Username,
+			&user.IsActive,
+			&user.OpenReviews,
+			&user.ReviewLimit,
+			&user.Absent,
+			&user.Tags,
+			&user.Level,
+		}, sched.dest()...)...)
+		if err != nil {
+			return nil, err
+		}
+		sched.apply(&user)
+		users = append(users, &user)
+	}
+
+	return users, rows.Err()
+}
+
+// GetUser returns the user with its primary team as TeamName and all of its teams as TeamNames.
+func (s *Storage) GetUser(ctx context.Context, userID string) (*domain.User, error) {
+	const op = "storage.user.GetUser"
+
+	const query = `
+		SELECT u.user_id, u.username, COALESCE(u.team_name, ''), u.is_active,
+		       ARRAY(SELECT m.team_name FROM team_memberships m WHERE m.user_id = u.user_id ORDER BY m.team_name),
+		       u.max_open_reviews,
+		       (SELECT array_agg(t.tag ORDER BY t.tag) FROM user_tags t WHERE t.user_id = u.user_id),
+		       COALESCE(u.level, ''),
+		       u.timezone, u.work_start, u.work_end, u.work_days
+		FROM users u
+		WHERE u.user_id = $1
+	`
+
+	var user domain.User
+	var sched schedule
+	err := s.Db.QueryRow(ctx, query, userID).Scan(append([]any{
+		&user.UserID,
+		&user.Username,
+		&user.TeamName,
+		&user.IsActive,
+		&user.TeamNames,
+		&user.MaxOpenReviews,
+		&user.Tags,
+		&user.Level,
+	}, sched.dest()...)...)
+	if pg.IsNoRowsError(err) {
+		return nil, fmt.Errorf("%s: %w", op, storageErr.ErrUserNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	sched.apply(&user)
+
+	return &user, nil
+}
+
+// GetUsersByIDs returns the users that exist among userIDs with their primary team as TeamName.
+// A user without a team has an empty TeamName.
+func (s *Storage) GetUsersByIDs(ctx context.Context, userIDs []string) ([]*domain.User, error) {
+	const op = "storage.user.GetUsersByIDs"
+
+	const query = `
+		SELECT user_id, username, COALESCE(team_name, ''), is_active
+		FROM users
+		WHERE user_id = ANY($1)
+		ORDER BY user_id
+	`
+
+	rows, err := s.Db.Query(ctx, query, userIDs)
 	if err != nil {
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 	defer rows.Close()
 
-	var ids []string
+	var users []*domain.User
 	for rows.Next() {
-		var id string
-		err := rows.Scan(&id)
+		var user domain.User
+		err := rows.Scan(&user.UserID, &user.Username, &user.TeamName, &user.IsActive)
 		if err != nil {
 			return nil, fmt.Errorf("%s: %w", op, err)
 		}
-		ids = append(ids, id)
+		users = append(users, &user)
 	}
 
 	if err := rows.Err(); err != nil {
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
-	return ids, nil
+	return users, nil
+}
+
+// SearchUsers returns one page of the users matching search, ordered by user_id,
+// with all of their teams as TeamNames, and the number of matching users.
+func (s *Storage) SearchUsers(ctx context.Context, search domain.UserSearch) ([]*domain.User, int, error) {
+	const op = "storage.user.SearchUsers"
+
+	const where = `
+		WHERE ($1 = '' OR lower(u.username) LIKE $1 OR lower(u.user_id) LIKE $1)
+		  AND ($2 = '' OR EXISTS (
+			SELECT 1 FROM team_memberships m WHERE m.user_id = u.user_id AND m.team_name = $2
+		  ))
+		  AND ($3::boolean IS NULL OR u.is_active = $3)
+	`
+
+	const countQuery = "SELECT COUNT(*) FROM users u" + where
+
+	const query = `
+		SELECT u.user_id, u.username, COALESCE(u.team_name, ''), u.is_active,
+		       ARRAY(SELECT m.team_name FROM team_memberships m WHERE m.user_id = u.user_id ORDER BY m.team_name)
+		FROM users u
+	` + where + `
+		ORDER BY u.user_id
+		LIMIT $4 OFFSET $5
+	`
+
+	pattern := ""
+	if search.Prefix != "" {
+		pattern = likePrefix(search.Prefix)
+	}
+
+	var total int
+	err := s.Db.QueryRow(ctx, countQuery, pattern, search.TeamName, search.IsActive).Scan(&total)
+	if err != nil {
+		return nil, 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	rows, err := s.Db.Query(ctx, query, pattern, search.TeamName, search.IsActive, search.Limit, search.Offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	users := []*domain.User{}
+	for rows.Next() {
+		var user domain.User
+		err := rows.Scan(&user.UserID, &user.Username, &user.TeamName, &user.IsActive, &user.TeamNames)
+		if err != nil {
+			return nil, 0, fmt.Errorf("%s: %w", op, err)
+		}
+		users = append(users, &user)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return users, total, nil
+}
+
+// RemoveMemberships removes the users from the team. A user whose primary team it was
+// gets another of its teams as primary, or none. Every user must be a member of the team.
+func (s *Storage) RemoveMemberships(ctx context.Context, teamName string, userIDs []string) error {
+	const op = "storage.user.RemoveMemberships"
+
+	if len(userIDs) == 0 {
+		return nil
+	}
+
+	tx, err := s.Db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback(ctx)
+
+	const deleteQuery = "DELETE FROM team_memberships WHERE team_name = $1 AND user_id = ANY($2)"
+
+	result, err := tx.Exec(ctx, deleteQuery, teamName, userIDs)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if result.RowsAffected() != int64(len(uniq(userIDs))) {
+		return fmt.Errorf("%s: %w", op, storageErr.ErrUserNotFound)
+	}
+
+	const primaryQuery = `
+		UPDATE users u
+		SET team_name = (SELECT MIN(m.team_name) FROM team_memberships m WHERE m.user_id = u.user_id)
+		WHERE u.user_id = ANY($2)
+		  AND u.team_name = $1
+	`
+
+	if _, err = tx.Exec(ctx, primaryQuery, teamName, userIDs); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
 }
+
+// MoveMembership moves the user from fromTeamName to toTeamName. With an empty fromTeamName
+// the user only joins toTeamName. toTeamName becomes the primary team if fromTeamName was,
+// or if the user had none.
+func (s *Storage) MoveMembership(ctx context.Context, userID string, fromTeamName string, toTeamName string) error {
+	const op = "storage.user.MoveMembership"
+
+	tx, err := s.Db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback(ctx)
+
+	const primaryQuery = `
+		UPDATE users
+		SET team_name = CASE WHEN team_name IS NULL OR team_name = $2 THEN $3 ELSE team_name END
+		WHERE user_id = $1
+	`
+
+	result, err := tx.Exec(ctx, primaryQuery, userID, fromTeamName, toTeamName)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("%s: %w", op, storageErr.ErrUserNotFound)
+	}
+
+	if fromTeamName != "" {
+		const deleteQuery = "DELETE FROM team_memberships WHERE user_id = $1 AND team_name = $2"
+
+		result, err = tx.Exec(ctx, deleteQuery, userID, fromTeamName)
+		if err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+		if result.RowsAffected() == 0 {
+			return fmt.Errorf("%s: %w", op, storageErr.ErrUserNotFound)
+		}
+	}
+
+	const insertQuery = "INSERT INTO team_memberships (user_id, team_name) VALUES ($1, $2) ON CONFLICT DO NOTHING"
+
+	if _, err = tx.Exec(ctx, insertQuery, userID, toTeamName); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func uniq(ids []string) map[string]struct{} {
+	set := make(map[string]struct{}, len(ids))
+	for _, id := range ids {
+		set[id] = struct{}{}
+	}
+	return set
+}
+
+// likePrefix returns a lower-cased LIKE pattern matching strings that start with prefix.
+func likePrefix(prefix string) string {
+	return likeEscaper.Replace(strings.ToLower(prefix)) + "%"
+}
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
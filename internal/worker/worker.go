@@ -0,0 +1,104 @@
+// Package worker runs background jobs on a fixed interval and on demand.
+package worker
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/whitxowl/pr-reviewer-assignment-service.git/internal/domain"
+)
+
+// Job is one run of a worker. Errors are logged and reported in the status;
+// the worker keeps running.
+type Job func(ctx context.Context) error
+
+// Worker runs its job when woken after a change the job should act on, and on a
+// fixed interval, to pick up changes made by other instances or by time passing.
+type Worker struct {
+	log      *slog.Logger
+	job      Job
+	interval time.Duration
+	wake     chan struct{}
+
+	mu     sync.RWMutex
+	status domain.WorkerStatus
+}
+
+func New(log *slog.Logger, name string, interval time.Duration, job Job) *Worker {
+	return &Worker{
+		log:      log,
+		job:      job,
+		interval: interval,
+		wake:     make(chan struct{}, 1),
+		status:   domain.WorkerStatus{Name: name},
+	}
+}
+
+// Wake asks the worker to run. It never blocks: wakes arriving while a run is
+// already due are folded into it.
+func (w *Worker) Wake() {
+	select {
+	case w.wake <- struct{}{}:
+	default:
+	}
+}
+
+// Run runs the job until ctx is done.
+func (w *Worker) Run(ctx context.Context) {
+	w.setRunning(true)
+	defer w.setRunning(false)
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		w.run(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-w.wake:
+		}
+	}
+}
+
+func (w *Worker) run(ctx context.Context) {
+	err := w.job(ctx)
+
+	ranAt := time.Now()
+
+	w.mu.Lock()
+	w.status.LastRunAt = &ranAt
+	w.status.LastError = ""
+	if err != nil {
+		w.status.LastError = err.Error()
+	}
+	w.mu.Unlock()
+
+	if err != nil {
+		w.log.ErrorContext(ctx, "worker run failed", "worker", w.status.Name, "error", err)
+	}
+}
+
+func (w *Worker) setRunning(running bool) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.status.Running = running
+}
+
+// Status reports the worker to the readiness check.
+func (w *Worker) Status() domain.WorkerStatus {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+
+	status := w.status
+	if status.LastRunAt != nil {
+		ranAt := *status.LastRunAt
+		status.LastRunAt = &ranAt
+	}
+	return status
+}
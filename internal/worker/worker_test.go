@@ -0,0 +1,66 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWorker_Run(t *testing.T) {
+	log := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	runs := make(chan struct{}, 3)
+	results := []error{nil, errors.New("query error")}
+	job := func(context.Context) error {
+		err := results[0]
+		results = results[1:]
+		runs <- struct{}{}
+		return err
+	}
+
+	// The interval is long enough that only Wake triggers the second run.
+	worker := New(log, "test_worker", time.Hour, job)
+	assert.False(t, worker.Status().Running)
+
+	done := make(chan struct{})
+	go func() {
+		worker.Run(ctx)
+		close(done)
+	}()
+
+	waitRun(t, runs)
+	assert.Eventually(t, func() bool { return worker.Status().LastRunAt != nil }, time.Second, time.Millisecond)
+
+	status := worker.Status()
+	assert.Equal(t, "test_worker", status.Name)
+	assert.True(t, status.Running)
+	assert.Empty(t, status.LastError)
+
+	worker.Wake()
+	waitRun(t, runs)
+	assert.Eventually(t, func() bool { return worker.Status().LastError == "query error" }, time.Second, time.Millisecond)
+
+	cancel()
+	<-done
+	assert.False(t, worker.Status().Running)
+	assert.Empty(t, results)
+}
+
+func waitRun(t *testing.T, runs <-chan struct{}) {
+	t.Helper()
+
+	select {
+	case <-runs:
+	case <-time.After(time.Second):
+		require.FailNow(t, "worker did not run")
+	}
+}
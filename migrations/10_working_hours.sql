@@ -0,0 +1,14 @@
+-- +goose Up
+-- The IANA time zone and weekly working hours of a user. work_start and work_end
+-- are minutes after midnight; work_days has bit N set for weekday N, Sunday
+-- being 0. The schedule columns are all set or all NULL.
+ALTER TABLE users ADD COLUMN timezone TEXT NULL;
+ALTER TABLE users ADD COLUMN work_start INT NULL CHECK (work_start BETWEEN 0 AND 1439);
+ALTER TABLE users ADD COLUMN work_end INT NULL CHECK (work_end BETWEEN 0 AND 1439);
+ALTER TABLE users ADD COLUMN work_days INT NULL CHECK (work_days BETWEEN 1 AND 127);
+
+-- +goose Down
+ALTER TABLE users DROP COLUMN work_days;
+ALTER TABLE users DROP COLUMN work_end;
+ALTER TABLE users DROP COLUMN work_start;
+ALTER TABLE users DROP COLUMN timezone;
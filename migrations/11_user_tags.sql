@@ -0,0 +1,15 @@
+-- +goose Up
+-- Skill tags of a user, matched against the tags a PR requires.
+CREATE TABLE IF NOT EXISTS user_tags
+(
+    user_id TEXT NOT NULL,
+    tag     TEXT NOT NULL,
+
+    CONSTRAINT pk_user_tags PRIMARY KEY (user_id, tag),
+    CONSTRAINT fk_tag_user FOREIGN KEY (user_id) REFERENCES users(user_id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_user_tags_tag ON user_tags (tag);
+
+-- +goose Down
+DROP TABLE IF EXISTS user_tags;
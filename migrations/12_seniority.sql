@@ -0,0 +1,20 @@
+-- +goose Up
+-- Seniority of a user: junior, middle or senior. NULL if unknown.
+ALTER TABLE users ADD COLUMN level TEXT NULL CHECK (level IN ('junior', 'middle', 'senior'));
+
+-- Seniority rules of a team: a PR by an author of author_level needs at least
+-- min_reviewers reviewers of min_level or above.
+CREATE TABLE IF NOT EXISTS team_seniority_rules
+(
+    team_name     TEXT NOT NULL,
+    author_level  TEXT NOT NULL CHECK (author_level IN ('junior', 'middle', 'senior')),
+    min_level     TEXT NOT NULL CHECK (min_level IN ('junior', 'middle', 'senior')),
+    min_reviewers INT  NOT NULL CHECK (min_reviewers > 0),
+
+    CONSTRAINT pk_team_seniority_rules PRIMARY KEY (team_name, author_level, min_level),
+    CONSTRAINT fk_seniority_rule_team FOREIGN KEY (team_name) REFERENCES teams(team_name) ON DELETE CASCADE
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS team_seniority_rules;
+ALTER TABLE users DROP COLUMN level;
@@ -0,0 +1,17 @@
+-- +goose Up
+-- Skill tags a PR requires of its reviewers, kept so that reviewers picked
+-- later for its queued slots cover them too.
+CREATE TABLE IF NOT EXISTS pull_request_required_tags
+(
+    repository      TEXT NOT NULL,
+    pull_request_id TEXT NOT NULL,
+    tag             TEXT NOT NULL,
+
+    PRIMARY KEY (repository, pull_request_id, tag),
+
+    CONSTRAINT fk_required_tag_pr FOREIGN KEY (repository, pull_request_id)
+        REFERENCES pull_requests(repository, pull_request_id) ON DELETE CASCADE
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS pull_request_required_tags;
@@ -0,0 +1,28 @@
+-- +goose Up
+-- Every assignment of a reviewer to a PR, kept when the reviewer is later
+-- replaced or removed. reviewer_id has no foreign key: the history records who
+-- was assigned at the time. author_id is the PR's, copied for pairing counts.
+CREATE TABLE IF NOT EXISTS assignment_history
+(
+    assignment_id   BIGSERIAL PRIMARY KEY,
+    repository      TEXT      NOT NULL,
+    pull_request_id TEXT      NOT NULL,
+    author_id       TEXT      NOT NULL,
+    reviewer_id     TEXT      NOT NULL,
+    assigned_at     TIMESTAMP NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT fk_history_pr FOREIGN KEY (repository, pull_request_id)
+        REFERENCES pull_requests(repository, pull_request_id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_assignment_history_author ON assignment_history (author_id, assigned_at);
+
+-- The current reviewers are the only earlier assignments known.
+INSERT INTO assignment_history (repository, pull_request_id, author_id, reviewer_id, assigned_at)
+SELECT prr.repository, prr.pull_request_id, pr.author_id, prr.user_id, pr.created_at
+FROM pull_request_reviewers prr
+JOIN pull_requests pr
+    ON pr.repository = prr.repository AND pr.pull_request_id = prr.pull_request_id;
+
+-- +goose Down
+DROP TABLE IF EXISTS assignment_history;
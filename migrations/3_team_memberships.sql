@@ -0,0 +1,32 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS team_memberships
+(
+    user_id   TEXT NOT NULL,
+    team_name TEXT NOT NULL,
+
+    PRIMARY KEY (user_id, team_name),
+
+    CONSTRAINT fk_membership_user FOREIGN KEY (user_id) REFERENCES users(user_id) ON DELETE CASCADE,
+    CONSTRAINT fk_membership_team FOREIGN KEY (team_name) REFERENCES teams(team_name) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_team_memberships_team_name ON team_memberships(team_name);
+
+-- users.team_name stays as the primary team of a user; every user is a member of it.
+INSERT INTO team_memberships (user_id, team_name)
+SELECT user_id, team_name FROM users WHERE team_name IS NOT NULL
+ON CONFLICT DO NOTHING;
+
+-- The team a PR was opened for; reviewers are picked from it.
+ALTER TABLE pull_requests
+    ADD COLUMN team_name TEXT NULL
+        CONSTRAINT fk_pr_team REFERENCES teams(team_name) ON DELETE SET NULL;
+
+UPDATE pull_requests pr
+SET team_name = u.team_name
+FROM users u
+WHERE u.user_id = pr.author_id;
+
+-- +goose Down
+ALTER TABLE pull_requests DROP COLUMN team_name;
+DROP TABLE IF EXISTS team_memberships;
@@ -0,0 +1,16 @@
+-- +goose Up
+-- ID formats are configurable and validated by the service (ID_USER_PATTERN,
+-- ID_PR_PATTERN); the schema only rejects empty IDs.
+ALTER TABLE users DROP CONSTRAINT IF EXISTS users_user_id_check;
+ALTER TABLE users ADD CONSTRAINT users_user_id_check CHECK (user_id <> '');
+
+ALTER TABLE pull_requests DROP CONSTRAINT IF EXISTS pull_requests_pull_request_id_check;
+ALTER TABLE pull_requests ADD CONSTRAINT pull_requests_pull_request_id_check CHECK (pull_request_id <> '');
+
+-- +goose Down
+-- NOT VALID keeps IDs created under other formats; only new rows are checked.
+ALTER TABLE users DROP CONSTRAINT IF EXISTS users_user_id_check;
+ALTER TABLE users ADD CONSTRAINT users_user_id_check CHECK (user_id ~ '^u[0-9]+$') NOT VALID;
+
+ALTER TABLE pull_requests DROP CONSTRAINT IF EXISTS pull_requests_pull_request_id_check;
+ALTER TABLE pull_requests ADD CONSTRAINT pull_requests_pull_request_id_check CHECK (pull_request_id ~ '^pr-[0-9]+$') NOT VALID;
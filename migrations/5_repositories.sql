@@ -0,0 +1,42 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS repositories
+(
+    name            TEXT PRIMARY KEY CHECK (name <> ''),
+    team_name       TEXT NULL,
+    reviewers_count INT  NOT NULL DEFAULT 2 CHECK (reviewers_count > 0),
+
+    CONSTRAINT fk_repository_team FOREIGN KEY (team_name) REFERENCES teams(team_name) ON DELETE SET NULL
+);
+
+-- A PR is unique within its repository. PRs created without a repository,
+-- including all PRs created before this migration, have the empty repository,
+-- which is why pull_requests.repository has no foreign key.
+ALTER TABLE pull_requests ADD COLUMN repository TEXT NOT NULL DEFAULT '';
+ALTER TABLE pull_request_reviewers ADD COLUMN repository TEXT NOT NULL DEFAULT '';
+
+ALTER TABLE pull_request_reviewers DROP CONSTRAINT fk_pr;
+ALTER TABLE pull_request_reviewers DROP CONSTRAINT pull_request_reviewers_pkey;
+ALTER TABLE pull_requests DROP CONSTRAINT pull_requests_pkey;
+
+ALTER TABLE pull_requests ADD PRIMARY KEY (repository, pull_request_id);
+ALTER TABLE pull_request_reviewers ADD PRIMARY KEY (repository, pull_request_id, user_id);
+ALTER TABLE pull_request_reviewers
+    ADD CONSTRAINT fk_pr FOREIGN KEY (repository, pull_request_id)
+        REFERENCES pull_requests(repository, pull_request_id) ON DELETE CASCADE;
+
+-- +goose Down
+-- Fails while two repositories have a PR with the same pull_request_id.
+ALTER TABLE pull_request_reviewers DROP CONSTRAINT fk_pr;
+ALTER TABLE pull_request_reviewers DROP CONSTRAINT pull_request_reviewers_pkey;
+ALTER TABLE pull_requests DROP CONSTRAINT pull_requests_pkey;
+
+ALTER TABLE pull_requests ADD PRIMARY KEY (pull_request_id);
+ALTER TABLE pull_request_reviewers ADD PRIMARY KEY (pull_request_id, user_id);
+ALTER TABLE pull_request_reviewers
+    ADD CONSTRAINT fk_pr FOREIGN KEY (pull_request_id)
+        REFERENCES pull_requests(pull_request_id) ON DELETE CASCADE;
+
+ALTER TABLE pull_request_reviewers DROP COLUMN repository;
+ALTER TABLE pull_requests DROP COLUMN repository;
+
+DROP TABLE IF EXISTS repositories;
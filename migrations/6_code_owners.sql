@@ -0,0 +1,35 @@
+-- +goose Up
+-- CODEOWNERS-style rules of a repository, in file order: the last rule matching
+-- a file owns it.
+CREATE TABLE IF NOT EXISTS code_owner_rules
+(
+    repository TEXT NOT NULL,
+    position   INT  NOT NULL,
+    pattern    TEXT NOT NULL CHECK (pattern <> ''),
+
+    PRIMARY KEY (repository, position),
+
+    CONSTRAINT fk_rule_repository FOREIGN KEY (repository) REFERENCES repositories(name) ON DELETE CASCADE
+);
+
+-- Every owner of a rule is either a user or a team.
+CREATE TABLE IF NOT EXISTS code_owners
+(
+    repository TEXT NOT NULL,
+    position   INT  NOT NULL,
+    user_id    TEXT NULL,
+    team_name  TEXT NULL,
+
+    CHECK ((user_id IS NULL) <> (team_name IS NULL)),
+    UNIQUE (repository, position, user_id),
+    UNIQUE (repository, position, team_name),
+
+    CONSTRAINT fk_owner_rule FOREIGN KEY (repository, position)
+        REFERENCES code_owner_rules(repository, position) ON DELETE CASCADE,
+    CONSTRAINT fk_owner_user FOREIGN KEY (user_id) REFERENCES users(user_id) ON DELETE CASCADE,
+    CONSTRAINT fk_owner_team FOREIGN KEY (team_name) REFERENCES teams(team_name) ON DELETE CASCADE
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS code_owners;
+DROP TABLE IF EXISTS code_owner_rules;
@@ -0,0 +1,25 @@
+-- +goose Up
+-- Maximum number of OPEN PRs a user reviews at once. NULL on a user falls back
+-- to the user's primary team; NULL on the team means no limit.
+ALTER TABLE users ADD COLUMN max_open_reviews INT NULL CHECK (max_open_reviews >= 0);
+ALTER TABLE teams ADD COLUMN max_open_reviews INT NULL CHECK (max_open_reviews >= 0);
+
+-- Reviewer slots left empty because every candidate was at capacity, waiting
+-- for later assignment.
+CREATE TABLE IF NOT EXISTS pending_reviewer_slots
+(
+    repository      TEXT      NOT NULL,
+    pull_request_id TEXT      NOT NULL,
+    slots           INT       NOT NULL CHECK (slots > 0),
+    queued_at       TIMESTAMP NOT NULL DEFAULT NOW(),
+
+    PRIMARY KEY (repository, pull_request_id),
+
+    CONSTRAINT fk_pending_pr FOREIGN KEY (repository, pull_request_id)
+        REFERENCES pull_requests(repository, pull_request_id) ON DELETE CASCADE
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS pending_reviewer_slots;
+ALTER TABLE teams DROP COLUMN max_open_reviews;
+ALTER TABLE users DROP COLUMN max_open_reviews;
@@ -0,0 +1,26 @@
+package migrations
+
+import (
+	"embed"
+	"io/fs"
+)
+
+// FS holds the goose SQL migrations so that the binary can apply them
+// without an external goose installation.
+//
+//go:embed *.sql
+var FS embed.FS
+
+//go:embed sqlite/*.sql
+var sqliteFS embed.FS
+
+// SQLiteFS holds the migrations of the SQLite storage backend.
+var SQLiteFS = mustSub(sqliteFS, "sqlite")
+
+func mustSub(fsys fs.FS, dir string) fs.FS {
+	sub, err := fs.Sub(fsys, dir)
+	if err != nil {
+		panic(err)
+	}
+	return sub
+}
@@ -0,0 +1,95 @@
+-- +goose NO TRANSACTION
+-- +goose Up
+-- ID formats are configurable and validated by the service (ID_USER_PATTERN,
+-- ID_PR_PATTERN); the schema only rejects empty IDs.
+--
+-- SQLite cannot drop a CHECK, so users and pull_requests are rebuilt. Foreign
+-- keys are switched off meanwhile so that dropping the old tables does not
+-- cascade; PRAGMA foreign_keys is a no-op inside a transaction, hence NO
+-- TRANSACTION. This relies on the single-connection pool of pkg/sqlite.
+PRAGMA foreign_keys = OFF;
+
+BEGIN;
+
+CREATE TABLE users_new
+(
+    user_id   TEXT PRIMARY KEY CHECK (user_id <> ''),
+    username  TEXT NOT NULL,
+    team_name TEXT,
+    is_active BOOLEAN DEFAULT TRUE,
+
+    CONSTRAINT fk_user_team FOREIGN KEY (team_name) REFERENCES teams(team_name) ON DELETE SET NULL
+);
+
+INSERT INTO users_new (user_id, username, team_name, is_active)
+SELECT user_id, username, team_name, is_active FROM users;
+
+DROP TABLE users;
+ALTER TABLE users_new RENAME TO users;
+
+CREATE TABLE pull_requests_new
+(
+    pull_request_id   TEXT PRIMARY KEY CHECK (pull_request_id <> ''),
+    pull_request_name TEXT NOT NULL,
+    author_id         TEXT NOT NULL,
+    status            TEXT NOT NULL DEFAULT 'OPEN' CHECK (status IN ('OPEN', 'MERGED')),
+    created_at        TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    merged_at         TIMESTAMP NULL,
+    team_name         TEXT NULL REFERENCES teams(team_name) ON DELETE SET NULL,
+
+    CONSTRAINT fk_pr_author FOREIGN KEY (author_id) REFERENCES users(user_id) ON DELETE CASCADE
+);
+
+INSERT INTO pull_requests_new (pull_request_id, pull_request_name, author_id, status, created_at, merged_at, team_name)
+SELECT pull_request_id, pull_request_name, author_id, status, created_at, merged_at, team_name FROM pull_requests;
+
+DROP TABLE pull_requests;
+ALTER TABLE pull_requests_new RENAME TO pull_requests;
+
+COMMIT;
+
+PRAGMA foreign_keys = ON;
+
+-- +goose Down
+PRAGMA foreign_keys = OFF;
+
+BEGIN;
+
+CREATE TABLE users_old
+(
+    user_id   TEXT PRIMARY KEY CHECK (user_id GLOB 'u[0-9]*' AND substr(user_id, 2) NOT GLOB '*[^0-9]*'),
+    username  TEXT NOT NULL,
+    team_name TEXT,
+    is_active BOOLEAN DEFAULT TRUE,
+
+    CONSTRAINT fk_user_team FOREIGN KEY (team_name) REFERENCES teams(team_name) ON DELETE SET NULL
+);
+
+INSERT INTO users_old (user_id, username, team_name, is_active)
+SELECT user_id, username, team_name, is_active FROM users;
+
+DROP TABLE users;
+ALTER TABLE users_old RENAME TO users;
+
+CREATE TABLE pull_requests_old
+(
+    pull_request_id   TEXT PRIMARY KEY CHECK (pull_request_id GLOB 'pr-[0-9]*' AND substr(pull_request_id, 4) NOT GLOB '*[^0-9]*'),
+    pull_request_name TEXT NOT NULL,
+    author_id         TEXT NOT NULL,
+    status            TEXT NOT NULL DEFAULT 'OPEN' CHECK (status IN ('OPEN', 'MERGED')),
+    created_at        TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    merged_at         TIMESTAMP NULL,
+    team_name         TEXT NULL REFERENCES teams(team_name) ON DELETE SET NULL,
+
+    CONSTRAINT fk_pr_author FOREIGN KEY (author_id) REFERENCES users(user_id) ON DELETE CASCADE
+);
+
+INSERT INTO pull_requests_old (pull_request_id, pull_request_name, author_id, status, created_at, merged_at, team_name)
+SELECT pull_request_id, pull_request_name, author_id, status, created_at, merged_at, team_name FROM pull_requests;
+
+DROP TABLE pull_requests;
+ALTER TABLE pull_requests_old RENAME TO pull_requests;
+
+COMMIT;
+
+PRAGMA foreign_keys = ON;
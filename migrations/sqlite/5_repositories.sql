@@ -0,0 +1,111 @@
+-- +goose NO TRANSACTION
+-- +goose Up
+-- pull_requests and pull_request_reviewers get new primary keys, which SQLite
+-- can only do by rebuilding them; see 4_relax_id_checks.sql for why foreign
+-- keys are switched off outside of a transaction.
+PRAGMA foreign_keys = OFF;
+
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS repositories
+(
+    name            TEXT PRIMARY KEY CHECK (name <> ''),
+    team_name       TEXT NULL,
+    reviewers_count INTEGER NOT NULL DEFAULT 2 CHECK (reviewers_count > 0),
+
+    CONSTRAINT fk_repository_team FOREIGN KEY (team_name) REFERENCES teams(team_name) ON DELETE SET NULL
+);
+
+-- A PR is unique within its repository. PRs created without a repository,
+-- including all PRs created before this migration, have the empty repository,
+-- which is why pull_requests.repository has no foreign key.
+CREATE TABLE pull_requests_new
+(
+    repository        TEXT NOT NULL DEFAULT '',
+    pull_request_id   TEXT NOT NULL CHECK (pull_request_id <> ''),
+    pull_request_name TEXT NOT NULL,
+    author_id         TEXT NOT NULL,
+    status            TEXT NOT NULL DEFAULT 'OPEN' CHECK (status IN ('OPEN', 'MERGED')),
+    created_at        TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    merged_at         TIMESTAMP NULL,
+    team_name         TEXT NULL REFERENCES teams(team_name) ON DELETE SET NULL,
+
+    PRIMARY KEY (repository, pull_request_id),
+
+    CONSTRAINT fk_pr_author FOREIGN KEY (author_id) REFERENCES users(user_id) ON DELETE CASCADE
+);
+
+INSERT INTO pull_requests_new (pull_request_id, pull_request_name, author_id, status, created_at, merged_at, team_name)
+SELECT pull_request_id, pull_request_name, author_id, status, created_at, merged_at, team_name FROM pull_requests;
+
+CREATE TABLE pull_request_reviewers_new
+(
+    repository      TEXT NOT NULL DEFAULT '',
+    pull_request_id TEXT NOT NULL,
+    user_id         TEXT NOT NULL,
+
+    PRIMARY KEY (repository, pull_request_id, user_id),
+
+    CONSTRAINT fk_pr FOREIGN KEY (repository, pull_request_id)
+        REFERENCES pull_requests(repository, pull_request_id) ON DELETE CASCADE,
+    CONSTRAINT fk_reviewer FOREIGN KEY (user_id) REFERENCES users(user_id) ON DELETE CASCADE
+);
+
+INSERT INTO pull_request_reviewers_new (pull_request_id, user_id)
+SELECT pull_request_id, user_id FROM pull_request_reviewers;
+
+DROP TABLE pull_request_reviewers;
+DROP TABLE pull_requests;
+ALTER TABLE pull_requests_new RENAME TO pull_requests;
+ALTER TABLE pull_request_reviewers_new RENAME TO pull_request_reviewers;
+
+COMMIT;
+
+PRAGMA foreign_keys = ON;
+
+-- +goose Down
+-- Fails while two repositories have a PR with the same pull_request_id.
+PRAGMA foreign_keys = OFF;
+
+BEGIN;
+
+CREATE TABLE pull_requests_old
+(
+    pull_request_id   TEXT PRIMARY KEY CHECK (pull_request_id <> ''),
+    pull_request_name TEXT NOT NULL,
+    author_id         TEXT NOT NULL,
+    status            TEXT NOT NULL DEFAULT 'OPEN' CHECK (status IN ('OPEN', 'MERGED')),
+    created_at        TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    merged_at         TIMESTAMP NULL,
+    team_name         TEXT NULL REFERENCES teams(team_name) ON DELETE SET NULL,
+
+    CONSTRAINT fk_pr_author FOREIGN KEY (author_id) REFERENCES users(user_id) ON DELETE CASCADE
+);
+
+INSERT INTO pull_requests_old (pull_request_id, pull_request_name, author_id, status, created_at, merged_at, team_name)
+SELECT pull_request_id, pull_request_name, author_id, status, created_at, merged_at, team_name FROM pull_requests;
+
+CREATE TABLE pull_request_reviewers_old
+(
+    pull_request_id TEXT NOT NULL,
+    user_id         TEXT NOT NULL,
+
+    PRIMARY KEY (pull_request_id, user_id),
+
+    CONSTRAINT fk_pr FOREIGN KEY (pull_request_id) REFERENCES pull_requests(pull_request_id) ON DELETE CASCADE,
+    CONSTRAINT fk_reviewer FOREIGN KEY (user_id) REFERENCES users(user_id) ON DELETE CASCADE
+);
+
+INSERT INTO pull_request_reviewers_old (pull_request_id, user_id)
+SELECT pull_request_id, user_id FROM pull_request_reviewers;
+
+DROP TABLE pull_request_reviewers;
+DROP TABLE pull_requests;
+ALTER TABLE pull_requests_old RENAME TO pull_requests;
+ALTER TABLE pull_request_reviewers_old RENAME TO pull_request_reviewers;
+
+DROP TABLE IF EXISTS repositories;
+
+COMMIT;
+
+PRAGMA foreign_keys = ON;
@@ -0,0 +1,37 @@
+-- +goose Up
+-- Why each current reviewer of a PR was assigned. A rationale goes away with
+-- its assignment.
+CREATE TABLE IF NOT EXISTS assignment_rationales
+(
+    repository      TEXT    NOT NULL,
+    pull_request_id TEXT    NOT NULL,
+    reviewer_id     TEXT    NOT NULL,
+    strategy        TEXT    NOT NULL CHECK (strategy <> ''),
+    rule            TEXT    NOT NULL DEFAULT '',
+    pool_size       INTEGER NOT NULL CHECK (pool_size >= 0),
+
+    PRIMARY KEY (repository, pull_request_id, reviewer_id),
+
+    CONSTRAINT fk_rationale_reviewer FOREIGN KEY (repository, pull_request_id, reviewer_id)
+        REFERENCES pull_request_reviewers(repository, pull_request_id, user_id) ON DELETE CASCADE
+);
+
+-- Candidates left out of the pool of an assignment. user_id has no foreign key:
+-- the rationale records who was considered at the time.
+CREATE TABLE IF NOT EXISTS assignment_exclusions
+(
+    repository      TEXT NOT NULL,
+    pull_request_id TEXT NOT NULL,
+    reviewer_id     TEXT NOT NULL,
+    user_id         TEXT NOT NULL,
+    reason          TEXT NOT NULL CHECK (reason <> ''),
+
+    PRIMARY KEY (repository, pull_request_id, reviewer_id, user_id),
+
+    CONSTRAINT fk_exclusion_rationale FOREIGN KEY (repository, pull_request_id, reviewer_id)
+        REFERENCES assignment_rationales(repository, pull_request_id, reviewer_id) ON DELETE CASCADE
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS assignment_exclusions;
+DROP TABLE IF EXISTS assignment_rationales;
@@ -0,0 +1,23 @@
+-- +goose Up
+-- Periods in which a user is not selected as a reviewer. uid is set on absences
+-- imported from a calendar so that a re-import updates them in place.
+CREATE TABLE IF NOT EXISTS user_absences
+(
+    absence_id    INTEGER   PRIMARY KEY AUTOINCREMENT,
+    user_id       TEXT      NOT NULL,
+    starts_at     TIMESTAMP NOT NULL,
+    ends_at       TIMESTAMP NOT NULL,
+    reason        TEXT      NOT NULL DEFAULT '',
+    reassign      BOOLEAN   NOT NULL DEFAULT FALSE,
+    reassigned_at TIMESTAMP NULL,
+    uid           TEXT      NULL,
+
+    CONSTRAINT fk_absence_user FOREIGN KEY (user_id) REFERENCES users(user_id) ON DELETE CASCADE,
+    CONSTRAINT chk_absence_period CHECK (julianday(ends_at) > julianday(starts_at)),
+    CONSTRAINT uq_absence_uid UNIQUE (user_id, uid)
+);
+
+CREATE INDEX IF NOT EXISTS idx_user_absences_user ON user_absences (user_id, starts_at);
+
+-- +goose Down
+DROP TABLE IF EXISTS user_absences;
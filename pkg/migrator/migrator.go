@@ -0,0 +1,138 @@
+package migrator
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log/slog"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/jackc/pgx/v5/stdlib"
+	"github.com/pressly/goose/v3"
+	"github.com/pressly/goose/v3/lock"
+)
+
+var ErrNewMigrator = errors.New("failed to create new migrator instance")
+
+type Result = goose.MigrationResult
+
+type Status = goose.MigrationStatus
+
+type Migrator struct {
+	provider *goose.Provider
+}
+
+// New creates a migrator for an arbitrary database/sql connection.
+// Extra goose options (e.g. a session locker) may be supplied by the caller.
+func New(db *sql.DB, dialect goose.Dialect, fsys fs.FS, log *slog.Logger, opts ...goose.ProviderOption) (*Migrator, error) {
+	opts = append(opts, goose.WithSlog(log))
+
+	provider, err := goose.NewProvider(dialect, db, fsys, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrNewMigrator, err.Error())
+	}
+
+	return &Migrator{
+		provider: provider,
+	}, nil
+}
+
+// NewPostgres creates a migrator on top of the pgx pool. Every migration run
+// holds a Postgres advisory lock, so concurrent replicas never migrate at the same time.
+func NewPostgres(pool *pgxpool.Pool, fsys fs.FS, log *slog.Logger) (*Migrator, error) {
+	locker, err := lock.NewPostgresSessionLocker()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrNewMigrator, err.Error())
+	}
+
+	db := stdlib.OpenDBFromPool(pool)
+
+	m, err := New(db, goose.DialectPostgres, fsys, log, goose.WithSessionLocker(locker))
+	if err != nil {
+		db.Close()
+
+		return nil, err
+	}
+
+	return m, nil
+}
+
+// NewSQLite creates a migrator for a SQLite database. SQLite serializes
+// writers itself, so no extra locking is needed.
+func NewSQLite(db *sql.DB, fsys fs.FS, log *slog.Logger) (*Migrator, error) {
+	return New(db, goose.DialectSQLite3, fsys, log)
+}
+
+// LatestVersion returns the version of the newest known migration.
+func (m *Migrator) LatestVersion() int64 {
+	sources := m.provider.ListSources()
+	if len(sources) == 0 {
+		return 0
+	}
+
+	return sources[len(sources)-1].Version
+}
+
+func (m *Migrator) Up(ctx context.Context) ([]*Result, error) {
+	const op = "migrator.Up"
+
+	results, err := m.provider.Up(ctx)
+	if err != nil {
+		return results, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return results, nil
+}
+
+func (m *Migrator) Down(ctx context.Context) ([]*Result, error) {
+	const op = "migrator.Down"
+
+	result, err := m.provider.Down(ctx)
+	if errors.Is(err, goose.ErrNoNextVersion) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return []*Result{result}, nil
+}
+
+// To migrates up or down until the database is at the given version.
+func (m *Migrator) To(ctx context.Context, version int64) ([]*Result, error) {
+	const op = "migrator.To"
+
+	current, err := m.provider.GetDBVersion(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	var results []*Result
+	if version >= current {
+		results, err = m.provider.UpTo(ctx, version)
+	} else {
+		results, err = m.provider.DownTo(ctx, version)
+	}
+	if err != nil {
+		return results, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return results, nil
+}
+
+func (m *Migrator) Status(ctx context.Context) ([]*Status, error) {
+	const op = "migrator.Status"
+
+	statuses, err := m.provider.Status(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return statuses, nil
+}
+
+func (m *Migrator) Close() error {
+	return m.provider.Close()
+}
@@ -10,6 +10,7 @@ import (
 const (
 	UniqueViolationCode        = "23505"
 	ErrForeignKeyViolationCode = "23503"
+	UndefinedTableCode         = "42P01"
 )
 
 func IsUniqueViolationError(err error) bool {
@@ -34,3 +35,11 @@ func IsForeignKeyErr(err error) bool {
 func IsNoRowsError(err error) bool {
 	return errors.Is(err, pgx.ErrNoRows)
 }
+
+func IsUndefinedTableError(err error) bool {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		return pgErr.Code == UndefinedTableCode
+	}
+	return false
+}
@@ -54,6 +54,14 @@ type DB interface {
 
 type Batch = pgx.Batch
 
+// Identifier names the table of a CopyFrom.
+type Identifier = pgx.Identifier
+
+// CopyFromRows is the source of a CopyFrom from rows held in memory.
+func CopyFromRows(rows [][]any) pgx.CopyFromSource {
+	return pgx.CopyFromRows(rows)
+}
+
 type Querier interface {
 	Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error)
 	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
@@ -64,4 +72,10 @@ type Tx interface {
 	DB
 	Querier
 	SendBatch(ctx context.Context, b *Batch) pgx.BatchResults
+	CopyFrom(ctx context.Context, table Identifier, columns []string, rows pgx.CopyFromSource) (int64, error)
+}
+
+type Pool interface {
+	DB
+	Ping(ctx context.Context) error
 }
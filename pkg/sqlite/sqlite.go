@@ -0,0 +1,34 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	_ "modernc.org/sqlite"
+)
+
+var ErrOpen = errors.New("failed to open sqlite database")
+
+// Open opens the database file at path with foreign keys enforced, the same
+// way Postgres always enforces them. SQLite allows one writer at a time, so
+// the pool is limited to a single connection.
+func Open(ctx context.Context, path string) (*sql.DB, error) {
+	dsn := fmt.Sprintf("file:%s?_pragma=foreign_keys(1)&_pragma=busy_timeout(5000)&_time_format=sqlite", path)
+
+	db, err := sql.Open("sqlite", dsn)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrOpen, err.Error())
+	}
+
+	db.SetMaxOpenConns(1)
+
+	if err = db.PingContext(ctx); err != nil {
+		db.Close()
+
+		return nil, fmt.Errorf("%w: %s", ErrOpen, err.Error())
+	}
+
+	return db, nil
+}